@@ -2,12 +2,14 @@ package calendar
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/takumi/personal-website/internal/model"
 )
 
 // EventInput describes an event to insert into Google Calendar.
+// On update, empty fields are left untouched.
 type EventInput struct {
 	Summary     string
 	Description string
@@ -16,15 +18,33 @@ type EventInput struct {
 	Attendees   []string
 }
 
+// EventAttendee captures an attendee and their RSVP state.
+type EventAttendee struct {
+	Email          string
+	ResponseStatus string
+}
+
 // Event captures the important identifiers of a Google Calendar entry.
+// Status, Start, End, and Attendees are populated when the event is fetched.
 type Event struct {
 	ID          string
 	HTMLLink    string
 	HangoutLink string
+	Status      string
+	Start       time.Time
+	End         time.Time
+	Attendees   []EventAttendee
 }
 
+// ErrEventNotFound indicates the referenced event no longer exists on the calendar.
+var ErrEventNotFound = errors.New("calendar event not found")
+
 // Client abstracts the subset of Google Calendar operations required for booking.
+// ListBusyWindows reports the combined busy time of every listed calendar.
 type Client interface {
-	ListBusyWindows(ctx context.Context, calendarID string, from, to time.Time) ([]model.TimeWindow, error)
+	ListBusyWindows(ctx context.Context, calendarIDs []string, from, to time.Time) ([]model.TimeWindow, error)
 	CreateEvent(ctx context.Context, calendarID string, input EventInput) (*Event, error)
+	GetEvent(ctx context.Context, calendarID, eventID string) (*Event, error)
+	UpdateEvent(ctx context.Context, calendarID, eventID string, input EventInput) (*Event, error)
+	DeleteEvent(ctx context.Context, calendarID, eventID string) error
 }
@@ -0,0 +1,208 @@
+// Package ics renders and parses RFC 5545 iCalendar documents.
+package ics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Method is the iTIP method carried by a calendar object (RFC 5546).
+type Method string
+
+const (
+	MethodPublish Method = "PUBLISH"
+	MethodRequest Method = "REQUEST"
+	MethodCancel  Method = "CANCEL"
+)
+
+// Event status values.
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	productID      = "-//takumi//personal-website//EN"
+	utcLayout      = "20060102T150405Z"
+	maxLineOctets  = 75
+	lineTerminator = "\r\n"
+)
+
+// Event describes a single VEVENT. UID must stay stable across updates of the same meeting
+// and Sequence must grow with every revision so clients replace the earlier copy.
+type Event struct {
+	UID         string
+	Sequence    int
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Status      string
+	Organizer   string
+	Attendees   []string
+}
+
+// Calendar is a VCALENDAR object. An empty Method omits the METHOD property, which suits
+// subscription feeds.
+type Calendar struct {
+	Method Method
+	Name   string
+	Events []Event
+}
+
+// Bytes renders the calendar with CRLF line endings and lines folded at 75 octets.
+func (c Calendar) Bytes() []byte {
+	w := &writer{}
+	w.header(c)
+	for _, event := range c.Events {
+		w.event(event)
+	}
+	w.line("END", "VCALENDAR")
+	return []byte(w.builder.String())
+}
+
+// ContentType returns the MIME type for the calendar, including its method when set.
+func (c Calendar) ContentType() string {
+	if c.Method == "" {
+		return `text/calendar; charset="utf-8"`
+	}
+	return fmt.Sprintf(`text/calendar; charset="utf-8"; method=%s`, c.Method)
+}
+
+// Encoder streams a calendar to an io.Writer one event at a time, so large exports are never
+// held in memory. The output is only a complete calendar once Close has been called.
+type Encoder struct {
+	out io.Writer
+	w   writer
+}
+
+// NewEncoder writes the calendar's header and any events it already holds.
+func NewEncoder(out io.Writer, c Calendar) (*Encoder, error) {
+	e := &Encoder{out: out}
+	e.w.header(c)
+	for _, event := range c.Events {
+		e.w.event(event)
+	}
+	return e, e.flush()
+}
+
+// Encode writes one event.
+func (e *Encoder) Encode(event Event) error {
+	e.w.event(event)
+	return e.flush()
+}
+
+// Close ends the calendar. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	e.w.line("END", "VCALENDAR")
+	return e.flush()
+}
+
+func (e *Encoder) flush() error {
+	_, err := io.WriteString(e.out, e.w.builder.String())
+	e.w.builder.Reset()
+	return err
+}
+
+type writer struct {
+	builder strings.Builder
+}
+
+func (w *writer) header(c Calendar) {
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", productID)
+	w.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		w.line("METHOD", string(c.Method))
+	}
+	if name := strings.TrimSpace(c.Name); name != "" {
+		w.line("X-WR-CALNAME", escapeText(name))
+	}
+}
+
+func (w *writer) event(event Event) {
+	stamp := event.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", escapeText(event.UID))
+	w.line("SEQUENCE", fmt.Sprintf("%d", event.Sequence))
+	w.line("DTSTAMP", formatUTC(stamp))
+	w.line("DTSTART", formatUTC(event.Start))
+	w.line("DTEND", formatUTC(event.End))
+	w.optional("SUMMARY", event.Summary)
+	w.optional("DESCRIPTION", event.Description)
+	w.optional("LOCATION", event.Location)
+	if url := strings.TrimSpace(event.URL); url != "" {
+		w.line("URL", url)
+	}
+	if status := strings.TrimSpace(event.Status); status != "" {
+		w.line("STATUS", status)
+	}
+	if organizer := strings.TrimSpace(event.Organizer); organizer != "" {
+		w.line("ORGANIZER", "mailto:"+organizer)
+	}
+	for _, attendee := range event.Attendees {
+		if attendee = strings.TrimSpace(attendee); attendee != "" {
+			w.line("ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=TRUE", "mailto:"+attendee)
+		}
+	}
+	w.line("END", "VEVENT")
+}
+
+func (w *writer) optional(name, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		w.line(name, escapeText(value))
+	}
+}
+
+// line writes one content line, folding it so no physical line exceeds 75 octets without
+// splitting a UTF-8 sequence.
+func (w *writer) line(name, value string) {
+	content := name + ":" + value
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		w.builder.WriteString(content[:cut])
+		w.builder.WriteString(lineTerminator)
+		w.builder.WriteString(" ")
+		content = content[cut:]
+		// Continuation lines spend one octet on the leading space.
+		limit = maxLineOctets - 1
+	}
+	w.builder.WriteString(content)
+	w.builder.WriteString(lineTerminator)
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
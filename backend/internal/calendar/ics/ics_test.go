@@ -0,0 +1,80 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarBytesRendersEscapedEvent(t *testing.T) {
+	start := time.Date(2024, 5, 1, 18, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	calendar := Calendar{
+		Method: MethodRequest,
+		Events: []Event{{
+			UID:         "meeting-reservation-7@personal-website",
+			Sequence:    3,
+			Stamp:       time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+			Start:       start,
+			End:         start.Add(30 * time.Minute),
+			Summary:     "Consultation with Ada, Lovelace",
+			Description: "line one\nline two; with semicolon",
+			Status:      StatusConfirmed,
+			Organizer:   "owner@example.com",
+			Attendees:   []string{"guest@example.com"},
+		}},
+	}
+
+	rendered := string(calendar.Bytes())
+	require.True(t, strings.HasPrefix(rendered, "BEGIN:VCALENDAR\r\n"))
+	require.True(t, strings.HasSuffix(rendered, "END:VCALENDAR\r\n"))
+	require.Contains(t, rendered, "METHOD:REQUEST\r\n")
+	require.Contains(t, rendered, "UID:meeting-reservation-7@personal-website\r\n")
+	require.Contains(t, rendered, "SEQUENCE:3\r\n")
+	require.Contains(t, rendered, "DTSTART:20240501T090000Z\r\n")
+	require.Contains(t, rendered, "DTEND:20240501T093000Z\r\n")
+	require.Contains(t, rendered, `SUMMARY:Consultation with Ada\, Lovelace`)
+	require.Contains(t, rendered, `DESCRIPTION:line one\nline two\; with semicolon`)
+	require.Contains(t, rendered, "ORGANIZER:mailto:owner@example.com\r\n")
+	require.Contains(t, rendered, "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=TRUE:mailto:guest@example.com\r\n")
+	require.Equal(t, `text/calendar; charset="utf-8"; method=REQUEST`, calendar.ContentType())
+}
+
+func TestCalendarBytesFoldsLongLinesOnRuneBoundaries(t *testing.T) {
+	description := strings.Repeat("予約", 60)
+	rendered := string(Calendar{Events: []Event{{
+		UID:         "uid",
+		Stamp:       time.Unix(0, 0),
+		Start:       time.Unix(0, 0),
+		End:         time.Unix(3600, 0),
+		Description: description,
+	}}}.Bytes())
+
+	require.NotContains(t, rendered, "METHOD:")
+	for _, line := range strings.Split(strings.TrimSuffix(rendered, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+		require.True(t, utf8.ValidString(line), "line %q splits a rune", line)
+	}
+	unfolded := strings.ReplaceAll(rendered, "\r\n ", "")
+	require.Contains(t, unfolded, "DESCRIPTION:"+description+"\r\n")
+}
+
+func TestEncoderMatchesBytes(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	events := []Event{
+		{UID: "a@personal-website", Stamp: start, Start: start, End: start.Add(time.Hour), Summary: "First"},
+		{UID: "b@personal-website", Stamp: start, Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), Summary: "Second"},
+	}
+
+	var streamed strings.Builder
+	encoder, err := NewEncoder(&streamed, Calendar{Name: "Export"})
+	require.NoError(t, err)
+	for _, event := range events {
+		require.NoError(t, encoder.Encode(event))
+	}
+	require.NoError(t, encoder.Close())
+
+	require.Equal(t, string(Calendar{Name: "Export", Events: events}.Bytes()), streamed.String())
+}
@@ -0,0 +1,308 @@
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformed indicates calendar data that cannot be read as iCalendar.
+var ErrMalformed = errors.New("ics: malformed calendar")
+
+// Transparency values for TRANSP.
+const (
+	TranspOpaque      = "OPAQUE"
+	TranspTransparent = "TRANSPARENT"
+)
+
+// ParsedEvent is a VEVENT read from a feed, reduced to the fields that matter for busy time.
+// Start and End carry the event's own time zone so recurrences keep their wall-clock time.
+// RecurrenceID is set on instances that override one occurrence of a recurring series.
+type ParsedEvent struct {
+	UID          string
+	Summary      string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Status       string
+	Transparency string
+	RRule        string
+	ExDates      []time.Time
+	RecurrenceID time.Time
+}
+
+// Parse reads the VEVENTs from an iCalendar stream. Floating and date-only values are read in
+// the calendar's X-WR-TIMEZONE when present and in fallback otherwise; TZID parameters must
+// name IANA zones (VTIMEZONE definitions are not interpreted). Events whose start cannot be
+// read are skipped rather than failing the whole feed.
+func Parse(r io.Reader, fallback *time.Location) ([]ParsedEvent, error) {
+	if fallback == nil {
+		fallback = time.UTC
+	}
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events   []ParsedEvent
+		stack    []string
+		current  []property
+		sawStart bool
+	)
+	defaultLoc := fallback
+	for _, raw := range lines {
+		prop, ok := parseProperty(raw)
+		if !ok {
+			continue
+		}
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			stack = append(stack, component)
+			if component == "VCALENDAR" {
+				sawStart = true
+			}
+			if component == "VEVENT" && len(stack) == 2 {
+				current = current[:0]
+			}
+			continue
+		case "END":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: unexpected END:%s", ErrMalformed, prop.value)
+			}
+			if stack[len(stack)-1] == "VEVENT" && len(stack) == 2 {
+				if event, ok := buildEvent(current, defaultLoc); ok {
+					events = append(events, event)
+				}
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		switch {
+		case len(stack) == 1 && prop.name == "X-WR-TIMEZONE":
+			if loc, err := time.LoadLocation(strings.TrimSpace(prop.value)); err == nil {
+				defaultLoc = loc
+			}
+		case len(stack) == 2 && stack[1] == "VEVENT":
+			// Nested components such as VALARM sit deeper in the stack and are ignored.
+			current = append(current, prop)
+		}
+	}
+	if !sawStart {
+		return nil, fmt.Errorf("%w: missing BEGIN:VCALENDAR", ErrMalformed)
+	}
+	return events, nil
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// unfold joins continuation lines (those starting with a space or tab) onto the previous line.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ics: read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty splits "NAME;PARAM=value:VALUE", honouring quoted parameter values.
+func parseProperty(line string) (property, bool) {
+	inQuotes := false
+	colon := -1
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ':':
+			if !inQuotes {
+				colon = i
+			}
+		}
+		if colon >= 0 {
+			break
+		}
+	}
+	if colon <= 0 {
+		return property{}, false
+	}
+
+	head := line[:colon]
+	prop := property{value: line[colon+1:], params: map[string]string{}}
+	parts := strings.Split(head, ";")
+	prop.name = strings.ToUpper(strings.TrimSpace(parts[0]))
+	for _, param := range parts[1:] {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		prop.params[strings.ToUpper(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return prop, true
+}
+
+func buildEvent(props []property, defaultLoc *time.Location) (ParsedEvent, bool) {
+	var (
+		event    ParsedEvent
+		duration time.Duration
+		hasStart bool
+		hasEnd   bool
+	)
+	for _, prop := range props {
+		switch prop.name {
+		case "UID":
+			event.UID = strings.TrimSpace(prop.value)
+		case "SUMMARY":
+			event.Summary = unescapeText(prop.value)
+		case "STATUS":
+			event.Status = strings.ToUpper(strings.TrimSpace(prop.value))
+		case "TRANSP":
+			event.Transparency = strings.ToUpper(strings.TrimSpace(prop.value))
+		case "RRULE":
+			event.RRule = strings.TrimSpace(prop.value)
+		case "DTSTART":
+			start, allDay, err := parseDateTime(prop, defaultLoc)
+			if err != nil {
+				return ParsedEvent{}, false
+			}
+			event.Start, event.AllDay, hasStart = start, allDay, true
+		case "DTEND":
+			if end, _, err := parseDateTime(prop, defaultLoc); err == nil {
+				event.End, hasEnd = end, true
+			}
+		case "DURATION":
+			if parsed, err := parseDuration(prop.value); err == nil {
+				duration = parsed
+			}
+		case "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				item := property{name: prop.name, params: prop.params, value: value}
+				if exdate, _, err := parseDateTime(item, defaultLoc); err == nil {
+					event.ExDates = append(event.ExDates, exdate)
+				}
+			}
+		case "RECURRENCE-ID":
+			if recurrenceID, _, err := parseDateTime(prop, defaultLoc); err == nil {
+				event.RecurrenceID = recurrenceID
+			}
+		}
+	}
+	if !hasStart {
+		return ParsedEvent{}, false
+	}
+
+	switch {
+	case hasEnd:
+	case duration > 0:
+		event.End = event.Start.Add(duration)
+	case event.AllDay:
+		// RFC 5545 3.6.1: a date-only DTSTART without DTEND lasts one day.
+		event.End = event.Start.AddDate(0, 0, 1)
+	default:
+		event.End = event.Start
+	}
+	if event.End.Before(event.Start) {
+		event.End = event.Start
+	}
+	return event, true
+}
+
+// parseDateTime reads DATE and DATE-TIME values. UTC values keep the UTC location, TZID values
+// are placed in that zone, and floating or date-only values use defaultLoc.
+func parseDateTime(prop property, defaultLoc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	loc := defaultLoc
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		parsed, err := time.ParseInLocation("20060102", value, loc)
+		return parsed, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse(utcLayout, value)
+		return parsed, false, err
+	}
+	parsed, err := time.ParseInLocation("20060102T150405", value, loc)
+	return parsed, false, err
+}
+
+// parseDuration reads RFC 5545 durations such as "PT1H30M", "P1D", or "P2W".
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, fmt.Errorf("%w: invalid duration %q", ErrMalformed, value)
+	}
+
+	var (
+		total  time.Duration
+		digits string
+		inTime bool
+	)
+	units := map[bool]map[byte]time.Duration{
+		false: {'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour},
+		true:  {'H': time.Hour, 'M': time.Minute, 'S': time.Second},
+	}
+	for i := 1; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch == 'T':
+			inTime = true
+		case ch >= '0' && ch <= '9':
+			digits += string(ch)
+		default:
+			unit, ok := units[inTime][ch]
+			if !ok || digits == "" {
+				return 0, fmt.Errorf("%w: invalid duration %q", ErrMalformed, value)
+			}
+			amount, err := strconv.Atoi(digits)
+			if err != nil {
+				return 0, fmt.Errorf("%w: invalid duration %q", ErrMalformed, value)
+			}
+			total += time.Duration(amount) * unit
+			digits = ""
+		}
+	}
+	if digits != "" {
+		return 0, fmt.Errorf("%w: invalid duration %q", ErrMalformed, value)
+	}
+	return sign * total, nil
+}
+
+func unescapeText(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
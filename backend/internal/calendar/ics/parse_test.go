@@ -0,0 +1,77 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReadsZonedAllDayAndRecurringEvents(t *testing.T) {
+	feed := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"X-WR-TIMEZONE:Asia/Tokyo",
+		"BEGIN:VTIMEZONE",
+		"TZID:Asia/Tokyo",
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:lecture@example.ac.jp",
+		"SUMMARY:Algorithms\\, room 3",
+		"DTSTART;TZID=Asia/Tokyo:20240506T103000",
+		"DURATION:PT1H30M",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"EXDATE;TZID=Asia/Tokyo:20240513T103000,20240520T103000",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT10M",
+		"DTSTART:20000101T000000Z",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday",
+		"DTSTART;VALUE=DATE:20240503",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:dentist",
+		"DTSTART:20240507T010000Z",
+		"DTEND:20240507T020000Z",
+		"DESCRIPTION:a long description that is folded",
+		"  across two lines",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := Parse(strings.NewReader(feed), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	lecture := events[0]
+	require.Equal(t, "Algorithms, room 3", lecture.Summary)
+	require.Equal(t, time.Date(2024, 5, 6, 10, 30, 0, 0, tokyo), lecture.Start)
+	require.Equal(t, 90*time.Minute, lecture.End.Sub(lecture.Start))
+	require.Equal(t, "FREQ=WEEKLY;BYDAY=MO", lecture.RRule)
+	require.Len(t, lecture.ExDates, 2)
+	require.True(t, lecture.ExDates[1].Equal(time.Date(2024, 5, 20, 1, 30, 0, 0, time.UTC)))
+
+	holiday := events[1]
+	require.True(t, holiday.AllDay)
+	require.Equal(t, TranspTransparent, holiday.Transparency)
+	require.Equal(t, time.Date(2024, 5, 3, 0, 0, 0, 0, tokyo), holiday.Start)
+	require.Equal(t, time.Date(2024, 5, 4, 0, 0, 0, 0, tokyo), holiday.End)
+
+	require.Equal(t, StatusCancelled, events[2].Status)
+	require.True(t, events[2].Start.Equal(time.Date(2024, 5, 7, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestParseRejectsNonCalendarInput(t *testing.T) {
+	_, err := Parse(strings.NewReader("<html>login required</html>"), time.UTC)
+	require.ErrorIs(t, err, ErrMalformed)
+}
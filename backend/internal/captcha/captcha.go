@@ -0,0 +1,52 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrMissingToken indicates the client did not submit a verification token.
+	ErrMissingToken = errors.New("captcha token is required")
+	// ErrRejected indicates the provider judged the token invalid, expired, or likely automated.
+	ErrRejected = errors.New("captcha verification rejected")
+)
+
+// Request carries a client-supplied token for server-side verification.
+type Request struct {
+	Token    string
+	RemoteIP string
+	// Action names the protected flow (e.g. "booking"); providers that report actions
+	// reject tokens minted for a different one.
+	Action string
+}
+
+// Verifier validates human-verification tokens with a provider.
+type Verifier interface {
+	Verify(ctx context.Context, req Request) error
+}
+
+// FakeVerifier deterministically accepts every non-empty token except RejectToken.
+// It is intended for tests and local development.
+type FakeVerifier struct {
+	RejectToken string
+}
+
+// NewFakeVerifier returns a verifier that rejects only the given token.
+func NewFakeVerifier(rejectToken string) *FakeVerifier {
+	return &FakeVerifier{RejectToken: strings.TrimSpace(rejectToken)}
+}
+
+func (f *FakeVerifier) Verify(_ context.Context, req Request) error {
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return ErrMissingToken
+	}
+	if f.RejectToken != "" && token == f.RejectToken {
+		return ErrRejected
+	}
+	return nil
+}
+
+var _ Verifier = (*FakeVerifier)(nil)
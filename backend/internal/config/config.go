@@ -50,18 +50,52 @@ type GoogleOAuthConfig struct {
 }
 
 type ContactConfig struct {
-	Timezone         string   `mapstructure:"timezone"`
-	SlotDurationMin  int      `mapstructure:"slot_duration_minutes"`
-	WorkdayStartHour int      `mapstructure:"workday_start_hour"`
-	WorkdayEndHour   int      `mapstructure:"workday_end_hour"`
-	HorizonDays      int      `mapstructure:"horizon_days"`
-	BufferMinutes    int      `mapstructure:"buffer_minutes"`
-	Topics           []string `mapstructure:"topics"`
-	RecaptchaSiteKey string   `mapstructure:"recaptcha_site_key"`
-	MinimumLeadHours int      `mapstructure:"minimum_lead_hours"`
-	ConsentText      string   `mapstructure:"consent_text"`
-	SupportEmail     string   `mapstructure:"support_email"`
-	CalendarTimezone string   `mapstructure:"calendar_timezone"`
+	Timezone         string        `mapstructure:"timezone"`
+	SlotDurationMin  int           `mapstructure:"slot_duration_minutes"`
+	WorkdayStartHour int           `mapstructure:"workday_start_hour"`
+	WorkdayEndHour   int           `mapstructure:"workday_end_hour"`
+	HorizonDays      int           `mapstructure:"horizon_days"`
+	BufferMinutes    int           `mapstructure:"buffer_minutes"`
+	Topics           []string      `mapstructure:"topics"`
+	RecaptchaSiteKey string        `mapstructure:"recaptcha_site_key"`
+	MinimumLeadHours int           `mapstructure:"minimum_lead_hours"`
+	ConsentText      string        `mapstructure:"consent_text"`
+	SupportEmail     string        `mapstructure:"support_email"`
+	CalendarTimezone string        `mapstructure:"calendar_timezone"`
+	Captcha          CaptchaConfig `mapstructure:"captcha"`
+	// OwnerAlert emails the owner about each contact form submission, at
+	// booking.notification_receiver or else SupportEmail. AutoReply acknowledges the submission
+	// to the visitor in the language they wrote in.
+	OwnerAlert bool `mapstructure:"owner_alert"`
+	AutoReply  bool `mapstructure:"auto_reply"`
+	// Spam scores contact messages and bookings before they are stored.
+	Spam SpamConfig `mapstructure:"spam"`
+}
+
+// SpamConfig tunes the spam filter. Each rule adds to a submission's score and submissions
+// scoring Threshold or more are held for review. Submissions sent less than MinSubmitTime after
+// the form opened, with more than MaxLinks links, from a DisposableDomains address, or repeating
+// a message already sent DuplicateLimit times within DuplicateWindow are suspicious.
+type SpamConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`
+	Threshold         float64       `mapstructure:"threshold"`
+	MinSubmitTime     time.Duration `mapstructure:"min_submit_time"`
+	MaxLinks          int           `mapstructure:"max_links"`
+	DisposableDomains []string      `mapstructure:"disposable_domains"`
+	DuplicateWindow   time.Duration `mapstructure:"duplicate_window"`
+	DuplicateLimit    int           `mapstructure:"duplicate_limit"`
+}
+
+// CaptchaConfig selects the human-verification provider for public booking and contact forms.
+// Provider is required; "fake" accepts any token but FakeRejectToken and is meant for local
+// development and tests only.
+type CaptchaConfig struct {
+	Provider        string        `mapstructure:"provider"`
+	SecretKey       string        `mapstructure:"secret_key"`
+	MinScore        float64       `mapstructure:"min_score"`
+	VerifyURL       string        `mapstructure:"verify_url"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+	FakeRejectToken string        `mapstructure:"fake_reject_token"`
 }
 
 type BookingConfig struct {
@@ -76,6 +110,59 @@ type BookingConfig struct {
 	BackoffMultiplier    float64       `mapstructure:"backoff_multiplier"`
 	CircuitOpenSeconds   int           `mapstructure:"circuit_open_seconds"`
 	CircuitFailureThresh int           `mapstructure:"circuit_failure_threshold"`
+	OutboxPollInterval   time.Duration `mapstructure:"outbox_poll_interval"`
+	OutboxBatchSize      int           `mapstructure:"outbox_batch_size"`
+	OutboxLease          time.Duration `mapstructure:"outbox_lease"`
+	OutboxMaxAttempts    int           `mapstructure:"outbox_max_attempts"`
+	OutboxInitialBackoff time.Duration `mapstructure:"outbox_initial_backoff"`
+	OutboxMaxBackoff     time.Duration `mapstructure:"outbox_max_backoff"`
+	// ReminderOffsets lists how long before a confirmed meeting each reminder email goes out.
+	ReminderOffsets  []time.Duration `mapstructure:"reminder_offsets"`
+	ReminderInterval time.Duration   `mapstructure:"reminder_interval"`
+	// DefaultLocale ("ja" or "en") is used for owner notices and visitors whose language is unknown.
+	DefaultLocale string `mapstructure:"default_locale"`
+	// ApprovalExpiry is how long a booking for an approval-required topic may wait for a
+	// decision before it is cancelled automatically.
+	ApprovalExpiry time.Duration `mapstructure:"approval_expiry"`
+	// WaitlistClaimWindow is how long a waitlisted visitor has to claim a freed slot before it
+	// is offered to the next person. WaitlistClaimURL is the public page the claim link opens;
+	// the offer token is appended as the "token" query parameter.
+	WaitlistClaimWindow time.Duration `mapstructure:"waitlist_claim_window"`
+	WaitlistClaimURL    string        `mapstructure:"waitlist_claim_url"`
+	// NoShowBlacklistThreshold is how many no-shows a visitor may accumulate before the admin
+	// reservation view suggests blacklisting their email. Zero disables the suggestion.
+	NoShowBlacklistThreshold int `mapstructure:"no_show_blacklist_threshold"`
+	// CalendarReconcileInterval is how often upcoming reservations are compared with their
+	// Google Calendar events; zero disables the check. With CalendarReconcileAutoApply, deleted,
+	// declined, and moved events are mirrored onto the reservation instead of only being flagged.
+	CalendarReconcileInterval  time.Duration `mapstructure:"calendar_reconcile_interval"`
+	CalendarReconcileAutoApply bool          `mapstructure:"calendar_reconcile_auto_apply"`
+	// NotificationRetryInterval is how often failed or never-sent notification emails are retried;
+	// zero disables the worker, which is refused while contact emails are enabled because the
+	// worker is their only sender. Each retry waits twice as long as the previous one, starting at
+	// NotificationRetryInitialBackoff and capped at NotificationRetryMaxBackoff, and a
+	// notification is marked dead after NotificationRetryMaxAttempts attempts.
+	NotificationRetryInterval       time.Duration `mapstructure:"notification_retry_interval"`
+	NotificationRetryMaxAttempts    int           `mapstructure:"notification_retry_max_attempts"`
+	NotificationRetryInitialBackoff time.Duration `mapstructure:"notification_retry_initial_backoff"`
+	NotificationRetryMaxBackoff     time.Duration `mapstructure:"notification_retry_max_backoff"`
+	// ExternalCalendars are ICS feeds or CalDAV collections whose events block booking slots in
+	// addition to the Google calendar. Fetched events are reused for ExternalCalendarCacheTTL.
+	ExternalCalendars        []ExternalCalendarConfig `mapstructure:"external_calendars"`
+	ExternalCalendarCacheTTL time.Duration            `mapstructure:"external_calendar_cache_ttl"`
+}
+
+// ExternalCalendarConfig describes one read-only calendar source. Type is "ics" (a plain feed
+// URL) or "caldav" (a calendar collection queried with REPORT). Credentials are optional; the
+// password is read from the environment variable named by PasswordEnv. Timezone applies to
+// floating times in the source and defaults to the contact timezone.
+type ExternalCalendarConfig struct {
+	Name        string `mapstructure:"name"`
+	Type        string `mapstructure:"type"`
+	URL         string `mapstructure:"url"`
+	Username    string `mapstructure:"username"`
+	PasswordEnv string `mapstructure:"password_env"`
+	Timezone    string `mapstructure:"timezone"`
 }
 
 type SecurityConfig struct {
@@ -101,6 +188,9 @@ type SecurityConfig struct {
 	AllowCredentials           bool                 `mapstructure:"allow_credentials"`
 	PrimaryOrigin              string               `mapstructure:"primary_origin"`
 	AdminRateLimit             AdminRateLimitConfig `mapstructure:"admin_rate_limit"`
+	// IdempotencyTTL is how long the response to a public POST sent with an Idempotency-Key is
+	// kept for replay. Zero disables Idempotency-Key handling.
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
 }
 
 type AdminRateLimitConfig struct {
@@ -144,7 +234,9 @@ type AppConfig struct {
 	Metrics   MetricsConfig     `mapstructure:"metrics"`
 	Logging   LoggingConfig     `mapstructure:"logging"`
 	Database  DatabaseConfig    `mapstructure:"database"`
-	DBDriver  string            `mapstructure:"db_driver"`
+	// DBDriver is "mysql" or "firestore". Meeting reservations have no Firestore store, so
+	// startup fails with "firestore" rather than keeping bookings in memory.
+	DBDriver string `mapstructure:"db_driver"`
 }
 
 type AdminAuthConfig struct {
@@ -233,12 +325,57 @@ func load() (*AppConfig, error) {
 	v.SetDefault("contact.recaptcha_site_key", "")
 	v.SetDefault("contact.minimum_lead_hours", 24)
 	v.SetDefault("contact.consent_text", "")
+	v.SetDefault("contact.owner_alert", true)
+	v.SetDefault("contact.auto_reply", true)
+	v.SetDefault("contact.spam.enabled", true)
+	v.SetDefault("contact.spam.threshold", 1.0)
+	v.SetDefault("contact.spam.min_submit_time", 3*time.Second)
+	v.SetDefault("contact.spam.max_links", 3)
+	v.SetDefault("contact.spam.disposable_domains", []string{
+		"mailinator.com",
+		"guerrillamail.com",
+		"10minutemail.com",
+		"temp-mail.org",
+		"yopmail.com",
+		"trashmail.com",
+		"sharklasers.com",
+		"getnada.com",
+	})
+	v.SetDefault("contact.spam.duplicate_window", 24*time.Hour)
+	v.SetDefault("contact.spam.duplicate_limit", 2)
+	// No captcha provider default: an unset provider fails at startup instead of silently
+	// accepting every form submission.
+	v.SetDefault("contact.captcha.provider", "")
+	v.SetDefault("contact.captcha.secret_key", "")
+	v.SetDefault("contact.captcha.min_score", 0.5)
+	v.SetDefault("contact.captcha.verify_url", "")
+	v.SetDefault("contact.captcha.timeout", 5*time.Second)
+	v.SetDefault("contact.captcha.fake_reject_token", "fail")
 	v.SetDefault("booking.request_timeout", 8*time.Second)
 	v.SetDefault("booking.max_retries", 3)
 	v.SetDefault("booking.initial_backoff", 750*time.Millisecond)
 	v.SetDefault("booking.backoff_multiplier", 2.0)
 	v.SetDefault("booking.circuit_open_seconds", 60)
 	v.SetDefault("booking.circuit_failure_threshold", 3)
+	v.SetDefault("booking.outbox_poll_interval", 5*time.Second)
+	v.SetDefault("booking.outbox_batch_size", 20)
+	v.SetDefault("booking.outbox_lease", 2*time.Minute)
+	v.SetDefault("booking.outbox_max_attempts", 8)
+	v.SetDefault("booking.outbox_initial_backoff", 30*time.Second)
+	v.SetDefault("booking.outbox_max_backoff", time.Hour)
+	v.SetDefault("booking.reminder_offsets", []time.Duration{24 * time.Hour, time.Hour})
+	v.SetDefault("booking.reminder_interval", time.Minute)
+	v.SetDefault("booking.default_locale", "ja")
+	v.SetDefault("booking.approval_expiry", 48*time.Hour)
+	v.SetDefault("booking.waitlist_claim_window", 2*time.Hour)
+	v.SetDefault("booking.no_show_blacklist_threshold", 2)
+	v.SetDefault("booking.calendar_reconcile_interval", 15*time.Minute)
+	v.SetDefault("booking.calendar_reconcile_auto_apply", false)
+	v.SetDefault("booking.notification_retry_interval", time.Minute)
+	v.SetDefault("booking.notification_retry_max_attempts", 5)
+	v.SetDefault("booking.notification_retry_initial_backoff", 5*time.Minute)
+	v.SetDefault("booking.notification_retry_max_backoff", 6*time.Hour)
+	v.SetDefault("booking.external_calendar_cache_ttl", 10*time.Minute)
 	v.SetDefault("booking.access_token_env", "")
 	v.SetDefault("security.enable_csrf", true)
 	v.SetDefault("security.csrf_signing_key", "local-dev-csrf-secret-change-me")
@@ -274,6 +411,7 @@ func load() (*AppConfig, error) {
 		"/api/admin/auth/callback",
 		"/api/admin/auth/session",
 	})
+	v.SetDefault("security.idempotency_ttl", 24*time.Hour)
 	v.SetDefault("security.allowed_origins", []string{})
 	v.SetDefault("security.allow_credentials", true)
 	v.SetDefault("security.primary_origin", "")
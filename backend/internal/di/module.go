@@ -1,6 +1,7 @@
 package di
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"strings"
@@ -11,8 +12,11 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/captcha"
 	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/handler"
+	"github.com/takumi/personal-website/internal/infra/calendarfeed"
+	infracaptcha "github.com/takumi/personal-website/internal/infra/captcha"
 	firestoredb "github.com/takumi/personal-website/internal/infra/firestore"
 	"github.com/takumi/personal-website/internal/infra/google"
 	mysqlinfra "github.com/takumi/personal-website/internal/infra/mysql"
@@ -58,21 +62,39 @@ var Module = fx.Module("di",
 		provider.NewAdminResearchRepository,
 		provideContactRepository,
 		provider.NewAdminContactRepository,
+		provideContactNotificationRepository,
+		provideSpamRepository,
 		provideAvailabilityRepository,
+		provideScheduleBlackoutRepository,
+		provideWaitlistRepository,
 		provideBlogRepository,
 		provideMeetingReservationRepository,
 		provideMeetingNotificationRepository,
+		provideBookingOutboxRepository,
+		provideCalendarFeedTokenRepository,
+		provideReservationDriftRepository,
+		provideNotificationTemplateRepository,
 		provideBlacklistRepository,
+		provideIdempotencyRepository,
 		provideHTTPClient,
 		provideGoogleTokenProvider,
 		provideCalendarClient,
 		provideGmailClient,
+		provideCaptchaVerifier,
 		service.NewProfileService,
 		service.NewProjectService,
 		service.NewResearchService,
+		service.NewSpamFilter,
 		service.NewContactService,
 		service.NewAvailabilityService,
 		service.NewBookingService,
+		service.NewWaitlistService,
+		service.NewOutboxDispatcher,
+		service.NewReminderScheduler,
+		service.NewCalendarReconciler,
+		service.NewNotificationRetrier,
+		service.NewCalendarFeedService,
+		service.NewNotificationTemplateService,
 		adminservice.NewService,
 		handler.NewHealthHandler,
 		handler.NewProfileHandler,
@@ -80,6 +102,9 @@ var Module = fx.Module("di",
 		handler.NewResearchHandler,
 		handler.NewContactHandler,
 		handler.NewBookingHandler,
+		handler.NewWaitlistHandler,
+		handler.NewCalendarFeedHandler,
+		handler.NewNotificationTemplateHandler,
 		handler.NewAuthHandler,
 		handler.NewAdminAuthHandler,
 		handler.NewAdminHandler,
@@ -94,12 +119,17 @@ var Module = fx.Module("di",
 		middleware.NewRateLimiter,
 		middleware.NewAdminRateLimiter,
 		middleware.NewAdminSessionMiddleware,
+		middleware.NewIdempotencyMiddleware,
 		middleware.NewAdminGuard,
 		middleware.NewAdminModeGuard,
 		middleware.NewCSRFMiddleware,
 		provideCSRFManager,
 		telemetry.NewMetrics,
 	),
+	fx.Invoke(registerOutboxDispatcher),
+	fx.Invoke(registerReminderScheduler),
+	fx.Invoke(registerCalendarReconciler),
+	fx.Invoke(registerNotificationRetrier),
 )
 
 func provideAuthConfig(cfg *config.AppConfig) config.AuthConfig {
@@ -176,6 +206,55 @@ func provideGmailClient(client *http.Client, provider google.TokenProvider) mail
 	return google.NewGmailAPIClient(client, provider)
 }
 
+func provideCaptchaVerifier(client *http.Client, cfg *config.AppConfig) (captcha.Verifier, error) {
+	return infracaptcha.NewVerifier(cfg.Contact.Captcha, client)
+}
+
+// registerOutboxDispatcher runs the booking outbox dispatcher for the lifetime of the app.
+func registerOutboxDispatcher(lc fx.Lifecycle, dispatcher *service.OutboxDispatcher) {
+	registerBackgroundWorker(lc, "booking outbox dispatcher", dispatcher.Run)
+}
+
+// registerReminderScheduler runs the meeting reminder scheduler for the lifetime of the app.
+func registerReminderScheduler(lc fx.Lifecycle, scheduler *service.ReminderScheduler) {
+	registerBackgroundWorker(lc, "reminder scheduler", scheduler.Run)
+}
+
+// registerCalendarReconciler runs the reservation/calendar reconciler for the lifetime of the app.
+func registerCalendarReconciler(lc fx.Lifecycle, reconciler *service.CalendarReconciler) {
+	registerBackgroundWorker(lc, "calendar reconciler", reconciler.Run)
+}
+
+// registerNotificationRetrier runs the notification retry worker for the lifetime of the app.
+func registerNotificationRetrier(lc fx.Lifecycle, retrier *service.NotificationRetrier) {
+	registerBackgroundWorker(lc, "notification retrier", retrier.Run)
+}
+
+// registerBackgroundWorker starts run when the app starts and cancels its context on stop,
+// waiting for it to return until the stop deadline.
+func registerBackgroundWorker(lc fx.Lifecycle, name string, run func(context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				log.Printf("%s did not stop before shutdown deadline: %v", name, stopCtx.Err())
+			}
+			return nil
+		},
+	})
+}
+
 func provideCSRFManager(cfg *config.AppConfig) *csrfmgr.Manager {
 	if cfg == nil || !cfg.Security.EnableCSRF {
 		return nil
@@ -304,16 +383,44 @@ func provideHomePageConfigRepository(cfg *config.AppConfig, db *sqlx.DB, fs *fir
 	}
 }
 
-func provideAvailabilityRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.AvailabilityRepository {
+func provideAvailabilityRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client, blackouts repository.ScheduleBlackoutRepository, httpClient *http.Client) repository.AvailabilityRepository {
+	var repo repository.AvailabilityRepository
 	driver := normalizedDriver(cfg)
 	switch driver {
 	case "firestore":
-		return provider.NewAvailabilityRepository(nil, fs, cfg)
+		repo = provider.NewAvailabilityRepository(nil, fs, cfg, blackouts)
 	case "mysql":
-		return provider.NewAvailabilityRepository(db, nil, cfg)
+		repo = provider.NewAvailabilityRepository(db, nil, cfg, blackouts)
 	default:
 		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
-		return provider.NewAvailabilityRepository(db, fs, cfg)
+		repo = provider.NewAvailabilityRepository(db, fs, cfg, blackouts)
+	}
+	return calendarfeed.NewAvailabilityRepository(repo, httpClient, cfg)
+}
+
+func provideScheduleBlackoutRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.ScheduleBlackoutRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewScheduleBlackoutRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewScheduleBlackoutRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewScheduleBlackoutRepository(db, fs, cfg)
+	}
+}
+
+func provideWaitlistRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.WaitlistRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewWaitlistRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewWaitlistRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewWaitlistRepository(db, fs, cfg)
 	}
 }
 
@@ -330,7 +437,7 @@ func provideBlogRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Cli
 	}
 }
 
-func provideMeetingReservationRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.MeetingReservationRepository {
+func provideMeetingReservationRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) (repository.MeetingReservationRepository, error) {
 	driver := normalizedDriver(cfg)
 	switch driver {
 	case "firestore":
@@ -356,6 +463,84 @@ func provideMeetingNotificationRepository(cfg *config.AppConfig, db *sqlx.DB, fs
 	}
 }
 
+func provideContactNotificationRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.ContactNotificationRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewContactNotificationRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewContactNotificationRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewContactNotificationRepository(db, fs, cfg)
+	}
+}
+
+func provideSpamRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.SpamRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewSpamRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewSpamRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewSpamRepository(db, fs, cfg)
+	}
+}
+
+func provideCalendarFeedTokenRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.CalendarFeedTokenRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewCalendarFeedTokenRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewCalendarFeedTokenRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewCalendarFeedTokenRepository(db, fs, cfg)
+	}
+}
+
+func provideReservationDriftRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.ReservationDriftRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewReservationDriftRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewReservationDriftRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewReservationDriftRepository(db, fs, cfg)
+	}
+}
+
+func provideNotificationTemplateRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.NotificationTemplateRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewNotificationTemplateRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewNotificationTemplateRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewNotificationTemplateRepository(db, fs, cfg)
+	}
+}
+
+func provideBookingOutboxRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client, reservations repository.MeetingReservationRepository) repository.BookingOutboxRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewBookingOutboxRepository(nil, fs, cfg, reservations)
+	case "mysql":
+		return provider.NewBookingOutboxRepository(db, nil, cfg, reservations)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewBookingOutboxRepository(db, fs, cfg, reservations)
+	}
+}
+
 func provideBlacklistRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.BlacklistRepository {
 	driver := normalizedDriver(cfg)
 	switch driver {
@@ -369,6 +554,19 @@ func provideBlacklistRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestor
 	}
 }
 
+func provideIdempotencyRepository(cfg *config.AppConfig, db *sqlx.DB, fs *firestore.Client) repository.IdempotencyRepository {
+	driver := normalizedDriver(cfg)
+	switch driver {
+	case "firestore":
+		return provider.NewIdempotencyRepository(nil, fs, cfg)
+	case "mysql":
+		return provider.NewIdempotencyRepository(db, nil, cfg)
+	default:
+		log.Printf("unknown db_driver %q; defaulting to mysql if available", driver)
+		return provider.NewIdempotencyRepository(db, fs, cfg)
+	}
+}
+
 func normalizedDriver(cfg *config.AppConfig) string {
 	if cfg == nil {
 		return ""
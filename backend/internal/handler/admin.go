@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -409,6 +411,23 @@ func (h *AdminHandler) ListContacts(c *gin.Context) {
 	c.JSON(http.StatusOK, messages)
 }
 
+// ExportContacts streams contact messages as CSV or NDJSON.
+func (h *AdminHandler) ExportContacts(c *gin.Context) {
+	filter, err := parseContactFilter(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	format, columns := parseExportOptions(c)
+
+	export, err := h.svc.ExportContactMessages(c.Request.Context(), adminsvc.ContactExport{Filter: filter, Format: format, Columns: columns})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	streamExport(c, "contacts", export)
+}
+
 func (h *AdminHandler) GetContact(c *gin.Context) {
 	id := strings.TrimSpace(c.Param("id"))
 	if id == "" {
@@ -445,6 +464,31 @@ func (h *AdminHandler) UpdateContact(c *gin.Context) {
 	c.JSON(http.StatusOK, message)
 }
 
+// MarkContactSpam flags a message as spam and teaches the spam filter to catch similar ones.
+func (h *AdminHandler) MarkContactSpam(c *gin.Context) {
+	h.classifyContact(c, model.SpamVerdictSpam)
+}
+
+// MarkContactHam releases a message from spam and teaches the spam filter to trust it.
+func (h *AdminHandler) MarkContactHam(c *gin.Context) {
+	h.classifyContact(c, model.SpamVerdictHam)
+}
+
+func (h *AdminHandler) classifyContact(c *gin.Context, verdict model.SpamVerdict) {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid id", nil))
+		return
+	}
+
+	message, err := h.svc.ClassifyContactMessage(c.Request.Context(), id, verdict)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, message)
+}
+
 func (h *AdminHandler) DeleteContact(c *gin.Context) {
 	id := strings.TrimSpace(c.Param("id"))
 	if id == "" {
@@ -513,34 +557,117 @@ func (h *AdminHandler) DeleteBlacklist(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-// Reservations ----------------------------------------------------------------
+// Blackouts ---------------------------------------------------------------
 
-type reservationResponse struct {
-	ID                     uint64                         `json:"id"`
-	Name                   string                         `json:"name"`
-	Email                  string                         `json:"email"`
-	Topic                  string                         `json:"topic"`
-	Message                string                         `json:"message"`
-	StartAt                time.Time                      `json:"startAt"`
-	EndAt                  time.Time                      `json:"endAt"`
-	DurationMinutes        int                            `json:"durationMinutes"`
-	GoogleEventID          string                         `json:"googleEventId,omitempty"`
-	GoogleCalendarStatus   string                         `json:"googleCalendarStatus,omitempty"`
-	Status                 model.MeetingReservationStatus `json:"status"`
-	ConfirmationSentAt     *time.Time                     `json:"confirmationSentAt,omitempty"`
-	LastNotificationSentAt *time.Time                     `json:"lastNotificationSentAt,omitempty"`
-	LookupHash             string                         `json:"lookupHash"`
-	CancellationReason     string                         `json:"cancellationReason,omitempty"`
-	CreatedAt              time.Time                      `json:"createdAt"`
-	UpdatedAt              time.Time                      `json:"updatedAt"`
-	Notifications          []model.MeetingNotification    `json:"notifications,omitempty"`
+func (h *AdminHandler) ListBlackouts(c *gin.Context) {
+	blackouts, err := h.svc.ListBlackouts(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": blackouts})
+}
+
+func (h *AdminHandler) GetBlackout(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+	blackout, err := h.svc.GetBlackout(c.Request.Context(), uint64(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": blackout})
 }
 
+func (h *AdminHandler) CreateBlackout(c *gin.Context) {
+	var req blackoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid blackout payload", err))
+		return
+	}
+	blackout, err := h.svc.CreateBlackout(c.Request.Context(), req.toInput())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": blackout})
+}
+
+func (h *AdminHandler) UpdateBlackout(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+	var req blackoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid blackout payload", err))
+		return
+	}
+	blackout, err := h.svc.UpdateBlackout(c.Request.Context(), uint64(id), req.toInput())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": blackout})
+}
+
+func (h *AdminHandler) DeleteBlackout(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteBlackout(c.Request.Context(), uint64(id)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Reservations ----------------------------------------------------------------
+
+type reservationResponse struct {
+	ID                     uint64                                 `json:"id"`
+	Name                   string                                 `json:"name"`
+	Email                  string                                 `json:"email"`
+	Topic                  string                                 `json:"topic"`
+	Message                string                                 `json:"message"`
+	IntakeAnswers          []model.IntakeAnswer                   `json:"intakeAnswers,omitempty"`
+	StartAt                time.Time                              `json:"startAt"`
+	EndAt                  time.Time                              `json:"endAt"`
+	DurationMinutes        int                                    `json:"durationMinutes"`
+	GoogleEventID          string                                 `json:"googleEventId,omitempty"`
+	GoogleCalendarStatus   string                                 `json:"googleCalendarStatus,omitempty"`
+	Status                 model.MeetingReservationStatus         `json:"status"`
+	RequiresApproval       bool                                   `json:"requiresApproval"`
+	ConfirmationSentAt     *time.Time                             `json:"confirmationSentAt,omitempty"`
+	LastNotificationSentAt *time.Time                             `json:"lastNotificationSentAt,omitempty"`
+	LookupHash             string                                 `json:"lookupHash"`
+	CancellationReason     string                                 `json:"cancellationReason,omitempty"`
+	CreatedAt              time.Time                              `json:"createdAt"`
+	UpdatedAt              time.Time                              `json:"updatedAt"`
+	Notifications          []model.MeetingNotification            `json:"notifications,omitempty"`
+	NotificationAttempts   []model.MeetingNotificationAttempt     `json:"notificationAttempts,omitempty"`
+	StatusHistory          []model.MeetingReservationStatusChange `json:"statusHistory"`
+	BlacklistSuggestion    *model.BlacklistSuggestion             `json:"blacklistSuggestion,omitempty"`
+}
+
+// reservationUpdateRequest carries an administrator's status change. Reason is recorded in the
+// status history; CancellationReason is kept for older clients.
 type reservationUpdateRequest struct {
 	Status             string `json:"status"`
+	Reason             string `json:"reason"`
 	CancellationReason string `json:"cancellationReason"`
 }
 
+// legacyReservationStatuses maps the statuses used before the lifecycle gained explicit states.
+// An administrator cancelling with the old value cancels on the owner's behalf.
+var legacyReservationStatuses = map[string]model.MeetingReservationStatus{
+	"pending":   model.MeetingReservationStatusRequested,
+	"cancelled": model.MeetingReservationStatusCancelledByOwner,
+}
+
 func (h *AdminHandler) ListReservations(c *gin.Context) {
 	filter, err := parseReservationFilter(c)
 	if err != nil {
@@ -556,12 +683,12 @@ func (h *AdminHandler) ListReservations(c *gin.Context) {
 
 	response := make([]reservationResponse, 0, len(reservations))
 	for _, reservation := range reservations {
-		notifications, err := h.svc.ListReservationNotifications(c.Request.Context(), reservation.ID)
+		item, err := h.reservationResponse(c.Request.Context(), reservation)
 		if err != nil {
 			respondError(c, err)
 			return
 		}
-		response = append(response, makeReservationResponse(reservation, notifications))
+		response = append(response, item)
 	}
 
 	c.JSON(http.StatusOK, gin.H{"data": response})
@@ -579,20 +706,28 @@ func (h *AdminHandler) UpdateReservationStatus(c *gin.Context) {
 		return
 	}
 
-	status := model.MeetingReservationStatus(strings.TrimSpace(strings.ToLower(req.Status)))
-	reservation, err := h.svc.UpdateReservationStatus(c.Request.Context(), uint64(id), status, req.CancellationReason)
+	value := strings.TrimSpace(strings.ToLower(req.Status))
+	status := model.MeetingReservationStatus(value)
+	if legacy, ok := legacyReservationStatuses[value]; ok {
+		status = legacy
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = req.CancellationReason
+	}
+	reservation, err := h.svc.UpdateReservationStatus(c.Request.Context(), uint64(id), status, reason)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
 
-	notifications, err := h.svc.ListReservationNotifications(c.Request.Context(), reservation.ID)
+	response, err := h.reservationResponse(c.Request.Context(), *reservation)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": makeReservationResponse(*reservation, notifications)})
+	c.JSON(http.StatusOK, gin.H{"data": response})
 }
 
 func (h *AdminHandler) RetryReservationNotification(c *gin.Context) {
@@ -607,13 +742,57 @@ func (h *AdminHandler) RetryReservationNotification(c *gin.Context) {
 		return
 	}
 
-	notifications, err := h.svc.ListReservationNotifications(c.Request.Context(), reservation.ID)
+	response, err := h.reservationResponse(c.Request.Context(), *reservation)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": makeReservationResponse(*reservation, notifications)})
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// ExportReservations streams the reservations matching the list filters (plus from/to) as
+// CSV, NDJSON, or ICS.
+func (h *AdminHandler) ExportReservations(c *gin.Context) {
+	filter, err := parseReservationFilter(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	format, columns := parseExportOptions(c)
+
+	export, err := h.svc.ExportReservations(c.Request.Context(), adminsvc.ReservationExport{Filter: filter, Format: format, Columns: columns})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	streamExport(c, "reservations", export)
+}
+
+// ListReservationDrift reports reservations whose Google Calendar event was deleted, moved,
+// or declined outside the app. Pass includeResolved=true to include reviewed entries.
+func (h *AdminHandler) ListReservationDrift(c *gin.Context) {
+	includeResolved := c.Query("includeResolved") == "true"
+	drifts, err := h.svc.ListReservationDrift(c.Request.Context(), includeResolved)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": drifts})
+}
+
+func (h *AdminHandler) ResolveReservationDrift(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	drift, err := h.svc.ResolveReservationDrift(c.Request.Context(), uint64(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": drift})
 }
 
 func parseReservationFilter(c *gin.Context) (adminsvc.ReservationFilter, error) {
@@ -631,10 +810,13 @@ func parseReservationFilter(c *gin.Context) (adminsvc.ReservationFilter, error)
 					continue
 				}
 				status := model.MeetingReservationStatus(value)
-				switch status {
-				case model.MeetingReservationStatusPending,
-					model.MeetingReservationStatusConfirmed,
-					model.MeetingReservationStatusCancelled:
+				switch {
+				case value == "cancelled":
+					// Older clients filter on the single cancelled status.
+					filter.Status = append(filter.Status, model.MeetingReservationStatusCancelledByVisitor, model.MeetingReservationStatusCancelledByOwner)
+				case value == "pending":
+					filter.Status = append(filter.Status, model.MeetingReservationStatusRequested)
+				case status.Valid():
 					filter.Status = append(filter.Status, status)
 				default:
 					return adminsvc.ReservationFilter{}, errs.New(
@@ -663,9 +845,135 @@ func parseReservationFilter(c *gin.Context) (adminsvc.ReservationFilter, error)
 		filter.Date = &parsed
 	}
 
+	from, before, err := parseDateRange(c)
+	if err != nil {
+		return adminsvc.ReservationFilter{}, err
+	}
+	filter.StartFrom = from
+	filter.StartBefore = before
+
+	return filter, nil
+}
+
+// parseDateRange reads the inclusive from/to days (YYYY-MM-DD, UTC) and returns them as the
+// half-open range [from, to+1 day).
+func parseDateRange(c *gin.Context) (*time.Time, *time.Time, error) {
+	var from, before *time.Time
+	if value := strings.TrimSpace(c.Query("from")); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid from date (expected YYYY-MM-DD)", err)
+		}
+		from = &parsed
+	}
+	if value := strings.TrimSpace(c.Query("to")); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid to date (expected YYYY-MM-DD)", err)
+		}
+		next := parsed.Add(24 * time.Hour)
+		before = &next
+	}
+	if from != nil && before != nil && !from.Before(*before) {
+		return nil, nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "from must not be after to", nil)
+	}
+	return from, before, nil
+}
+
+func parseContactFilter(c *gin.Context) (adminsvc.ContactFilter, error) {
+	filter := adminsvc.ContactFilter{}
+
+	for _, raw := range c.QueryArray("status") {
+		for _, piece := range strings.Split(raw, ",") {
+			value := model.ContactStatus(strings.TrimSpace(strings.ToLower(piece)))
+			switch value {
+			case "":
+				continue
+			case model.ContactStatusPending, model.ContactStatusInReview, model.ContactStatusResolved, model.ContactStatusArchived, model.ContactStatusSpam:
+				filter.Status = append(filter.Status, value)
+			default:
+				return adminsvc.ContactFilter{}, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("unsupported contact status %q", value), nil)
+			}
+		}
+	}
+
+	filter.Email = strings.TrimSpace(c.Query("email"))
+
+	if dateString := strings.TrimSpace(c.Query("date")); dateString != "" {
+		parsed, err := time.Parse("2006-01-02", dateString)
+		if err != nil {
+			return adminsvc.ContactFilter{}, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid date format (expected YYYY-MM-DD)", err)
+		}
+		filter.Date = &parsed
+	}
+
+	from, before, err := parseDateRange(c)
+	if err != nil {
+		return adminsvc.ContactFilter{}, err
+	}
+	filter.CreatedFrom = from
+	filter.CreatedBefore = before
+
 	return filter, nil
 }
 
+// parseExportOptions reads ?format= and the comma-separated ?columns= list.
+func parseExportOptions(c *gin.Context) (adminsvc.ExportFormat, []string) {
+	var columns []string
+	for _, raw := range c.QueryArray("columns") {
+		for _, piece := range strings.Split(raw, ",") {
+			if name := strings.TrimSpace(piece); name != "" {
+				columns = append(columns, name)
+			}
+		}
+	}
+	return adminsvc.ExportFormat(c.Query("format")), columns
+}
+
+// streamExport sends the export as a download. The status is committed before the rows are
+// written, so a failure midway can only be logged and cut the response short.
+func streamExport(c *gin.Context, name string, export *adminsvc.Export) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), export.Extension)
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Cache-Control", "private, no-store")
+	c.Status(http.StatusOK)
+	if err := export.Write(c.Writer); err != nil {
+		log.Printf("admin export %s: %v", filename, err)
+		c.Abort()
+	}
+}
+
+// reservationResponse loads the notifications and their delivery attempts, status history and any
+// blacklist suggestion shown with a reservation in the admin view.
+func (h *AdminHandler) reservationResponse(ctx context.Context, reservation model.MeetingReservation) (reservationResponse, error) {
+	notifications, err := h.svc.ListReservationNotifications(ctx, reservation.ID)
+	if err != nil {
+		return reservationResponse{}, err
+	}
+	attempts, err := h.svc.ListReservationNotificationAttempts(ctx, reservation.ID)
+	if err != nil {
+		return reservationResponse{}, err
+	}
+	history, err := h.svc.ListReservationHistory(ctx, reservation.ID)
+	if err != nil {
+		return reservationResponse{}, err
+	}
+	suggestion, err := h.svc.SuggestBlacklist(ctx, &reservation)
+	if err != nil {
+		return reservationResponse{}, err
+	}
+
+	response := makeReservationResponse(reservation, notifications)
+	response.NotificationAttempts = attempts
+	response.StatusHistory = history
+	if response.StatusHistory == nil {
+		response.StatusHistory = []model.MeetingReservationStatusChange{}
+	}
+	response.BlacklistSuggestion = suggestion
+	return response, nil
+}
+
 func makeReservationResponse(reservation model.MeetingReservation, notifications []model.MeetingNotification) reservationResponse {
 	response := reservationResponse{
 		ID:                     reservation.ID,
@@ -673,12 +981,14 @@ func makeReservationResponse(reservation model.MeetingReservation, notifications
 		Email:                  strings.TrimSpace(reservation.Email),
 		Topic:                  strings.TrimSpace(reservation.Topic),
 		Message:                reservation.Message,
+		IntakeAnswers:          reservation.IntakeAnswers,
 		StartAt:                reservation.StartAt,
 		EndAt:                  reservation.EndAt,
 		DurationMinutes:        reservation.DurationMinutes,
 		GoogleEventID:          strings.TrimSpace(reservation.GoogleEventID),
 		GoogleCalendarStatus:   strings.TrimSpace(reservation.GoogleCalendarStatus),
 		Status:                 reservation.Status,
+		RequiresApproval:       reservation.RequiresApproval,
 		ConfirmationSentAt:     reservation.ConfirmationSentAt,
 		LastNotificationSentAt: reservation.LastNotificationSentAt,
 		LookupHash:             reservation.LookupHash,
@@ -1048,6 +1358,14 @@ type blacklistRequest struct {
 	Reason string `json:"reason"`
 }
 
+type blackoutRequest struct {
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Reason     string    `json:"reason"`
+	Recurrence string    `json:"recurrence"`
+	Timezone   string    `json:"timezone"`
+}
+
 type techCatalogRequest struct {
 	Slug        string `json:"slug"`
 	DisplayName string `json:"displayName"`
@@ -1132,25 +1450,29 @@ type meetingTemplateRequest struct {
 }
 
 type contactSettingsRequest struct {
-	ID                 uint64                `json:"id"`
-	HeroTitle          model.LocalizedText   `json:"heroTitle"`
-	HeroDescription    model.LocalizedText   `json:"heroDescription"`
-	Topics             []contactTopicRequest `json:"topics"`
-	ConsentText        model.LocalizedText   `json:"consentText"`
-	MinimumLeadHours   int                   `json:"minimumLeadHours"`
-	RecaptchaSiteKey   string                `json:"recaptchaSiteKey"`
-	SupportEmail       string                `json:"supportEmail"`
-	CalendarTimezone   string                `json:"calendarTimezone"`
-	GoogleCalendarID   string                `json:"googleCalendarId"`
-	BookingWindowDays  int                   `json:"bookingWindowDays"`
-	MeetingURLTemplate string                `json:"meetingUrlTemplate"`
-	UpdatedAt          string                `json:"updatedAt"`
+	ID                  uint64                `json:"id"`
+	HeroTitle           model.LocalizedText   `json:"heroTitle"`
+	HeroDescription     model.LocalizedText   `json:"heroDescription"`
+	Topics              []contactTopicRequest `json:"topics"`
+	ConsentText         model.LocalizedText   `json:"consentText"`
+	MinimumLeadHours    int                   `json:"minimumLeadHours"`
+	RecaptchaSiteKey    string                `json:"recaptchaSiteKey"`
+	SupportEmail        string                `json:"supportEmail"`
+	CalendarTimezone    string                `json:"calendarTimezone"`
+	GoogleCalendarID    string                `json:"googleCalendarId"`
+	BookingWindowDays   int                   `json:"bookingWindowDays"`
+	MeetingURLTemplate  string                `json:"meetingUrlTemplate"`
+	ConflictCalendarIDs []string              `json:"conflictCalendarIds"`
+	WorkingHours        *model.WorkingHours   `json:"workingHours"`
+	UpdatedAt           string                `json:"updatedAt"`
 }
 
 type contactTopicRequest struct {
-	ID          string              `json:"id"`
-	Label       model.LocalizedText `json:"label"`
-	Description model.LocalizedText `json:"description"`
+	ID               string                 `json:"id"`
+	Label            model.LocalizedText    `json:"label"`
+	Description      model.LocalizedText    `json:"description"`
+	RequiresApproval bool                   `json:"requiresApproval"`
+	Questions        []model.IntakeQuestion `json:"questions"`
 }
 
 type homeSettingsRequest struct {
@@ -1193,26 +1515,30 @@ func (r contactSettingsRequest) toInput() (adminsvc.ContactSettingsInput, error)
 	topics := make([]adminsvc.ContactTopicInput, 0, len(r.Topics))
 	for _, topic := range r.Topics {
 		topics = append(topics, adminsvc.ContactTopicInput{
-			ID:          topic.ID,
-			Label:       topic.Label,
-			Description: topic.Description,
+			ID:               topic.ID,
+			Label:            topic.Label,
+			Description:      topic.Description,
+			RequiresApproval: topic.RequiresApproval,
+			Questions:        topic.Questions,
 		})
 	}
 
 	return adminsvc.ContactSettingsInput{
-		ID:                 r.ID,
-		HeroTitle:          r.HeroTitle,
-		HeroDescription:    r.HeroDescription,
-		Topics:             topics,
-		ConsentText:        r.ConsentText,
-		MinimumLeadHours:   r.MinimumLeadHours,
-		RecaptchaSiteKey:   r.RecaptchaSiteKey,
-		SupportEmail:       r.SupportEmail,
-		CalendarTimezone:   r.CalendarTimezone,
-		GoogleCalendarID:   r.GoogleCalendarID,
-		BookingWindowDays:  r.BookingWindowDays,
-		MeetingURLTemplate: strings.TrimSpace(r.MeetingURLTemplate),
-		ExpectedUpdatedAt:  parsed,
+		ID:                  r.ID,
+		HeroTitle:           r.HeroTitle,
+		HeroDescription:     r.HeroDescription,
+		Topics:              topics,
+		ConsentText:         r.ConsentText,
+		MinimumLeadHours:    r.MinimumLeadHours,
+		RecaptchaSiteKey:    r.RecaptchaSiteKey,
+		SupportEmail:        r.SupportEmail,
+		CalendarTimezone:    r.CalendarTimezone,
+		GoogleCalendarID:    r.GoogleCalendarID,
+		BookingWindowDays:   r.BookingWindowDays,
+		MeetingURLTemplate:  strings.TrimSpace(r.MeetingURLTemplate),
+		ConflictCalendarIDs: r.ConflictCalendarIDs,
+		WorkingHours:        r.WorkingHours,
+		ExpectedUpdatedAt:   parsed,
 	}, nil
 }
 
@@ -1267,6 +1593,16 @@ func (r blacklistRequest) toInput() adminsvc.BlacklistInput {
 	}
 }
 
+func (r blackoutRequest) toInput() adminsvc.BlackoutInput {
+	return adminsvc.BlackoutInput{
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+		Reason:     r.Reason,
+		Recurrence: r.Recurrence,
+		Timezone:   r.Timezone,
+	}
+}
+
 func parseRFC3339Timestamp(value string) (time.Time, error) {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
@@ -28,6 +28,11 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		return
 	}
 
+	req.RemoteIP = c.ClientIP()
+	if req.Locale == "" {
+		req.Locale = c.GetHeader("Accept-Language")
+	}
+
 	result, err := h.booking.Book(c.Request.Context(), req)
 	if err != nil {
 		respondError(c, err)
@@ -52,3 +57,43 @@ func (h *BookingHandler) GetReservation(c *gin.Context) {
 		"data": result,
 	})
 }
+
+// CancelReservation lets a visitor cancel their own booking using the lookup hash.
+func (h *BookingHandler) CancelReservation(c *gin.Context) {
+	var req model.CancelRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid cancellation payload", err))
+			return
+		}
+	}
+
+	result, err := h.booking.CancelReservation(c.Request.Context(), c.Param("lookupHash"), req.Reason)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// RescheduleReservation moves a visitor's booking to a new slot using the lookup hash.
+func (h *BookingHandler) RescheduleReservation(c *gin.Context) {
+	var req model.RescheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid reschedule payload", err))
+		return
+	}
+
+	result, err := h.booking.RescheduleReservation(c.Request.Context(), c.Param("lookupHash"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/service"
+)
+
+// CalendarFeedHandler serves the private reservations feed and its token administration.
+type CalendarFeedHandler struct {
+	feeds service.CalendarFeedService
+}
+
+// NewCalendarFeedHandler wires the calendar feed service into an HTTP handler.
+func NewCalendarFeedHandler(feeds service.CalendarFeedService) *CalendarFeedHandler {
+	return &CalendarFeedHandler{feeds: feeds}
+}
+
+// ReservationsFeed renders reservations and blackouts as iCalendar for the token in ?token=.
+func (h *CalendarFeedHandler) ReservationsFeed(c *gin.Context) {
+	body, err := h.feeds.RenderReservationsFeed(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, `text/calendar; charset="utf-8"`, body)
+}
+
+// ListTokens returns the issued feed tokens, including revoked ones, without their secrets.
+func (h *CalendarFeedHandler) ListTokens(c *gin.Context) {
+	tokens, err := h.feeds.ListTokens(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tokens})
+}
+
+type calendarFeedTokenRequest struct {
+	Label string `json:"label"`
+}
+
+// IssueToken creates a token with an optional label and returns its secret and feed path once.
+func (h *CalendarFeedHandler) IssueToken(c *gin.Context) {
+	var req calendarFeedTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid calendar feed token payload", err))
+		return
+	}
+	issued, err := h.feeds.IssueToken(c.Request.Context(), req.Label)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": issued})
+}
+
+// RevokeToken disables the token in :id so the feed stops answering to it.
+func (h *CalendarFeedHandler) RevokeToken(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+	revoked, err := h.feeds.RevokeToken(c.Request.Context(), uint64(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": revoked})
+}
@@ -37,6 +37,11 @@ func (h *ContactHandler) SubmitContact(c *gin.Context) {
 		return
 	}
 
+	req.RemoteIP = c.ClientIP()
+	if req.Locale == "" {
+		req.Locale = c.GetHeader("Accept-Language")
+	}
+
 	submission, err := h.contact.SubmitContact(c.Request.Context(), &req)
 	if err != nil {
 		respondError(c, err)
@@ -69,6 +74,8 @@ func (h *ContactHandler) GetAvailability(c *gin.Context) {
 		opts.Days = value
 	}
 
+	opts.Timezone = c.Query("tz")
+
 	availability, err := h.availability.GetAvailability(c.Request.Context(), opts)
 	if err != nil {
 		respondError(c, err)
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/middleware"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/service"
+)
+
+// NotificationTemplateHandler exposes admin editing, preview and test-send of email templates.
+type NotificationTemplateHandler struct {
+	templates service.NotificationTemplateService
+}
+
+// NewNotificationTemplateHandler wires the notification template service into an HTTP handler.
+func NewNotificationTemplateHandler(templates service.NotificationTemplateService) *NotificationTemplateHandler {
+	return &NotificationTemplateHandler{templates: templates}
+}
+
+func (h *NotificationTemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templates.ListTemplates(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": templates})
+}
+
+func (h *NotificationTemplateHandler) GetTemplate(c *gin.Context) {
+	template, err := h.templates.GetTemplate(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": template})
+}
+
+func (h *NotificationTemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req model.NotificationTemplateInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid notification template payload", err))
+		return
+	}
+	template, err := h.templates.UpdateTemplate(c.Request.Context(), c.Param("key"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": template})
+}
+
+// ResetTemplate drops the admin override so the built-in template is used again.
+func (h *NotificationTemplateHandler) ResetTemplate(c *gin.Context) {
+	template, err := h.templates.ResetTemplate(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": template})
+}
+
+// notificationPreviewRequest optionally carries an unsaved draft; without one the saved
+// template is rendered.
+type notificationPreviewRequest struct {
+	Locale   string                           `json:"locale"`
+	Template *model.NotificationTemplateInput `json:"template"`
+}
+
+func (h *NotificationTemplateHandler) PreviewTemplate(c *gin.Context) {
+	req, ok := bindNotificationPreview(c)
+	if !ok {
+		return
+	}
+	rendered, err := h.templates.PreviewTemplate(c.Request.Context(), c.Param("key"), req.Locale, req.Template)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": rendered})
+}
+
+// SendTestNotification mails the rendered preview to the signed-in admin.
+func (h *NotificationTemplateHandler) SendTestNotification(c *gin.Context) {
+	req, ok := bindNotificationPreview(c)
+	if !ok {
+		return
+	}
+	var recipient string
+	if session, ok := middleware.GetSessionFromContext(c); ok {
+		recipient = session.Email
+	}
+	rendered, err := h.templates.SendTestNotification(c.Request.Context(), c.Param("key"), req.Locale, req.Template, recipient)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": rendered, "recipient": recipient})
+}
+
+func bindNotificationPreview(c *gin.Context) (notificationPreviewRequest, bool) {
+	var req notificationPreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid notification preview payload", err))
+		return req, false
+	}
+	return req, true
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/service"
+)
+
+// WaitlistHandler exposes joining the waitlist and claiming offered slots.
+type WaitlistHandler struct {
+	waitlist service.WaitlistService
+}
+
+// NewWaitlistHandler wires the waitlist service into an HTTP handler.
+func NewWaitlistHandler(waitlist service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{waitlist: waitlist}
+}
+
+// JoinWaitlist records a visitor waiting for a slot on one of their preferred dates.
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	var req model.WaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid waitlist payload", err))
+		return
+	}
+
+	req.RemoteIP = c.ClientIP()
+	if req.Locale == "" {
+		req.Locale = c.GetHeader("Accept-Language")
+	}
+
+	entry, err := h.waitlist.Join(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": entry,
+	})
+}
+
+// GetOffer describes the slot offered to the holder of the claim token.
+func (h *WaitlistHandler) GetOffer(c *gin.Context) {
+	offer, err := h.waitlist.GetOffer(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": offer,
+	})
+}
+
+// ClaimOffer books the offered slot for the waitlisted visitor.
+func (h *WaitlistHandler) ClaimOffer(c *gin.Context) {
+	var req model.WaitlistClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid waitlist claim payload", err))
+		return
+	}
+
+	result, err := h.waitlist.ClaimOffer(c.Request.Context(), req.Token)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": result,
+	})
+}
@@ -0,0 +1,213 @@
+// Package calendarfeed reads busy time from read-only external calendars published as ICS
+// feeds or CalDAV collections.
+package calendarfeed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+const (
+	sourceTypeICS    = "ics"
+	sourceTypeCalDAV = "caldav"
+
+	// maxFeedBytes bounds how much of a feed is read so a misbehaving server cannot exhaust memory.
+	maxFeedBytes = 10 << 20
+)
+
+type availabilityRepository struct {
+	base    repository.AvailabilityRepository
+	client  *http.Client
+	sources []*source
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+type source struct {
+	cfg      config.ExternalCalendarConfig
+	location *time.Location
+
+	mu     sync.Mutex
+	cached *cacheEntry
+}
+
+// cacheEntry holds the events fetched for [from, to). A zero range means the whole feed.
+type cacheEntry struct {
+	events    []ics.ParsedEvent
+	from      time.Time
+	to        time.Time
+	fetchedAt time.Time
+}
+
+func (e *cacheEntry) covers(from, to time.Time) bool {
+	if e.from.IsZero() && e.to.IsZero() {
+		return true
+	}
+	return !from.Before(e.from) && !to.After(e.to)
+}
+
+// NewAvailabilityRepository adds the busy windows of the configured external calendars to
+// those reported by base. Each source is fetched at most once per cache TTL; when a refresh
+// fails the last good copy keeps being served, and a source that has never loaded is skipped
+// so an unreachable feed cannot take booking offline. Without sources base is returned as is.
+func NewAvailabilityRepository(base repository.AvailabilityRepository, client *http.Client, cfg *config.AppConfig) repository.AvailabilityRepository {
+	if cfg == nil || len(cfg.Booking.ExternalCalendars) == 0 {
+		return base
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	defaultLoc := time.UTC
+	if loc, err := time.LoadLocation(strings.TrimSpace(cfg.Contact.Timezone)); err == nil {
+		defaultLoc = loc
+	}
+
+	sources := make([]*source, 0, len(cfg.Booking.ExternalCalendars))
+	for _, sourceCfg := range cfg.Booking.ExternalCalendars {
+		sourceCfg.Type = strings.ToLower(strings.TrimSpace(sourceCfg.Type))
+		if sourceCfg.Type == "" {
+			sourceCfg.Type = sourceTypeICS
+		}
+		if strings.TrimSpace(sourceCfg.URL) == "" || (sourceCfg.Type != sourceTypeICS && sourceCfg.Type != sourceTypeCalDAV) {
+			log.Printf("external calendar %q: skipped (type %q, url set=%v)", sourceCfg.Name, sourceCfg.Type, sourceCfg.URL != "")
+			continue
+		}
+		location := defaultLoc
+		if tz := strings.TrimSpace(sourceCfg.Timezone); tz != "" {
+			if loc, err := time.LoadLocation(tz); err == nil {
+				location = loc
+			} else {
+				log.Printf("external calendar %q: invalid timezone %q; using %s", sourceCfg.Name, tz, defaultLoc)
+			}
+		}
+		sources = append(sources, &source{cfg: sourceCfg, location: location})
+	}
+	if len(sources) == 0 {
+		return base
+	}
+
+	return &availabilityRepository{
+		base:    base,
+		client:  client,
+		sources: sources,
+		ttl:     cfg.Booking.ExternalCalendarCacheTTL,
+		now:     time.Now,
+	}
+}
+
+func (r *availabilityRepository) ListBusyWindows(ctx context.Context, from, to time.Time) ([]model.TimeWindow, error) {
+	var windows []model.TimeWindow
+	if r.base != nil {
+		local, err := r.base.ListBusyWindows(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, local...)
+	}
+
+	for _, src := range r.sources {
+		events, err := r.events(ctx, src, from, to)
+		if err != nil {
+			log.Printf("external calendar %q: %v", src.cfg.Name, err)
+			continue
+		}
+		windows = append(windows, busyWindows(events, from, to)...)
+	}
+	return windows, nil
+}
+
+// events returns the source's events for [from, to), refreshing the cache when it is stale or
+// does not cover the range. Holding the source lock while fetching collapses concurrent misses
+// into a single request.
+func (r *availabilityRepository) events(ctx context.Context, src *source, from, to time.Time) ([]ics.ParsedEvent, error) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	now := r.now()
+	cached := src.cached
+	if cached != nil && cached.covers(from, to) && now.Sub(cached.fetchedAt) < r.ttl {
+		return cached.events, nil
+	}
+
+	entry, err := r.fetch(ctx, src, from, to)
+	if err != nil {
+		if cached != nil && cached.covers(from, to) {
+			log.Printf("external calendar %q: refresh failed, serving copy from %s: %v", src.cfg.Name, cached.fetchedAt.Format(time.RFC3339), err)
+			return cached.events, nil
+		}
+		return nil, err
+	}
+	entry.fetchedAt = now
+	src.cached = entry
+	return entry.events, nil
+}
+
+func (r *availabilityRepository) fetch(ctx context.Context, src *source, from, to time.Time) (*cacheEntry, error) {
+	if src.cfg.Type == sourceTypeCalDAV {
+		// Widen the query to whole UTC days so later lookups inside the range hit the cache.
+		rangeFrom := from.UTC().Truncate(24 * time.Hour)
+		rangeTo := to.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		events, err := r.queryCalDAV(ctx, src, rangeFrom, rangeTo)
+		if err != nil {
+			return nil, err
+		}
+		return &cacheEntry{events: events, from: rangeFrom, to: rangeTo}, nil
+	}
+
+	events, err := r.fetchICS(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheEntry{events: events}, nil
+}
+
+func (r *availabilityRepository) fetchICS(ctx context.Context, src *source) ([]ics.ParsedEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.cfg.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build feed request: %w", err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+	setBasicAuth(req, src.cfg)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch feed: unexpected status %d", resp.StatusCode)
+	}
+
+	events, err := ics.Parse(io.LimitReader(resp.Body, maxFeedBytes), src.location)
+	if err != nil {
+		return nil, fmt.Errorf("parse feed: %w", err)
+	}
+	return events, nil
+}
+
+func setBasicAuth(req *http.Request, cfg config.ExternalCalendarConfig) {
+	username := strings.TrimSpace(cfg.Username)
+	if username == "" {
+		return
+	}
+	var password string
+	if env := strings.TrimSpace(cfg.PasswordEnv); env != "" {
+		password = os.Getenv(env)
+	}
+	req.SetBasicAuth(username, password)
+}
+
+var _ repository.AvailabilityRepository = (*availabilityRepository)(nil)
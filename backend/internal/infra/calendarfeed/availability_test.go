@@ -0,0 +1,171 @@
+package calendarfeed
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+const timetableFeed = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:lecture\r\n" +
+	"DTSTART;TZID=Asia/Tokyo:20240506T103000\r\n" +
+	"DTEND;TZID=Asia/Tokyo:20240506T120000\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=6\r\n" +
+	"EXDATE;TZID=Asia/Tokyo:20240508T103000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:lecture\r\n" +
+	"RECURRENCE-ID;TZID=Asia/Tokyo:20240513T103000\r\n" +
+	"DTSTART;TZID=Asia/Tokyo:20240513T140000\r\n" +
+	"DTEND;TZID=Asia/Tokyo:20240513T153000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday\r\n" +
+	"DTSTART;VALUE=DATE:20240515\r\n" +
+	"TRANSP:TRANSPARENT\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestAvailabilityRepositoryMergesCachedICSFeed(t *testing.T) {
+	var (
+		requests atomic.Int32
+		failing  atomic.Bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "student" || password != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar")
+		_, _ = io.WriteString(w, timetableFeed)
+	}))
+	defer server.Close()
+
+	t.Setenv("TIMETABLE_PASSWORD", "s3cret")
+	local := model.TimeWindow{
+		Start:  time.Date(2024, 5, 6, 6, 0, 0, 0, time.UTC),
+		End:    time.Date(2024, 5, 6, 7, 0, 0, 0, time.UTC),
+		Source: model.BusyWindowSourceReservation,
+	}
+	repo := NewAvailabilityRepository(inmemory.NewAvailabilityRepositoryWithWindows([]model.TimeWindow{local}), server.Client(), &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "Asia/Tokyo"},
+		Booking: config.BookingConfig{
+			ExternalCalendarCacheTTL: time.Minute,
+			ExternalCalendars: []config.ExternalCalendarConfig{{
+				Name:        "timetable",
+				URL:         server.URL,
+				Username:    "student",
+				PasswordEnv: "TIMETABLE_PASSWORD",
+			}},
+		},
+	})
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo.(*availabilityRepository).now = func() time.Time { return now }
+
+	from := time.Date(2024, 5, 5, 15, 0, 0, 0, time.UTC)
+	to := from.Add(14 * 24 * time.Hour)
+	windows, err := repo.ListBusyWindows(context.Background(), from, to)
+	require.NoError(t, err)
+
+	var external []string
+	for _, window := range windows {
+		if window.Source == model.BusyWindowSourceExternal {
+			external = append(external, window.Start.Format(time.RFC3339)+"/"+window.End.Format(time.RFC3339))
+		}
+	}
+	require.Contains(t, windows, local)
+	require.Equal(t, []string{
+		"2024-05-06T01:30:00Z/2024-05-06T03:00:00Z",
+		"2024-05-13T05:00:00Z/2024-05-13T06:30:00Z", // moved instance replaces 10:30
+		"2024-05-15T01:30:00Z/2024-05-15T03:00:00Z",
+	}, external)
+
+	_, err = repo.ListBusyWindows(context.Background(), from.Add(24*time.Hour), from.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), requests.Load())
+
+	// Once the TTL lapses the feed is fetched again; a failing refresh keeps the last copy.
+	now = now.Add(2 * time.Minute)
+	failing.Store(true)
+	windows, err = repo.ListBusyWindows(context.Background(), from, to)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load())
+	require.Len(t, windows, 4)
+}
+
+func TestAvailabilityRepositoryQueriesCalDAVTimeRange(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		require.Equal(t, "REPORT", r.Method)
+		require.Equal(t, "1", r.Header.Get("Depth"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), `<C:time-range start="20240506T000000Z" end="20240508T000000Z"/>`)
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:cal="urn:ietf:params:xml:ns:caldav">
+  <d:response>
+    <d:href>/calendars/me/personal/dinner.ics</d:href>
+    <d:propstat>
+      <d:prop><cal:calendar-data>BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:dinner
+DTSTART:20240506T100000Z
+DTEND:20240506T113000Z
+END:VEVENT
+END:VCALENDAR
+</cal:calendar-data></d:prop>
+      <d:status>HTTP/1.1 200 OK</d:status>
+    </d:propstat>
+  </d:response>
+</d:multistatus>`)
+	}))
+	defer server.Close()
+
+	repo := NewAvailabilityRepository(inmemory.NewAvailabilityRepository(), server.Client(), &config.AppConfig{
+		Booking: config.BookingConfig{
+			ExternalCalendarCacheTTL: time.Minute,
+			ExternalCalendars: []config.ExternalCalendarConfig{{
+				Name: "personal",
+				Type: "CalDAV",
+				URL:  server.URL + "/calendars/me/personal/",
+			}},
+		},
+	})
+
+	from := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	windows, err := repo.ListBusyWindows(context.Background(), from, from.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []model.TimeWindow{{
+		Start:  time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC),
+		End:    time.Date(2024, 5, 6, 11, 30, 0, 0, time.UTC),
+		Source: model.BusyWindowSourceExternal,
+	}}, windows)
+
+	// A narrower booking check inside the fetched days is answered from the cache.
+	_, err = repo.ListBusyWindows(context.Background(), from.Add(time.Hour), from.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), requests.Load())
+}
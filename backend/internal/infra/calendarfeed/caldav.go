@@ -0,0 +1,81 @@
+package calendarfeed
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar/ics"
+)
+
+const calDAVTimeLayout = "20060102T150405Z"
+
+// calendarQueryTemplate is a CalDAV calendar-query REPORT (RFC 4791 7.8) asking for the data of
+// every VEVENT overlapping a time range. Servers return recurring series whole, so expansion
+// happens locally like for plain feeds.
+const calendarQueryTemplate = `<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop>
+    <C:calendar-data/>
+  </D:prop>
+  <C:filter>
+    <C:comp-filter name="VCALENDAR">
+      <C:comp-filter name="VEVENT">
+        <C:time-range start="%s" end="%s"/>
+      </C:comp-filter>
+    </C:comp-filter>
+  </C:filter>
+</C:calendar-query>`
+
+type multistatus struct {
+	Responses []struct {
+		Propstats []struct {
+			Status       string `xml:"status"`
+			CalendarData string `xml:"prop>calendar-data"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+func (r *availabilityRepository) queryCalDAV(ctx context.Context, src *source, from, to time.Time) ([]ics.ParsedEvent, error) {
+	body := fmt.Sprintf(calendarQueryTemplate, from.UTC().Format(calDAVTimeLayout), to.UTC().Format(calDAVTimeLayout))
+	req, err := http.NewRequestWithContext(ctx, "REPORT", src.cfg.URL, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build caldav report: %w", err)
+	}
+	req.Header.Set("Content-Type", `application/xml; charset="utf-8"`)
+	req.Header.Set("Depth", "1")
+	setBasicAuth(req, src.cfg)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("caldav report: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("caldav report: unexpected status %d", resp.StatusCode)
+	}
+
+	var result multistatus
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxFeedBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode caldav multistatus: %w", err)
+	}
+
+	var events []ics.ParsedEvent
+	for _, response := range result.Responses {
+		for _, propstat := range response.Propstats {
+			if !strings.Contains(propstat.Status, " 200 ") || strings.TrimSpace(propstat.CalendarData) == "" {
+				continue
+			}
+			parsed, err := ics.Parse(strings.NewReader(propstat.CalendarData), src.location)
+			if err != nil {
+				return nil, fmt.Errorf("parse caldav calendar data: %w", err)
+			}
+			events = append(events, parsed...)
+		}
+	}
+	return events, nil
+}
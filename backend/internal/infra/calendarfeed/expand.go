@@ -0,0 +1,88 @@
+package calendarfeed
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/schedule"
+)
+
+// busyWindows expands feed events into the external busy windows overlapping [from, to).
+// Recurring series are expanded with their RRULE minus EXDATEs and any occurrence replaced by
+// a RECURRENCE-ID instance; cancelled and transparent (free) events block nothing.
+func busyWindows(events []ics.ParsedEvent, from, to time.Time) []model.TimeWindow {
+	overridden := make(map[string]map[int64]struct{})
+	for _, event := range events {
+		if event.RecurrenceID.IsZero() || event.UID == "" {
+			continue
+		}
+		if overridden[event.UID] == nil {
+			overridden[event.UID] = make(map[int64]struct{})
+		}
+		overridden[event.UID][event.RecurrenceID.Unix()] = struct{}{}
+	}
+
+	var windows []model.TimeWindow
+	for _, event := range events {
+		if event.Status == ics.StatusCancelled || event.Transparency == ics.TranspTransparent {
+			continue
+		}
+		duration := event.End.Sub(event.Start)
+		if duration <= 0 {
+			continue
+		}
+
+		starts := []time.Time{event.Start}
+		if event.RRule != "" && event.RecurrenceID.IsZero() {
+			rule, err := schedule.ParseRule(event.RRule)
+			if err != nil {
+				log.Printf("external calendar event %q: %v; using its first occurrence only", event.UID, err)
+			} else {
+				starts = rule.Between(event.Start, from.Add(-duration), to)
+			}
+			starts = skipExcluded(starts, event.ExDates, overridden[event.UID])
+		}
+
+		for _, start := range starts {
+			end := start.Add(duration)
+			if !start.Before(to) || !end.After(from) {
+				continue
+			}
+			windows = append(windows, model.TimeWindow{
+				Start:  start.UTC(),
+				End:    end.UTC(),
+				Source: model.BusyWindowSourceExternal,
+			})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	return windows
+}
+
+func skipExcluded(starts, exdates []time.Time, overridden map[int64]struct{}) []time.Time {
+	if len(exdates) == 0 && len(overridden) == 0 {
+		return starts
+	}
+	excluded := make(map[int64]struct{}, len(exdates)+len(overridden))
+	for _, exdate := range exdates {
+		excluded[exdate.Unix()] = struct{}{}
+	}
+	for key := range overridden {
+		excluded[key] = struct{}{}
+	}
+
+	kept := starts[:0]
+	for _, start := range starts {
+		if _, ok := excluded[start.Unix()]; ok {
+			continue
+		}
+		kept = append(kept, start)
+	}
+	return kept
+}
@@ -0,0 +1,148 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+)
+
+const (
+	recaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	hcaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+
+	defaultMinScore = 0.5
+)
+
+// RecaptchaV3Verifier validates reCAPTCHA v3 tokens and enforces a minimum score.
+type RecaptchaV3Verifier struct {
+	site     siteVerifier
+	minScore float64
+}
+
+// NewRecaptchaV3Verifier constructs a reCAPTCHA v3 verifier. An empty endpoint uses Google's API.
+func NewRecaptchaV3Verifier(client *http.Client, secret, endpoint string, minScore float64) *RecaptchaV3Verifier {
+	if minScore <= 0 {
+		minScore = defaultMinScore
+	}
+	return &RecaptchaV3Verifier{
+		site:     newSiteVerifier("recaptcha", endpoint, recaptchaVerifyURL, secret, client),
+		minScore: minScore,
+	}
+}
+
+func (v *RecaptchaV3Verifier) Verify(ctx context.Context, req captcha.Request) error {
+	result, err := v.site.verify(ctx, req)
+	if err != nil {
+		return err
+	}
+	if expected := strings.TrimSpace(req.Action); expected != "" && result.Action != "" && result.Action != expected {
+		return fmt.Errorf("%w: recaptcha action %q does not match %q", captcha.ErrRejected, result.Action, expected)
+	}
+	if result.Score == nil {
+		return fmt.Errorf("%w: recaptcha response has no score; is the secret a v3 key?", captcha.ErrRejected)
+	}
+	if *result.Score < v.minScore {
+		return fmt.Errorf("%w: recaptcha score %.2f below %.2f", captcha.ErrRejected, *result.Score, v.minScore)
+	}
+	return nil
+}
+
+// TurnstileVerifier validates Cloudflare Turnstile tokens.
+type TurnstileVerifier struct {
+	site siteVerifier
+}
+
+// NewTurnstileVerifier constructs a Turnstile verifier. An empty endpoint uses Cloudflare's API.
+func NewTurnstileVerifier(client *http.Client, secret, endpoint string) *TurnstileVerifier {
+	return &TurnstileVerifier{site: newSiteVerifier("turnstile", endpoint, turnstileVerifyURL, secret, client)}
+}
+
+func (v *TurnstileVerifier) Verify(ctx context.Context, req captcha.Request) error {
+	result, err := v.site.verify(ctx, req)
+	if err != nil {
+		return err
+	}
+	if expected := strings.TrimSpace(req.Action); expected != "" && result.Action != "" && result.Action != expected {
+		return fmt.Errorf("%w: turnstile action %q does not match %q", captcha.ErrRejected, result.Action, expected)
+	}
+	return nil
+}
+
+// HCaptchaVerifier validates hCaptcha tokens.
+type HCaptchaVerifier struct {
+	site siteVerifier
+}
+
+// NewHCaptchaVerifier constructs an hCaptcha verifier. An empty endpoint uses hCaptcha's API.
+func NewHCaptchaVerifier(client *http.Client, secret, endpoint string) *HCaptchaVerifier {
+	return &HCaptchaVerifier{site: newSiteVerifier("hcaptcha", endpoint, hcaptchaVerifyURL, secret, client)}
+}
+
+func (v *HCaptchaVerifier) Verify(ctx context.Context, req captcha.Request) error {
+	_, err := v.site.verify(ctx, req)
+	return err
+}
+
+// NewVerifier selects a verifier from configuration. Supported providers are
+// "recaptcha" (v3), "turnstile", "hcaptcha", and "fake". A provider must be chosen explicitly:
+// the fake verifier accepts almost any token, so it is never picked by default.
+func NewVerifier(cfg config.CaptchaConfig, client *http.Client) (captcha.Verifier, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if cfg.Timeout > 0 {
+		clone := *client
+		clone.Timeout = cfg.Timeout
+		client = &clone
+	}
+
+	provider := strings.ToLower(strings.TrimSpace(cfg.Provider))
+	if provider == "" {
+		return nil, errors.New("captcha provider is not configured; set contact.captcha.provider")
+	}
+	if provider != "fake" && strings.TrimSpace(cfg.SecretKey) == "" {
+		return nil, fmt.Errorf("captcha provider %q requires a secret key", provider)
+	}
+
+	switch provider {
+	case "recaptcha", "recaptcha_v3":
+		return NewRecaptchaV3Verifier(client, cfg.SecretKey, cfg.VerifyURL, cfg.MinScore), nil
+	case "turnstile":
+		return NewTurnstileVerifier(client, cfg.SecretKey, cfg.VerifyURL), nil
+	case "hcaptcha":
+		return NewHCaptchaVerifier(client, cfg.SecretKey, cfg.VerifyURL), nil
+	case "fake":
+		log.Printf("WARNING: captcha provider is \"fake\"; public forms accept any token except %q. Use it for local development and tests only.", cfg.FakeRejectToken)
+		return captcha.NewFakeVerifier(cfg.FakeRejectToken), nil
+	default:
+		return nil, fmt.Errorf("unsupported captcha provider %q", cfg.Provider)
+	}
+}
+
+func newSiteVerifier(provider, endpoint, fallback, secret string, client *http.Client) siteVerifier {
+	if strings.TrimSpace(endpoint) == "" {
+		endpoint = fallback
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return siteVerifier{
+		provider: provider,
+		endpoint: endpoint,
+		secret:   strings.TrimSpace(secret),
+		client:   client,
+	}
+}
+
+var (
+	_ captcha.Verifier = (*RecaptchaV3Verifier)(nil)
+	_ captcha.Verifier = (*TurnstileVerifier)(nil)
+	_ captcha.Verifier = (*HCaptchaVerifier)(nil)
+)
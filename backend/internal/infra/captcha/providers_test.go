@@ -0,0 +1,84 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+)
+
+func newSiteVerifyServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.Equal(t, "token", r.PostForm.Get("response"))
+		require.Equal(t, "203.0.113.7", r.PostForm.Get("remoteip"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+var testRequest = captcha.Request{Token: "token", RemoteIP: "203.0.113.7", Action: "booking"}
+
+func TestRecaptchaV3VerifierScoreThreshold(t *testing.T) {
+	low := newSiteVerifyServer(t, `{"success": true, "score": 0.3, "action": "booking"}`)
+	err := NewRecaptchaV3Verifier(low.Client(), "secret", low.URL, 0.5).Verify(context.Background(), testRequest)
+	require.ErrorIs(t, err, captcha.ErrRejected)
+
+	high := newSiteVerifyServer(t, `{"success": true, "score": 0.9, "action": "booking"}`)
+	require.NoError(t, NewRecaptchaV3Verifier(high.Client(), "secret", high.URL, 0.5).Verify(context.Background(), testRequest))
+
+	wrongAction := newSiteVerifyServer(t, `{"success": true, "score": 0.9, "action": "contact"}`)
+	err = NewRecaptchaV3Verifier(wrongAction.Client(), "secret", wrongAction.URL, 0.5).Verify(context.Background(), testRequest)
+	require.ErrorIs(t, err, captcha.ErrRejected)
+}
+
+func TestTurnstileAndHCaptchaVerifiers(t *testing.T) {
+	failed := newSiteVerifyServer(t, `{"success": false, "error-codes": ["timeout-or-duplicate"]}`)
+	err := NewTurnstileVerifier(failed.Client(), "secret", failed.URL).Verify(context.Background(), testRequest)
+	require.ErrorIs(t, err, captcha.ErrRejected)
+	require.Contains(t, err.Error(), "timeout-or-duplicate")
+
+	ok := newSiteVerifyServer(t, `{"success": true, "hostname": "example.com"}`)
+	require.NoError(t, NewHCaptchaVerifier(ok.Client(), "secret", ok.URL).Verify(context.Background(), testRequest))
+
+	err = NewHCaptchaVerifier(ok.Client(), "secret", ok.URL).Verify(context.Background(), captcha.Request{})
+	require.ErrorIs(t, err, captcha.ErrMissingToken)
+}
+
+func TestSiteVerifierUpstreamFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	err := NewHCaptchaVerifier(server.Client(), "secret", server.URL).Verify(context.Background(), testRequest)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, captcha.ErrRejected)
+}
+
+func TestNewVerifierSelectsProvider(t *testing.T) {
+	verifier, err := NewVerifier(config.CaptchaConfig{Provider: "fake", FakeRejectToken: "fail"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(context.Background(), captcha.Request{Token: "ok"}))
+	require.ErrorIs(t, verifier.Verify(context.Background(), captcha.Request{Token: "fail"}), captcha.ErrRejected)
+
+	verifier, err = NewVerifier(config.CaptchaConfig{Provider: "Turnstile", SecretKey: "secret"}, nil)
+	require.NoError(t, err)
+	require.IsType(t, &TurnstileVerifier{}, verifier)
+
+	_, err = NewVerifier(config.CaptchaConfig{}, nil)
+	require.Error(t, err)
+	_, err = NewVerifier(config.CaptchaConfig{Provider: "recaptcha"}, nil)
+	require.Error(t, err)
+	_, err = NewVerifier(config.CaptchaConfig{Provider: "unknown", SecretKey: "secret"}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/takumi/personal-website/internal/captcha"
+)
+
+// siteVerifyResponse is the response shape shared by reCAPTCHA, Turnstile, and hCaptcha.
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score,omitempty"`
+	Action     string   `json:"action,omitempty"`
+	Hostname   string   `json:"hostname,omitempty"`
+	ErrorCodes []string `json:"error-codes,omitempty"`
+}
+
+// siteVerifier posts tokens to a provider's siteverify endpoint.
+type siteVerifier struct {
+	provider string
+	endpoint string
+	secret   string
+	client   *http.Client
+}
+
+func (v *siteVerifier) verify(ctx context.Context, req captcha.Request) (*siteVerifyResponse, error) {
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return nil, captcha.ErrMissingToken
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	if ip := strings.TrimSpace(req.RemoteIP); ip != "" {
+		form.Set("remoteip", ip)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: build request: %w", v.provider, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: siteverify: %w", v.provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return nil, fmt.Errorf("%s: siteverify status %d: %s", v.provider, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%s: decode siteverify response: %w", v.provider, err)
+	}
+	if !payload.Success {
+		return nil, fmt.Errorf("%w: %s error codes %v", captcha.ErrRejected, v.provider, payload.ErrorCodes)
+	}
+	return &payload, nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"time"
@@ -18,6 +19,7 @@ const (
 	calendarBaseURL   = "https://www.googleapis.com/calendar/v3"
 	freeBusyEndpoint  = "/freeBusy"
 	calendarEventsFmt = "/calendars/%s/events"
+	calendarEventFmt  = "/calendars/%s/events/%s"
 )
 
 // CalendarAPIClient implements Google Calendar REST calls.
@@ -39,19 +41,28 @@ func NewCalendarAPIClient(httpClient *http.Client, tokenProvider TokenProvider,
 	}
 }
 
-func (c *CalendarAPIClient) ListBusyWindows(ctx context.Context, calendarID string, from, to time.Time) ([]model.TimeWindow, error) {
+// ListBusyWindows queries all calendars in a single FreeBusy request. A calendar Google
+// reports an error for (for example one the account can no longer read) is logged and skipped
+// so the remaining calendars still block slots.
+func (c *CalendarAPIClient) ListBusyWindows(ctx context.Context, calendarIDs []string, from, to time.Time) ([]model.TimeWindow, error) {
+	if len(calendarIDs) == 0 {
+		return nil, nil
+	}
+
 	token, err := c.tokenProvider.AccessToken(ctx)
 	// If token provider is not configured, allow graceful degradation.
 	if err != nil {
 		return nil, err
 	}
 
+	items := make([]map[string]string, 0, len(calendarIDs))
+	for _, calendarID := range calendarIDs {
+		items = append(items, map[string]string{"id": calendarID})
+	}
 	payload := map[string]any{
 		"timeMin": from.Format(time.RFC3339),
 		"timeMax": to.Format(time.RFC3339),
-		"items": []map[string]string{
-			{"id": calendarID},
-		},
+		"items":   items,
 	}
 
 	body, err := json.Marshal(payload)
@@ -79,6 +90,9 @@ func (c *CalendarAPIClient) ListBusyWindows(ctx context.Context, calendarID stri
 
 	var decoded struct {
 		Calendars map[string]struct {
+			Errors []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
 			Busy []struct {
 				Start string `json:"start"`
 				End   string `json:"end"`
@@ -90,26 +104,31 @@ func (c *CalendarAPIClient) ListBusyWindows(ctx context.Context, calendarID stri
 		return nil, fmt.Errorf("calendar freebusy decode: %w", err)
 	}
 
-	calendarEntry, ok := decoded.Calendars[calendarID]
-	if !ok {
-		return nil, nil
-	}
-
-	windows := make([]model.TimeWindow, 0, len(calendarEntry.Busy))
-	for _, busy := range calendarEntry.Busy {
-		start, err := time.Parse(time.RFC3339, busy.Start)
-		if err != nil {
+	var windows []model.TimeWindow
+	for _, calendarID := range calendarIDs {
+		calendarEntry, ok := decoded.Calendars[calendarID]
+		if !ok {
 			continue
 		}
-		end, err := time.Parse(time.RFC3339, busy.End)
-		if err != nil {
+		if len(calendarEntry.Errors) > 0 {
+			log.Printf("calendar freebusy: skipping calendar %q: %s", calendarID, calendarEntry.Errors[0].Reason)
 			continue
 		}
-		windows = append(windows, model.TimeWindow{
-			Start:  start.UTC(),
-			End:    end.UTC(),
-			Source: model.BusyWindowSourceExternal,
-		})
+		for _, busy := range calendarEntry.Busy {
+			start, err := time.Parse(time.RFC3339, busy.Start)
+			if err != nil {
+				continue
+			}
+			end, err := time.Parse(time.RFC3339, busy.End)
+			if err != nil {
+				continue
+			}
+			windows = append(windows, model.TimeWindow{
+				Start:  start.UTC(),
+				End:    end.UTC(),
+				Source: model.BusyWindowSourceExternal,
+			})
+		}
 	}
 
 	return windows, nil
@@ -184,6 +203,171 @@ func (c *CalendarAPIClient) CreateEvent(ctx context.Context, calendarID string,
 	}, nil
 }
 
+// GetEvent fetches an event including its status, time span, and attendee responses.
+func (c *CalendarAPIClient) GetEvent(ctx context.Context, calendarID, eventID string) (*calendar.Event, error) {
+	token, err := c.tokenProvider.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(calendarEventFmt, url.PathEscape(calendarID), url.PathEscape(eventID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, calendarBaseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calendar get request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calendar get call: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, calendar.ErrEventNotFound
+	}
+	if resp.StatusCode >= 400 {
+		payload, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return nil, fmt.Errorf("calendar get error: status=%d body=%s", resp.StatusCode, string(payload))
+	}
+
+	return decodeEvent(resp.Body, "calendar get")
+}
+
+// UpdateEvent patches the non-empty fields of an existing event and notifies attendees.
+func (c *CalendarAPIClient) UpdateEvent(ctx context.Context, calendarID, eventID string, input calendar.EventInput) (*calendar.Event, error) {
+	token, err := c.tokenProvider.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	event := map[string]any{}
+	if input.Summary != "" {
+		event["summary"] = input.Summary
+	}
+	if input.Description != "" {
+		event["description"] = input.Description
+	}
+	if !input.Start.IsZero() {
+		event["start"] = map[string]string{
+			"dateTime": input.Start.Format(time.RFC3339),
+			"timeZone": c.timezone,
+		}
+	}
+	if !input.End.IsZero() {
+		event["end"] = map[string]string{
+			"dateTime": input.End.Format(time.RFC3339),
+			"timeZone": c.timezone,
+		}
+	}
+	if len(input.Attendees) > 0 {
+		event["attendees"] = buildAttendees(input.Attendees)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("calendar patch marshal: %w", err)
+	}
+
+	path := fmt.Sprintf(calendarEventFmt, url.PathEscape(calendarID), url.PathEscape(eventID))
+	endpoint := fmt.Sprintf("%s%s?sendUpdates=all", calendarBaseURL, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("calendar patch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calendar patch call: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, calendar.ErrEventNotFound
+	}
+	if resp.StatusCode >= 400 {
+		payload, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return nil, fmt.Errorf("calendar patch error: status=%d body=%s", resp.StatusCode, string(payload))
+	}
+
+	return decodeEvent(resp.Body, "calendar patch")
+}
+
+// DeleteEvent removes an event and sends cancellation notices to its attendees.
+func (c *CalendarAPIClient) DeleteEvent(ctx context.Context, calendarID, eventID string) error {
+	token, err := c.tokenProvider.AccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf(calendarEventFmt, url.PathEscape(calendarID), url.PathEscape(eventID))
+	endpoint := fmt.Sprintf("%s%s?sendUpdates=all", calendarBaseURL, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("calendar delete request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calendar delete call: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return calendar.ErrEventNotFound
+	}
+	if resp.StatusCode >= 400 {
+		payload, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return fmt.Errorf("calendar delete error: status=%d body=%s", resp.StatusCode, string(payload))
+	}
+	return nil
+}
+
+func decodeEvent(body io.Reader, operation string) (*calendar.Event, error) {
+	var decoded struct {
+		ID          string `json:"id"`
+		HTMLLink    string `json:"htmlLink"`
+		HangoutLink string `json:"hangoutLink"`
+		Status      string `json:"status"`
+		Start       struct {
+			DateTime string `json:"dateTime"`
+		} `json:"start"`
+		End struct {
+			DateTime string `json:"dateTime"`
+		} `json:"end"`
+		Attendees []struct {
+			Email          string `json:"email"`
+			ResponseStatus string `json:"responseStatus"`
+		} `json:"attendees"`
+	}
+	if err := json.NewDecoder(body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("%s decode: %w", operation, err)
+	}
+
+	event := &calendar.Event{
+		ID:          decoded.ID,
+		HTMLLink:    decoded.HTMLLink,
+		HangoutLink: decoded.HangoutLink,
+		Status:      decoded.Status,
+	}
+	if start, err := time.Parse(time.RFC3339, decoded.Start.DateTime); err == nil {
+		event.Start = start.UTC()
+	}
+	if end, err := time.Parse(time.RFC3339, decoded.End.DateTime); err == nil {
+		event.End = end.UTC()
+	}
+	for _, attendee := range decoded.Attendees {
+		event.Attendees = append(event.Attendees, calendar.EventAttendee{
+			Email:          attendee.Email,
+			ResponseStatus: attendee.ResponseStatus,
+		})
+	}
+	return event, nil
+}
+
 func buildAttendees(addresses []string) []map[string]string {
 	list := make([]map[string]string, 0, len(addresses))
 	for _, addr := range addresses {
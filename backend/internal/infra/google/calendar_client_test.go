@@ -0,0 +1,125 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/calendar"
+)
+
+type staticTokenProvider string
+
+func (p staticTokenProvider) AccessToken(context.Context) (string, error) {
+	return string(p), nil
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCalendarAPIClientGetEvent(t *testing.T) {
+	client := NewCalendarAPIClient(&http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodGet, req.Method)
+			require.Equal(t, "/calendar/v3/calendars/primary/events/evt-1", req.URL.Path)
+			require.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+			return jsonResponse(http.StatusOK, `{
+				"id": "evt-1",
+				"status": "confirmed",
+				"start": {"dateTime": "2024-05-01T10:00:00+09:00"},
+				"end": {"dateTime": "2024-05-01T10:30:00+09:00"},
+				"attendees": [{"email": "guest@example.com", "responseStatus": "declined"}]
+			}`), nil
+		}),
+	}, staticTokenProvider("token"), "Asia/Tokyo")
+
+	event, err := client.GetEvent(context.Background(), "primary", "evt-1")
+	require.NoError(t, err)
+	require.Equal(t, "confirmed", event.Status)
+	require.True(t, event.Start.Equal(time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC)))
+	require.Equal(t, []calendar.EventAttendee{{Email: "guest@example.com", ResponseStatus: "declined"}}, event.Attendees)
+}
+
+func TestCalendarAPIClientUpdateEventPatchesOnlyProvidedFields(t *testing.T) {
+	client := NewCalendarAPIClient(&http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodPatch, req.Method)
+			require.Equal(t, "all", req.URL.Query().Get("sendUpdates"))
+
+			var payload map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+			require.Contains(t, payload, "start")
+			require.Contains(t, payload, "end")
+			require.NotContains(t, payload, "summary")
+			require.NotContains(t, payload, "attendees")
+			return jsonResponse(http.StatusOK, `{"id": "evt-1"}`), nil
+		}),
+	}, staticTokenProvider("token"), "UTC")
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	event, err := client.UpdateEvent(context.Background(), "primary", "evt-1", calendar.EventInput{
+		Start: start,
+		End:   start.Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "evt-1", event.ID)
+}
+
+func TestCalendarAPIClientDeleteEventMissing(t *testing.T) {
+	client := NewCalendarAPIClient(&http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodDelete, req.Method)
+			return jsonResponse(http.StatusGone, `{}`), nil
+		}),
+	}, staticTokenProvider("token"), "UTC")
+
+	err := client.DeleteEvent(context.Background(), "primary", "evt-1")
+	require.True(t, errors.Is(err, calendar.ErrEventNotFound))
+}
+
+func TestCalendarAPIClientListBusyWindowsQueriesAllCalendarsAtOnce(t *testing.T) {
+	calls := 0
+	client := NewCalendarAPIClient(&http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			require.Equal(t, http.MethodPost, req.Method)
+			require.Equal(t, "/calendar/v3/freeBusy", req.URL.Path)
+
+			var payload struct {
+				Items []struct {
+					ID string `json:"id"`
+				} `json:"items"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+			require.Len(t, payload.Items, 3)
+			require.Equal(t, "lab@group.calendar.google.com", payload.Items[1].ID)
+			return jsonResponse(http.StatusOK, `{
+				"calendars": {
+					"primary": {"busy": [{"start": "2024-05-01T10:00:00+09:00", "end": "2024-05-01T11:00:00+09:00"}]},
+					"lab@group.calendar.google.com": {"busy": [{"start": "2024-05-01T04:00:00Z", "end": "2024-05-01T05:00:00Z"}]},
+					"revoked@example.com": {"errors": [{"domain": "global", "reason": "notFound"}], "busy": []}
+				}
+			}`), nil
+		}),
+	}, staticTokenProvider("token"), "Asia/Tokyo")
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	windows, err := client.ListBusyWindows(context.Background(), []string{"primary", "lab@group.calendar.google.com", "revoked@example.com"}, from, from.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Len(t, windows, 2)
+	require.True(t, windows[0].Start.Equal(time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC)))
+	require.True(t, windows[1].Start.Equal(time.Date(2024, 5, 1, 4, 0, 0, 0, time.UTC)))
+}
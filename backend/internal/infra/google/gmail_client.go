@@ -7,7 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"strings"
 
 	"github.com/takumi/personal-website/internal/mail"
@@ -80,7 +83,88 @@ func buildMIMEMessage(message mail.Message) string {
 	}
 	builder.WriteString(fmt.Sprintf("Subject: %s\r\n", message.Subject))
 	builder.WriteString("MIME-Version: 1.0\r\n")
-	builder.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
-	builder.WriteString(message.Body)
+
+	if message.HTMLBody == "" && len(message.Attachments) == 0 {
+		builder.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+		builder.WriteString(message.Body)
+		return builder.String()
+	}
+
+	altType, altBody := buildAlternativeParts(message)
+	if len(message.Attachments) == 0 {
+		builder.WriteString(fmt.Sprintf("Content-Type: %s\r\n\r\n", altType))
+		builder.Write(altBody)
+		return builder.String()
+	}
+
+	// multipart/mixed carries the readable alternatives first, then each file. The writers
+	// target in-memory buffers, so their errors are ignored.
+	var mixedBody bytes.Buffer
+	mixed := multipart.NewWriter(&mixedBody)
+	part, _ := mixed.CreatePart(textproto.MIMEHeader{"Content-Type": {altType}})
+	_, _ = part.Write(altBody)
+	for _, attachment := range message.Attachments {
+		header := textproto.MIMEHeader{
+			"Content-Type":              {attachmentContentType(attachment)},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		}
+		part, _ := mixed.CreatePart(header)
+		writeBase64(part, attachment.Data)
+	}
+	_ = mixed.Close()
+
+	builder.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixed.Boundary()))
+	builder.Write(mixedBody.Bytes())
 	return builder.String()
 }
+
+// buildAlternativeParts renders the plain, HTML and calendar renditions of the message as a
+// multipart/alternative body, ordered from least to most preferred as RFC 2046 requires.
+func buildAlternativeParts(message mail.Message) (string, []byte) {
+	var body bytes.Buffer
+	alternative := multipart.NewWriter(&body)
+
+	writeTextPart(alternative, `text/plain; charset="utf-8"`, []byte(message.Body))
+	if message.HTMLBody != "" {
+		writeTextPart(alternative, `text/html; charset="utf-8"`, []byte(message.HTMLBody))
+	}
+	for _, attachment := range message.Attachments {
+		if isCalendarAttachment(attachment) {
+			writeTextPart(alternative, attachmentContentType(attachment), attachment.Data)
+		}
+	}
+	_ = alternative.Close()
+
+	return fmt.Sprintf("multipart/alternative; boundary=%q", alternative.Boundary()), body.Bytes()
+}
+
+func writeTextPart(w *multipart.Writer, contentType string, data []byte) {
+	part, _ := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	writeBase64(part, data)
+}
+
+// writeBase64 encodes data in 76-character lines as required for MIME bodies.
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		_, _ = io.WriteString(w, encoded[:76]+"\r\n")
+		encoded = encoded[76:]
+	}
+	_, _ = io.WriteString(w, encoded+"\r\n")
+}
+
+func attachmentContentType(attachment mail.Attachment) string {
+	if contentType := strings.TrimSpace(attachment.ContentType); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+func isCalendarAttachment(attachment mail.Attachment) bool {
+	mediaType, _, err := mime.ParseMediaType(attachment.ContentType)
+	return err == nil && mediaType == "text/calendar"
+}
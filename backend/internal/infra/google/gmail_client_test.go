@@ -0,0 +1,89 @@
+package google
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/mail"
+)
+
+func TestBuildMIMEMessagePlainText(t *testing.T) {
+	raw := buildMIMEMessage(mail.Message{
+		From:    "owner@example.com",
+		To:      []string{"guest@example.com"},
+		Subject: "Hello",
+		Body:    "plain body",
+	})
+
+	require.Contains(t, raw, "Content-Type: text/plain; charset=\"utf-8\"\r\n\r\nplain body")
+}
+
+func TestBuildMIMEMessageWithCalendarAttachment(t *testing.T) {
+	invite := "BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nEND:VCALENDAR\r\n"
+	raw := buildMIMEMessage(mail.Message{
+		From:     "owner@example.com",
+		To:       []string{"guest@example.com"},
+		Subject:  "Invite",
+		Body:     "plain body",
+		HTMLBody: "<p>html body</p>",
+		Attachments: []mail.Attachment{{
+			Filename:    "invite.ics",
+			ContentType: `text/calendar; charset="utf-8"; method=REQUEST`,
+			Data:        []byte(invite),
+		}},
+	})
+
+	msg, err := netmail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	mixed := multipart.NewReader(msg.Body, params["boundary"])
+	alternativePart, err := mixed.NextPart()
+	require.NoError(t, err)
+	altType, altParams, err := mime.ParseMediaType(alternativePart.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", altType)
+
+	alternative := multipart.NewReader(alternativePart, altParams["boundary"])
+	var renditions []string
+	for {
+		part, err := alternative.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		partType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		renditions = append(renditions, partType)
+		if partType == "text/html" {
+			require.Equal(t, "<p>html body</p>", decodeBase64Part(t, part))
+		}
+	}
+	require.Equal(t, []string{"text/plain", "text/html", "text/calendar"}, renditions)
+
+	attachment, err := mixed.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, "invite.ics", attachment.FileName())
+	require.Equal(t, invite, decodeBase64Part(t, attachment))
+
+	_, err = mixed.NextPart()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func decodeBase64Part(t *testing.T, part *multipart.Part) string {
+	t.Helper()
+	encoded, err := io.ReadAll(part)
+	require.NoError(t, err)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	require.NoError(t, err)
+	return string(decoded)
+}
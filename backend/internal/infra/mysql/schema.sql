@@ -247,8 +247,10 @@ CREATE TABLE IF NOT EXISTS contact_form_settings (
   support_email VARCHAR(255) NOT NULL,
   calendar_timezone VARCHAR(64) NOT NULL,
   google_calendar_id VARCHAR(255) NULL,
+  conflict_calendar_ids JSON NULL,
   booking_window_days INT DEFAULT 30,
   meeting_url_template TEXT NULL,
+  working_hours JSON NULL,
   created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
   updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -256,18 +258,81 @@ CREATE TABLE IF NOT EXISTS contact_form_settings (
 ALTER TABLE contact_form_settings
   ADD COLUMN meeting_url_template TEXT NULL AFTER booking_window_days;
 
+ALTER TABLE contact_form_settings
+  ADD COLUMN working_hours JSON NULL AFTER meeting_url_template;
+
+ALTER TABLE contact_form_settings
+  ADD COLUMN conflict_calendar_ids JSON NULL AFTER google_calendar_id;
+
+-- お問い合わせ受信履歴
+CREATE TABLE IF NOT EXISTS contact_messages (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  topic VARCHAR(255) NULL,
+  message TEXT NOT NULL,
+  locale VARCHAR(8) NULL,
+  intake_answers JSON NULL,
+  status VARCHAR(32) NOT NULL DEFAULT 'pending',
+  admin_note TEXT NULL,
+  spam_score DECIMAL(6,2) NULL,
+  spam_signals JSON NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_contact_messages_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS contact_notifications (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  contact_id BIGINT UNSIGNED NOT NULL,
+  notification_type ENUM('owner_alert','auto_reply') NOT NULL,
+  status ENUM('pending','sent','failed','dead','skipped') DEFAULT 'pending',
+  error_message TEXT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at DATETIME(3) NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_contact_notifications_contact (contact_id, id),
+  INDEX idx_contact_notifications_retry (status, next_attempt_at),
+  CONSTRAINT fk_contact_notifications_contact FOREIGN KEY (contact_id) REFERENCES contact_messages(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- スパム判定（同一本文の検出と管理者の判定結果）
+CREATE TABLE IF NOT EXISTS spam_fingerprints (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  content_hash CHAR(64) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  source ENUM('contact','booking') NOT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_spam_fingerprints_hash (content_hash, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS spam_feedback (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  contact_id BIGINT UNSIGNED NULL,
+  content_hash CHAR(64) NULL,
+  email VARCHAR(255) NOT NULL,
+  verdict ENUM('spam','ham') NOT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_spam_feedback_hash (content_hash),
+  INDEX idx_spam_feedback_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
 CREATE TABLE IF NOT EXISTS meeting_reservations (
   id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
   name VARCHAR(255) NOT NULL,
   email VARCHAR(255) NOT NULL,
   topic VARCHAR(255) NULL,
   message TEXT NULL,
+  locale VARCHAR(8) NULL,
+  visitor_timezone VARCHAR(64) NULL,
+  intake_answers JSON NULL,
   start_at DATETIME(3) NOT NULL,
   end_at DATETIME(3) NOT NULL,
   duration_minutes INT NOT NULL,
   google_event_id VARCHAR(255) NULL,
   google_calendar_status ENUM('pending','confirmed','declined','cancelled') DEFAULT 'pending',
-  status ENUM('pending','confirmed','cancelled') DEFAULT 'pending',
+  status ENUM('requested','confirmed','rescheduled','completed','no_show','cancelled_by_visitor','cancelled_by_owner') NOT NULL DEFAULT 'requested',
+  requires_approval TINYINT(1) NOT NULL DEFAULT 0,
   confirmation_sent_at DATETIME(3) NULL,
   last_notification_sent_at DATETIME(3) NULL,
   lookup_hash CHAR(64) NOT NULL,
@@ -279,16 +344,144 @@ CREATE TABLE IF NOT EXISTS meeting_reservations (
   INDEX idx_meeting_reservations_lookup (lookup_hash)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
+CREATE TABLE IF NOT EXISTS meeting_reservation_status_history (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  from_status VARCHAR(32) NULL,
+  to_status VARCHAR(32) NOT NULL,
+  actor VARCHAR(16) NOT NULL,
+  reason TEXT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_meeting_reservation_status_history_reservation (reservation_id, id),
+  CONSTRAINT fk_meeting_reservation_status_history_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS reservation_calendar_drifts (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  google_event_id VARCHAR(255) NULL,
+  kind VARCHAR(32) NOT NULL,
+  action VARCHAR(16) NOT NULL,
+  stored_start_at DATETIME(3) NOT NULL,
+  stored_end_at DATETIME(3) NOT NULL,
+  event_start_at DATETIME(3) NULL,
+  event_end_at DATETIME(3) NULL,
+  detail TEXT NULL,
+  dedupe_key VARCHAR(191) NOT NULL,
+  detected_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  resolved_at DATETIME(3) NULL,
+  UNIQUE KEY uq_reservation_calendar_drifts_dedupe (reservation_id, dedupe_key),
+  INDEX idx_reservation_calendar_drifts_resolved (resolved_at, id),
+  CONSTRAINT fk_reservation_calendar_drifts_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
 CREATE TABLE IF NOT EXISTS meeting_notifications (
   id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
   reservation_id BIGINT UNSIGNED NOT NULL,
-  notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email') NOT NULL,
-  status ENUM('pending','sent','failed') DEFAULT 'pending',
+  notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email','owner_notification','request_received_email','approval_email','decline_email','approval_expiry','waitlist_offer','calendar_cancellation') NOT NULL,
+  status ENUM('pending','sent','failed','dead','skipped') DEFAULT 'pending',
   error_message TEXT NULL,
+  dedupe_key VARCHAR(191) NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at DATETIME(3) NULL,
   created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  UNIQUE KEY uq_meeting_notifications_dedupe (reservation_id, dedupe_key),
+  INDEX idx_meeting_notifications_retry (status, next_attempt_at),
   CONSTRAINT fk_meeting_notifications_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
+CREATE TABLE IF NOT EXISTS meeting_notification_attempts (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  notification_id BIGINT UNSIGNED NOT NULL,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  attempt INT NOT NULL,
+  status ENUM('sent','failed','skipped') NOT NULL,
+  error_message TEXT NULL,
+  attempted_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_meeting_notification_attempts_reservation (reservation_id, id),
+  CONSTRAINT fk_meeting_notification_attempts_notification FOREIGN KEY (notification_id) REFERENCES meeting_notifications(id) ON DELETE CASCADE,
+  CONSTRAINT fk_meeting_notification_attempts_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS booking_outbox (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  kind VARCHAR(64) NOT NULL,
+  status ENUM('pending','processing','done','dead') NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  max_attempts INT NOT NULL DEFAULT 8,
+  next_attempt_at DATETIME(3) NOT NULL,
+  last_error TEXT NULL,
+  completed_at DATETIME(3) NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_booking_outbox_due (status, next_attempt_at),
+  INDEX idx_booking_outbox_reservation (reservation_id),
+  CONSTRAINT fk_booking_outbox_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS booking_slot_locks (
+  slot_date DATE NOT NULL PRIMARY KEY,
+  locked_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  label VARCHAR(255) NULL,
+  token_hash CHAR(64) NOT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  last_used_at DATETIME(3) NULL,
+  revoked_at DATETIME(3) NULL,
+  UNIQUE KEY uq_calendar_feed_tokens_hash (token_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  topic VARCHAR(255) NULL,
+  locale VARCHAR(8) NULL,
+  preferred_dates JSON NOT NULL,
+  status VARCHAR(16) NOT NULL DEFAULT 'waiting',
+  offer_reservation_id BIGINT UNSIGNED NULL,
+  offer_start_at DATETIME(3) NULL,
+  offer_end_at DATETIME(3) NULL,
+  offer_expires_at DATETIME(3) NULL,
+  offer_token_hash CHAR(64) NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE KEY uq_waitlist_entries_offer_token (offer_token_hash),
+  INDEX idx_waitlist_entries_status (status, created_at),
+  INDEX idx_waitlist_entries_offer (offer_reservation_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 通知メールテンプレート（管理画面で上書きしたもののみ保存）
+CREATE TABLE IF NOT EXISTS notification_templates (
+  template_key VARCHAR(64) NOT NULL PRIMARY KEY,
+  subject_ja VARCHAR(512) NULL,
+  subject_en VARCHAR(512) NULL,
+  text_body_ja TEXT NULL,
+  text_body_en TEXT NULL,
+  html_body_ja TEXT NULL,
+  html_body_en TEXT NULL,
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE meeting_reservations
+  ADD COLUMN locale VARCHAR(8) NULL AFTER message;
+
+ALTER TABLE meeting_reservations
+  ADD COLUMN requires_approval TINYINT(1) NOT NULL DEFAULT 0 AFTER status;
+
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email','owner_notification','request_received_email','approval_email','decline_email','approval_expiry','waitlist_offer','calendar_cancellation') NOT NULL;
+
+ALTER TABLE meeting_notifications
+  ADD COLUMN dedupe_key VARCHAR(191) NULL AFTER error_message;
+
+ALTER TABLE meeting_notifications
+  ADD UNIQUE KEY uq_meeting_notifications_dedupe (reservation_id, dedupe_key);
+
 ALTER TABLE profile_social_links
   MODIFY COLUMN provider ENUM('github','zenn','linkedin','x','email','website','other') NOT NULL;
 
@@ -304,9 +497,29 @@ CREATE TABLE IF NOT EXISTS schedule_blackouts (
   id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
   start_time DATETIME(3) NOT NULL,
   end_time DATETIME(3) NOT NULL,
-  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)
+  reason VARCHAR(255) NULL,
+  recurrence_rule VARCHAR(512) NULL,
+  timezone VARCHAR(64) NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_schedule_blackouts_range (start_time, end_time)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
+ALTER TABLE schedule_blackouts
+  ADD COLUMN reason VARCHAR(255) NULL AFTER end_time;
+
+ALTER TABLE schedule_blackouts
+  ADD COLUMN recurrence_rule VARCHAR(512) NULL AFTER reason;
+
+ALTER TABLE schedule_blackouts
+  ADD COLUMN timezone VARCHAR(64) NULL AFTER recurrence_rule;
+
+ALTER TABLE schedule_blackouts
+  ADD COLUMN updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) AFTER created_at;
+
+ALTER TABLE schedule_blackouts
+  ADD INDEX idx_schedule_blackouts_range (start_time, end_time);
+
 -- 管理者セッション
 CREATE TABLE IF NOT EXISTS admin_sessions (
   id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
@@ -325,6 +538,18 @@ CREATE TABLE IF NOT EXISTS admin_sessions (
   INDEX idx_admin_sessions_last_accessed (last_accessed_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
+-- 公開 POST の冪等キー（保存済みレスポンスを再送に返す）
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  idempotency_key CHAR(64) NOT NULL PRIMARY KEY,
+  fingerprint CHAR(64) NOT NULL,
+  status_code INT NOT NULL DEFAULT 0,
+  content_type VARCHAR(255) NULL,
+  response_body MEDIUMBLOB NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  expires_at DATETIME(3) NOT NULL,
+  INDEX idx_idempotency_keys_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
 INSERT INTO profiles (
   display_name,
   headline_ja,
@@ -412,3 +637,46 @@ FROM profiles p
 WHERE NOT EXISTS (SELECT 1 FROM home_page_config)
 ORDER BY p.id
 LIMIT 1;
+-- IANA zone the visitor booked from; confirmation emails show the time in both zones.
+ALTER TABLE meeting_reservations
+  ADD COLUMN visitor_timezone VARCHAR(64) NULL AFTER locale;
+-- Answers to the per-topic intake questions, with labels captured at submission time.
+ALTER TABLE meeting_reservations
+  ADD COLUMN intake_answers JSON NULL AFTER visitor_timezone;
+ALTER TABLE contact_messages
+  ADD COLUMN intake_answers JSON NULL AFTER locale;
+-- Reservation lifecycle: explicit states and a status history. Legacy cancellations cannot be
+-- attributed to either side and are recorded as cancelled by the visitor.
+ALTER TABLE meeting_reservations
+  MODIFY COLUMN status ENUM('pending','cancelled','requested','confirmed','rescheduled','completed','no_show','cancelled_by_visitor','cancelled_by_owner') NOT NULL DEFAULT 'requested';
+UPDATE meeting_reservations SET status = 'requested' WHERE status = 'pending';
+UPDATE meeting_reservations SET status = 'cancelled_by_visitor' WHERE status = 'cancelled';
+ALTER TABLE meeting_reservations
+  MODIFY COLUMN status ENUM('requested','confirmed','rescheduled','completed','no_show','cancelled_by_visitor','cancelled_by_owner') NOT NULL DEFAULT 'requested';
+-- Failed and unsent notifications are retried in the background with backoff; each try is kept
+-- as an attempt row, and notifications that run out of attempts are marked dead.
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN status ENUM('pending','sent','failed','dead','skipped') DEFAULT 'pending';
+ALTER TABLE meeting_notifications
+  ADD COLUMN attempts INT NOT NULL DEFAULT 0 AFTER dedupe_key;
+ALTER TABLE meeting_notifications
+  ADD COLUMN next_attempt_at DATETIME(3) NULL AFTER attempts;
+ALTER TABLE meeting_notifications
+  ADD INDEX idx_meeting_notifications_retry (status, next_attempt_at);
+-- Spam filtering: contact messages keep the score and signals they arrived with.
+ALTER TABLE contact_messages
+  ADD COLUMN spam_score DECIMAL(6,2) NULL AFTER admin_note;
+ALTER TABLE contact_messages
+  ADD COLUMN spam_signals JSON NULL AFTER spam_score;
+-- Contact form emails are queued and sent by the notification retry worker.
+ALTER TABLE contact_notifications
+  MODIFY COLUMN status ENUM('pending','sent','failed','dead','skipped') DEFAULT 'pending';
+ALTER TABLE contact_notifications
+  ADD COLUMN attempts INT NOT NULL DEFAULT 0 AFTER error_message;
+ALTER TABLE contact_notifications
+  ADD COLUMN next_attempt_at DATETIME(3) NULL AFTER attempts;
+ALTER TABLE contact_notifications
+  ADD INDEX idx_contact_notifications_retry (status, next_attempt_at);
+-- Calendar events of cancelled reservations are deleted by an outbox job.
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email','owner_notification','request_received_email','approval_email','decline_email','approval_expiry','waitlist_offer','calendar_cancellation') NOT NULL;
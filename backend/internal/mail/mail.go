@@ -2,13 +2,25 @@ package mail
 
 import "context"
 
-// Message represents an email to be delivered.
+// Message represents an email to be delivered. Body is the plain-text part; when HTMLBody is
+// set the two are sent as alternatives.
 type Message struct {
-	From    string
-	To      []string
-	CC      []string
-	Subject string
-	Body    string
+	From        string
+	To          []string
+	CC          []string
+	Subject     string
+	Body        string
+	HTMLBody    string
+	Attachments []Attachment
+}
+
+// Attachment is a file delivered alongside the message body. Calendar attachments
+// (text/calendar) are additionally offered as an alternative body part so mail clients
+// render them as invitations.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
 
 // Client delivers email messages.
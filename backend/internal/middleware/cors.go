@@ -42,8 +42,8 @@ func NewCORSMiddleware(cfg *config.AppConfig) *CORSMiddleware {
 	config := cors.Config{
 		AllowOrigins:     []string{primaryOrigin},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Authorization", "Content-Type", security.CSRFHeaderName, "X-Request-ID", "X-Requested-With"},
-		ExposeHeaders:    []string{"X-Request-ID"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", security.CSRFHeaderName, "X-Request-ID", "X-Requested-With", IdempotencyKeyHeader},
+		ExposeHeaders:    []string{"X-Request-ID", IdempotentReplayedHeader},
 		AllowCredentials: security.AllowCredentials,
 		MaxAge:           10 * time.Minute,
 	}
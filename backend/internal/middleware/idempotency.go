@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key that identifies one logical request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response served from the stored original.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTimeout bounds how long a key stays reserved by a request that never
+	// finishes (a crashed instance, for example) before another request may take it over.
+	idempotencyLockTimeout   = time.Minute
+	idempotencyPurgeInterval = time.Hour
+)
+
+// IdempotencyMiddleware makes POST endpoints safe to retry. The first request with a given
+// Idempotency-Key runs normally and its response is stored for the configured TTL; repeats with
+// the same payload get the stored response back, while reusing the key for a different payload
+// is rejected with 422. Server errors are not stored, so the client can retry with the same key.
+// Requests without the header are unaffected.
+type IdempotencyMiddleware struct {
+	repo repository.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewIdempotencyMiddleware constructs the middleware and purges expired keys in the background.
+func NewIdempotencyMiddleware(lc fx.Lifecycle, repo repository.IdempotencyRepository, cfg *config.AppConfig) *IdempotencyMiddleware {
+	if cfg == nil || repo == nil || cfg.Security.IdempotencyTTL <= 0 {
+		return &IdempotencyMiddleware{}
+	}
+	m := &IdempotencyMiddleware{repo: repo, ttl: cfg.Security.IdempotencyTTL}
+
+	if lc != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				go m.purgeLoop(ctx)
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}
+
+	return m
+}
+
+// Handler returns the gin middleware.
+func (m *IdempotencyMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m == nil || m.repo == nil {
+			c.Next()
+			return
+		}
+		clientKey := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if clientKey == "" {
+			c.Next()
+			return
+		}
+		if len(clientKey) > maxIdempotencyKeyLength {
+			m.reject(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters", nil))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			m.reject(c, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "failed to read request body", err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now().UTC()
+		record := &model.IdempotencyRecord{
+			Key:         idempotencyStorageKey(c, clientKey),
+			Fingerprint: idempotencyFingerprint(body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(idempotencyLockTimeout),
+		}
+		ctx := c.Request.Context()
+		if err := m.repo.CreateIdempotencyRecord(ctx, record); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				m.replay(c, record, now)
+				return
+			}
+			// A storage outage should not take the endpoint down with it; the request runs
+			// without duplicate protection.
+			log.Printf("idempotency: reserve key for %s: %v", c.FullPath(), err)
+			c.Next()
+			return
+		}
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Settle the key even if the client has gone away, so a retry finds the response.
+		settleCtx := context.WithoutCancel(ctx)
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			if err := m.repo.DeleteIdempotencyRecord(settleCtx, record.Key); err != nil {
+				log.Printf("idempotency: release key for %s: %v", c.FullPath(), err)
+			}
+			return
+		}
+		record.StatusCode = status
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.Body = recorder.body.Bytes()
+		record.ExpiresAt = time.Now().UTC().Add(m.ttl)
+		if err := m.repo.CompleteIdempotencyRecord(settleCtx, record); err != nil {
+			log.Printf("idempotency: store response for %s: %v", c.FullPath(), err)
+		}
+	}
+}
+
+// replay answers a request whose key is already taken: with the stored response when the payload
+// matches, or with an error when it differs or the original is still running.
+func (m *IdempotencyMiddleware) replay(c *gin.Context, attempt *model.IdempotencyRecord, now time.Time) {
+	stored, err := m.repo.FindIdempotencyRecord(c.Request.Context(), attempt.Key, now)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		m.reject(c, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load idempotent response", err))
+		return
+	}
+
+	switch {
+	case stored == nil:
+		// The key expired between the reservation attempt and this lookup.
+		m.reject(c, errs.New(errs.CodeConflict, http.StatusConflict, "a request with this Idempotency-Key was just completed; retry the request", nil))
+	case stored.Fingerprint != attempt.Fingerprint:
+		m.reject(c, errs.New(errs.CodeInvalidInput, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request payload", nil))
+	case !stored.Completed():
+		m.reject(c, errs.New(errs.CodeConflict, http.StatusConflict, "a request with this Idempotency-Key is still being processed", nil))
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+		c.Abort()
+	}
+}
+
+func (m *IdempotencyMiddleware) reject(c *gin.Context, err *errs.AppError) {
+	respondJSONError(c, err)
+	c.Abort()
+}
+
+func (m *IdempotencyMiddleware) purgeLoop(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.repo.DeleteExpiredIdempotencyRecords(ctx, time.Now().UTC()); err != nil && ctx.Err() == nil {
+				log.Printf("idempotency: purge expired keys: %v", err)
+			}
+		}
+	}
+}
+
+// idempotencyStorageKey scopes the client's key to the request path, so the same key sent to two
+// endpoints (or for two reservations) names two requests.
+func idempotencyStorageKey(c *gin.Context, clientKey string) string {
+	sum := sha256.Sum256([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n" + clientKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func idempotencyFingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotencyRecorder keeps a copy of the response body while it is written to the client.
+type idempotencyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func newIdempotencyEngine(t *testing.T, status *int) (*gin.Engine, *int) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	cfg := &config.AppConfig{}
+	cfg.Security.IdempotencyTTL = time.Hour
+	idempotency := NewIdempotencyMiddleware(nil, inmemory.NewIdempotencyRepository(), cfg)
+
+	calls := 0
+	engine := gin.New()
+	engine.POST("/bookings", idempotency.Handler(), func(c *gin.Context) {
+		calls++
+		var payload map[string]any
+		require.NoError(t, c.ShouldBindJSON(&payload))
+		c.JSON(*status, gin.H{"call": calls, "name": payload["name"]})
+	})
+	return engine, &calls
+}
+
+func postWithKey(engine *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/bookings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusCreated
+	engine, calls := newIdempotencyEngine(t, &status)
+
+	first := postWithKey(engine, "key-1", `{"name":"Akari"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	require.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	second := postWithKey(engine, "key-1", `{"name":"Akari"}`)
+	require.Equal(t, http.StatusCreated, second.Code)
+	require.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	require.Equal(t, first.Body.String(), second.Body.String())
+	require.Equal(t, first.Header().Get("Content-Type"), second.Header().Get("Content-Type"))
+	require.Equal(t, 1, *calls)
+
+	// Another key, or no key at all, is a new request.
+	require.Equal(t, http.StatusCreated, postWithKey(engine, "key-2", `{"name":"Akari"}`).Code)
+	require.Equal(t, http.StatusCreated, postWithKey(engine, "", `{"name":"Akari"}`).Code)
+	require.Equal(t, 3, *calls)
+}
+
+func TestIdempotencyRejectsKeyReuseWithDifferentPayload(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusCreated
+	engine, calls := newIdempotencyEngine(t, &status)
+
+	require.Equal(t, http.StatusCreated, postWithKey(engine, "key-1", `{"name":"Akari"}`).Code)
+	rec := postWithKey(engine, "key-1", `{"name":"Lucas"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Contains(t, rec.Body.String(), "different request payload")
+	require.Equal(t, 1, *calls)
+
+	rec = postWithKey(engine, strings.Repeat("k", maxIdempotencyKeyLength+1), `{"name":"Akari"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusInternalServerError
+	engine, calls := newIdempotencyEngine(t, &status)
+
+	require.Equal(t, http.StatusInternalServerError, postWithKey(engine, "key-1", `{"name":"Akari"}`).Code)
+
+	status = http.StatusCreated
+	rec := postWithKey(engine, "key-1", `{"name":"Akari"}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+	require.Equal(t, 2, *calls)
+}
@@ -86,19 +86,29 @@ const (
 	ContactStatusInReview ContactStatus = "in_review"
 	ContactStatusResolved ContactStatus = "resolved"
 	ContactStatusArchived ContactStatus = "archived"
+	// ContactStatusSpam holds messages the spam filter or an admin flagged, for review.
+	ContactStatusSpam ContactStatus = "spam"
 )
 
 // ContactMessage captures incoming contact submissions enriched with moderation metadata.
 type ContactMessage struct {
-	ID        string        `json:"id"`
-	Name      string        `json:"name"`
-	Email     string        `json:"email"`
-	Topic     string        `json:"topic"`
-	Message   string        `json:"message"`
-	Status    ContactStatus `json:"status"`
-	AdminNote string        `json:"adminNote"`
-	CreatedAt time.Time     `json:"createdAt"`
-	UpdatedAt time.Time     `json:"updatedAt"`
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Email         string         `json:"email"`
+	Topic         string         `json:"topic"`
+	Message       string         `json:"message"`
+	Locale        string         `json:"locale,omitempty"`
+	IntakeAnswers []IntakeAnswer `json:"intakeAnswers,omitempty"`
+	Status        ContactStatus  `json:"status"`
+	AdminNote     string         `json:"adminNote"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+	// Notifications lists the owner alert and auto-reply sent for the message; only the admin
+	// detail view fills it in.
+	Notifications []ContactNotification `json:"notifications,omitempty"`
+	// SpamScore and SpamSignals are the spam filter's assessment when the message arrived.
+	SpamScore   float64      `json:"spamScore"`
+	SpamSignals []SpamSignal `json:"spamSignals,omitempty"`
 }
 
 // BlacklistEntry captures blacklisted emails that should be rejected.
@@ -3,20 +3,51 @@ package model
 import "time"
 
 // BookingRequest represents an inbound reservation submitted from the contact form.
+// Timezone is the visitor's IANA zone; emails and lookups then show both zones. Answers are keyed
+// by intake question ID.
 type BookingRequest struct {
-	Name            string    `json:"name"`
-	Email           string    `json:"email"`
-	StartTime       time.Time `json:"startTime"`
-	DurationMinutes int       `json:"durationMinutes"`
-	Agenda          string    `json:"agenda"`
-	Topic           string    `json:"topic"`
-	RecaptchaToken  string    `json:"recaptchaToken"`
+	Name            string                        `json:"name"`
+	Email           string                        `json:"email"`
+	StartTime       time.Time                     `json:"startTime"`
+	DurationMinutes int                           `json:"durationMinutes"`
+	Agenda          string                        `json:"agenda"`
+	Topic           string                        `json:"topic"`
+	Locale          string                        `json:"locale"`
+	Timezone        string                        `json:"timezone"`
+	Answers         map[string]IntakeAnswerValues `json:"answers"`
+	RecaptchaToken  string                        `json:"recaptchaToken"`
+	RemoteIP        string                        `json:"-"`
+	// Website and FormStartedAt feed the spam filter, as on the contact form.
+	Website       string     `json:"website"`
+	FormStartedAt *time.Time `json:"formStartedAt,omitempty"`
 }
 
 // BookingResult summarises a booked meeting reservation and associated metadata.
+// CalendarTime is the meeting in the calendar timezone; VisitorTime repeats it in the visitor's
+// timezone when the booking recorded one.
 type BookingResult struct {
 	Reservation      MeetingReservation `json:"reservation"`
 	CalendarEventID  string             `json:"calendarEventId"`
 	SupportEmail     string             `json:"supportEmail"`
 	CalendarTimezone string             `json:"calendarTimezone"`
+	CalendarTime     ZonedTimeRange     `json:"calendarTime"`
+	VisitorTime      *ZonedTimeRange    `json:"visitorTime,omitempty"`
+}
+
+// ZonedTimeRange is a time range expressed in a named timezone.
+type ZonedTimeRange struct {
+	Timezone string    `json:"timezone"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
+// RescheduleRequest moves an existing reservation to a new start time.
+type RescheduleRequest struct {
+	StartTime       time.Time `json:"startTime"`
+	DurationMinutes int       `json:"durationMinutes"`
+}
+
+// CancelRequest carries the optional reason supplied when a visitor cancels a booking.
+type CancelRequest struct {
+	Reason string `json:"reason"`
 }
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// CalendarFeedToken grants read access to the private reservations feed. Only a SHA-256 hash
+// of the secret is stored, so a token can be shown to the administrator exactly once.
+type CalendarFeedToken struct {
+	ID         uint64     `json:"id"`
+	Label      string     `json:"label"`
+	TokenHash  string     `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IssuedCalendarFeedToken is returned when a token is created and carries the secret and the
+// feed path it unlocks.
+type IssuedCalendarFeedToken struct {
+	CalendarFeedToken
+	Token    string `json:"token"`
+	FeedPath string `json:"feedPath"`
+}
@@ -1,11 +1,29 @@
 package model
 
+import "time"
+
 // ContactRequest represents a submission from the contact form.
 type ContactRequest struct {
 	Name    string `json:"name" binding:"required"`
 	Email   string `json:"email" binding:"required,email"`
 	Message string `json:"message" binding:"required"`
 	Topic   string `json:"topic"`
+	Locale  string `json:"locale"`
+	// Answers are keyed by intake question ID; IntakeAnswers holds them once validated.
+	Answers       map[string]IntakeAnswerValues `json:"answers"`
+	IntakeAnswers []IntakeAnswer                `json:"-"`
+	// RecaptchaToken carries the human-verification token for whichever provider is configured.
+	RecaptchaToken string `json:"recaptchaToken"`
+	RemoteIP       string `json:"-"`
+	// Website is a honeypot field the form hides from people; FormStartedAt is when the form
+	// was opened. Both feed the spam filter.
+	Website       string     `json:"website"`
+	FormStartedAt *time.Time `json:"formStartedAt,omitempty"`
+	// Status, Spam and AdminNote are set by the service before the submission is stored;
+	// Status defaults to pending.
+	Status    ContactStatus   `json:"-"`
+	Spam      *SpamAssessment `json:"-"`
+	AdminNote string          `json:"-"`
 }
 
 // ContactSubmission is a stub for persistence/queueing, ready for expansion.
@@ -14,3 +32,18 @@ type ContactSubmission struct {
 	Status  string `json:"status"`
 	Comment string `json:"comment"`
 }
+
+// ContactNotification records an email sent about a contact form submission: the owner alert or
+// the auto-reply to the visitor. Status is pending until the notification retrier sends it, then
+// sent, failed (retried at NextAttemptAt), dead once it runs out of attempts, or skipped when
+// there was no address to send to.
+type ContactNotification struct {
+	ID            uint64     `json:"id"`
+	ContactID     string     `json:"contactId"`
+	Type          string     `json:"type"`
+	Status        string     `json:"status"`
+	ErrorMessage  string     `json:"errorMessage,omitempty"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
@@ -267,29 +267,37 @@ type ResearchDocument struct {
 	Tech              []TechMembership `json:"tech"`
 }
 
-// ContactTopicV2 describes a selectable topic rendered on the contact form.
+// ContactTopicV2 describes a selectable topic rendered on the contact form. Bookings for a topic
+// with RequiresApproval stay pending until an administrator approves or declines them. Questions
+// are the extra intake fields asked when the topic is chosen.
 type ContactTopicV2 struct {
-	ID          string        `json:"id"`
-	Label       LocalizedText `json:"label"`
-	Description LocalizedText `json:"description"`
+	ID               string           `json:"id"`
+	Label            LocalizedText    `json:"label"`
+	Description      LocalizedText    `json:"description"`
+	RequiresApproval bool             `json:"requiresApproval,omitempty"`
+	Questions        []IntakeQuestion `json:"questions,omitempty"`
 }
 
 // ContactFormSettingsV2 holds the configurable attributes of the contact form/public booking experience.
+// ConflictCalendarIDs lists further Google calendars whose busy time blocks slots; events are
+// still created only on the booking calendar.
 type ContactFormSettingsV2 struct {
-	ID                 uint64           `json:"id"`
-	HeroTitle          LocalizedText    `json:"heroTitle"`
-	HeroDescription    LocalizedText    `json:"heroDescription"`
-	Topics             []ContactTopicV2 `json:"topics"`
-	ConsentText        LocalizedText    `json:"consentText"`
-	MinimumLeadHours   int              `json:"minimumLeadHours"`
-	RecaptchaSiteKey   string           `json:"recaptchaSiteKey"`
-	SupportEmail       string           `json:"supportEmail"`
-	CalendarTimezone   string           `json:"calendarTimezone"`
-	GoogleCalendarID   string           `json:"googleCalendarId"`
-	BookingWindowDays  int              `json:"bookingWindowDays"`
-	MeetingURLTemplate string           `json:"meetingUrlTemplate"`
-	CreatedAt          time.Time        `json:"createdAt"`
-	UpdatedAt          time.Time        `json:"updatedAt"`
+	ID                  uint64           `json:"id"`
+	HeroTitle           LocalizedText    `json:"heroTitle"`
+	HeroDescription     LocalizedText    `json:"heroDescription"`
+	Topics              []ContactTopicV2 `json:"topics"`
+	ConsentText         LocalizedText    `json:"consentText"`
+	MinimumLeadHours    int              `json:"minimumLeadHours"`
+	RecaptchaSiteKey    string           `json:"recaptchaSiteKey"`
+	SupportEmail        string           `json:"supportEmail"`
+	CalendarTimezone    string           `json:"calendarTimezone"`
+	GoogleCalendarID    string           `json:"googleCalendarId"`
+	ConflictCalendarIDs []string         `json:"conflictCalendarIds"`
+	BookingWindowDays   int              `json:"bookingWindowDays"`
+	MeetingURLTemplate  string           `json:"meetingUrlTemplate"`
+	WorkingHours        WorkingHours     `json:"workingHours"`
+	CreatedAt           time.Time        `json:"createdAt"`
+	UpdatedAt           time.Time        `json:"updatedAt"`
 }
 
 // HomeQuickLink describes hero quick links on the home screen.
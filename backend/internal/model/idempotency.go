@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// IdempotencyRecord remembers a request sent with an Idempotency-Key so a retry can be answered
+// with the original response. Key is derived from the route and the client's key; Fingerprint is
+// a hash of the request payload. A record without a StatusCode is still being processed.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Completed reports whether the original request has finished and its response is stored.
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
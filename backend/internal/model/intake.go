@@ -0,0 +1,79 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IntakeQuestionType selects how an intake question is rendered and validated.
+type IntakeQuestionType string
+
+const (
+	// IntakeQuestionText takes free text.
+	IntakeQuestionText IntakeQuestionType = "text"
+	// IntakeQuestionSelect takes exactly one of the question's options.
+	IntakeQuestionSelect IntakeQuestionType = "select"
+	// IntakeQuestionCheckbox takes any number of the question's options, or is a single
+	// yes/no box when the question has no options.
+	IntakeQuestionCheckbox IntakeQuestionType = "checkbox"
+)
+
+// IntakeQuestion is an extra field a contact topic asks for, such as company or role.
+type IntakeQuestion struct {
+	ID       string                 `json:"id"`
+	Type     IntakeQuestionType     `json:"type"`
+	Label    LocalizedText          `json:"label"`
+	Required bool                   `json:"required,omitempty"`
+	Options  []IntakeQuestionOption `json:"options,omitempty"`
+}
+
+// IntakeQuestionOption is a choice offered by select and checkbox questions.
+type IntakeQuestionOption struct {
+	Value string        `json:"value"`
+	Label LocalizedText `json:"label"`
+}
+
+// IntakeAnswerValues is a submitted answer. It accepts a string, a boolean (for a single
+// checkbox), or a list of strings, and always holds the answer as a list.
+type IntakeAnswerValues []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *IntakeAnswerValues) UnmarshalJSON(data []byte) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	switch value := raw.(type) {
+	case nil:
+		*v = nil
+	case string:
+		*v = IntakeAnswerValues{value}
+	case bool:
+		*v = nil
+		if value {
+			*v = IntakeAnswerValues{"true"}
+		}
+	case []any:
+		values := make(IntakeAnswerValues, 0, len(value))
+		for _, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("intake answer list must contain strings")
+			}
+			values = append(values, text)
+		}
+		*v = values
+	default:
+		return fmt.Errorf("intake answer must be a string, boolean, or list of strings")
+	}
+	return nil
+}
+
+// IntakeAnswer is a validated answer stored with a reservation or contact message. Label is the
+// question label in the submitter's locale at the time, so the answer stays readable after the
+// question is edited; Values hold option values for select and checkbox questions.
+type IntakeAnswer struct {
+	QuestionID string   `json:"questionId"`
+	Label      string   `json:"label"`
+	Values     []string `json:"values"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "strings"
+
 // LocalizedText represents a translatable string with Japanese and English variants.
 type LocalizedText struct {
 	Ja string `json:"ja,omitempty"`
@@ -13,3 +15,35 @@ func NewLocalizedText(ja, en string) LocalizedText {
 		En: en,
 	}
 }
+
+// Locales supported for visitor-facing text such as notification emails.
+const (
+	LocaleJa = "ja"
+	LocaleEn = "en"
+)
+
+// NormalizeLocale maps a language tag ("ja-JP") or an Accept-Language header
+// ("en-US,en;q=0.9") to a supported locale. It returns "" when nothing matches.
+func NormalizeLocale(value string) string {
+	for _, part := range strings.Split(value, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		primary := strings.ToLower(strings.SplitN(strings.ReplaceAll(tag, "_", "-"), "-", 2)[0])
+		switch primary {
+		case LocaleJa, LocaleEn:
+			return primary
+		}
+	}
+	return ""
+}
+
+// Resolve returns the variant for locale, falling back to the other language when it is empty.
+func (t LocalizedText) Resolve(locale string) string {
+	primary, fallback := t.Ja, t.En
+	if locale == LocaleEn {
+		primary, fallback = t.En, t.Ja
+	}
+	if strings.TrimSpace(primary) != "" {
+		return primary
+	}
+	return fallback
+}
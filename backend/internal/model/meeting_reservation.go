@@ -1,17 +1,126 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
-// MeetingReservationStatus captures the lifecycle of a reservation.
+// MeetingReservationStatus captures the lifecycle of a reservation. A reservation starts as
+// requested, becomes confirmed once its invitation is sent (or an administrator approves it),
+// and ends completed, no_show, or cancelled by either side.
 type MeetingReservationStatus string
 
 const (
-	MeetingReservationStatusPending   MeetingReservationStatus = "pending"
-	MeetingReservationStatusConfirmed MeetingReservationStatus = "confirmed"
-	MeetingReservationStatusCancelled MeetingReservationStatus = "cancelled"
+	MeetingReservationStatusRequested          MeetingReservationStatus = "requested"
+	MeetingReservationStatusConfirmed          MeetingReservationStatus = "confirmed"
+	MeetingReservationStatusRescheduled        MeetingReservationStatus = "rescheduled"
+	MeetingReservationStatusCompleted          MeetingReservationStatus = "completed"
+	MeetingReservationStatusNoShow             MeetingReservationStatus = "no_show"
+	MeetingReservationStatusCancelledByVisitor MeetingReservationStatus = "cancelled_by_visitor"
+	MeetingReservationStatusCancelledByOwner   MeetingReservationStatus = "cancelled_by_owner"
+)
+
+// ActiveMeetingReservationStatuses are the statuses of reservations that still hold their slot.
+var ActiveMeetingReservationStatuses = []MeetingReservationStatus{
+	MeetingReservationStatusRequested,
+	MeetingReservationStatusConfirmed,
+	MeetingReservationStatusRescheduled,
+}
+
+// meetingReservationTransitions lists the statuses each status may move to. Completed and
+// no_show may be swapped to correct a mistake; cancellations are final.
+var meetingReservationTransitions = map[MeetingReservationStatus][]MeetingReservationStatus{
+	MeetingReservationStatusRequested: {
+		MeetingReservationStatusConfirmed,
+		MeetingReservationStatusCancelledByVisitor,
+		MeetingReservationStatusCancelledByOwner,
+	},
+	MeetingReservationStatusConfirmed: {
+		MeetingReservationStatusRescheduled,
+		MeetingReservationStatusCompleted,
+		MeetingReservationStatusNoShow,
+		MeetingReservationStatusCancelledByVisitor,
+		MeetingReservationStatusCancelledByOwner,
+	},
+	MeetingReservationStatusRescheduled: {
+		MeetingReservationStatusRescheduled,
+		MeetingReservationStatusCompleted,
+		MeetingReservationStatusNoShow,
+		MeetingReservationStatusCancelledByVisitor,
+		MeetingReservationStatusCancelledByOwner,
+	},
+	MeetingReservationStatusCompleted: {MeetingReservationStatusNoShow},
+	MeetingReservationStatusNoShow:    {MeetingReservationStatusCompleted},
+}
+
+// Valid reports whether s is a known status.
+func (s MeetingReservationStatus) Valid() bool {
+	switch s {
+	case MeetingReservationStatusRequested,
+		MeetingReservationStatusConfirmed,
+		MeetingReservationStatusRescheduled,
+		MeetingReservationStatusCompleted,
+		MeetingReservationStatusNoShow,
+		MeetingReservationStatusCancelledByVisitor,
+		MeetingReservationStatusCancelledByOwner:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsActive reports whether a reservation in this status still holds its slot.
+func (s MeetingReservationStatus) IsActive() bool {
+	for _, active := range ActiveMeetingReservationStatuses {
+		if s == active {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCancelled reports whether the reservation was cancelled by either side.
+func (s MeetingReservationStatus) IsCancelled() bool {
+	return s == MeetingReservationStatusCancelledByVisitor || s == MeetingReservationStatusCancelledByOwner
+}
+
+// CanTransitionTo reports whether the transition table allows moving from s to next.
+func (s MeetingReservationStatus) CanTransitionTo(next MeetingReservationStatus) bool {
+	for _, allowed := range meetingReservationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Actors recorded on reservation status changes.
+const (
+	ReservationActorVisitor = "visitor"
+	ReservationActorOwner   = "owner"
+	ReservationActorSystem  = "system"
 )
 
-// MeetingReservation represents a booking persisted in meeting_reservations.
+// MeetingReservationStatusChange is one entry of a reservation's status history. FromStatus is
+// empty for the entry written when the reservation is created.
+type MeetingReservationStatusChange struct {
+	ID            uint64                   `json:"id"`
+	ReservationID uint64                   `json:"reservationId"`
+	FromStatus    MeetingReservationStatus `json:"fromStatus,omitempty"`
+	ToStatus      MeetingReservationStatus `json:"toStatus"`
+	Actor         string                   `json:"actor"`
+	Reason        string                   `json:"reason,omitempty"`
+	CreatedAt     time.Time                `json:"createdAt"`
+}
+
+// BlacklistSuggestion flags a visitor who has repeatedly not shown up and is not yet blacklisted.
+type BlacklistSuggestion struct {
+	Email       string `json:"email"`
+	NoShowCount int    `json:"noShowCount"`
+}
+
+// MeetingReservation represents a booking persisted in meeting_reservations. RequiresApproval
+// marks a tentative request that is only confirmed once an administrator approves it.
 type MeetingReservation struct {
 	ID                     uint64                   `json:"id"`
 	LookupHash             string                   `json:"lookupHash"`
@@ -19,12 +128,16 @@ type MeetingReservation struct {
 	Email                  string                   `json:"email"`
 	Topic                  string                   `json:"topic"`
 	Message                string                   `json:"message"`
+	Locale                 string                   `json:"locale,omitempty"`
+	VisitorTimezone        string                   `json:"visitorTimezone,omitempty"`
+	IntakeAnswers          []IntakeAnswer           `json:"intakeAnswers,omitempty"`
 	StartAt                time.Time                `json:"startAt"`
 	EndAt                  time.Time                `json:"endAt"`
 	DurationMinutes        int                      `json:"durationMinutes"`
 	GoogleEventID          string                   `json:"googleEventId"`
 	GoogleCalendarStatus   string                   `json:"googleCalendarStatus"`
 	Status                 MeetingReservationStatus `json:"status"`
+	RequiresApproval       bool                     `json:"requiresApproval,omitempty"`
 	ConfirmationSentAt     *time.Time               `json:"confirmationSentAt,omitempty"`
 	LastNotificationSentAt *time.Time               `json:"lastNotificationSentAt,omitempty"`
 	CancellationReason     string                   `json:"cancellationReason,omitempty"`
@@ -32,12 +145,37 @@ type MeetingReservation struct {
 	UpdatedAt              time.Time                `json:"updatedAt"`
 }
 
-// MeetingNotification captures entries written to meeting_notifications.
+// CalendarUID identifies the reservation in every iCalendar object produced for it (mailed
+// invites, the subscription feed, exports), so calendar clients update or remove the entry they
+// already hold instead of adding a new one.
+func (r MeetingReservation) CalendarUID() string {
+	return fmt.Sprintf("meeting-reservation-%d@personal-website", r.ID)
+}
+
+// MeetingNotification captures entries written to meeting_notifications. Status is pending,
+// sent, or failed; the retry worker also moves notifications to dead once they run out of
+// attempts and to skipped when they no longer apply. Attempts counts the worker's deliveries and
+// NextAttemptAt is when it tries a failed notification again.
 type MeetingNotification struct {
-	ID            uint64    `json:"id"`
-	ReservationID uint64    `json:"reservationId"`
-	Type          string    `json:"type"`
-	Status        string    `json:"status"`
-	ErrorMessage  string    `json:"errorMessage,omitempty"`
-	CreatedAt     time.Time `json:"createdAt"`
+	ID            uint64     `json:"id"`
+	ReservationID uint64     `json:"reservationId"`
+	Type          string     `json:"type"`
+	Status        string     `json:"status"`
+	ErrorMessage  string     `json:"errorMessage,omitempty"`
+	DedupeKey     string     `json:"dedupeKey,omitempty"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// MeetingNotificationAttempt records one delivery attempt by the retry worker. Status is sent,
+// failed, or skipped.
+type MeetingNotificationAttempt struct {
+	ID             uint64    `json:"id"`
+	NotificationID uint64    `json:"notificationId"`
+	ReservationID  uint64    `json:"reservationId"`
+	Attempt        int       `json:"attempt"`
+	Status         string    `json:"status"`
+	ErrorMessage   string    `json:"errorMessage,omitempty"`
+	AttemptedAt    time.Time `json:"attemptedAt"`
 }
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// NotificationTemplateKey identifies one kind of notification email.
+type NotificationTemplateKey string
+
+const (
+	NotificationTemplateBookingConfirmation NotificationTemplateKey = "booking_confirmation"
+	NotificationTemplateBookingReschedule   NotificationTemplateKey = "booking_reschedule"
+	NotificationTemplateBookingCancellation NotificationTemplateKey = "booking_cancellation"
+	NotificationTemplateBookingReminder     NotificationTemplateKey = "booking_reminder"
+	NotificationTemplateOwnerBookingNotice  NotificationTemplateKey = "owner_booking_notice"
+	NotificationTemplateBookingRequested    NotificationTemplateKey = "booking_request_received"
+	NotificationTemplateBookingApproved     NotificationTemplateKey = "booking_approved"
+	NotificationTemplateBookingDeclined     NotificationTemplateKey = "booking_declined"
+	NotificationTemplateWaitlistOffer       NotificationTemplateKey = "waitlist_offer"
+	NotificationTemplateContactAutoReply    NotificationTemplateKey = "contact_auto_reply"
+	NotificationTemplateOwnerContactNotice  NotificationTemplateKey = "owner_contact_notice"
+)
+
+// NotificationTemplate holds the localized subject and bodies of a notification email.
+// Subjects and plain-text bodies are text/template sources; HTML bodies are html/template
+// sources and may be left empty to send plain text only.
+type NotificationTemplate struct {
+	Key        NotificationTemplateKey `json:"key"`
+	Subject    LocalizedText           `json:"subject"`
+	TextBody   LocalizedText           `json:"textBody"`
+	HTMLBody   LocalizedText           `json:"htmlBody"`
+	Customized bool                    `json:"customized"`
+	UpdatedAt  *time.Time              `json:"updatedAt,omitempty"`
+}
+
+// NotificationTemplateInput carries an admin edit (or unsaved draft) of a template.
+type NotificationTemplateInput struct {
+	Subject  LocalizedText `json:"subject"`
+	TextBody LocalizedText `json:"textBody"`
+	HTMLBody LocalizedText `json:"htmlBody"`
+}
+
+// RenderedNotification is a notification template rendered for one locale.
+type RenderedNotification struct {
+	Locale   string `json:"locale"`
+	Subject  string `json:"subject"`
+	TextBody string `json:"textBody"`
+	HTMLBody string `json:"htmlBody,omitempty"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// OutboxJobKind names a side effect queued alongside a reservation.
+type OutboxJobKind string
+
+const (
+	OutboxJobCreateCalendarEvent OutboxJobKind = "create_calendar_event"
+	OutboxJobSendConfirmation    OutboxJobKind = "send_confirmation"
+	OutboxJobNotifyOwner         OutboxJobKind = "notify_owner"
+	OutboxJobSendRequestReceived OutboxJobKind = "send_request_received"
+	OutboxJobSendApproval        OutboxJobKind = "send_approval"
+	OutboxJobSendDecline         OutboxJobKind = "send_decline"
+	OutboxJobExpireApproval      OutboxJobKind = "expire_approval"
+	OutboxJobOfferWaitlist       OutboxJobKind = "offer_waitlist"
+	OutboxJobDeleteCalendarEvent OutboxJobKind = "delete_calendar_event"
+)
+
+// OutboxJobStatus captures the dispatcher lifecycle of an outbox job.
+type OutboxJobStatus string
+
+const (
+	OutboxJobStatusPending    OutboxJobStatus = "pending"
+	OutboxJobStatusProcessing OutboxJobStatus = "processing"
+	OutboxJobStatusDone       OutboxJobStatus = "done"
+	OutboxJobStatusDead       OutboxJobStatus = "dead"
+)
+
+// OutboxJob is a side effect persisted in booking_outbox together with its reservation.
+// While a job is processing, NextAttemptAt holds the lease expiry.
+type OutboxJob struct {
+	ID            uint64          `json:"id"`
+	ReservationID uint64          `json:"reservationId"`
+	Kind          OutboxJobKind   `json:"kind"`
+	Status        OutboxJobStatus `json:"status"`
+	Attempts      int             `json:"attempts"`
+	MaxAttempts   int             `json:"maxAttempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	LastError     string          `json:"lastError,omitempty"`
+	CompletedAt   *time.Time      `json:"completedAt,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+}
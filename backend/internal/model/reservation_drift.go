@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// ReservationDriftKind names how a reservation's Google Calendar event diverged from the stored
+// reservation.
+type ReservationDriftKind string
+
+const (
+	ReservationDriftEventDeleted     ReservationDriftKind = "event_deleted"
+	ReservationDriftEventMoved       ReservationDriftKind = "event_moved"
+	ReservationDriftAttendeeDeclined ReservationDriftKind = "attendee_declined"
+)
+
+// ReservationDriftAction records what the calendar reconciler did about a drift: applied it to
+// the reservation, or flagged it for the administrator to review.
+type ReservationDriftAction string
+
+const (
+	ReservationDriftApplied ReservationDriftAction = "applied"
+	ReservationDriftFlagged ReservationDriftAction = "flagged"
+)
+
+// ReservationDrift is one difference found between a reservation and its calendar event.
+// EventStartAt and EventEndAt hold the event's time when it was moved. DedupeKey identifies the
+// drift so an unchanged event is reported once.
+type ReservationDrift struct {
+	ID            uint64                 `json:"id"`
+	ReservationID uint64                 `json:"reservationId"`
+	GoogleEventID string                 `json:"googleEventId"`
+	Kind          ReservationDriftKind   `json:"kind"`
+	Action        ReservationDriftAction `json:"action"`
+	StoredStartAt time.Time              `json:"storedStartAt"`
+	StoredEndAt   time.Time              `json:"storedEndAt"`
+	EventStartAt  *time.Time             `json:"eventStartAt,omitempty"`
+	EventEndAt    *time.Time             `json:"eventEndAt,omitempty"`
+	Detail        string                 `json:"detail,omitempty"`
+	DedupeKey     string                 `json:"-"`
+	DetectedAt    time.Time              `json:"detectedAt"`
+	ResolvedAt    *time.Time             `json:"resolvedAt,omitempty"`
+}
@@ -25,6 +25,16 @@ const (
 	AvailabilitySlotStatusAvailable AvailabilitySlotStatus = "available"
 	AvailabilitySlotStatusReserved  AvailabilitySlotStatus = "reserved"
 	AvailabilitySlotStatusBlackout  AvailabilitySlotStatus = "blackout"
+	// AvailabilitySlotStatusUnavailable marks free slots that fall outside the booking rules.
+	AvailabilitySlotStatusUnavailable AvailabilitySlotStatus = "unavailable"
+)
+
+// AvailabilitySlotReason explains why an otherwise free slot cannot be booked.
+type AvailabilitySlotReason string
+
+const (
+	AvailabilitySlotReasonMinimumLead   AvailabilitySlotReason = "minimum_lead_time"
+	AvailabilitySlotReasonBookingWindow AvailabilitySlotReason = "outside_booking_window"
 )
 
 // AvailabilitySlot describes a single bookable slot.
@@ -34,6 +44,7 @@ type AvailabilitySlot struct {
 	End        time.Time              `json:"end"`
 	Status     AvailabilitySlotStatus `json:"status"`
 	IsBookable bool                   `json:"isBookable"`
+	Reason     AvailabilitySlotReason `json:"reason,omitempty"`
 }
 
 // AvailabilityDay aggregates slots for a calendar date.
@@ -42,9 +53,54 @@ type AvailabilityDay struct {
 	Slots []AvailabilitySlot `json:"slots"`
 }
 
-// AvailabilityResponse is returned by the contact availability endpoint.
+// AvailabilityResponse is returned by the contact availability endpoint. Days and slot times
+// are in Timezone (the visitor's zone when requested); working hours and booking rules always
+// follow BusinessTimezone.
 type AvailabilityResponse struct {
-	Timezone    string            `json:"timezone"`
-	GeneratedAt time.Time         `json:"generatedAt"`
-	Days        []AvailabilityDay `json:"days"`
+	Timezone         string            `json:"timezone"`
+	BusinessTimezone string            `json:"businessTimezone"`
+	GeneratedAt      time.Time         `json:"generatedAt"`
+	Days             []AvailabilityDay `json:"days"`
+}
+
+// ScheduleBlackout blocks bookings for a one-off window or, when Recurrence holds an RRULE,
+// for every occurrence of the series. StartTime/EndTime describe the first occurrence and
+// Timezone anchors the wall-clock time of later occurrences.
+type ScheduleBlackout struct {
+	ID         uint64    `json:"id"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Reason     string    `json:"reason,omitempty"`
+	Recurrence string    `json:"recurrence,omitempty"`
+	Timezone   string    `json:"timezone,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// WorkingInterval is a bookable range within a day using "HH:MM" wall-clock times.
+// End may be "24:00" to run until midnight.
+type WorkingInterval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// WeekdayWorkingHours lists the bookable intervals for a weekday (0 = Sunday).
+type WeekdayWorkingHours struct {
+	Weekday   time.Weekday      `json:"weekday"`
+	Intervals []WorkingInterval `json:"intervals"`
+}
+
+// WorkingHoursOverride replaces the weekly schedule on a single date (YYYY-MM-DD).
+// An empty interval list closes the date entirely.
+type WorkingHoursOverride struct {
+	Date      string            `json:"date"`
+	Intervals []WorkingInterval `json:"intervals"`
+	Note      string            `json:"note,omitempty"`
+}
+
+// WorkingHours describes when bookings are accepted. Weekdays missing from Weekly are closed;
+// an empty Weekly list means the static contact configuration applies.
+type WorkingHours struct {
+	Weekly    []WeekdayWorkingHours  `json:"weekly"`
+	Overrides []WorkingHoursOverride `json:"overrides"`
 }
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Sources a spam fingerprint can come from.
+const (
+	SpamSourceContact = "contact"
+	SpamSourceBooking = "booking"
+)
+
+// SpamVerdict is an admin's classification of a contact message.
+type SpamVerdict string
+
+const (
+	SpamVerdictSpam SpamVerdict = "spam"
+	SpamVerdictHam  SpamVerdict = "ham"
+)
+
+// SpamSignal is one rule's contribution to a spam score. Negative scores come from earlier ham
+// verdicts and lower the total.
+type SpamSignal struct {
+	Rule   string  `json:"rule"`
+	Score  float64 `json:"score"`
+	Detail string  `json:"detail,omitempty"`
+}
+
+// SpamAssessment is the spam pipeline's result for one submission. Spam is set when Score
+// reaches the configured threshold.
+type SpamAssessment struct {
+	Score       float64      `json:"score"`
+	Spam        bool         `json:"spam"`
+	Signals     []SpamSignal `json:"signals,omitempty"`
+	ContentHash string       `json:"-"`
+}
+
+// SpamFingerprint records that a message with ContentHash was submitted, so repeats can be
+// counted.
+type SpamFingerprint struct {
+	ContentHash string
+	Email       string
+	Source      string
+	CreatedAt   time.Time
+}
+
+// SpamFeedback is a spam or ham verdict an admin gave a contact message. The pipeline looks
+// verdicts up by content hash and sender email.
+type SpamFeedback struct {
+	ID          uint64      `json:"id"`
+	ContactID   string      `json:"contactId"`
+	ContentHash string      `json:"contentHash"`
+	Email       string      `json:"email"`
+	Verdict     SpamVerdict `json:"verdict"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+// SpamContentHash fingerprints a message so resubmissions match regardless of letter case and
+// whitespace. It returns an empty string for a blank message.
+func SpamContentHash(message string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(message)), " ")
+	if normalized == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// WaitlistStatus tracks a waitlist entry from joining to its outcome.
+type WaitlistStatus string
+
+const (
+	// WaitlistStatusWaiting entries are offered freed slots on one of their preferred dates.
+	WaitlistStatusWaiting WaitlistStatus = "waiting"
+	// WaitlistStatusOffered entries hold a claim link for one freed slot until the offer expires.
+	WaitlistStatusOffered WaitlistStatus = "offered"
+	// WaitlistStatusBooked entries claimed their offer and became a reservation.
+	WaitlistStatusBooked WaitlistStatus = "booked"
+	// WaitlistStatusLapsed entries let their offer expire; the visitor can join again.
+	WaitlistStatusLapsed WaitlistStatus = "lapsed"
+)
+
+// WaitlistEntry is a visitor waiting for a slot on a fully booked day. Preferred dates are
+// YYYY-MM-DD days in the booking timezone. While an offer is open the Offer* fields describe
+// the freed slot; only a SHA-256 hash of the claim token is stored.
+type WaitlistEntry struct {
+	ID                 uint64         `json:"id"`
+	Name               string         `json:"name"`
+	Email              string         `json:"email"`
+	Topic              string         `json:"topic,omitempty"`
+	Locale             string         `json:"locale,omitempty"`
+	PreferredDates     []string       `json:"preferredDates"`
+	Status             WaitlistStatus `json:"status"`
+	OfferReservationID uint64         `json:"offerReservationId,omitempty"`
+	OfferStartAt       *time.Time     `json:"offerStartAt,omitempty"`
+	OfferEndAt         *time.Time     `json:"offerEndAt,omitempty"`
+	OfferExpiresAt     *time.Time     `json:"offerExpiresAt,omitempty"`
+	OfferTokenHash     string         `json:"-"`
+	CreatedAt          time.Time      `json:"createdAt"`
+	UpdatedAt          time.Time      `json:"updatedAt"`
+}
+
+// WaitlistRequest is the public payload for joining the waitlist.
+type WaitlistRequest struct {
+	Name           string   `json:"name"`
+	Email          string   `json:"email"`
+	Topic          string   `json:"topic"`
+	PreferredDates []string `json:"preferredDates"`
+	Locale         string   `json:"locale"`
+	RecaptchaToken string   `json:"recaptchaToken"`
+	RemoteIP       string   `json:"-"`
+}
+
+// WaitlistClaimRequest claims an offered slot with the token from the offer email.
+type WaitlistClaimRequest struct {
+	Token string `json:"token"`
+}
+
+// WaitlistOffer describes an open offer to the visitor holding its claim token.
+type WaitlistOffer struct {
+	Name      string    `json:"name"`
+	Topic     string    `json:"topic,omitempty"`
+	StartAt   time.Time `json:"startAt"`
+	EndAt     time.Time `json:"endAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -55,6 +55,13 @@ type BlogRepository interface {
 }
 
 // MeetingReservationRepository manages reservations backed by meeting_reservations.
+// TransitionReservationStatus moves a reservation from change.FromStatus to change.ToStatus and
+// appends the change to its status history in one step; it returns ErrConflict when the stored
+// status is no longer FromStatus. Creating a reservation writes the first history entry.
+// CreateReservation and RescheduleReservation claim their slot atomically and return ErrConflict
+// when another active reservation overlaps it; RescheduleReservation also returns ErrConflict
+// once the reservation is no longer active, and keeps the stored event ID when googleEventID is
+// empty.
 type MeetingReservationRepository interface {
 	CreateReservation(ctx context.Context, reservation *model.MeetingReservation) (*model.MeetingReservation, error)
 	FindReservationByLookupHash(ctx context.Context, lookupHash string) (*model.MeetingReservation, error)
@@ -62,19 +69,85 @@ type MeetingReservationRepository interface {
 	ListReservations(ctx context.Context, filter MeetingReservationListFilter) ([]model.MeetingReservation, error)
 	ListConflictingReservations(ctx context.Context, start, end time.Time) ([]model.MeetingReservation, error)
 	MarkConfirmationSent(ctx context.Context, id uint64, sentAt time.Time) (*model.MeetingReservation, error)
-	CancelReservation(ctx context.Context, id uint64, reason string) (*model.MeetingReservation, error)
-	UpdateReservationStatus(ctx context.Context, id uint64, status model.MeetingReservationStatus, reason string) (*model.MeetingReservation, error)
+	RescheduleReservation(ctx context.Context, id uint64, start, end time.Time, googleEventID string) (*model.MeetingReservation, error)
+	TransitionReservationStatus(ctx context.Context, change *model.MeetingReservationStatusChange) (*model.MeetingReservation, error)
+	ListStatusHistory(ctx context.Context, reservationID uint64) ([]model.MeetingReservationStatusChange, error)
 }
 
 // MeetingNotificationRepository records outgoing notifications linked to reservations.
+// ClaimNotification inserts a notification carrying a DedupeKey and returns ErrConflict when
+// that key was already claimed for the reservation, so only one sender wins.
 type MeetingNotificationRepository interface {
 	RecordNotification(ctx context.Context, notification *model.MeetingNotification) (*model.MeetingNotification, error)
 	ListNotifications(ctx context.Context, reservationID uint64) ([]model.MeetingNotification, error)
+	ClaimNotification(ctx context.Context, notification *model.MeetingNotification) (*model.MeetingNotification, error)
+	UpdateNotificationStatus(ctx context.Context, id uint64, status, errorMessage string) (*model.MeetingNotification, error)
+	// ClaimRetryableNotifications leases up to limit notifications of the given types that are
+	// due for delivery: failed ones whose next attempt time has passed, and pending ones created
+	// before pendingBefore so sends still in flight are left alone. Claimed notifications are not
+	// returned again until the lease expires or an attempt is recorded.
+	ClaimRetryableNotifications(ctx context.Context, types []string, now, pendingBefore time.Time, limit int, lease time.Duration) ([]model.MeetingNotification, error)
+	// RecordNotificationAttempt stores the attempt and moves its notification to status,
+	// scheduling the next try at nextAttemptAt when set.
+	RecordNotificationAttempt(ctx context.Context, attempt *model.MeetingNotificationAttempt, status string, nextAttemptAt *time.Time) (*model.MeetingNotification, error)
+	ListNotificationAttempts(ctx context.Context, reservationID uint64) ([]model.MeetingNotificationAttempt, error)
+}
+
+// SpamRepository stores what the spam filter learns from: fingerprints of recent submissions
+// and the spam/ham verdicts admins give contact messages. ListSpamFeedback returns verdicts
+// matching either the content hash or the email, newest first.
+type SpamRepository interface {
+	RecordSpamFingerprint(ctx context.Context, fingerprint *model.SpamFingerprint) error
+	CountSpamFingerprints(ctx context.Context, contentHash string, since time.Time) (int, error)
+	RecordSpamFeedback(ctx context.Context, feedback *model.SpamFeedback) (*model.SpamFeedback, error)
+	ListSpamFeedback(ctx context.Context, contentHash, email string) ([]model.SpamFeedback, error)
+}
+
+// ContactNotificationRepository queues and records the emails sent about contact form submissions.
+// ListContactNotifications returns them oldest first.
+type ContactNotificationRepository interface {
+	RecordContactNotification(ctx context.Context, notification *model.ContactNotification) (*model.ContactNotification, error)
+	UpdateContactNotificationStatus(ctx context.Context, id uint64, status, errorMessage string) (*model.ContactNotification, error)
+	// ClaimRetryableContactNotifications leases up to limit pending or failed notifications whose
+	// next attempt time has passed. Claimed notifications are not returned again until the lease
+	// expires or an attempt is recorded.
+	ClaimRetryableContactNotifications(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.ContactNotification, error)
+	// RecordContactNotificationAttempt moves a notification to status after its attempt-th try,
+	// scheduling the next try at nextAttemptAt when set.
+	RecordContactNotificationAttempt(ctx context.Context, id uint64, attempt int, status, errorMessage string, nextAttemptAt *time.Time) (*model.ContactNotification, error)
+	ListContactNotifications(ctx context.Context, contactID string) ([]model.ContactNotification, error)
+}
+
+// ReservationDriftRepository stores the differences the calendar reconciler found between
+// reservations and their calendar events. RecordDrift returns ErrConflict when the reservation
+// already has a drift with the same DedupeKey, so a drift left in place is reported once.
+// ListDrifts returns the newest drifts first and skips resolved ones unless includeResolved.
+type ReservationDriftRepository interface {
+	RecordDrift(ctx context.Context, drift *model.ReservationDrift) (*model.ReservationDrift, error)
+	ListDrifts(ctx context.Context, includeResolved bool) ([]model.ReservationDrift, error)
+	ResolveDrift(ctx context.Context, id uint64, resolvedAt time.Time) (*model.ReservationDrift, error)
+}
+
+// BookingOutboxRepository persists reservations atomically with their outbox jobs and lets the
+// dispatcher lease and settle those jobs. Settling a job (complete, retry, dead-letter) counts an
+// attempt; deferring does not. EnqueueJobs queues follow-up jobs for an existing reservation.
+// AttachCalendarEvent only links an event to a reservation without one and returns ErrConflict
+// when another event was linked first.
+type BookingOutboxRepository interface {
+	CreateReservationWithJobs(ctx context.Context, reservation *model.MeetingReservation, jobs []model.OutboxJob) (*model.MeetingReservation, error)
+	EnqueueJobs(ctx context.Context, reservationID uint64, jobs []model.OutboxJob) error
+	ClaimDueJobs(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.OutboxJob, error)
+	ListJobs(ctx context.Context, reservationID uint64) ([]model.OutboxJob, error)
+	CompleteJob(ctx context.Context, id uint64, completedAt time.Time) error
+	RetryJob(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error
+	DeferJob(ctx context.Context, id uint64, nextAttemptAt time.Time) error
+	DeadLetterJob(ctx context.Context, id uint64, lastError string) error
+	AttachCalendarEvent(ctx context.Context, reservationID uint64, eventID string) error
 }
 
 // AdminContactRepository exposes management capabilities for contact submissions.
 type AdminContactRepository interface {
-	ListContactMessages(ctx context.Context) ([]model.ContactMessage, error)
+	ListContactMessages(ctx context.Context, filter ContactMessageListFilter) ([]model.ContactMessage, error)
 	GetContactMessage(ctx context.Context, id string) (*model.ContactMessage, error)
 	UpdateContactMessage(ctx context.Context, message *model.ContactMessage) (*model.ContactMessage, error)
 	DeleteContactMessage(ctx context.Context, id string) error
@@ -95,9 +168,86 @@ type BlacklistRepository interface {
 	FindBlacklistEntryByEmail(ctx context.Context, email string) (*model.BlacklistEntry, error)
 }
 
+// ScheduleBlackoutRepository manages one-off and recurring blackout windows.
+type ScheduleBlackoutRepository interface {
+	ListBlackouts(ctx context.Context) ([]model.ScheduleBlackout, error)
+	GetBlackout(ctx context.Context, id uint64) (*model.ScheduleBlackout, error)
+	CreateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error)
+	UpdateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error)
+	DeleteBlackout(ctx context.Context, id uint64) error
+}
+
 // MeetingReservationListFilter captures optional filters when listing reservations.
 type MeetingReservationListFilter struct {
 	Status []model.MeetingReservationStatus
 	Email  string
 	Date   *time.Time
+	// StartFrom and StartBefore bound start_at to [StartFrom, StartBefore) when set.
+	StartFrom   *time.Time
+	StartBefore *time.Time
+	// After resumes the list past the last reservation of a previous page and Limit caps the
+	// page size when positive.
+	After *MeetingReservationCursor
+	Limit int
+}
+
+// MeetingReservationCursor marks a position in reservation list order (start_at, then id,
+// both descending).
+type MeetingReservationCursor struct {
+	StartAt time.Time
+	ID      uint64
+}
+
+// ContactMessageListFilter captures optional filters when listing contact messages.
+type ContactMessageListFilter struct {
+	Status []model.ContactStatus
+	Email  string
+	// CreatedFrom and CreatedBefore bound created_at to [CreatedFrom, CreatedBefore) when set.
+	CreatedFrom   *time.Time
+	CreatedBefore *time.Time
+	// After resumes the list past the last message of a previous page and Limit caps the page
+	// size when positive.
+	After *ContactMessageCursor
+	Limit int
+}
+
+// ContactMessageCursor marks a position in contact message list order (created_at, then id,
+// both descending).
+type ContactMessageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// CalendarFeedTokenRepository stores the hashed tokens that unlock the private calendar feed.
+// FindFeedTokenByHash returns revoked tokens as well; callers check RevokedAt.
+type CalendarFeedTokenRepository interface {
+	ListFeedTokens(ctx context.Context) ([]model.CalendarFeedToken, error)
+	CreateFeedToken(ctx context.Context, token *model.CalendarFeedToken) (*model.CalendarFeedToken, error)
+	FindFeedTokenByHash(ctx context.Context, tokenHash string) (*model.CalendarFeedToken, error)
+	RevokeFeedToken(ctx context.Context, id uint64, revokedAt time.Time) (*model.CalendarFeedToken, error)
+	TouchFeedToken(ctx context.Context, id uint64, usedAt time.Time) error
+}
+
+// NotificationTemplateRepository stores admin overrides of the built-in notification email
+// templates. Keys without an override return ErrNotFound.
+type NotificationTemplateRepository interface {
+	ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error)
+	GetTemplate(ctx context.Context, key model.NotificationTemplateKey) (*model.NotificationTemplate, error)
+	SaveTemplate(ctx context.Context, template *model.NotificationTemplate) (*model.NotificationTemplate, error)
+	DeleteTemplate(ctx context.Context, key model.NotificationTemplateKey) error
+}
+
+// WaitlistRepository stores visitors waiting for a slot on fully booked days.
+// ListEntries returns entries in the order they joined. FindOfferByReservation returns the
+// entry currently offered the slot freed by the given reservation, and FindEntryByOfferToken
+// also returns entries whose offer has been claimed or has lapsed. TransitionEntry stores the
+// entry like UpdateEntry, but only while the stored entry still has status from and offer token
+// tokenHash; otherwise it returns ErrConflict, so only one of two concurrent claims wins.
+type WaitlistRepository interface {
+	CreateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error)
+	ListEntries(ctx context.Context, statuses []model.WaitlistStatus) ([]model.WaitlistEntry, error)
+	FindOfferByReservation(ctx context.Context, reservationID uint64) (*model.WaitlistEntry, error)
+	FindEntryByOfferToken(ctx context.Context, tokenHash string) (*model.WaitlistEntry, error)
+	UpdateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error)
+	TransitionEntry(ctx context.Context, entry *model.WaitlistEntry, from model.WaitlistStatus, tokenHash string) (*model.WaitlistEntry, error)
 }
@@ -9,19 +9,13 @@ import (
 
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/schedule"
 )
 
 type availabilityRepository struct {
 	base baseRepository
 }
 
-const blackoutsCollection = "schedule_blackouts"
-
-type blackoutDocument struct {
-	StartTime time.Time `firestore:"startTime"`
-	EndTime   time.Time `firestore:"endTime"`
-}
-
 func NewAvailabilityRepository(client *firestore.Client, prefix string) repository.AvailabilityRepository {
 	return &availabilityRepository{base: newBaseRepository(client, prefix)}
 }
@@ -50,28 +44,38 @@ func (r *availabilityRepository) fetchMeetingWindows(ctx context.Context, from,
 }
 
 func (r *availabilityRepository) fetchBlackoutWindows(ctx context.Context, from, to time.Time) ([]model.TimeWindow, error) {
-	query := r.base.collection(blackoutsCollection).
-		Where("endTime", ">", from).
-		Where("startTime", "<", to)
+	collection := r.base.collection(blackoutsCollection)
 
-	snapshots, err := query.Documents(ctx).GetAll()
+	oneOff, err := collection.
+		Where("endTime", ">", from).
+		Where("startTime", "<", to).
+		Documents(ctx).GetAll()
 	if err != nil {
 		return nil, fmt.Errorf("firestore availability: list blackouts: %w", err)
 	}
 
-	windows := make([]model.TimeWindow, 0, len(snapshots))
-	for _, snap := range snapshots {
-		var entry blackoutDocument
-		if err := snap.DataTo(&entry); err != nil {
-			return nil, fmt.Errorf("firestore availability: decode blackout %s: %w", snap.Ref.ID, err)
+	// Recurring blackouts can repeat long after their first occurrence ends, so they are
+	// fetched separately and expanded in memory.
+	recurring, err := collection.Where("recurring", "==", true).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("firestore availability: list recurring blackouts: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(oneOff)+len(recurring))
+	snapshots := make([]*firestore.DocumentSnapshot, 0, len(oneOff)+len(recurring))
+	for _, snap := range append(oneOff, recurring...) {
+		if _, ok := seen[snap.Ref.ID]; ok {
+			continue
 		}
-		windows = append(windows, model.TimeWindow{
-			Start:  entry.StartTime,
-			End:    entry.EndTime,
-			Source: model.BusyWindowSourceBlackout,
-		})
+		seen[snap.Ref.ID] = struct{}{}
+		snapshots = append(snapshots, snap)
 	}
-	return windows, nil
+
+	blackouts, err := decodeBlackouts(snapshots)
+	if err != nil {
+		return nil, err
+	}
+	return schedule.ExpandBlackouts(blackouts, from, to), nil
 }
 
 var _ repository.AvailabilityRepository = (*availabilityRepository)(nil)
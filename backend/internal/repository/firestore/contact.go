@@ -3,7 +3,6 @@ package firestore
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
@@ -21,14 +20,24 @@ type contactRepository struct {
 const contactCollection = "contact_messages"
 
 type contactDocument struct {
-	Name      string              `firestore:"name"`
-	Email     string              `firestore:"email"`
-	Topic     string              `firestore:"topic"`
-	Message   string              `firestore:"message"`
-	Status    model.ContactStatus `firestore:"status"`
-	AdminNote string              `firestore:"adminNote"`
-	CreatedAt time.Time           `firestore:"createdAt"`
-	UpdatedAt time.Time           `firestore:"updatedAt"`
+	Name          string                 `firestore:"name"`
+	Email         string                 `firestore:"email"`
+	Topic         string                 `firestore:"topic"`
+	Message       string                 `firestore:"message"`
+	Locale        string                 `firestore:"locale,omitempty"`
+	IntakeAnswers []intakeAnswerDocument `firestore:"intakeAnswers,omitempty"`
+	Status        model.ContactStatus    `firestore:"status"`
+	AdminNote     string                 `firestore:"adminNote"`
+	CreatedAt     time.Time              `firestore:"createdAt"`
+	UpdatedAt     time.Time              `firestore:"updatedAt"`
+	SpamScore     float64                `firestore:"spamScore,omitempty"`
+	SpamSignals   []spamSignalDocument   `firestore:"spamSignals,omitempty"`
+}
+
+type spamSignalDocument struct {
+	Rule   string  `firestore:"rule"`
+	Score  float64 `firestore:"score"`
+	Detail string  `firestore:"detail,omitempty"`
 }
 
 // NewContactRepository returns a Firestore-backed implementation for contact submissions.
@@ -44,15 +53,27 @@ func (r *contactRepository) CreateSubmission(ctx context.Context, payload *model
 	}
 
 	now := time.Now().UTC()
+	status := payload.Status
+	if status == "" {
+		status = model.ContactStatusPending
+	}
 	doc := contactDocument{
-		Name:      stringsTrim(payload.Name),
-		Email:     stringsTrim(payload.Email),
-		Topic:     stringsTrim(payload.Topic),
-		Message:   stringsTrim(payload.Message),
-		Status:    model.ContactStatusPending,
-		AdminNote: "",
-		CreatedAt: now,
-		UpdatedAt: now,
+		Name:          stringsTrim(payload.Name),
+		Email:         stringsTrim(payload.Email),
+		Topic:         stringsTrim(payload.Topic),
+		Message:       stringsTrim(payload.Message),
+		Locale:        stringsTrim(payload.Locale),
+		IntakeAnswers: encodeIntakeAnswerDocuments(payload.IntakeAnswers),
+		Status:        status,
+		AdminNote:     stringsTrim(payload.AdminNote),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if payload.Spam != nil {
+		doc.SpamScore = payload.Spam.Score
+		for _, signal := range payload.Spam.Signals {
+			doc.SpamSignals = append(doc.SpamSignals, spamSignalDocument{Rule: signal.Rule, Score: signal.Score, Detail: signal.Detail})
+		}
 	}
 
 	ref, _, err := r.base.collection(contactCollection).Add(ctx, doc)
@@ -62,17 +83,33 @@ func (r *contactRepository) CreateSubmission(ctx context.Context, payload *model
 
 	return &model.ContactSubmission{
 		ID:      ref.ID,
-		Status:  string(model.ContactStatusPending),
+		Status:  string(status),
 		Comment: fmt.Sprintf("stored at %s", now.Format(time.RFC3339)),
 	}, nil
 }
 
-func (r *contactRepository) ListContactMessages(ctx context.Context) ([]model.ContactMessage, error) {
-	iter := r.base.collection(contactCollection).Documents(ctx)
+// ListContactMessages walks messages newest first. The created range and the page cursor are
+// part of the query; status and email are matched while iterating so no composite index is
+// needed, and iteration stops once the page is full.
+func (r *contactRepository) ListContactMessages(ctx context.Context, filter repository.ContactMessageListFilter) ([]model.ContactMessage, error) {
+	query := r.base.collection(contactCollection).Query
+	if filter.CreatedFrom != nil {
+		query = query.Where("createdAt", ">=", filter.CreatedFrom.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("createdAt", "<", filter.CreatedBefore.UTC())
+	}
+	query = query.OrderBy("createdAt", firestore.Desc).OrderBy(firestore.DocumentID, firestore.Desc)
+	if after := filter.After; after != nil {
+		query = query.StartAfter(after.CreatedAt.UTC(), after.ID)
+	}
+
+	iter := query.Documents(ctx)
 	defer iter.Stop()
 
+	email := strings.TrimSpace(filter.Email)
 	messages := make([]model.ContactMessage, 0)
-	for {
+	for filter.Limit <= 0 || len(messages) < filter.Limit {
 		docSnap, err := iter.Next()
 		if err == iterator.Done {
 			break
@@ -84,17 +121,27 @@ func (r *contactRepository) ListContactMessages(ctx context.Context) ([]model.Co
 		if err != nil {
 			return nil, err
 		}
+		if !hasContactStatus(msg.Status, filter.Status) {
+			continue
+		}
+		if email != "" && !strings.EqualFold(strings.TrimSpace(msg.Email), email) {
+			continue
+		}
 		messages = append(messages, *msg)
 	}
+	return messages, nil
+}
 
-	sort.Slice(messages, func(i, j int) bool {
-		if messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
-			return messages[i].ID > messages[j].ID
+func hasContactStatus(status model.ContactStatus, statuses []model.ContactStatus) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, candidate := range statuses {
+		if status == candidate {
+			return true
 		}
-		return messages[i].CreatedAt.After(messages[j].CreatedAt)
-	})
-
-	return messages, nil
+	}
+	return false
 }
 
 func (r *contactRepository) GetContactMessage(ctx context.Context, id string) (*model.ContactMessage, error) {
@@ -176,19 +223,53 @@ func decodeContactDocument(id string, snapshot *firestore.DocumentSnapshot) (*mo
 	}
 
 	message := &model.ContactMessage{
-		ID:        id,
-		Name:      doc.Name,
-		Email:     doc.Email,
-		Topic:     doc.Topic,
-		Message:   doc.Message,
-		Status:    status,
-		AdminNote: doc.AdminNote,
-		CreatedAt: createdAt.UTC(),
-		UpdatedAt: updatedAt.UTC(),
+		ID:            id,
+		Name:          doc.Name,
+		Email:         doc.Email,
+		Topic:         doc.Topic,
+		Message:       doc.Message,
+		Locale:        doc.Locale,
+		IntakeAnswers: decodeIntakeAnswerDocuments(doc.IntakeAnswers),
+		Status:        status,
+		AdminNote:     doc.AdminNote,
+		CreatedAt:     createdAt.UTC(),
+		UpdatedAt:     updatedAt.UTC(),
+		SpamScore:     doc.SpamScore,
+	}
+	for _, signal := range doc.SpamSignals {
+		message.SpamSignals = append(message.SpamSignals, model.SpamSignal{Rule: signal.Rule, Score: signal.Score, Detail: signal.Detail})
 	}
 	return message, nil
 }
 
+type intakeAnswerDocument struct {
+	QuestionID string   `firestore:"questionId"`
+	Label      string   `firestore:"label"`
+	Values     []string `firestore:"values"`
+}
+
+func encodeIntakeAnswerDocuments(answers []model.IntakeAnswer) []intakeAnswerDocument {
+	if len(answers) == 0 {
+		return nil
+	}
+	docs := make([]intakeAnswerDocument, 0, len(answers))
+	for _, answer := range answers {
+		docs = append(docs, intakeAnswerDocument{QuestionID: answer.QuestionID, Label: answer.Label, Values: answer.Values})
+	}
+	return docs
+}
+
+func decodeIntakeAnswerDocuments(docs []intakeAnswerDocument) []model.IntakeAnswer {
+	if len(docs) == 0 {
+		return nil
+	}
+	answers := make([]model.IntakeAnswer, 0, len(docs))
+	for _, doc := range docs {
+		answers = append(answers, model.IntakeAnswer{QuestionID: doc.QuestionID, Label: doc.Label, Values: doc.Values})
+	}
+	return answers
+}
+
 func stringsTrim(value string) string {
 	return strings.TrimSpace(value)
 }
@@ -0,0 +1,148 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+const idempotencyKeysCollection = "idempotency_keys"
+
+type idempotencyDocument struct {
+	Fingerprint string    `firestore:"fingerprint"`
+	StatusCode  int       `firestore:"statusCode"`
+	ContentType string    `firestore:"contentType,omitempty"`
+	Body        []byte    `firestore:"body,omitempty"`
+	CreatedAt   time.Time `firestore:"createdAt"`
+	ExpiresAt   time.Time `firestore:"expiresAt"`
+}
+
+type idempotencyRepository struct {
+	base baseRepository
+}
+
+// NewIdempotencyRepository returns a Firestore-backed Idempotency-Key store. Keys are stored as
+// document IDs; a TTL policy on expiresAt can remove expired documents server-side.
+func NewIdempotencyRepository(client *firestore.Client, prefix string) repository.IdempotencyRepository {
+	return &idempotencyRepository{base: newBaseRepository(client, prefix)}
+}
+
+func (r *idempotencyRepository) CreateIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil || record.Key == "" {
+		return repository.ErrInvalidInput
+	}
+
+	ref := r.base.doc(idempotencyKeysCollection, record.Key)
+	err := r.base.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		switch status.Code(err) {
+		case codes.NotFound:
+			// free
+		case codes.OK:
+			var existing idempotencyDocument
+			if err := snap.DataTo(&existing); err != nil {
+				return fmt.Errorf("firestore idempotency: decode %s: %w", record.Key, err)
+			}
+			if existing.ExpiresAt.After(record.CreatedAt) {
+				return repository.ErrConflict
+			}
+		default:
+			return fmt.Errorf("firestore idempotency: get %s: %w", record.Key, err)
+		}
+
+		return tx.Set(ref, idempotencyDocument{
+			Fingerprint: record.Fingerprint,
+			CreatedAt:   record.CreatedAt.UTC(),
+			ExpiresAt:   record.ExpiresAt.UTC(),
+		})
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return repository.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) FindIdempotencyRecord(ctx context.Context, key string, now time.Time) (*model.IdempotencyRecord, error) {
+	snap, err := r.base.doc(idempotencyKeysCollection, key).Get(ctx)
+	switch status.Code(err) {
+	case codes.NotFound:
+		return nil, repository.ErrNotFound
+	case codes.OK:
+		// continue
+	default:
+		return nil, fmt.Errorf("firestore idempotency: get %s: %w", key, err)
+	}
+
+	var doc idempotencyDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("firestore idempotency: decode %s: %w", key, err)
+	}
+	if !doc.ExpiresAt.After(now) {
+		return nil, repository.ErrNotFound
+	}
+	return &model.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: doc.Fingerprint,
+		StatusCode:  doc.StatusCode,
+		ContentType: doc.ContentType,
+		Body:        doc.Body,
+		CreatedAt:   doc.CreatedAt.UTC(),
+		ExpiresAt:   doc.ExpiresAt.UTC(),
+	}, nil
+}
+
+func (r *idempotencyRepository) CompleteIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil {
+		return repository.ErrInvalidInput
+	}
+
+	_, err := r.base.doc(idempotencyKeysCollection, record.Key).Update(ctx, []firestore.Update{
+		{Path: "statusCode", Value: record.StatusCode},
+		{Path: "contentType", Value: record.ContentType},
+		{Path: "body", Value: record.Body},
+		{Path: "expiresAt", Value: record.ExpiresAt.UTC()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return repository.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("firestore idempotency: complete %s: %w", record.Key, err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteIdempotencyRecord(ctx context.Context, key string) error {
+	if _, err := r.base.doc(idempotencyKeysCollection, key).Delete(ctx); err != nil {
+		return fmt.Errorf("firestore idempotency: delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	docs, err := r.base.collection(idempotencyKeysCollection).Where("expiresAt", "<=", now.UTC()).Documents(ctx).GetAll()
+	if err != nil {
+		return 0, fmt.Errorf("firestore idempotency: list expired: %w", err)
+	}
+
+	var removed int64
+	for _, doc := range docs {
+		if _, err := doc.Ref.Delete(ctx); err != nil {
+			return removed, fmt.Errorf("firestore idempotency: delete %s: %w", doc.Ref.ID, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+var _ repository.IdempotencyRepository = (*idempotencyRepository)(nil)
@@ -0,0 +1,185 @@
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type scheduleBlackoutRepository struct {
+	base baseRepository
+}
+
+const blackoutsCollection = "schedule_blackouts"
+
+type blackoutDocument struct {
+	ID         int64     `firestore:"id"`
+	StartTime  time.Time `firestore:"startTime"`
+	EndTime    time.Time `firestore:"endTime"`
+	Reason     string    `firestore:"reason"`
+	Recurrence string    `firestore:"recurrence"`
+	Recurring  bool      `firestore:"recurring"`
+	Timezone   string    `firestore:"timezone"`
+	CreatedAt  time.Time `firestore:"createdAt"`
+	UpdatedAt  time.Time `firestore:"updatedAt"`
+}
+
+func NewScheduleBlackoutRepository(client *firestore.Client, prefix string) repository.ScheduleBlackoutRepository {
+	return &scheduleBlackoutRepository{base: newBaseRepository(client, prefix)}
+}
+
+func (r *scheduleBlackoutRepository) ListBlackouts(ctx context.Context) ([]model.ScheduleBlackout, error) {
+	docs, err := r.base.collection(blackoutsCollection).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("firestore blackouts: list: %w", err)
+	}
+
+	result, err := decodeBlackouts(docs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].StartTime.Equal(result[j].StartTime) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+	return result, nil
+}
+
+func (r *scheduleBlackoutRepository) GetBlackout(ctx context.Context, id uint64) (*model.ScheduleBlackout, error) {
+	snapshot, err := r.base.doc(blackoutsCollection, strconv.FormatUint(id, 10)).Get(ctx)
+	if err != nil {
+		if notFound(err) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("firestore blackouts: get %d: %w", id, err)
+	}
+	blackout, err := decodeBlackout(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &blackout, nil
+}
+
+func (r *scheduleBlackoutRepository) CreateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error) {
+	if blackout == nil || !blackout.EndTime.After(blackout.StartTime) {
+		return nil, repository.ErrInvalidInput
+	}
+
+	id, err := nextID(ctx, r.base.client, r.base.prefix, blackoutsCollection)
+	if err != nil {
+		return nil, fmt.Errorf("firestore blackouts: next id: %w", err)
+	}
+
+	now := time.Now().UTC()
+	payload := toBlackoutDocument(blackout)
+	payload.ID = id
+	payload.CreatedAt = now
+	payload.UpdatedAt = now
+
+	if _, err := r.base.doc(blackoutsCollection, strconv.FormatInt(id, 10)).Create(ctx, payload); err != nil {
+		return nil, fmt.Errorf("firestore blackouts: create %d: %w", id, err)
+	}
+	return r.GetBlackout(ctx, uint64(id))
+}
+
+func (r *scheduleBlackoutRepository) UpdateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error) {
+	if blackout == nil || blackout.ID == 0 || !blackout.EndTime.After(blackout.StartTime) {
+		return nil, repository.ErrInvalidInput
+	}
+
+	payload := toBlackoutDocument(blackout)
+	docRef := r.base.doc(blackoutsCollection, strconv.FormatUint(blackout.ID, 10))
+	if _, err := docRef.Update(ctx, []firestore.Update{
+		{Path: "startTime", Value: payload.StartTime},
+		{Path: "endTime", Value: payload.EndTime},
+		{Path: "reason", Value: payload.Reason},
+		{Path: "recurrence", Value: payload.Recurrence},
+		{Path: "recurring", Value: payload.Recurring},
+		{Path: "timezone", Value: payload.Timezone},
+		{Path: "updatedAt", Value: time.Now().UTC()},
+	}); err != nil {
+		if notFound(err) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("firestore blackouts: update %d: %w", blackout.ID, err)
+	}
+	return r.GetBlackout(ctx, blackout.ID)
+}
+
+func (r *scheduleBlackoutRepository) DeleteBlackout(ctx context.Context, id uint64) error {
+	docRef := r.base.doc(blackoutsCollection, strconv.FormatUint(id, 10))
+	if _, err := docRef.Get(ctx); err != nil {
+		if notFound(err) {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("firestore blackouts: fetch %d: %w", id, err)
+	}
+	if _, err := docRef.Delete(ctx); err != nil {
+		return fmt.Errorf("firestore blackouts: delete %d: %w", id, err)
+	}
+	return nil
+}
+
+func toBlackoutDocument(blackout *model.ScheduleBlackout) blackoutDocument {
+	recurrence := strings.TrimSpace(blackout.Recurrence)
+	return blackoutDocument{
+		StartTime:  blackout.StartTime.UTC(),
+		EndTime:    blackout.EndTime.UTC(),
+		Reason:     strings.TrimSpace(blackout.Reason),
+		Recurrence: recurrence,
+		Recurring:  recurrence != "",
+		Timezone:   strings.TrimSpace(blackout.Timezone),
+	}
+}
+
+func decodeBlackouts(docs []*firestore.DocumentSnapshot) ([]model.ScheduleBlackout, error) {
+	result := make([]model.ScheduleBlackout, 0, len(docs))
+	for _, doc := range docs {
+		blackout, err := decodeBlackout(doc)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, blackout)
+	}
+	return result, nil
+}
+
+func decodeBlackout(doc *firestore.DocumentSnapshot) (model.ScheduleBlackout, error) {
+	var payload blackoutDocument
+	if err := doc.DataTo(&payload); err != nil {
+		return model.ScheduleBlackout{}, fmt.Errorf("firestore blackouts: decode %s: %w", doc.Ref.ID, err)
+	}
+	id := uint64(payload.ID)
+	if id == 0 {
+		// Older documents used auto IDs and carried no numeric id field.
+		if parsed, err := strconv.ParseUint(doc.Ref.ID, 10, 64); err == nil {
+			id = parsed
+		}
+	}
+	updatedAt := payload.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = payload.CreatedAt
+	}
+	return model.ScheduleBlackout{
+		ID:         id,
+		StartTime:  payload.StartTime.UTC(),
+		EndTime:    payload.EndTime.UTC(),
+		Reason:     payload.Reason,
+		Recurrence: payload.Recurrence,
+		Timezone:   payload.Timezone,
+		CreatedAt:  payload.CreatedAt.UTC(),
+		UpdatedAt:  updatedAt.UTC(),
+	}, nil
+}
+
+var _ repository.ScheduleBlackoutRepository = (*scheduleBlackoutRepository)(nil)
@@ -0,0 +1,260 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type waitlistRepository struct {
+	base baseRepository
+}
+
+const waitlistCollection = "waitlist_entries"
+
+type waitlistDocument struct {
+	ID                 int64      `firestore:"id"`
+	Name               string     `firestore:"name"`
+	Email              string     `firestore:"email"`
+	Topic              string     `firestore:"topic"`
+	Locale             string     `firestore:"locale"`
+	PreferredDates     []string   `firestore:"preferredDates"`
+	Status             string     `firestore:"status"`
+	OfferReservationID int64      `firestore:"offerReservationId"`
+	OfferStartAt       *time.Time `firestore:"offerStartAt"`
+	OfferEndAt         *time.Time `firestore:"offerEndAt"`
+	OfferExpiresAt     *time.Time `firestore:"offerExpiresAt"`
+	OfferTokenHash     string     `firestore:"offerTokenHash"`
+	CreatedAt          time.Time  `firestore:"createdAt"`
+	UpdatedAt          time.Time  `firestore:"updatedAt"`
+}
+
+// NewWaitlistRepository returns a Firestore-backed waitlist repository.
+func NewWaitlistRepository(client *firestore.Client, prefix string) repository.WaitlistRepository {
+	return &waitlistRepository{base: newBaseRepository(client, prefix)}
+}
+
+func (r *waitlistRepository) CreateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error) {
+	if entry == nil || strings.TrimSpace(entry.Email) == "" || len(entry.PreferredDates) == 0 {
+		return nil, repository.ErrInvalidInput
+	}
+
+	id, err := nextID(ctx, r.base.client, r.base.prefix, waitlistCollection)
+	if err != nil {
+		return nil, fmt.Errorf("firestore waitlist: next id: %w", err)
+	}
+
+	now := time.Now().UTC()
+	status := entry.Status
+	if status == "" {
+		status = model.WaitlistStatusWaiting
+	}
+	payload := waitlistDocument{
+		ID:             id,
+		Name:           entry.Name,
+		Email:          entry.Email,
+		Topic:          strings.TrimSpace(entry.Topic),
+		Locale:         strings.TrimSpace(entry.Locale),
+		PreferredDates: copyStringSlice(entry.PreferredDates),
+		Status:         string(status),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	docRef := r.base.doc(waitlistCollection, strconv.FormatInt(id, 10))
+	if _, err := docRef.Create(ctx, payload); err != nil {
+		return nil, fmt.Errorf("firestore waitlist: create %d: %w", id, err)
+	}
+	return r.getEntry(ctx, uint64(id))
+}
+
+func (r *waitlistRepository) ListEntries(ctx context.Context, statuses []model.WaitlistStatus) ([]model.WaitlistEntry, error) {
+	query := r.base.collection(waitlistCollection).Query
+	if len(statuses) > 0 {
+		values := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, string(status))
+		}
+		query = query.Where("status", "in", values)
+	}
+
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("firestore waitlist: list: %w", err)
+	}
+
+	entries := make([]model.WaitlistEntry, 0, len(docs))
+	for _, doc := range docs {
+		entry, err := decodeWaitlistEntry(doc)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	// Sorted here rather than in the query so no composite index is needed.
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].ID < entries[j].ID
+		}
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+func (r *waitlistRepository) FindOfferByReservation(ctx context.Context, reservationID uint64) (*model.WaitlistEntry, error) {
+	docs, err := r.base.collection(waitlistCollection).
+		Where("offerReservationId", "==", int64(reservationID)).
+		Where("status", "==", string(model.WaitlistStatusOffered)).
+		Limit(1).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("firestore waitlist: query offer for reservation %d: %w", reservationID, err)
+	}
+	if len(docs) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	entry, err := decodeWaitlistEntry(docs[0])
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepository) FindEntryByOfferToken(ctx context.Context, tokenHash string) (*model.WaitlistEntry, error) {
+	if strings.TrimSpace(tokenHash) == "" {
+		return nil, repository.ErrNotFound
+	}
+
+	docs, err := r.base.collection(waitlistCollection).
+		Where("offerTokenHash", "==", tokenHash).
+		Limit(1).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("firestore waitlist: query offer token: %w", err)
+	}
+	if len(docs) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	entry, err := decodeWaitlistEntry(docs[0])
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepository) UpdateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error) {
+	if entry == nil || entry.ID == 0 || entry.Status == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	docRef := r.base.doc(waitlistCollection, strconv.FormatUint(entry.ID, 10))
+	if _, err := docRef.Update(ctx, waitlistEntryUpdates(entry)); err != nil {
+		if notFound(err) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("firestore waitlist: update %d: %w", entry.ID, err)
+	}
+	return r.getEntry(ctx, entry.ID)
+}
+
+func (r *waitlistRepository) TransitionEntry(ctx context.Context, entry *model.WaitlistEntry, from model.WaitlistStatus, tokenHash string) (*model.WaitlistEntry, error) {
+	if entry == nil || entry.ID == 0 || entry.Status == "" || from == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	docRef := r.base.doc(waitlistCollection, strconv.FormatUint(entry.ID, 10))
+	err := r.base.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(docRef)
+		if err != nil {
+			if notFound(err) {
+				return repository.ErrNotFound
+			}
+			return fmt.Errorf("firestore waitlist: get %d: %w", entry.ID, err)
+		}
+		stored, err := decodeWaitlistEntry(snapshot)
+		if err != nil {
+			return err
+		}
+		if stored.Status != from || stored.OfferTokenHash != tokenHash {
+			return repository.ErrConflict
+		}
+		return tx.Update(docRef, waitlistEntryUpdates(entry))
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("firestore waitlist: transition %d: %w", entry.ID, err)
+	}
+	return r.getEntry(ctx, entry.ID)
+}
+
+// waitlistEntryUpdates lists the fields that change after an entry is created.
+func waitlistEntryUpdates(entry *model.WaitlistEntry) []firestore.Update {
+	return []firestore.Update{
+		{Path: "status", Value: string(entry.Status)},
+		{Path: "offerReservationId", Value: int64(entry.OfferReservationID)},
+		{Path: "offerStartAt", Value: utcTimePtr(entry.OfferStartAt)},
+		{Path: "offerEndAt", Value: utcTimePtr(entry.OfferEndAt)},
+		{Path: "offerExpiresAt", Value: utcTimePtr(entry.OfferExpiresAt)},
+		{Path: "offerTokenHash", Value: entry.OfferTokenHash},
+		{Path: "updatedAt", Value: time.Now().UTC()},
+	}
+}
+
+func (r *waitlistRepository) getEntry(ctx context.Context, id uint64) (*model.WaitlistEntry, error) {
+	snapshot, err := r.base.doc(waitlistCollection, strconv.FormatUint(id, 10)).Get(ctx)
+	if err != nil {
+		if notFound(err) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("firestore waitlist: get %d: %w", id, err)
+	}
+	entry, err := decodeWaitlistEntry(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func decodeWaitlistEntry(doc *firestore.DocumentSnapshot) (model.WaitlistEntry, error) {
+	var payload waitlistDocument
+	if err := doc.DataTo(&payload); err != nil {
+		return model.WaitlistEntry{}, fmt.Errorf("firestore waitlist: decode %s: %w", doc.Ref.ID, err)
+	}
+	return model.WaitlistEntry{
+		ID:                 uint64(payload.ID),
+		Name:               payload.Name,
+		Email:              payload.Email,
+		Topic:              payload.Topic,
+		Locale:             payload.Locale,
+		PreferredDates:     copyStringSlice(payload.PreferredDates),
+		Status:             model.WaitlistStatus(payload.Status),
+		OfferReservationID: uint64(payload.OfferReservationID),
+		OfferStartAt:       utcTimePtr(payload.OfferStartAt),
+		OfferEndAt:         utcTimePtr(payload.OfferEndAt),
+		OfferExpiresAt:     utcTimePtr(payload.OfferExpiresAt),
+		OfferTokenHash:     payload.OfferTokenHash,
+		CreatedAt:          payload.CreatedAt.UTC(),
+		UpdatedAt:          payload.UpdatedAt.UTC(),
+	}, nil
+}
+
+func utcTimePtr(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t := value.UTC()
+	return &t
+}
+
+var _ repository.WaitlistRepository = (*waitlistRepository)(nil)
@@ -6,10 +6,12 @@ import (
 
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/schedule"
 )
 
 type availabilityRepository struct {
-	busy []model.TimeWindow
+	busy      []model.TimeWindow
+	blackouts repository.ScheduleBlackoutRepository
 }
 
 // NewAvailabilityRepository constructs an in-memory availability repository with no busy windows.
@@ -26,6 +28,14 @@ func NewAvailabilityRepositoryWithWindows(windows []model.TimeWindow) repository
 	}
 }
 
+// NewAvailabilityRepositoryWithBlackouts reports blackouts managed by the given repository as busy windows.
+func NewAvailabilityRepositoryWithBlackouts(blackouts repository.ScheduleBlackoutRepository) repository.AvailabilityRepository {
+	return &availabilityRepository{
+		busy:      []model.TimeWindow{},
+		blackouts: blackouts,
+	}
+}
+
 func (r *availabilityRepository) ListBusyWindows(ctx context.Context, from, to time.Time) ([]model.TimeWindow, error) {
 	result := make([]model.TimeWindow, 0, len(r.busy))
 	for _, window := range r.busy {
@@ -34,5 +44,13 @@ func (r *availabilityRepository) ListBusyWindows(ctx context.Context, from, to t
 		}
 		result = append(result, window)
 	}
+
+	if r.blackouts != nil {
+		blackouts, err := r.blackouts.ListBlackouts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, schedule.ExpandBlackouts(blackouts, from, to)...)
+	}
 	return result, nil
 }
@@ -0,0 +1,214 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type bookingOutboxRepository struct {
+	mu           sync.Mutex
+	seq          uint64
+	jobs         []model.OutboxJob
+	reservations *meetingReservationRepository
+}
+
+// NewBookingOutboxRepository constructs an in-memory outbox that writes reservations into the
+// given in-memory reservation repository. Any other implementation gets a private store.
+func NewBookingOutboxRepository(reservations repository.MeetingReservationRepository) repository.BookingOutboxRepository {
+	store, ok := reservations.(*meetingReservationRepository)
+	if !ok {
+		store = &meetingReservationRepository{}
+	}
+	return &bookingOutboxRepository{reservations: store}
+}
+
+func (r *bookingOutboxRepository) CreateReservationWithJobs(ctx context.Context, reservation *model.MeetingReservation, jobs []model.OutboxJob) (*model.MeetingReservation, error) {
+	if reservation == nil {
+		return nil, repository.ErrInvalidInput
+	}
+	for _, job := range jobs {
+		if strings.TrimSpace(string(job.Kind)) == "" {
+			return nil, repository.ErrInvalidInput
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created, err := r.reservations.CreateReservation(ctx, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	r.appendJobs(created.ID, jobs)
+	return created, nil
+}
+
+func (r *bookingOutboxRepository) EnqueueJobs(ctx context.Context, reservationID uint64, jobs []model.OutboxJob) error {
+	if reservationID == 0 {
+		return repository.ErrInvalidInput
+	}
+	for _, job := range jobs {
+		if strings.TrimSpace(string(job.Kind)) == "" {
+			return repository.ErrInvalidInput
+		}
+	}
+	if _, err := r.reservations.FindReservationByID(ctx, reservationID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.appendJobs(reservationID, jobs)
+	return nil
+}
+
+// appendJobs stores jobs as pending for the reservation; callers hold r.mu.
+func (r *bookingOutboxRepository) appendJobs(reservationID uint64, jobs []model.OutboxJob) {
+	now := time.Now().UTC()
+	for _, job := range jobs {
+		r.seq++
+		entry := job
+		entry.ID = r.seq
+		entry.ReservationID = reservationID
+		entry.Status = model.OutboxJobStatusPending
+		entry.Attempts = 0
+		if entry.NextAttemptAt.IsZero() {
+			entry.NextAttemptAt = now
+		}
+		entry.CreatedAt = now
+		entry.UpdatedAt = now
+		r.jobs = append(r.jobs, entry)
+	}
+}
+
+func (r *bookingOutboxRepository) ClaimDueJobs(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.OutboxJob, error) {
+	if limit <= 0 {
+		return []model.OutboxJob{}, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	claimed := make([]model.OutboxJob, 0, limit)
+	for index, job := range r.jobs {
+		if len(claimed) == limit {
+			break
+		}
+		if job.Status != model.OutboxJobStatusPending && job.Status != model.OutboxJobStatusProcessing {
+			continue
+		}
+		if job.NextAttemptAt.After(now) {
+			continue
+		}
+		job.Status = model.OutboxJobStatusProcessing
+		job.NextAttemptAt = now.Add(lease).UTC()
+		job.UpdatedAt = now.UTC()
+		r.jobs[index] = job
+		claimed = append(claimed, copyOutboxJob(job))
+	}
+	return claimed, nil
+}
+
+func (r *bookingOutboxRepository) ListJobs(ctx context.Context, reservationID uint64) ([]model.OutboxJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]model.OutboxJob, 0)
+	for _, job := range r.jobs {
+		if job.ReservationID == reservationID {
+			result = append(result, copyOutboxJob(job))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *bookingOutboxRepository) CompleteJob(ctx context.Context, id uint64, completedAt time.Time) error {
+	return r.settle(id, func(job *model.OutboxJob) {
+		completed := completedAt.UTC()
+		job.Status = model.OutboxJobStatusDone
+		job.Attempts++
+		job.LastError = ""
+		job.CompletedAt = &completed
+	})
+}
+
+func (r *bookingOutboxRepository) RetryJob(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error {
+	return r.settle(id, func(job *model.OutboxJob) {
+		job.Status = model.OutboxJobStatusPending
+		job.Attempts++
+		job.NextAttemptAt = nextAttemptAt.UTC()
+		job.LastError = strings.TrimSpace(lastError)
+	})
+}
+
+func (r *bookingOutboxRepository) DeferJob(ctx context.Context, id uint64, nextAttemptAt time.Time) error {
+	return r.settle(id, func(job *model.OutboxJob) {
+		job.Status = model.OutboxJobStatusPending
+		job.NextAttemptAt = nextAttemptAt.UTC()
+	})
+}
+
+func (r *bookingOutboxRepository) DeadLetterJob(ctx context.Context, id uint64, lastError string) error {
+	return r.settle(id, func(job *model.OutboxJob) {
+		job.Status = model.OutboxJobStatusDead
+		job.Attempts++
+		job.LastError = strings.TrimSpace(lastError)
+	})
+}
+
+func (r *bookingOutboxRepository) AttachCalendarEvent(ctx context.Context, reservationID uint64, eventID string) error {
+	store := r.reservations
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for index, entry := range store.reservations {
+		if entry.ID != reservationID {
+			continue
+		}
+		if entry.GoogleEventID != "" {
+			return repository.ErrConflict
+		}
+		entry.GoogleEventID = strings.TrimSpace(eventID)
+		if !entry.Status.IsCancelled() {
+			entry.GoogleCalendarStatus = "confirmed"
+		}
+		entry.UpdatedAt = time.Now().UTC()
+		store.reservations[index] = entry
+		return nil
+	}
+	return repository.ErrNotFound
+}
+
+func (r *bookingOutboxRepository) settle(id uint64, apply func(job *model.OutboxJob)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index := range r.jobs {
+		if r.jobs[index].ID != id {
+			continue
+		}
+		apply(&r.jobs[index])
+		r.jobs[index].UpdatedAt = time.Now().UTC()
+		return nil
+	}
+	return repository.ErrNotFound
+}
+
+func copyOutboxJob(job model.OutboxJob) model.OutboxJob {
+	result := job
+	if job.CompletedAt != nil {
+		completed := job.CompletedAt.UTC()
+		result.CompletedAt = &completed
+	}
+	return result
+}
+
+var _ repository.BookingOutboxRepository = (*bookingOutboxRepository)(nil)
@@ -0,0 +1,119 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type calendarFeedTokenRepository struct {
+	mu     sync.RWMutex
+	seq    uint64
+	tokens []model.CalendarFeedToken
+}
+
+// NewCalendarFeedTokenRepository constructs an in-memory calendar feed token repository.
+func NewCalendarFeedTokenRepository() repository.CalendarFeedTokenRepository {
+	return &calendarFeedTokenRepository{}
+}
+
+func (r *calendarFeedTokenRepository) ListFeedTokens(ctx context.Context) ([]model.CalendarFeedToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.CalendarFeedToken, 0, len(r.tokens))
+	for _, token := range r.tokens {
+		result = append(result, copyFeedToken(token))
+	}
+	return result, nil
+}
+
+func (r *calendarFeedTokenRepository) CreateFeedToken(ctx context.Context, token *model.CalendarFeedToken) (*model.CalendarFeedToken, error) {
+	if token == nil || strings.TrimSpace(token.TokenHash) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.tokens {
+		if existing.TokenHash == token.TokenHash {
+			return nil, repository.ErrDuplicate
+		}
+	}
+
+	r.seq++
+	entry := model.CalendarFeedToken{
+		ID:        r.seq,
+		Label:     strings.TrimSpace(token.Label),
+		TokenHash: token.TokenHash,
+		CreatedAt: time.Now().UTC(),
+	}
+	r.tokens = append(r.tokens, entry)
+	created := copyFeedToken(entry)
+	return &created, nil
+}
+
+func (r *calendarFeedTokenRepository) FindFeedTokenByHash(ctx context.Context, tokenHash string) (*model.CalendarFeedToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			found := copyFeedToken(token)
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *calendarFeedTokenRepository) RevokeFeedToken(ctx context.Context, id uint64, revokedAt time.Time) (*model.CalendarFeedToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index := range r.tokens {
+		if r.tokens[index].ID != id {
+			continue
+		}
+		if r.tokens[index].RevokedAt == nil {
+			revoked := revokedAt.UTC()
+			r.tokens[index].RevokedAt = &revoked
+		}
+		revoked := copyFeedToken(r.tokens[index])
+		return &revoked, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *calendarFeedTokenRepository) TouchFeedToken(ctx context.Context, id uint64, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index := range r.tokens {
+		if r.tokens[index].ID == id {
+			used := usedAt.UTC()
+			r.tokens[index].LastUsedAt = &used
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func copyFeedToken(token model.CalendarFeedToken) model.CalendarFeedToken {
+	result := token
+	if token.LastUsedAt != nil {
+		used := *token.LastUsedAt
+		result.LastUsedAt = &used
+	}
+	if token.RevokedAt != nil {
+		revoked := *token.RevokedAt
+		result.RevokedAt = &revoked
+	}
+	return result
+}
+
+var _ repository.CalendarFeedTokenRepository = (*calendarFeedTokenRepository)(nil)
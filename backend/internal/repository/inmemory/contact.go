@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/takumi/personal-website/internal/model"
@@ -12,6 +13,7 @@ import (
 )
 
 type contactRepository struct {
+	mu       sync.RWMutex
 	messages map[string]*model.ContactMessage
 }
 
@@ -29,40 +31,91 @@ func NewContactRepository() repository.ContactRepository {
 func (r *contactRepository) CreateSubmission(ctx context.Context, payload *model.ContactRequest) (*model.ContactSubmission, error) {
 	id := fmt.Sprintf("contact-%d", time.Now().UnixNano())
 	now := time.Now().UTC()
+	status := payload.Status
+	if status == "" {
+		status = model.ContactStatusPending
+	}
 	message := &model.ContactMessage{
-		ID:        id,
-		Name:      strings.TrimSpace(payload.Name),
-		Email:     strings.TrimSpace(payload.Email),
-		Topic:     strings.TrimSpace(payload.Topic),
-		Message:   strings.TrimSpace(payload.Message),
-		Status:    model.ContactStatusPending,
-		AdminNote: "",
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:            id,
+		Name:          strings.TrimSpace(payload.Name),
+		Email:         strings.TrimSpace(payload.Email),
+		Topic:         strings.TrimSpace(payload.Topic),
+		Message:       strings.TrimSpace(payload.Message),
+		Locale:        strings.TrimSpace(payload.Locale),
+		IntakeAnswers: cloneIntakeAnswers(payload.IntakeAnswers),
+		Status:        status,
+		AdminNote:     strings.TrimSpace(payload.AdminNote),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if payload.Spam != nil {
+		message.SpamScore = payload.Spam.Score
+		message.SpamSignals = append([]model.SpamSignal(nil), payload.Spam.Signals...)
 	}
+	r.mu.Lock()
 	r.messages[id] = message
+	r.mu.Unlock()
 	return &model.ContactSubmission{
 		ID:      id,
-		Status:  string(model.ContactStatusPending),
+		Status:  string(status),
 		Comment: fmt.Sprintf("queued at %s", now.Format(time.RFC3339)),
 	}, nil
 }
 
-func (r *contactRepository) ListContactMessages(ctx context.Context) ([]model.ContactMessage, error) {
+func (r *contactRepository) ListContactMessages(ctx context.Context, filter repository.ContactMessageListFilter) ([]model.ContactMessage, error) {
+	r.mu.RLock()
 	messages := make([]model.ContactMessage, 0, len(r.messages))
 	for _, msg := range r.messages {
+		if !matchesContactFilter(msg, filter) {
+			continue
+		}
 		messages = append(messages, *cloneContactMessage(msg))
 	}
+	r.mu.RUnlock()
 	sort.Slice(messages, func(i, j int) bool {
 		if messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
 			return messages[i].ID > messages[j].ID
 		}
 		return messages[i].CreatedAt.After(messages[j].CreatedAt)
 	})
+	if filter.Limit > 0 && len(messages) > filter.Limit {
+		messages = messages[:filter.Limit]
+	}
 	return messages, nil
 }
 
+func matchesContactFilter(msg *model.ContactMessage, filter repository.ContactMessageListFilter) bool {
+	if len(filter.Status) > 0 {
+		found := false
+		for _, status := range filter.Status {
+			if msg.Status == status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if email := strings.TrimSpace(filter.Email); email != "" && !strings.EqualFold(strings.TrimSpace(msg.Email), email) {
+		return false
+	}
+	if filter.CreatedFrom != nil && msg.CreatedAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !msg.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	if after := filter.After; after != nil {
+		return msg.CreatedAt.Before(after.CreatedAt) || (msg.CreatedAt.Equal(after.CreatedAt) && msg.ID < after.ID)
+	}
+	return true
+}
+
 func (r *contactRepository) GetContactMessage(ctx context.Context, id string) (*model.ContactMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	msg, ok := r.messages[strings.TrimSpace(id)]
 	if !ok {
 		return nil, repository.ErrNotFound
@@ -74,6 +127,9 @@ func (r *contactRepository) UpdateContactMessage(ctx context.Context, message *m
 	if message == nil {
 		return nil, repository.ErrInvalidInput
 	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	id := strings.TrimSpace(message.ID)
 	msg, ok := r.messages[id]
 	if !ok {
@@ -90,6 +146,9 @@ func (r *contactRepository) UpdateContactMessage(ctx context.Context, message *m
 }
 
 func (r *contactRepository) DeleteContactMessage(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	id = strings.TrimSpace(id)
 	if _, ok := r.messages[id]; !ok {
 		return repository.ErrNotFound
@@ -103,15 +162,32 @@ func cloneContactMessage(msg *model.ContactMessage) *model.ContactMessage {
 		return nil
 	}
 	clone := *msg
+	clone.IntakeAnswers = cloneIntakeAnswers(msg.IntakeAnswers)
+	if msg.SpamSignals != nil {
+		clone.SpamSignals = append([]model.SpamSignal(nil), msg.SpamSignals...)
+	}
 	return &clone
 }
 
+func cloneIntakeAnswers(answers []model.IntakeAnswer) []model.IntakeAnswer {
+	if answers == nil {
+		return nil
+	}
+	cloned := make([]model.IntakeAnswer, len(answers))
+	for i, answer := range answers {
+		cloned[i] = answer
+		cloned[i].Values = append([]string(nil), answer.Values...)
+	}
+	return cloned
+}
+
 func normalizeContactStatus(status model.ContactStatus) model.ContactStatus {
 	switch status {
 	case model.ContactStatusPending,
 		model.ContactStatusInReview,
 		model.ContactStatusResolved,
-		model.ContactStatusArchived:
+		model.ContactStatusArchived,
+		model.ContactStatusSpam:
 		return status
 	default:
 		return model.ContactStatusPending
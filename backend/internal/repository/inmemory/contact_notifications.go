@@ -0,0 +1,134 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type contactNotificationRepository struct {
+	mu            sync.RWMutex
+	seq           uint64
+	notifications []model.ContactNotification
+}
+
+// NewContactNotificationRepository constructs an in-memory contact notification repository.
+func NewContactNotificationRepository() repository.ContactNotificationRepository {
+	return &contactNotificationRepository{}
+}
+
+func (r *contactNotificationRepository) RecordContactNotification(ctx context.Context, notification *model.ContactNotification) (*model.ContactNotification, error) {
+	if notification == nil || strings.TrimSpace(notification.ContactID) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	entry := copyContactNotification(*notification)
+	entry.ID = r.seq
+	entry.ContactID = strings.TrimSpace(entry.ContactID)
+	entry.CreatedAt = time.Now().UTC()
+	r.notifications = append(r.notifications, entry)
+	copied := copyContactNotification(entry)
+	return &copied, nil
+}
+
+func (r *contactNotificationRepository) UpdateContactNotificationStatus(ctx context.Context, id uint64, status, errorMessage string) (*model.ContactNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.notifications {
+		if r.notifications[i].ID != id {
+			continue
+		}
+		r.notifications[i].Status = strings.TrimSpace(status)
+		r.notifications[i].ErrorMessage = strings.TrimSpace(errorMessage)
+		entry := copyContactNotification(r.notifications[i])
+		return &entry, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *contactNotificationRepository) ClaimRetryableContactNotifications(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.ContactNotification, error) {
+	if limit <= 0 {
+		return []model.ContactNotification{}, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	leaseUntil := now.Add(lease).UTC()
+	claimed := make([]model.ContactNotification, 0)
+	// Entries are appended in ID order, so the oldest due notifications are claimed first.
+	for i := range r.notifications {
+		if len(claimed) == limit {
+			break
+		}
+		entry := &r.notifications[i]
+		if entry.Status != "pending" && entry.Status != "failed" {
+			continue
+		}
+		if entry.NextAttemptAt != nil && entry.NextAttemptAt.After(now) {
+			continue
+		}
+		next := leaseUntil
+		entry.NextAttemptAt = &next
+		claimed = append(claimed, copyContactNotification(*entry))
+	}
+	return claimed, nil
+}
+
+func (r *contactNotificationRepository) RecordContactNotificationAttempt(ctx context.Context, id uint64, attempt int, status, errorMessage string, nextAttemptAt *time.Time) (*model.ContactNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.notifications {
+		entry := &r.notifications[i]
+		if entry.ID != id {
+			continue
+		}
+		entry.Status = strings.TrimSpace(status)
+		entry.ErrorMessage = strings.TrimSpace(errorMessage)
+		if attempt > entry.Attempts {
+			entry.Attempts = attempt
+		}
+		entry.NextAttemptAt = nil
+		if nextAttemptAt != nil {
+			next := nextAttemptAt.UTC()
+			entry.NextAttemptAt = &next
+		}
+		copied := copyContactNotification(*entry)
+		return &copied, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *contactNotificationRepository) ListContactNotifications(ctx context.Context, contactID string) ([]model.ContactNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contactID = strings.TrimSpace(contactID)
+	result := make([]model.ContactNotification, 0)
+	for _, entry := range r.notifications {
+		if entry.ContactID == contactID {
+			result = append(result, copyContactNotification(entry))
+		}
+	}
+	return result, nil
+}
+
+func copyContactNotification(notification model.ContactNotification) model.ContactNotification {
+	if notification.NextAttemptAt != nil {
+		next := *notification.NextAttemptAt
+		notification.NextAttemptAt = &next
+	}
+	return notification
+}
+
+var _ repository.ContactNotificationRepository = (*contactNotificationRepository)(nil)
@@ -53,15 +53,16 @@ func NewContactFormSettingsRepository() repository.ContactFormSettingsRepository
 				"送信によりプライバシーポリシーに同意したものとします。",
 				"By submitting you agree to the privacy policy.",
 			),
-			MinimumLeadHours:   24,
-			RecaptchaSiteKey:   "recaptcha-public-key",
-			SupportEmail:       "support@example.dev",
-			CalendarTimezone:   "Asia/Tokyo",
-			GoogleCalendarID:   "primary",
-			BookingWindowDays:  30,
-			MeetingURLTemplate: "こんにちは {{guest_name}} さん。\n以下のリンクからミーティングにご参加ください: {{meeting_url}}\nよろしくお願いいたします。",
-			CreatedAt:          now.AddDate(-1, 0, 0),
-			UpdatedAt:          now.Add(-6 * time.Hour),
+			MinimumLeadHours:    24,
+			RecaptchaSiteKey:    "recaptcha-public-key",
+			SupportEmail:        "support@example.dev",
+			CalendarTimezone:    "Asia/Tokyo",
+			GoogleCalendarID:    "primary",
+			ConflictCalendarIDs: []string{},
+			BookingWindowDays:   30,
+			MeetingURLTemplate:  "こんにちは {{guest_name}} さん。\n以下のリンクからミーティングにご参加ください: {{meeting_url}}\nよろしくお願いいたします。",
+			CreatedAt:           now.AddDate(-1, 0, 0),
+			UpdatedAt:           now.Add(-6 * time.Hour),
 		},
 	}
 }
@@ -122,23 +123,63 @@ func cloneContactSettings(settings *model.ContactFormSettingsV2) *model.ContactF
 				Ja: topic.Description.Ja,
 				En: topic.Description.En,
 			},
+			RequiresApproval: topic.RequiresApproval,
+			Questions:        cloneIntakeQuestions(topic.Questions),
 		}
 	}
 
 	return &model.ContactFormSettingsV2{
-		ID:                 settings.ID,
-		HeroTitle:          model.LocalizedText{Ja: settings.HeroTitle.Ja, En: settings.HeroTitle.En},
-		HeroDescription:    model.LocalizedText{Ja: settings.HeroDescription.Ja, En: settings.HeroDescription.En},
-		Topics:             copyTopics,
-		ConsentText:        model.LocalizedText{Ja: settings.ConsentText.Ja, En: settings.ConsentText.En},
-		MinimumLeadHours:   settings.MinimumLeadHours,
-		RecaptchaSiteKey:   settings.RecaptchaSiteKey,
-		SupportEmail:       settings.SupportEmail,
-		CalendarTimezone:   settings.CalendarTimezone,
-		GoogleCalendarID:   settings.GoogleCalendarID,
-		BookingWindowDays:  settings.BookingWindowDays,
-		MeetingURLTemplate: settings.MeetingURLTemplate,
-		CreatedAt:          settings.CreatedAt,
-		UpdatedAt:          settings.UpdatedAt,
+		ID:                  settings.ID,
+		HeroTitle:           model.LocalizedText{Ja: settings.HeroTitle.Ja, En: settings.HeroTitle.En},
+		HeroDescription:     model.LocalizedText{Ja: settings.HeroDescription.Ja, En: settings.HeroDescription.En},
+		Topics:              copyTopics,
+		ConsentText:         model.LocalizedText{Ja: settings.ConsentText.Ja, En: settings.ConsentText.En},
+		MinimumLeadHours:    settings.MinimumLeadHours,
+		RecaptchaSiteKey:    settings.RecaptchaSiteKey,
+		SupportEmail:        settings.SupportEmail,
+		CalendarTimezone:    settings.CalendarTimezone,
+		GoogleCalendarID:    settings.GoogleCalendarID,
+		ConflictCalendarIDs: append([]string{}, settings.ConflictCalendarIDs...),
+		BookingWindowDays:   settings.BookingWindowDays,
+		MeetingURLTemplate:  settings.MeetingURLTemplate,
+		WorkingHours:        cloneWorkingHours(settings.WorkingHours),
+		CreatedAt:           settings.CreatedAt,
+		UpdatedAt:           settings.UpdatedAt,
 	}
 }
+
+func cloneWorkingHours(hours model.WorkingHours) model.WorkingHours {
+	clone := model.WorkingHours{}
+	if hours.Weekly != nil {
+		clone.Weekly = make([]model.WeekdayWorkingHours, len(hours.Weekly))
+		for i, day := range hours.Weekly {
+			clone.Weekly[i] = model.WeekdayWorkingHours{
+				Weekday:   day.Weekday,
+				Intervals: append([]model.WorkingInterval(nil), day.Intervals...),
+			}
+		}
+	}
+	if hours.Overrides != nil {
+		clone.Overrides = make([]model.WorkingHoursOverride, len(hours.Overrides))
+		for i, override := range hours.Overrides {
+			clone.Overrides[i] = model.WorkingHoursOverride{
+				Date:      override.Date,
+				Intervals: append([]model.WorkingInterval(nil), override.Intervals...),
+				Note:      override.Note,
+			}
+		}
+	}
+	return clone
+}
+
+func cloneIntakeQuestions(questions []model.IntakeQuestion) []model.IntakeQuestion {
+	if questions == nil {
+		return nil
+	}
+	cloned := make([]model.IntakeQuestion, len(questions))
+	for i, question := range questions {
+		cloned[i] = question
+		cloned[i].Options = append([]model.IntakeQuestionOption(nil), question.Options...)
+	}
+	return cloned
+}
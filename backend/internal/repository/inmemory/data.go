@@ -525,7 +525,7 @@ var (
 			EndAt:           time.Date(2024, 5, 10, 10, 30, 0, 0, time.UTC),
 			DurationMinutes: 30,
 			GoogleEventID:   "evt-akari",
-			Status:          model.MeetingReservationStatusPending,
+			Status:          model.MeetingReservationStatusRequested,
 			CreatedAt:       time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
 			UpdatedAt:       time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
 		},
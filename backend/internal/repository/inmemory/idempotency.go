@@ -0,0 +1,97 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type idempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]model.IdempotencyRecord
+}
+
+// NewIdempotencyRepository returns an in-memory Idempotency-Key store.
+func NewIdempotencyRepository() repository.IdempotencyRepository {
+	return &idempotencyRepository{records: make(map[string]model.IdempotencyRecord)}
+}
+
+func (r *idempotencyRepository) CreateIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil || strings.TrimSpace(record.Key) == "" {
+		return repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.records[record.Key]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return repository.ErrConflict
+	}
+	r.records[record.Key] = copyIdempotencyRecord(*record)
+	return nil
+}
+
+func (r *idempotencyRepository) FindIdempotencyRecord(ctx context.Context, key string, now time.Time) (*model.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[key]
+	if !ok || !record.ExpiresAt.After(now) {
+		return nil, repository.ErrNotFound
+	}
+	copied := copyIdempotencyRecord(record)
+	return &copied, nil
+}
+
+func (r *idempotencyRepository) CompleteIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil {
+		return repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.records[record.Key]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	existing.StatusCode = record.StatusCode
+	existing.ContentType = record.ContentType
+	existing.Body = append([]byte(nil), record.Body...)
+	existing.ExpiresAt = record.ExpiresAt
+	r.records[record.Key] = existing
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteIdempotencyRecord(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, key)
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed int64
+	for key, record := range r.records {
+		if !record.ExpiresAt.After(now) {
+			delete(r.records, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func copyIdempotencyRecord(record model.IdempotencyRecord) model.IdempotencyRecord {
+	record.Body = append([]byte(nil), record.Body...)
+	return record
+}
+
+var _ repository.IdempotencyRepository = (*idempotencyRepository)(nil)
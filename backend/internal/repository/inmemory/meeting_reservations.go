@@ -15,6 +15,8 @@ type meetingReservationRepository struct {
 	mu           sync.RWMutex
 	seq          uint64
 	reservations []model.MeetingReservation
+	historySeq   uint64
+	history      []model.MeetingReservationStatusChange
 }
 
 // NewMeetingReservationRepository constructs an in-memory reservation repository.
@@ -36,9 +38,25 @@ func (r *meetingReservationRepository) CreateReservation(ctx context.Context, re
 		return nil, repository.ErrInvalidInput
 	}
 
+	if !reservation.EndAt.After(reservation.StartAt) {
+		return nil, repository.ErrInvalidInput
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	// Checking and inserting under one lock makes the slot claim atomic, matching the SQL store.
+	if reservation.Status.IsActive() {
+		for _, entry := range r.reservations {
+			if !entry.Status.IsActive() {
+				continue
+			}
+			if entry.StartAt.Before(reservation.EndAt) && entry.EndAt.After(reservation.StartAt) {
+				return nil, repository.ErrConflict
+			}
+		}
+	}
+
 	r.seq++
 	reservationCopy := copyReservation(*reservation)
 	reservationCopy.ID = r.seq
@@ -50,6 +68,11 @@ func (r *meetingReservationRepository) CreateReservation(ctx context.Context, re
 		reservationCopy.UpdatedAt = now
 	}
 	r.reservations = append(r.reservations, reservationCopy)
+	r.appendHistory(model.MeetingReservationStatusChange{
+		ReservationID: reservationCopy.ID,
+		ToStatus:      reservationCopy.Status,
+		Actor:         model.ReservationActorVisitor,
+	})
 	return copyReservationPtr(reservationCopy), nil
 }
 
@@ -114,6 +137,15 @@ func (r *meetingReservationRepository) ListReservations(ctx context.Context, fil
 				continue
 			}
 		}
+		if filter.StartFrom != nil && entry.StartAt.Before(*filter.StartFrom) {
+			continue
+		}
+		if filter.StartBefore != nil && !entry.StartAt.Before(*filter.StartBefore) {
+			continue
+		}
+		if after := filter.After; after != nil && !(entry.StartAt.Before(after.StartAt) || (entry.StartAt.Equal(after.StartAt) && entry.ID < after.ID)) {
+			continue
+		}
 		results = append(results, copyReservation(entry))
 	}
 
@@ -123,6 +155,9 @@ func (r *meetingReservationRepository) ListReservations(ctx context.Context, fil
 		}
 		return results[i].StartAt.After(results[j].StartAt)
 	})
+	if filter.Limit > 0 && len(results) > filter.Limit {
+		results = results[:filter.Limit]
+	}
 
 	return results, nil
 }
@@ -133,7 +168,7 @@ func (r *meetingReservationRepository) ListConflictingReservations(ctx context.C
 
 	var conflicts []model.MeetingReservation
 	for _, entry := range r.reservations {
-		if entry.Status == model.MeetingReservationStatusCancelled {
+		if !entry.Status.IsActive() {
 			continue
 		}
 		if entry.StartAt.Before(end) && entry.EndAt.After(start) {
@@ -151,9 +186,6 @@ func (r *meetingReservationRepository) MarkConfirmationSent(ctx context.Context,
 		if entry.ID != id {
 			continue
 		}
-		if entry.Status != model.MeetingReservationStatusCancelled {
-			entry.Status = model.MeetingReservationStatusConfirmed
-		}
 		sent := sentAt.UTC()
 		entry.ConfirmationSentAt = &sent
 		entry.LastNotificationSentAt = &sent
@@ -164,7 +196,11 @@ func (r *meetingReservationRepository) MarkConfirmationSent(ctx context.Context,
 	return nil, repository.ErrNotFound
 }
 
-func (r *meetingReservationRepository) CancelReservation(ctx context.Context, id uint64, reason string) (*model.MeetingReservation, error) {
+func (r *meetingReservationRepository) RescheduleReservation(ctx context.Context, id uint64, start, end time.Time, googleEventID string) (*model.MeetingReservation, error) {
+	if !end.After(start) {
+		return nil, repository.ErrInvalidInput
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -172,9 +208,24 @@ func (r *meetingReservationRepository) CancelReservation(ctx context.Context, id
 		if entry.ID != id {
 			continue
 		}
-		entry.Status = model.MeetingReservationStatusCancelled
-		entry.GoogleCalendarStatus = "cancelled"
-		entry.CancellationReason = strings.TrimSpace(reason)
+		if !entry.Status.IsActive() {
+			return nil, repository.ErrConflict
+		}
+		// Like CreateReservation, the conflict check and the move happen under one lock.
+		for _, other := range r.reservations {
+			if other.ID == id || !other.Status.IsActive() {
+				continue
+			}
+			if other.StartAt.Before(end) && other.EndAt.After(start) {
+				return nil, repository.ErrConflict
+			}
+		}
+		entry.StartAt = start.UTC()
+		entry.EndAt = end.UTC()
+		entry.DurationMinutes = int(end.Sub(start) / time.Minute)
+		if eventID := strings.TrimSpace(googleEventID); eventID != "" {
+			entry.GoogleEventID = eventID
+		}
 		entry.UpdatedAt = time.Now().UTC()
 		r.reservations[index] = entry
 		return copyReservationPtr(entry), nil
@@ -182,38 +233,61 @@ func (r *meetingReservationRepository) CancelReservation(ctx context.Context, id
 	return nil, repository.ErrNotFound
 }
 
-func (r *meetingReservationRepository) UpdateReservationStatus(ctx context.Context, id uint64, status model.MeetingReservationStatus, reason string) (*model.MeetingReservation, error) {
+func (r *meetingReservationRepository) TransitionReservationStatus(ctx context.Context, change *model.MeetingReservationStatusChange) (*model.MeetingReservation, error) {
+	if change == nil || change.ReservationID == 0 || !change.ToStatus.Valid() {
+		return nil, repository.ErrInvalidInput
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	for index, entry := range r.reservations {
-		if entry.ID != id {
+		if entry.ID != change.ReservationID {
 			continue
 		}
-		switch status {
-		case model.MeetingReservationStatusCancelled:
-			entry.Status = model.MeetingReservationStatusCancelled
+		if entry.Status != change.FromStatus {
+			return nil, repository.ErrConflict
+		}
+		entry.Status = change.ToStatus
+		switch {
+		case change.ToStatus.IsCancelled():
 			entry.GoogleCalendarStatus = "cancelled"
-			entry.CancellationReason = strings.TrimSpace(reason)
-		case model.MeetingReservationStatusConfirmed:
-			entry.Status = model.MeetingReservationStatusConfirmed
-			entry.CancellationReason = ""
-			if strings.TrimSpace(entry.GoogleCalendarStatus) == "" {
-				entry.GoogleCalendarStatus = "confirmed"
-			}
-		case model.MeetingReservationStatusPending:
-			entry.Status = model.MeetingReservationStatusPending
-			entry.CancellationReason = ""
-		default:
-			return nil, repository.ErrInvalidInput
+			entry.CancellationReason = strings.TrimSpace(change.Reason)
+		case change.ToStatus == model.MeetingReservationStatusConfirmed && strings.TrimSpace(entry.GoogleCalendarStatus) == "":
+			entry.GoogleCalendarStatus = "confirmed"
 		}
 		entry.UpdatedAt = time.Now().UTC()
 		r.reservations[index] = entry
+		r.appendHistory(*change)
 		return copyReservationPtr(entry), nil
 	}
 	return nil, repository.ErrNotFound
 }
 
+func (r *meetingReservationRepository) ListStatusHistory(ctx context.Context, reservationID uint64) ([]model.MeetingReservationStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var history []model.MeetingReservationStatusChange
+	for _, change := range r.history {
+		if change.ReservationID == reservationID {
+			history = append(history, change)
+		}
+	}
+	return history, nil
+}
+
+// appendHistory stores a status change; callers hold r.mu.
+func (r *meetingReservationRepository) appendHistory(change model.MeetingReservationStatusChange) {
+	r.historySeq++
+	change.ID = r.historySeq
+	change.Reason = strings.TrimSpace(change.Reason)
+	if change.CreatedAt.IsZero() {
+		change.CreatedAt = time.Now().UTC()
+	}
+	r.history = append(r.history, change)
+}
+
 func copyReservation(reservation model.MeetingReservation) model.MeetingReservation {
 	result := reservation
 	if reservation.ConfirmationSentAt != nil {
@@ -224,6 +298,7 @@ func copyReservation(reservation model.MeetingReservation) model.MeetingReservat
 		timestamp := reservation.LastNotificationSentAt.UTC()
 		result.LastNotificationSentAt = &timestamp
 	}
+	result.IntakeAnswers = cloneIntakeAnswers(reservation.IntakeAnswers)
 	return result
 }
 
@@ -235,13 +310,16 @@ func copyReservationPtr(reservation model.MeetingReservation) *model.MeetingRese
 type meetingNotificationRepository struct {
 	mu            sync.RWMutex
 	seq           uint64
+	attemptSeq    uint64
 	notifications map[uint64][]model.MeetingNotification
+	attempts      map[uint64][]model.MeetingNotificationAttempt
 }
 
 // NewMeetingNotificationRepository constructs an in-memory notification repository.
 func NewMeetingNotificationRepository() repository.MeetingNotificationRepository {
 	return &meetingNotificationRepository{
 		notifications: make(map[uint64][]model.MeetingNotification),
+		attempts:      make(map[uint64][]model.MeetingNotificationAttempt),
 	}
 }
 
@@ -253,19 +331,7 @@ func (r *meetingNotificationRepository) RecordNotification(ctx context.Context,
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.seq++
-	now := time.Now().UTC()
-	entry := model.MeetingNotification{
-		ID:            r.seq,
-		ReservationID: notification.ReservationID,
-		Type:          notification.Type,
-		Status:        notification.Status,
-		ErrorMessage:  notification.ErrorMessage,
-		CreatedAt:     now,
-	}
-
-	r.notifications[notification.ReservationID] = append(r.notifications[notification.ReservationID], entry)
-	return copyNotification(entry), nil
+	return r.appendLocked(*notification), nil
 }
 
 func (r *meetingNotificationRepository) ListNotifications(ctx context.Context, reservationID uint64) ([]model.MeetingNotification, error) {
@@ -279,13 +345,171 @@ func (r *meetingNotificationRepository) ListNotifications(ctx context.Context, r
 
 	result := make([]model.MeetingNotification, len(entries))
 	for i, entry := range entries {
-		result[i] = entry
+		result[i] = *copyNotification(entry)
 	}
 	return result, nil
 }
 
+func (r *meetingNotificationRepository) ClaimNotification(ctx context.Context, notification *model.MeetingNotification) (*model.MeetingNotification, error) {
+	if notification == nil || strings.TrimSpace(notification.DedupeKey) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strings.TrimSpace(notification.DedupeKey)
+	for _, entry := range r.notifications[notification.ReservationID] {
+		if entry.DedupeKey == key {
+			return nil, repository.ErrConflict
+		}
+	}
+
+	claim := *notification
+	claim.Status = "pending"
+	return r.appendLocked(claim), nil
+}
+
+func (r *meetingNotificationRepository) UpdateNotificationStatus(ctx context.Context, id uint64, status, errorMessage string) (*model.MeetingNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entries := range r.notifications {
+		for index := range entries {
+			if entries[index].ID != id {
+				continue
+			}
+			entries[index].Status = strings.TrimSpace(status)
+			entries[index].ErrorMessage = strings.TrimSpace(errorMessage)
+			return copyNotification(entries[index]), nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *meetingNotificationRepository) ClaimRetryableNotifications(ctx context.Context, types []string, now, pendingBefore time.Time, limit int, lease time.Duration) ([]model.MeetingNotification, error) {
+	if limit <= 0 || len(types) == 0 {
+		return []model.MeetingNotification{}, nil
+	}
+	wanted := make(map[string]struct{}, len(types))
+	for _, notificationType := range types {
+		wanted[strings.TrimSpace(notificationType)] = struct{}{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*model.MeetingNotification
+	for reservationID := range r.notifications {
+		entries := r.notifications[reservationID]
+		for index := range entries {
+			entry := &entries[index]
+			if _, ok := wanted[entry.Type]; !ok {
+				continue
+			}
+			if entry.NextAttemptAt != nil && entry.NextAttemptAt.After(now) {
+				continue
+			}
+			switch entry.Status {
+			case "failed":
+			case "pending":
+				if entry.CreatedAt.After(pendingBefore) {
+					continue
+				}
+			default:
+				continue
+			}
+			due = append(due, entry)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	leaseUntil := now.Add(lease).UTC()
+	claimed := make([]model.MeetingNotification, 0, len(due))
+	for _, entry := range due {
+		next := leaseUntil
+		entry.NextAttemptAt = &next
+		claimed = append(claimed, *copyNotification(*entry))
+	}
+	return claimed, nil
+}
+
+func (r *meetingNotificationRepository) RecordNotificationAttempt(ctx context.Context, attempt *model.MeetingNotificationAttempt, status string, nextAttemptAt *time.Time) (*model.MeetingNotification, error) {
+	if attempt == nil {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for reservationID, entries := range r.notifications {
+		for index := range entries {
+			entry := &entries[index]
+			if entry.ID != attempt.NotificationID {
+				continue
+			}
+
+			r.attemptSeq++
+			stored := *attempt
+			stored.ID = r.attemptSeq
+			stored.ReservationID = reservationID
+			stored.Status = strings.TrimSpace(stored.Status)
+			stored.ErrorMessage = strings.TrimSpace(stored.ErrorMessage)
+			if stored.AttemptedAt.IsZero() {
+				stored.AttemptedAt = time.Now().UTC()
+			}
+			r.attempts[reservationID] = append(r.attempts[reservationID], stored)
+
+			entry.Status = strings.TrimSpace(status)
+			entry.ErrorMessage = stored.ErrorMessage
+			if stored.Attempt > entry.Attempts {
+				entry.Attempts = stored.Attempt
+			}
+			entry.NextAttemptAt = nil
+			if nextAttemptAt != nil {
+				next := nextAttemptAt.UTC()
+				entry.NextAttemptAt = &next
+			}
+			return copyNotification(*entry), nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *meetingNotificationRepository) ListNotificationAttempts(ctx context.Context, reservationID uint64) ([]model.MeetingNotificationAttempt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.attempts[reservationID]
+	result := make([]model.MeetingNotificationAttempt, len(entries))
+	copy(result, entries)
+	return result, nil
+}
+
+func (r *meetingNotificationRepository) appendLocked(notification model.MeetingNotification) *model.MeetingNotification {
+	r.seq++
+	entry := model.MeetingNotification{
+		ID:            r.seq,
+		ReservationID: notification.ReservationID,
+		Type:          notification.Type,
+		Status:        notification.Status,
+		ErrorMessage:  notification.ErrorMessage,
+		DedupeKey:     strings.TrimSpace(notification.DedupeKey),
+		CreatedAt:     time.Now().UTC(),
+	}
+	r.notifications[notification.ReservationID] = append(r.notifications[notification.ReservationID], entry)
+	return copyNotification(entry)
+}
+
 func copyNotification(notification model.MeetingNotification) *model.MeetingNotification {
 	entry := notification
+	if notification.NextAttemptAt != nil {
+		next := *notification.NextAttemptAt
+		entry.NextAttemptAt = &next
+	}
 	return &entry
 }
 
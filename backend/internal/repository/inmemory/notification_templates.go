@@ -0,0 +1,87 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type notificationTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[model.NotificationTemplateKey]model.NotificationTemplate
+}
+
+// NewNotificationTemplateRepository constructs an in-memory notification template repository.
+func NewNotificationTemplateRepository() repository.NotificationTemplateRepository {
+	return &notificationTemplateRepository{
+		templates: make(map[model.NotificationTemplateKey]model.NotificationTemplate),
+	}
+}
+
+func (r *notificationTemplateRepository) ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.NotificationTemplate, 0, len(r.templates))
+	for _, template := range r.templates {
+		result = append(result, copyNotificationTemplate(template))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+func (r *notificationTemplateRepository) GetTemplate(ctx context.Context, key model.NotificationTemplateKey) (*model.NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, ok := r.templates[key]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	found := copyNotificationTemplate(template)
+	return &found, nil
+}
+
+func (r *notificationTemplateRepository) SaveTemplate(ctx context.Context, template *model.NotificationTemplate) (*model.NotificationTemplate, error) {
+	if template == nil || template.Key == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := copyNotificationTemplate(*template)
+	now := time.Now().UTC()
+	entry.UpdatedAt = &now
+	entry.Customized = true
+	r.templates[entry.Key] = entry
+
+	saved := copyNotificationTemplate(entry)
+	return &saved, nil
+}
+
+func (r *notificationTemplateRepository) DeleteTemplate(ctx context.Context, key model.NotificationTemplateKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.templates[key]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.templates, key)
+	return nil
+}
+
+func copyNotificationTemplate(template model.NotificationTemplate) model.NotificationTemplate {
+	result := template
+	if template.UpdatedAt != nil {
+		updated := *template.UpdatedAt
+		result.UpdatedAt = &updated
+	}
+	return result
+}
+
+var _ repository.NotificationTemplateRepository = (*notificationTemplateRepository)(nil)
@@ -0,0 +1,101 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type reservationDriftRepository struct {
+	mu     sync.RWMutex
+	seq    uint64
+	drifts []model.ReservationDrift
+}
+
+// NewReservationDriftRepository constructs an in-memory reservation drift repository.
+func NewReservationDriftRepository() repository.ReservationDriftRepository {
+	return &reservationDriftRepository{}
+}
+
+func (r *reservationDriftRepository) RecordDrift(ctx context.Context, drift *model.ReservationDrift) (*model.ReservationDrift, error) {
+	if drift == nil || drift.ReservationID == 0 || strings.TrimSpace(drift.DedupeKey) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.drifts {
+		if existing.ReservationID == drift.ReservationID && existing.DedupeKey == drift.DedupeKey {
+			return nil, repository.ErrConflict
+		}
+	}
+
+	r.seq++
+	entry := copyDrift(*drift)
+	entry.ID = r.seq
+	entry.ResolvedAt = nil
+	if entry.DetectedAt.IsZero() {
+		entry.DetectedAt = time.Now().UTC()
+	}
+	r.drifts = append(r.drifts, entry)
+	created := copyDrift(entry)
+	return &created, nil
+}
+
+func (r *reservationDriftRepository) ListDrifts(ctx context.Context, includeResolved bool) ([]model.ReservationDrift, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.ReservationDrift, 0, len(r.drifts))
+	for _, drift := range r.drifts {
+		if drift.ResolvedAt != nil && !includeResolved {
+			continue
+		}
+		result = append(result, copyDrift(drift))
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	return result, nil
+}
+
+func (r *reservationDriftRepository) ResolveDrift(ctx context.Context, id uint64, resolvedAt time.Time) (*model.ReservationDrift, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index := range r.drifts {
+		if r.drifts[index].ID != id {
+			continue
+		}
+		if r.drifts[index].ResolvedAt == nil {
+			resolved := resolvedAt.UTC()
+			r.drifts[index].ResolvedAt = &resolved
+		}
+		updated := copyDrift(r.drifts[index])
+		return &updated, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func copyDrift(drift model.ReservationDrift) model.ReservationDrift {
+	clone := drift
+	if drift.EventStartAt != nil {
+		start := *drift.EventStartAt
+		clone.EventStartAt = &start
+	}
+	if drift.EventEndAt != nil {
+		end := *drift.EventEndAt
+		clone.EventEndAt = &end
+	}
+	if drift.ResolvedAt != nil {
+		resolved := *drift.ResolvedAt
+		clone.ResolvedAt = &resolved
+	}
+	return clone
+}
+
+var _ repository.ReservationDriftRepository = (*reservationDriftRepository)(nil)
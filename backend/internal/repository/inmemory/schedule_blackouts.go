@@ -0,0 +1,118 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type scheduleBlackoutRepository struct {
+	mu        sync.RWMutex
+	seq       uint64
+	blackouts []model.ScheduleBlackout
+}
+
+// NewScheduleBlackoutRepository constructs an in-memory blackout repository.
+func NewScheduleBlackoutRepository() repository.ScheduleBlackoutRepository {
+	return &scheduleBlackoutRepository{}
+}
+
+func (r *scheduleBlackoutRepository) ListBlackouts(ctx context.Context) ([]model.ScheduleBlackout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.ScheduleBlackout, len(r.blackouts))
+	copy(result, r.blackouts)
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartTime.Equal(result[j].StartTime) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+	return result, nil
+}
+
+func (r *scheduleBlackoutRepository) GetBlackout(ctx context.Context, id uint64) (*model.ScheduleBlackout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.blackouts {
+		if entry.ID == id {
+			found := entry
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *scheduleBlackoutRepository) CreateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error) {
+	if blackout == nil || !blackout.EndTime.After(blackout.StartTime) {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	now := time.Now().UTC()
+	entry := normalizeBlackout(*blackout)
+	entry.ID = r.seq
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	r.blackouts = append(r.blackouts, entry)
+
+	created := entry
+	return &created, nil
+}
+
+func (r *scheduleBlackoutRepository) UpdateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error) {
+	if blackout == nil || blackout.ID == 0 || !blackout.EndTime.After(blackout.StartTime) {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index, existing := range r.blackouts {
+		if existing.ID != blackout.ID {
+			continue
+		}
+		entry := normalizeBlackout(*blackout)
+		entry.CreatedAt = existing.CreatedAt
+		entry.UpdatedAt = time.Now().UTC()
+		r.blackouts[index] = entry
+
+		updated := entry
+		return &updated, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *scheduleBlackoutRepository) DeleteBlackout(ctx context.Context, id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index, entry := range r.blackouts {
+		if entry.ID == id {
+			r.blackouts = append(r.blackouts[:index], r.blackouts[index+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func normalizeBlackout(blackout model.ScheduleBlackout) model.ScheduleBlackout {
+	blackout.StartTime = blackout.StartTime.UTC()
+	blackout.EndTime = blackout.EndTime.UTC()
+	blackout.Reason = strings.TrimSpace(blackout.Reason)
+	blackout.Recurrence = strings.TrimSpace(blackout.Recurrence)
+	blackout.Timezone = strings.TrimSpace(blackout.Timezone)
+	return blackout
+}
+
+var _ repository.ScheduleBlackoutRepository = (*scheduleBlackoutRepository)(nil)
@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type spamRepository struct {
+	mu           sync.RWMutex
+	fingerprints []model.SpamFingerprint
+	feedbackSeq  uint64
+	feedback     []model.SpamFeedback
+}
+
+// NewSpamRepository constructs an in-memory store for spam fingerprints and verdicts.
+func NewSpamRepository() repository.SpamRepository {
+	return &spamRepository{}
+}
+
+func (r *spamRepository) RecordSpamFingerprint(ctx context.Context, fingerprint *model.SpamFingerprint) error {
+	if fingerprint == nil || strings.TrimSpace(fingerprint.ContentHash) == "" {
+		return repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := *fingerprint
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	r.fingerprints = append(r.fingerprints, entry)
+	return nil
+}
+
+func (r *spamRepository) CountSpamFingerprints(ctx context.Context, contentHash string, since time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, entry := range r.fingerprints {
+		if entry.ContentHash == contentHash && !entry.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *spamRepository) RecordSpamFeedback(ctx context.Context, feedback *model.SpamFeedback) (*model.SpamFeedback, error) {
+	if feedback == nil || strings.TrimSpace(feedback.Email) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.feedbackSeq++
+	entry := *feedback
+	entry.ID = r.feedbackSeq
+	entry.Email = strings.ToLower(strings.TrimSpace(entry.Email))
+	entry.CreatedAt = time.Now().UTC()
+	r.feedback = append(r.feedback, entry)
+	return &entry, nil
+}
+
+func (r *spamRepository) ListSpamFeedback(ctx context.Context, contentHash, email string) ([]model.SpamFeedback, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	result := make([]model.SpamFeedback, 0)
+	for i := len(r.feedback) - 1; i >= 0; i-- {
+		entry := r.feedback[i]
+		if (contentHash != "" && entry.ContentHash == contentHash) || (email != "" && entry.Email == email) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+var _ repository.SpamRepository = (*spamRepository)(nil)
@@ -0,0 +1,176 @@
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type waitlistRepository struct {
+	mu      sync.RWMutex
+	seq     uint64
+	entries []model.WaitlistEntry
+}
+
+// NewWaitlistRepository constructs an in-memory waitlist repository.
+func NewWaitlistRepository() repository.WaitlistRepository {
+	return &waitlistRepository{}
+}
+
+func (r *waitlistRepository) CreateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error) {
+	if entry == nil || strings.TrimSpace(entry.Email) == "" || len(entry.PreferredDates) == 0 {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	now := time.Now().UTC()
+	created := copyWaitlistEntry(*entry)
+	created.ID = r.seq
+	if created.Status == "" {
+		created.Status = model.WaitlistStatusWaiting
+	}
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	r.entries = append(r.entries, created)
+
+	result := copyWaitlistEntry(created)
+	return &result, nil
+}
+
+func (r *waitlistRepository) ListEntries(ctx context.Context, statuses []model.WaitlistStatus) ([]model.WaitlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.WaitlistEntry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if len(statuses) > 0 && !containsWaitlistStatus(statuses, entry.Status) {
+			continue
+		}
+		result = append(result, copyWaitlistEntry(entry))
+	}
+	return result, nil
+}
+
+func (r *waitlistRepository) FindOfferByReservation(ctx context.Context, reservationID uint64) (*model.WaitlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		if entry.Status == model.WaitlistStatusOffered && entry.OfferReservationID == reservationID {
+			found := copyWaitlistEntry(entry)
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *waitlistRepository) FindEntryByOfferToken(ctx context.Context, tokenHash string) (*model.WaitlistEntry, error) {
+	if strings.TrimSpace(tokenHash) == "" {
+		return nil, repository.ErrNotFound
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		if entry.OfferTokenHash == tokenHash {
+			found := copyWaitlistEntry(entry)
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *waitlistRepository) UpdateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error) {
+	if entry == nil || entry.ID == 0 || entry.Status == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.update(entry, func(model.WaitlistEntry) bool { return true })
+}
+
+func (r *waitlistRepository) TransitionEntry(ctx context.Context, entry *model.WaitlistEntry, from model.WaitlistStatus, tokenHash string) (*model.WaitlistEntry, error) {
+	if entry == nil || entry.ID == 0 || entry.Status == "" || from == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.update(entry, func(stored model.WaitlistEntry) bool {
+		return stored.Status == from && stored.OfferTokenHash == tokenHash
+	})
+}
+
+// update stores the entry when matches accepts the stored version; the caller holds the lock.
+func (r *waitlistRepository) update(entry *model.WaitlistEntry, matches func(model.WaitlistEntry) bool) (*model.WaitlistEntry, error) {
+	if entry.OfferTokenHash != "" {
+		for _, existing := range r.entries {
+			if existing.ID != entry.ID && existing.OfferTokenHash == entry.OfferTokenHash {
+				return nil, repository.ErrDuplicate
+			}
+		}
+	}
+
+	for index := range r.entries {
+		if r.entries[index].ID != entry.ID {
+			continue
+		}
+		if !matches(r.entries[index]) {
+			return nil, repository.ErrConflict
+		}
+		// Only the status and the offer change after an entry is created.
+		updated := copyWaitlistEntry(r.entries[index])
+		source := copyWaitlistEntry(*entry)
+		updated.Status = source.Status
+		updated.OfferReservationID = source.OfferReservationID
+		updated.OfferStartAt = source.OfferStartAt
+		updated.OfferEndAt = source.OfferEndAt
+		updated.OfferExpiresAt = source.OfferExpiresAt
+		updated.OfferTokenHash = source.OfferTokenHash
+		updated.UpdatedAt = time.Now().UTC()
+		r.entries[index] = updated
+
+		result := copyWaitlistEntry(updated)
+		return &result, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func containsWaitlistStatus(statuses []model.WaitlistStatus, status model.WaitlistStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+func copyWaitlistEntry(entry model.WaitlistEntry) model.WaitlistEntry {
+	result := entry
+	result.PreferredDates = append([]string(nil), entry.PreferredDates...)
+	result.OfferStartAt = copyTimePtr(entry.OfferStartAt)
+	result.OfferEndAt = copyTimePtr(entry.OfferEndAt)
+	result.OfferExpiresAt = copyTimePtr(entry.OfferExpiresAt)
+	return result
+}
+
+func copyTimePtr(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+var _ repository.WaitlistRepository = (*waitlistRepository)(nil)
@@ -26,3 +26,16 @@ type ContactRepository interface {
 type AvailabilityRepository interface {
 	ListBusyWindows(ctx context.Context, from, to time.Time) ([]model.TimeWindow, error)
 }
+
+// IdempotencyRepository stores Idempotency-Key records until they expire. Expired records are
+// treated as absent.
+type IdempotencyRepository interface {
+	// CreateIdempotencyRecord reserves the key, replacing a record that expired by the new
+	// record's CreatedAt. It returns ErrConflict when an unexpired record already holds the key.
+	CreateIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error
+	FindIdempotencyRecord(ctx context.Context, key string, now time.Time) (*model.IdempotencyRecord, error)
+	// CompleteIdempotencyRecord stores the response of a reserved key and extends its expiry.
+	CompleteIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error
+	DeleteIdempotencyRecord(ctx context.Context, key string) error
+	DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error)
+}
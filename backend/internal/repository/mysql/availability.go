@@ -9,6 +9,7 @@ import (
 
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/schedule"
 )
 
 type availabilityRepository struct {
@@ -27,19 +28,24 @@ SELECT
 	end_at AS end_time,
 	'reservation' AS source
 FROM meeting_reservations
-WHERE status IN ('pending', 'confirmed')
+WHERE status IN ('requested', 'confirmed', 'rescheduled')
   AND end_at > ?
   AND start_at < ?
 ORDER BY start_at`
 
 	blackoutBusyQuery = `
 SELECT
+	id,
 	start_time,
 	end_time,
-	'blackout' AS source
+	reason,
+	recurrence_rule,
+	timezone,
+	created_at,
+	updated_at
 FROM schedule_blackouts
-WHERE end_time > ?
-  AND start_time < ?
+WHERE (end_time > ? AND start_time < ?)
+   OR (recurrence_rule IS NOT NULL AND recurrence_rule <> '' AND start_time < ?)
 ORDER BY start_time`
 )
 
@@ -65,18 +71,16 @@ func (r *availabilityRepository) ListBusyWindows(ctx context.Context, from, to t
 		})
 	}
 
-	var blackoutRows []busyRow
-	if err := r.db.SelectContext(ctx, &blackoutRows, blackoutBusyQuery, from, to); err != nil {
+	var blackoutRows []blackoutRow
+	if err := r.db.SelectContext(ctx, &blackoutRows, blackoutBusyQuery, from, to, to); err != nil {
 		return nil, fmt.Errorf("select blackout windows: %w", err)
 	}
 
+	blackouts := make([]model.ScheduleBlackout, 0, len(blackoutRows))
 	for _, row := range blackoutRows {
-		windows = append(windows, model.TimeWindow{
-			Start:  row.Start.UTC(),
-			End:    row.End.UTC(),
-			Source: model.BusyWindowSource(row.Source),
-		})
+		blackouts = append(blackouts, mapBlackoutRow(row))
 	}
+	windows = append(windows, schedule.ExpandBlackouts(blackouts, from, to)...)
 
 	return windows, nil
 }
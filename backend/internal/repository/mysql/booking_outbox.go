@@ -0,0 +1,303 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type bookingOutboxRepository struct {
+	db           *sqlx.DB
+	reservations *meetingReservationRepository
+}
+
+// NewBookingOutboxRepository returns a MySQL-backed outbox for reservation side effects.
+func NewBookingOutboxRepository(db *sqlx.DB) repository.BookingOutboxRepository {
+	return &bookingOutboxRepository{db: db, reservations: &meetingReservationRepository{db: db}}
+}
+
+type outboxJobRow struct {
+	ID            uint64         `db:"id"`
+	ReservationID uint64         `db:"reservation_id"`
+	Kind          string         `db:"kind"`
+	Status        string         `db:"status"`
+	Attempts      int            `db:"attempts"`
+	MaxAttempts   int            `db:"max_attempts"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	LastError     sql.NullString `db:"last_error"`
+	CompletedAt   sql.NullTime   `db:"completed_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+const insertOutboxJobQuery = `
+INSERT INTO booking_outbox (
+	reservation_id,
+	kind,
+	status,
+	attempts,
+	max_attempts,
+	next_attempt_at,
+	created_at,
+	updated_at
+) VALUES (?, ?, 'pending', 0, ?, ?, NOW(3), NOW(3))`
+
+const selectOutboxJobsBaseQuery = `
+SELECT
+	id,
+	reservation_id,
+	kind,
+	status,
+	attempts,
+	max_attempts,
+	next_attempt_at,
+	last_error,
+	completed_at,
+	created_at,
+	updated_at
+FROM booking_outbox`
+
+const selectDueOutboxJobsQuery = selectOutboxJobsBaseQuery + `
+WHERE status IN ('pending','processing')
+  AND next_attempt_at <= ?
+ORDER BY next_attempt_at ASC, id ASC
+LIMIT ?
+FOR UPDATE SKIP LOCKED`
+
+const setCalendarEventQuery = `
+UPDATE meeting_reservations
+SET
+	google_event_id = ?,
+	google_calendar_status = CASE WHEN status IN ('cancelled_by_visitor','cancelled_by_owner') THEN google_calendar_status ELSE 'confirmed' END,
+	updated_at = NOW(3)
+WHERE id = ?
+  AND (google_event_id IS NULL OR google_event_id = '')`
+
+func (r *bookingOutboxRepository) CreateReservationWithJobs(ctx context.Context, reservation *model.MeetingReservation, jobs []model.OutboxJob) (*model.MeetingReservation, error) {
+	if reservation == nil {
+		return nil, repository.ErrInvalidInput
+	}
+	for _, job := range jobs {
+		if strings.TrimSpace(string(job.Kind)) == "" {
+			return nil, repository.ErrInvalidInput
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	reservationID, err := insertReservationTx(ctx, tx, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, job := range jobs {
+		nextAttempt := job.NextAttemptAt.UTC()
+		if job.NextAttemptAt.IsZero() {
+			nextAttempt = now
+		}
+		if _, execErr := tx.ExecContext(ctx, insertOutboxJobQuery, reservationID, string(job.Kind), job.MaxAttempts, nextAttempt); execErr != nil {
+			err = fmt.Errorf("insert booking_outbox kind=%s: %w", job.Kind, execErr)
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit reservation with outbox: %w", err)
+	}
+
+	return r.reservations.findByID(ctx, uint64(reservationID))
+}
+
+func (r *bookingOutboxRepository) EnqueueJobs(ctx context.Context, reservationID uint64, jobs []model.OutboxJob) error {
+	if reservationID == 0 {
+		return repository.ErrInvalidInput
+	}
+	for _, job := range jobs {
+		if strings.TrimSpace(string(job.Kind)) == "" {
+			return repository.ErrInvalidInput
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	now := time.Now().UTC()
+	for _, job := range jobs {
+		nextAttempt := job.NextAttemptAt.UTC()
+		if job.NextAttemptAt.IsZero() {
+			nextAttempt = now
+		}
+		if _, execErr := tx.ExecContext(ctx, insertOutboxJobQuery, reservationID, string(job.Kind), job.MaxAttempts, nextAttempt); execErr != nil {
+			err = fmt.Errorf("insert booking_outbox reservation_id=%d kind=%s: %w", reservationID, job.Kind, execErr)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit booking_outbox enqueue: %w", err)
+	}
+	return nil
+}
+
+func (r *bookingOutboxRepository) ClaimDueJobs(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.OutboxJob, error) {
+	if limit <= 0 {
+		return []model.OutboxJob{}, nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	var rows []outboxJobRow
+	if selectErr := tx.SelectContext(ctx, &rows, selectDueOutboxJobsQuery, now.UTC(), limit); selectErr != nil {
+		err = fmt.Errorf("select due booking_outbox: %w", selectErr)
+		return nil, err
+	}
+	if len(rows) == 0 {
+		err = tx.Commit()
+		return []model.OutboxJob{}, err
+	}
+
+	leaseUntil := now.Add(lease).UTC()
+	ids := make([]any, 0, len(rows)+1)
+	ids = append(ids, leaseUntil)
+	placeholders := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+		placeholders = append(placeholders, "?")
+	}
+	query := `UPDATE booking_outbox SET status = 'processing', next_attempt_at = ?, updated_at = NOW(3) WHERE id IN (` + strings.Join(placeholders, ",") + `)`
+	if _, execErr := tx.ExecContext(ctx, query, ids...); execErr != nil {
+		err = fmt.Errorf("lease booking_outbox: %w", execErr)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit booking_outbox lease: %w", err)
+	}
+
+	jobs := make([]model.OutboxJob, 0, len(rows))
+	for _, row := range rows {
+		job := mapOutboxJobRow(row)
+		job.Status = model.OutboxJobStatusProcessing
+		job.NextAttemptAt = leaseUntil
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (r *bookingOutboxRepository) ListJobs(ctx context.Context, reservationID uint64) ([]model.OutboxJob, error) {
+	var rows []outboxJobRow
+	query := selectOutboxJobsBaseQuery + "\nWHERE reservation_id = ?\nORDER BY id ASC"
+	if err := r.db.SelectContext(ctx, &rows, query, reservationID); err != nil {
+		return nil, fmt.Errorf("select booking_outbox reservation_id=%d: %w", reservationID, err)
+	}
+
+	jobs := make([]model.OutboxJob, 0, len(rows))
+	for _, row := range rows {
+		jobs = append(jobs, mapOutboxJobRow(row))
+	}
+	return jobs, nil
+}
+
+func (r *bookingOutboxRepository) CompleteJob(ctx context.Context, id uint64, completedAt time.Time) error {
+	const query = `
+UPDATE booking_outbox
+SET status = 'done', attempts = attempts + 1, last_error = NULL, completed_at = ?, updated_at = NOW(3)
+WHERE id = ?`
+	return r.exec(ctx, "complete", id, query, completedAt.UTC(), id)
+}
+
+func (r *bookingOutboxRepository) RetryJob(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error {
+	const query = `
+UPDATE booking_outbox
+SET status = 'pending', attempts = attempts + 1, next_attempt_at = ?, last_error = ?, updated_at = NOW(3)
+WHERE id = ?`
+	return r.exec(ctx, "retry", id, query, nextAttemptAt.UTC(), nullIfEmpty(lastError), id)
+}
+
+func (r *bookingOutboxRepository) DeferJob(ctx context.Context, id uint64, nextAttemptAt time.Time) error {
+	const query = `
+UPDATE booking_outbox
+SET status = 'pending', next_attempt_at = ?, updated_at = NOW(3)
+WHERE id = ?`
+	return r.exec(ctx, "defer", id, query, nextAttemptAt.UTC(), id)
+}
+
+func (r *bookingOutboxRepository) DeadLetterJob(ctx context.Context, id uint64, lastError string) error {
+	const query = `
+UPDATE booking_outbox
+SET status = 'dead', attempts = attempts + 1, last_error = ?, updated_at = NOW(3)
+WHERE id = ?`
+	return r.exec(ctx, "dead-letter", id, query, nullIfEmpty(lastError), id)
+}
+
+func (r *bookingOutboxRepository) AttachCalendarEvent(ctx context.Context, reservationID uint64, eventID string) error {
+	res, err := r.db.ExecContext(ctx, setCalendarEventQuery, strings.TrimSpace(eventID), reservationID)
+	if err != nil {
+		return fmt.Errorf("update meeting_reservations event id=%d: %w", reservationID, err)
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		// Either the reservation is gone or another event was linked first.
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM meeting_reservations WHERE id = ?)", reservationID); err != nil {
+			return fmt.Errorf("check meeting_reservations id=%d: %w", reservationID, err)
+		}
+		if exists {
+			return repository.ErrConflict
+		}
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *bookingOutboxRepository) exec(ctx context.Context, action string, id uint64, query string, args ...any) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s booking_outbox id=%d: %w", action, id, err)
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func mapOutboxJobRow(row outboxJobRow) model.OutboxJob {
+	var completedAt *time.Time
+	if row.CompletedAt.Valid {
+		ts := row.CompletedAt.Time.UTC()
+		completedAt = &ts
+	}
+	return model.OutboxJob{
+		ID:            row.ID,
+		ReservationID: row.ReservationID,
+		Kind:          model.OutboxJobKind(strings.TrimSpace(row.Kind)),
+		Status:        model.OutboxJobStatus(strings.TrimSpace(row.Status)),
+		Attempts:      row.Attempts,
+		MaxAttempts:   row.MaxAttempts,
+		NextAttemptAt: row.NextAttemptAt.UTC(),
+		LastError:     strings.TrimSpace(row.LastError.String),
+		CompletedAt:   completedAt,
+		CreatedAt:     row.CreatedAt.UTC(),
+		UpdatedAt:     row.UpdatedAt.UTC(),
+	}
+}
+
+var _ repository.BookingOutboxRepository = (*bookingOutboxRepository)(nil)
@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type calendarFeedTokenRepository struct {
+	db *sqlx.DB
+}
+
+// NewCalendarFeedTokenRepository returns a MySQL-backed calendar feed token repository.
+func NewCalendarFeedTokenRepository(db *sqlx.DB) repository.CalendarFeedTokenRepository {
+	return &calendarFeedTokenRepository{db: db}
+}
+
+const selectFeedTokensBaseQuery = `
+SELECT
+	id,
+	label,
+	token_hash,
+	created_at,
+	last_used_at,
+	revoked_at
+FROM calendar_feed_tokens`
+
+const insertFeedTokenQuery = `
+INSERT INTO calendar_feed_tokens (
+	label,
+	token_hash,
+	created_at
+) VALUES (?, ?, NOW(3))`
+
+type feedTokenRow struct {
+	ID         uint64         `db:"id"`
+	Label      sql.NullString `db:"label"`
+	TokenHash  string         `db:"token_hash"`
+	CreatedAt  time.Time      `db:"created_at"`
+	LastUsedAt sql.NullTime   `db:"last_used_at"`
+	RevokedAt  sql.NullTime   `db:"revoked_at"`
+}
+
+func (r *calendarFeedTokenRepository) ListFeedTokens(ctx context.Context) ([]model.CalendarFeedToken, error) {
+	var rows []feedTokenRow
+	if err := r.db.SelectContext(ctx, &rows, selectFeedTokensBaseQuery+"\nORDER BY created_at DESC, id DESC"); err != nil {
+		return nil, fmt.Errorf("select calendar_feed_tokens: %w", err)
+	}
+
+	tokens := make([]model.CalendarFeedToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, mapFeedTokenRow(row))
+	}
+	return tokens, nil
+}
+
+func (r *calendarFeedTokenRepository) CreateFeedToken(ctx context.Context, token *model.CalendarFeedToken) (*model.CalendarFeedToken, error) {
+	if token == nil || strings.TrimSpace(token.TokenHash) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx, insertFeedTokenQuery, nullIfEmpty(token.Label), token.TokenHash)
+	if err != nil {
+		var mysqlErr *mysqlerr.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return nil, repository.ErrDuplicate
+		}
+		return nil, fmt.Errorf("insert calendar_feed_tokens: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("calendar_feed_tokens last insert id: %w", err)
+	}
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *calendarFeedTokenRepository) FindFeedTokenByHash(ctx context.Context, tokenHash string) (*model.CalendarFeedToken, error) {
+	return r.findOne(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *calendarFeedTokenRepository) RevokeFeedToken(ctx context.Context, id uint64, revokedAt time.Time) (*model.CalendarFeedToken, error) {
+	const query = `UPDATE calendar_feed_tokens SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, revokedAt.UTC(), id); err != nil {
+		return nil, fmt.Errorf("revoke calendar_feed_tokens id=%d: %w", id, err)
+	}
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *calendarFeedTokenRepository) TouchFeedToken(ctx context.Context, id uint64, usedAt time.Time) error {
+	const query = `UPDATE calendar_feed_tokens SET last_used_at = ? WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, usedAt.UTC(), id); err != nil {
+		return fmt.Errorf("touch calendar_feed_tokens id=%d: %w", id, err)
+	}
+	return nil
+}
+
+func (r *calendarFeedTokenRepository) findOne(ctx context.Context, condition string, arg any) (*model.CalendarFeedToken, error) {
+	var row feedTokenRow
+	if err := r.db.GetContext(ctx, &row, selectFeedTokensBaseQuery+"\nWHERE "+condition, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("select calendar_feed_tokens: %w", err)
+	}
+	token := mapFeedTokenRow(row)
+	return &token, nil
+}
+
+func mapFeedTokenRow(row feedTokenRow) model.CalendarFeedToken {
+	token := model.CalendarFeedToken{
+		ID:        row.ID,
+		Label:     strings.TrimSpace(row.Label.String),
+		TokenHash: strings.TrimSpace(row.TokenHash),
+		CreatedAt: row.CreatedAt.UTC(),
+	}
+	if row.LastUsedAt.Valid {
+		used := row.LastUsedAt.Time.UTC()
+		token.LastUsedAt = &used
+	}
+	if row.RevokedAt.Valid {
+		revoked := row.RevokedAt.Time.UTC()
+		token.RevokedAt = &revoked
+	}
+	return token
+}
+
+var _ repository.CalendarFeedTokenRepository = (*calendarFeedTokenRepository)(nil)
@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -30,25 +31,32 @@ INSERT INTO contact_messages (
 	email,
 	topic,
 	message,
+	locale,
+	intake_answers,
 	status,
 	admin_note,
+	spam_score,
+	spam_signals,
 	created_at,
 	updated_at
-) VALUES (?, ?, ?, ?, 'pending', '', NOW(), NOW())`
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`
 
-	listContactMessagesQuery = `
+	listContactMessagesBaseQuery = `
 SELECT
 	id,
 	name,
 	email,
 	topic,
 	message,
+	locale,
+	intake_answers,
 	status,
 	admin_note,
+	spam_score,
+	spam_signals,
 	created_at,
 	updated_at
-FROM contact_messages
-ORDER BY created_at DESC, id DESC`
+FROM contact_messages`
 
 	getContactMessageQuery = `
 SELECT
@@ -57,8 +65,12 @@ SELECT
 	email,
 	topic,
 	message,
+	locale,
+	intake_answers,
 	status,
 	admin_note,
+	spam_score,
+	spam_signals,
 	created_at,
 	updated_at
 FROM contact_messages
@@ -77,15 +89,19 @@ WHERE id = ?`
 )
 
 type contactRow struct {
-	ID        int64          `db:"id"`
-	Name      sql.NullString `db:"name"`
-	Email     sql.NullString `db:"email"`
-	Topic     sql.NullString `db:"topic"`
-	Message   sql.NullString `db:"message"`
-	Status    sql.NullString `db:"status"`
-	AdminNote sql.NullString `db:"admin_note"`
-	CreatedAt sql.NullTime   `db:"created_at"`
-	UpdatedAt sql.NullTime   `db:"updated_at"`
+	ID                int64           `db:"id"`
+	Name              sql.NullString  `db:"name"`
+	Email             sql.NullString  `db:"email"`
+	Topic             sql.NullString  `db:"topic"`
+	Message           sql.NullString  `db:"message"`
+	Locale            sql.NullString  `db:"locale"`
+	IntakeAnswersJSON []byte          `db:"intake_answers"`
+	Status            sql.NullString  `db:"status"`
+	AdminNote         sql.NullString  `db:"admin_note"`
+	SpamScore         sql.NullFloat64 `db:"spam_score"`
+	SpamSignalsJSON   []byte          `db:"spam_signals"`
+	CreatedAt         sql.NullTime    `db:"created_at"`
+	UpdatedAt         sql.NullTime    `db:"updated_at"`
 }
 
 func (r *contactRepository) CreateSubmission(ctx context.Context, payload *model.ContactRequest) (*model.ContactSubmission, error) {
@@ -98,11 +114,37 @@ func (r *contactRepository) CreateSubmission(ctx context.Context, payload *model
 		return nil, repository.ErrInvalidInput
 	}
 
+	intakeAnswers, err := encodeIntakeAnswers(payload.IntakeAnswers)
+	if err != nil {
+		return nil, fmt.Errorf("encode intake answers: %w", err)
+	}
+
+	status := payload.Status
+	if status == "" {
+		status = model.ContactStatusPending
+	}
+	var spamScore sql.NullFloat64
+	var spamSignals []byte
+	if payload.Spam != nil {
+		spamScore = sql.NullFloat64{Float64: payload.Spam.Score, Valid: true}
+		if len(payload.Spam.Signals) > 0 {
+			if spamSignals, err = json.Marshal(payload.Spam.Signals); err != nil {
+				return nil, fmt.Errorf("encode spam signals: %w", err)
+			}
+		}
+	}
+
 	result, err := r.db.ExecContext(ctx, insertContactQuery,
 		strings.TrimSpace(payload.Name),
 		email,
 		strings.TrimSpace(payload.Topic),
 		strings.TrimSpace(payload.Message),
+		nullIfEmpty(payload.Locale),
+		intakeAnswers,
+		string(status),
+		strings.TrimSpace(payload.AdminNote),
+		spamScore,
+		spamSignals,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("insert contact message: %w", err)
@@ -115,14 +157,57 @@ func (r *contactRepository) CreateSubmission(ctx context.Context, payload *model
 
 	return &model.ContactSubmission{
 		ID:      strconv.FormatInt(id, 10),
-		Status:  "pending",
+		Status:  string(status),
 		Comment: fmt.Sprintf("stored at %s", time.Now().UTC().Format(time.RFC3339)),
 	}, nil
 }
 
-func (r *contactRepository) ListContactMessages(ctx context.Context) ([]model.ContactMessage, error) {
+func (r *contactRepository) ListContactMessages(ctx context.Context, filter repository.ContactMessageListFilter) ([]model.ContactMessage, error) {
+	query := listContactMessagesBaseQuery
+	var conditions []string
+	var args []any
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, 0, len(filter.Status))
+		for _, status := range filter.Status {
+			placeholders = append(placeholders, "?")
+			args = append(args, string(status))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ",")+")")
+	}
+	if email := strings.ToLower(strings.TrimSpace(filter.Email)); email != "" {
+		conditions = append(conditions, "LOWER(email) = ?")
+		args = append(args, email)
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.CreatedFrom.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.CreatedBefore.UTC())
+	}
+	if filter.After != nil {
+		afterID, err := parseContactID(filter.After.ID)
+		if err != nil {
+			return nil, err
+		}
+		after := filter.After.CreatedAt.UTC()
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, after, after, afterID)
+	}
+
+	if len(conditions) > 0 {
+		query += "\nWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\nORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += "\nLIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
 	var rows []contactRow
-	if err := r.db.SelectContext(ctx, &rows, listContactMessagesQuery); err != nil {
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
 		return nil, fmt.Errorf("list contact messages: %w", err)
 	}
 
@@ -227,16 +312,32 @@ func mapContactRow(row contactRow) model.ContactMessage {
 	}
 
 	return model.ContactMessage{
-		ID:        strconv.FormatInt(row.ID, 10),
-		Name:      nullableString(row.Name),
-		Email:     nullableString(row.Email),
-		Topic:     nullableString(row.Topic),
-		Message:   nullableString(row.Message),
-		Status:    status,
-		AdminNote: nullableString(row.AdminNote),
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:            strconv.FormatInt(row.ID, 10),
+		Name:          nullableString(row.Name),
+		Email:         nullableString(row.Email),
+		Topic:         nullableString(row.Topic),
+		Message:       nullableString(row.Message),
+		Locale:        nullableString(row.Locale),
+		IntakeAnswers: decodeIntakeAnswers(row.IntakeAnswersJSON),
+		Status:        status,
+		AdminNote:     nullableString(row.AdminNote),
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+		SpamScore:     row.SpamScore.Float64,
+		SpamSignals:   decodeSpamSignals(row.SpamSignalsJSON),
+	}
+}
+
+// decodeSpamSignals reads a spam_signals column; unreadable content is treated as no signals.
+func decodeSpamSignals(payload []byte) []model.SpamSignal {
+	if len(payload) == 0 {
+		return nil
+	}
+	var signals []model.SpamSignal
+	if err := json.Unmarshal(payload, &signals); err != nil {
+		return nil
 	}
+	return signals
 }
 
 var _ repository.ContactRepository = (*contactRepository)(nil)
@@ -0,0 +1,221 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type contactNotificationRepository struct {
+	db *sqlx.DB
+}
+
+// NewContactNotificationRepository returns a MySQL-backed contact notification repository.
+func NewContactNotificationRepository(db *sqlx.DB) repository.ContactNotificationRepository {
+	return &contactNotificationRepository{db: db}
+}
+
+const (
+	insertContactNotificationQuery = `
+INSERT INTO contact_notifications (
+	contact_id,
+	notification_type,
+	status,
+	error_message,
+	created_at
+) VALUES (?, ?, ?, ?, NOW(3))`
+
+	selectContactNotificationsBaseQuery = `
+SELECT
+	id,
+	contact_id,
+	notification_type,
+	status,
+	error_message,
+	attempts,
+	next_attempt_at,
+	created_at
+FROM contact_notifications`
+
+	listContactNotificationsQuery = selectContactNotificationsBaseQuery + `
+WHERE contact_id = ?
+ORDER BY created_at ASC, id ASC`
+
+	selectRetryableContactNotificationsQuery = selectContactNotificationsBaseQuery + `
+WHERE status IN ('pending', 'failed')
+  AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
+ORDER BY id ASC
+LIMIT ?
+FOR UPDATE SKIP LOCKED`
+)
+
+type contactNotificationRow struct {
+	ID            uint64         `db:"id"`
+	ContactID     int64          `db:"contact_id"`
+	Type          string         `db:"notification_type"`
+	Status        string         `db:"status"`
+	ErrorMessage  sql.NullString `db:"error_message"`
+	Attempts      int            `db:"attempts"`
+	NextAttemptAt sql.NullTime   `db:"next_attempt_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+func (r *contactNotificationRepository) RecordContactNotification(ctx context.Context, notification *model.ContactNotification) (*model.ContactNotification, error) {
+	if notification == nil {
+		return nil, repository.ErrInvalidInput
+	}
+	contactID, err := parseContactID(notification.ContactID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.db.ExecContext(ctx, insertContactNotificationQuery,
+		contactID,
+		strings.TrimSpace(notification.Type),
+		strings.TrimSpace(notification.Status),
+		nullIfEmpty(notification.ErrorMessage),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert contact_notifications: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("contact_notifications last insert id: %w", err)
+	}
+	return r.findByID(ctx, uint64(id))
+}
+
+func (r *contactNotificationRepository) UpdateContactNotificationStatus(ctx context.Context, id uint64, status, errorMessage string) (*model.ContactNotification, error) {
+	const query = `
+UPDATE contact_notifications
+SET status = ?, error_message = ?
+WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, strings.TrimSpace(status), nullIfEmpty(errorMessage), id); err != nil {
+		return nil, fmt.Errorf("update contact_notifications id=%d: %w", id, err)
+	}
+	return r.findByID(ctx, id)
+}
+
+func (r *contactNotificationRepository) ClaimRetryableContactNotifications(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.ContactNotification, error) {
+	if limit <= 0 {
+		return []model.ContactNotification{}, nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	var rows []contactNotificationRow
+	if selectErr := tx.SelectContext(ctx, &rows, selectRetryableContactNotificationsQuery, now.UTC(), limit); selectErr != nil {
+		err = fmt.Errorf("select retryable contact_notifications: %w", selectErr)
+		return nil, err
+	}
+	if len(rows) == 0 {
+		err = tx.Commit()
+		return []model.ContactNotification{}, err
+	}
+
+	leaseUntil := now.Add(lease).UTC()
+	ids := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	update, args, inErr := sqlx.In(`UPDATE contact_notifications SET next_attempt_at = ? WHERE id IN (?)`, leaseUntil, ids)
+	if inErr != nil {
+		err = fmt.Errorf("lease contact_notifications query compose: %w", inErr)
+		return nil, err
+	}
+	if _, execErr := tx.ExecContext(ctx, r.db.Rebind(update), args...); execErr != nil {
+		err = fmt.Errorf("lease contact_notifications: %w", execErr)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit contact_notifications lease: %w", err)
+	}
+
+	notifications := make([]model.ContactNotification, 0, len(rows))
+	for _, row := range rows {
+		notification := mapContactNotificationRow(row)
+		next := leaseUntil
+		notification.NextAttemptAt = &next
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+func (r *contactNotificationRepository) RecordContactNotificationAttempt(ctx context.Context, id uint64, attempt int, status, errorMessage string, nextAttemptAt *time.Time) (*model.ContactNotification, error) {
+	const query = `
+UPDATE contact_notifications
+SET status = ?, error_message = ?, attempts = GREATEST(attempts, ?), next_attempt_at = ?
+WHERE id = ?`
+	var next sql.NullTime
+	if nextAttemptAt != nil {
+		next = sql.NullTime{Time: nextAttemptAt.UTC(), Valid: true}
+	}
+	if _, err := r.db.ExecContext(ctx, query, strings.TrimSpace(status), nullIfEmpty(errorMessage), attempt, next, id); err != nil {
+		return nil, fmt.Errorf("update contact_notifications attempt id=%d: %w", id, err)
+	}
+	return r.findByID(ctx, id)
+}
+
+func (r *contactNotificationRepository) ListContactNotifications(ctx context.Context, contactID string) ([]model.ContactNotification, error) {
+	internalID, err := parseContactID(contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []contactNotificationRow
+	if err := r.db.SelectContext(ctx, &rows, listContactNotificationsQuery, internalID); err != nil {
+		return nil, fmt.Errorf("select contact_notifications contact_id=%d: %w", internalID, err)
+	}
+
+	result := make([]model.ContactNotification, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mapContactNotificationRow(row))
+	}
+	return result, nil
+}
+
+func (r *contactNotificationRepository) findByID(ctx context.Context, id uint64) (*model.ContactNotification, error) {
+	var row contactNotificationRow
+	if err := r.db.GetContext(ctx, &row, selectContactNotificationsBaseQuery+"\nWHERE id = ?", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("select contact_notifications id=%d: %w", id, err)
+	}
+	notification := mapContactNotificationRow(row)
+	return &notification, nil
+}
+
+func mapContactNotificationRow(row contactNotificationRow) model.ContactNotification {
+	notification := model.ContactNotification{
+		ID:           row.ID,
+		ContactID:    strconv.FormatInt(row.ContactID, 10),
+		Type:         strings.TrimSpace(row.Type),
+		Status:       strings.TrimSpace(row.Status),
+		ErrorMessage: strings.TrimSpace(row.ErrorMessage.String),
+		Attempts:     row.Attempts,
+		CreatedAt:    row.CreatedAt.UTC(),
+	}
+	if row.NextAttemptAt.Valid {
+		next := row.NextAttemptAt.Time.UTC()
+		notification.NextAttemptAt = &next
+	}
+	return notification
+}
+
+var _ repository.ContactNotificationRepository = (*contactNotificationRepository)(nil)
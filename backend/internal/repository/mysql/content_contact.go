@@ -38,8 +38,10 @@ SELECT
     support_email,
     calendar_timezone,
     google_calendar_id,
+    conflict_calendar_ids,
     booking_window_days,
     meeting_url_template,
+    working_hours,
     created_at,
     updated_at
 FROM contact_form_settings`
@@ -62,8 +64,10 @@ UPDATE contact_form_settings SET
     support_email = ?,
     calendar_timezone = ?,
     google_calendar_id = ?,
+    conflict_calendar_ids = ?,
     booking_window_days = ?,
-    meeting_url_template = ?
+    meeting_url_template = ?,
+    working_hours = ?
 WHERE id = ? AND updated_at = ?`
 
 type contactSettingsRow struct {
@@ -80,16 +84,20 @@ type contactSettingsRow struct {
 	SupportEmail      sql.NullString `db:"support_email"`
 	CalendarTimezone  sql.NullString `db:"calendar_timezone"`
 	CalendarID        sql.NullString `db:"google_calendar_id"`
+	ConflictIDsJSON   []byte         `db:"conflict_calendar_ids"`
 	BookingWindowDays int            `db:"booking_window_days"`
 	MeetingTemplate   sql.NullString `db:"meeting_url_template"`
+	WorkingHoursJSON  []byte         `db:"working_hours"`
 	CreatedAt         sql.NullTime   `db:"created_at"`
 	UpdatedAt         sql.NullTime   `db:"updated_at"`
 }
 
 type contactTopicRow struct {
-	ID          string        `json:"id"`
-	Label       localizedJSON `json:"label"`
-	Description localizedJSON `json:"description"`
+	ID               string                 `json:"id"`
+	Label            localizedJSON          `json:"label"`
+	Description      localizedJSON          `json:"description"`
+	RequiresApproval bool                   `json:"requiresApproval,omitempty"`
+	Questions        []model.IntakeQuestion `json:"questions,omitempty"`
 }
 
 type localizedJSON struct {
@@ -111,19 +119,31 @@ func (r *contactFormSettingsRepository) GetContactFormSettings(ctx context.Conte
 		return nil, fmt.Errorf("decode contact topics: %w", err)
 	}
 
+	workingHours, err := decodeWorkingHours(row.WorkingHoursJSON)
+	if err != nil {
+		return nil, fmt.Errorf("decode working hours: %w", err)
+	}
+
+	conflictCalendarIDs, err := decodeConflictCalendarIDs(row.ConflictIDsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("decode conflict calendar ids: %w", err)
+	}
+
 	settings := &model.ContactFormSettingsV2{
-		ID:                 row.ID,
-		HeroTitle:          toLocalizedText(row.HeroTitleJA, row.HeroTitleEN),
-		HeroDescription:    toLocalizedText(row.HeroDescriptionJA, row.HeroDescriptionEN),
-		Topics:             topics,
-		ConsentText:        toLocalizedText(row.ConsentJA, row.ConsentEN),
-		MinimumLeadHours:   row.MinimumLeadHours,
-		RecaptchaSiteKey:   strings.TrimSpace(row.RecaptchaKey.String),
-		SupportEmail:       strings.TrimSpace(row.SupportEmail.String),
-		CalendarTimezone:   strings.TrimSpace(row.CalendarTimezone.String),
-		GoogleCalendarID:   strings.TrimSpace(row.CalendarID.String),
-		BookingWindowDays:  row.BookingWindowDays,
-		MeetingURLTemplate: strings.TrimSpace(row.MeetingTemplate.String),
+		ID:                  row.ID,
+		HeroTitle:           toLocalizedText(row.HeroTitleJA, row.HeroTitleEN),
+		HeroDescription:     toLocalizedText(row.HeroDescriptionJA, row.HeroDescriptionEN),
+		Topics:              topics,
+		ConsentText:         toLocalizedText(row.ConsentJA, row.ConsentEN),
+		MinimumLeadHours:    row.MinimumLeadHours,
+		RecaptchaSiteKey:    strings.TrimSpace(row.RecaptchaKey.String),
+		SupportEmail:        strings.TrimSpace(row.SupportEmail.String),
+		CalendarTimezone:    strings.TrimSpace(row.CalendarTimezone.String),
+		GoogleCalendarID:    strings.TrimSpace(row.CalendarID.String),
+		ConflictCalendarIDs: conflictCalendarIDs,
+		BookingWindowDays:   row.BookingWindowDays,
+		MeetingURLTemplate:  strings.TrimSpace(row.MeetingTemplate.String),
+		WorkingHours:        workingHours,
 	}
 
 	if row.CreatedAt.Valid {
@@ -152,6 +172,16 @@ func (r *contactFormSettingsRepository) UpdateContactFormSettings(ctx context.Co
 		return nil, fmt.Errorf("encode contact topics: %w", err)
 	}
 
+	workingHoursJSON, err := json.Marshal(settings.WorkingHours)
+	if err != nil {
+		return nil, fmt.Errorf("encode working hours: %w", err)
+	}
+
+	conflictIDsJSON, err := json.Marshal(settings.ConflictCalendarIDs)
+	if err != nil {
+		return nil, fmt.Errorf("encode conflict calendar ids: %w", err)
+	}
+
 	args := []any{
 		strings.TrimSpace(settings.HeroTitle.Ja),
 		strings.TrimSpace(settings.HeroTitle.En),
@@ -165,8 +195,10 @@ func (r *contactFormSettingsRepository) UpdateContactFormSettings(ctx context.Co
 		strings.TrimSpace(settings.SupportEmail),
 		strings.TrimSpace(settings.CalendarTimezone),
 		strings.TrimSpace(settings.GoogleCalendarID),
+		conflictIDsJSON,
 		settings.BookingWindowDays,
 		strings.TrimSpace(settings.MeetingURLTemplate),
+		workingHoursJSON,
 		settings.ID,
 		expectedUpdatedAt.UTC(),
 	}
@@ -224,6 +256,8 @@ func decodeContactTopics(payload []byte) ([]model.ContactTopicV2, error) {
 				Ja: strings.TrimSpace(row.Description.Ja),
 				En: strings.TrimSpace(row.Description.En),
 			},
+			RequiresApproval: row.RequiresApproval,
+			Questions:        row.Questions,
 		})
 	}
 
@@ -247,8 +281,35 @@ func encodeContactTopics(topics []model.ContactTopicV2) ([]byte, error) {
 				Ja: strings.TrimSpace(topic.Description.Ja),
 				En: strings.TrimSpace(topic.Description.En),
 			},
+			RequiresApproval: topic.RequiresApproval,
+			Questions:        topic.Questions,
 		})
 	}
 
 	return json.Marshal(rows)
 }
+
+func decodeWorkingHours(payload []byte) (model.WorkingHours, error) {
+	var hours model.WorkingHours
+	if len(payload) == 0 {
+		return hours, nil
+	}
+	if err := json.Unmarshal(payload, &hours); err != nil {
+		return model.WorkingHours{}, err
+	}
+	return hours, nil
+}
+
+func decodeConflictCalendarIDs(payload []byte) ([]string, error) {
+	ids := []string{}
+	if len(payload) == 0 {
+		return ids, nil
+	}
+	if err := json.Unmarshal(payload, &ids); err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	return ids, nil
+}
@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type idempotencyRepository struct {
+	db *sqlx.DB
+}
+
+// NewIdempotencyRepository returns a MySQL-backed Idempotency-Key store.
+func NewIdempotencyRepository(db *sqlx.DB) repository.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+type idempotencyRow struct {
+	Key          string         `db:"idempotency_key"`
+	Fingerprint  string         `db:"fingerprint"`
+	StatusCode   int            `db:"status_code"`
+	ContentType  sql.NullString `db:"content_type"`
+	ResponseBody []byte         `db:"response_body"`
+	CreatedAt    time.Time      `db:"created_at"`
+	ExpiresAt    time.Time      `db:"expires_at"`
+}
+
+const insertIdempotencyRecordQuery = `
+INSERT INTO idempotency_keys (
+	idempotency_key,
+	fingerprint,
+	status_code,
+	created_at,
+	expires_at
+) VALUES (?, ?, 0, ?, ?)`
+
+const selectIdempotencyRecordQuery = `
+SELECT
+	idempotency_key,
+	fingerprint,
+	status_code,
+	content_type,
+	response_body,
+	created_at,
+	expires_at
+FROM idempotency_keys
+WHERE idempotency_key = ? AND expires_at > ?`
+
+func (r *idempotencyRepository) CreateIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil || strings.TrimSpace(record.Key) == "" {
+		return repository.ErrInvalidInput
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	if _, execErr := tx.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE idempotency_key = ? AND expires_at <= ?`, record.Key, record.CreatedAt.UTC()); execErr != nil {
+		err = fmt.Errorf("delete expired idempotency_keys: %w", execErr)
+		return err
+	}
+	if _, execErr := tx.ExecContext(ctx, insertIdempotencyRecordQuery, record.Key, record.Fingerprint, record.CreatedAt.UTC(), record.ExpiresAt.UTC()); execErr != nil {
+		var mysqlErr *mysqlerr.MySQLError
+		if errors.As(execErr, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			err = repository.ErrConflict
+			return err
+		}
+		err = fmt.Errorf("insert idempotency_keys: %w", execErr)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit idempotency_keys: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) FindIdempotencyRecord(ctx context.Context, key string, now time.Time) (*model.IdempotencyRecord, error) {
+	var row idempotencyRow
+	if err := r.db.GetContext(ctx, &row, selectIdempotencyRecordQuery, key, now.UTC()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("select idempotency_keys: %w", err)
+	}
+	return &model.IdempotencyRecord{
+		Key:         row.Key,
+		Fingerprint: row.Fingerprint,
+		StatusCode:  row.StatusCode,
+		ContentType: row.ContentType.String,
+		Body:        row.ResponseBody,
+		CreatedAt:   row.CreatedAt.UTC(),
+		ExpiresAt:   row.ExpiresAt.UTC(),
+	}, nil
+}
+
+func (r *idempotencyRepository) CompleteIdempotencyRecord(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil {
+		return repository.ErrInvalidInput
+	}
+	const query = `
+UPDATE idempotency_keys
+SET status_code = ?, content_type = ?, response_body = ?, expires_at = ?
+WHERE idempotency_key = ?`
+	res, err := r.db.ExecContext(ctx, query, record.StatusCode, nullIfEmpty(record.ContentType), record.Body, record.ExpiresAt.UTC(), record.Key)
+	if err != nil {
+		return fmt.Errorf("update idempotency_keys: %w", err)
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteIdempotencyRecord(ctx context.Context, key string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE idempotency_key = ?`, key); err != nil {
+		return fmt.Errorf("delete idempotency_keys: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency_keys: %w", err)
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency_keys rows affected: %w", err)
+	}
+	return removed, nil
+}
+
+var _ repository.IdempotencyRepository = (*idempotencyRepository)(nil)
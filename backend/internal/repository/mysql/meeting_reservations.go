@@ -3,10 +3,12 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	mysqlerr "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/takumi/personal-website/internal/model"
@@ -28,12 +30,16 @@ type reservationRow struct {
 	Email                  sql.NullString `db:"email"`
 	Topic                  sql.NullString `db:"topic"`
 	Message                sql.NullString `db:"message"`
+	Locale                 sql.NullString `db:"locale"`
+	VisitorTimezone        sql.NullString `db:"visitor_timezone"`
+	IntakeAnswersJSON      []byte         `db:"intake_answers"`
 	StartAt                time.Time      `db:"start_at"`
 	EndAt                  time.Time      `db:"end_at"`
 	DurationMinutes        int            `db:"duration_minutes"`
 	GoogleEventID          sql.NullString `db:"google_event_id"`
 	GoogleCalendarStatus   sql.NullString `db:"google_calendar_status"`
 	Status                 string         `db:"status"`
+	RequiresApproval       bool           `db:"requires_approval"`
 	ConfirmationSentAt     sql.NullTime   `db:"confirmation_sent_at"`
 	LastNotificationSentAt sql.NullTime   `db:"last_notification_sent_at"`
 	LookupHash             string         `db:"lookup_hash"`
@@ -42,34 +48,61 @@ type reservationRow struct {
 	UpdatedAt              time.Time      `db:"updated_at"`
 }
 
+type statusHistoryRow struct {
+	ID            uint64         `db:"id"`
+	ReservationID uint64         `db:"reservation_id"`
+	FromStatus    sql.NullString `db:"from_status"`
+	ToStatus      string         `db:"to_status"`
+	Actor         string         `db:"actor"`
+	Reason        sql.NullString `db:"reason"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
 type notificationRow struct {
 	ID            uint64         `db:"id"`
 	ReservationID uint64         `db:"reservation_id"`
 	Type          string         `db:"notification_type"`
 	Status        string         `db:"status"`
 	ErrorMessage  sql.NullString `db:"error_message"`
+	DedupeKey     sql.NullString `db:"dedupe_key"`
+	Attempts      int            `db:"attempts"`
+	NextAttemptAt sql.NullTime   `db:"next_attempt_at"`
 	CreatedAt     time.Time      `db:"created_at"`
 }
 
+type notificationAttemptRow struct {
+	ID             uint64         `db:"id"`
+	NotificationID uint64         `db:"notification_id"`
+	ReservationID  uint64         `db:"reservation_id"`
+	Attempt        int            `db:"attempt"`
+	Status         string         `db:"status"`
+	ErrorMessage   sql.NullString `db:"error_message"`
+	AttemptedAt    time.Time      `db:"attempted_at"`
+}
+
 const insertReservationQuery = `
 INSERT INTO meeting_reservations (
 	name,
 	email,
 	topic,
 	message,
+	locale,
+	visitor_timezone,
+	intake_answers,
 	start_at,
 	end_at,
 	duration_minutes,
 	google_event_id,
 	google_calendar_status,
 	status,
+	requires_approval,
 	confirmation_sent_at,
 	last_notification_sent_at,
 	lookup_hash,
 	cancellation_reason,
 	created_at,
 	updated_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(3), NOW(3))`
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(3), NOW(3))`
 
 const selectByLookupQuery = `
 SELECT
@@ -78,12 +111,16 @@ SELECT
 	email,
 	topic,
 	message,
+	locale,
+	visitor_timezone,
+	intake_answers,
 	start_at,
 	end_at,
 	duration_minutes,
 	google_event_id,
 	google_calendar_status,
 	status,
+	requires_approval,
 	confirmation_sent_at,
 	last_notification_sent_at,
 	lookup_hash,
@@ -101,12 +138,16 @@ SELECT
 	email,
 	topic,
 	message,
+	locale,
+	visitor_timezone,
+	intake_answers,
 	start_at,
 	end_at,
 	duration_minutes,
 	google_event_id,
 	google_calendar_status,
 	status,
+	requires_approval,
 	confirmation_sent_at,
 	last_notification_sent_at,
 	lookup_hash,
@@ -114,7 +155,7 @@ SELECT
 	created_at,
 	updated_at
 FROM meeting_reservations
-WHERE status IN ('pending','confirmed')
+WHERE status IN ('requested','confirmed','rescheduled')
   AND start_at < ?
   AND end_at > ?
 ORDER BY start_at ASC`
@@ -126,12 +167,16 @@ SELECT
 	email,
 	topic,
 	message,
+	locale,
+	visitor_timezone,
+	intake_answers,
 	start_at,
 	end_at,
 	duration_minutes,
 	google_event_id,
 	google_calendar_status,
 	status,
+	requires_approval,
 	confirmation_sent_at,
 	last_notification_sent_at,
 	lookup_hash,
@@ -143,20 +188,44 @@ FROM meeting_reservations`
 const markConfirmationQuery = `
 UPDATE meeting_reservations
 SET
-	status = CASE WHEN status = 'cancelled' THEN status ELSE 'confirmed' END,
 	confirmation_sent_at = ?,
 	last_notification_sent_at = ?,
 	updated_at = NOW(3)
 WHERE id = ?`
 
-const cancelReservationQuery = `
+const rescheduleReservationQuery = `
 UPDATE meeting_reservations
 SET
-	status = 'cancelled',
-	cancellation_reason = ?,
-	google_calendar_status = 'cancelled',
+	start_at = ?,
+	end_at = ?,
+	duration_minutes = ?,
+	google_event_id = COALESCE(NULLIF(?, ''), google_event_id),
 	updated_at = NOW(3)
-WHERE id = ?`
+WHERE id = ?
+  AND status IN ('requested','confirmed','rescheduled')`
+
+const insertStatusHistoryQuery = `
+INSERT INTO meeting_reservation_status_history (
+	reservation_id,
+	from_status,
+	to_status,
+	actor,
+	reason,
+	created_at
+) VALUES (?, ?, ?, ?, ?, NOW(3))`
+
+const listStatusHistoryQuery = `
+SELECT
+	id,
+	reservation_id,
+	from_status,
+	to_status,
+	actor,
+	reason,
+	created_at
+FROM meeting_reservation_status_history
+WHERE reservation_id = ?
+ORDER BY id ASC`
 
 const insertNotificationQuery = `
 INSERT INTO meeting_notifications (
@@ -164,47 +233,166 @@ INSERT INTO meeting_notifications (
 	notification_type,
 	status,
 	error_message,
+	dedupe_key,
 	created_at
-) VALUES (?, ?, ?, ?, NOW(3))`
+) VALUES (?, ?, ?, ?, ?, NOW(3))`
 
-const listNotificationsQuery = `
+const selectNotificationsBaseQuery = `
 SELECT
 	id,
 	reservation_id,
 	notification_type,
 	status,
 	error_message,
+	dedupe_key,
+	attempts,
+	next_attempt_at,
 	created_at
-FROM meeting_notifications
+FROM meeting_notifications`
+
+const listNotificationsQuery = selectNotificationsBaseQuery + `
 WHERE reservation_id = ?
 ORDER BY created_at ASC, id ASC`
 
+// selectRetryableNotificationsQuery expects the notification types to be expanded with sqlx.In.
+const selectRetryableNotificationsQuery = selectNotificationsBaseQuery + `
+WHERE notification_type IN (?)
+  AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
+  AND (status = 'failed' OR (status = 'pending' AND created_at <= ?))
+ORDER BY id ASC
+LIMIT ?
+FOR UPDATE SKIP LOCKED`
+
+const insertNotificationAttemptQuery = `
+INSERT INTO meeting_notification_attempts (
+	notification_id,
+	reservation_id,
+	attempt,
+	status,
+	error_message,
+	attempted_at
+)
+SELECT id, reservation_id, ?, ?, ?, ?
+FROM meeting_notifications
+WHERE id = ?`
+
+const listNotificationAttemptsQuery = `
+SELECT
+	id,
+	notification_id,
+	reservation_id,
+	attempt,
+	status,
+	error_message,
+	attempted_at
+FROM meeting_notification_attempts
+WHERE reservation_id = ?
+ORDER BY id ASC`
+
+// mysqlDuplicateEntry is the server error raised when a unique key rejects an insert.
+const mysqlDuplicateEntry = 1062
+
 func (r *meetingReservationRepository) CreateReservation(ctx context.Context, reservation *model.MeetingReservation) (*model.MeetingReservation, error) {
 	if reservation == nil {
 		return nil, repository.ErrInvalidInput
 	}
 
-	_, err := r.db.ExecContext(ctx, insertReservationQuery,
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	reservationID, err := insertReservationTx(ctx, tx, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit meeting_reservations insert: %w", err)
+	}
+
+	return r.findByID(ctx, uint64(reservationID))
+}
+
+// insertReservationTx claims the reservation's slot and inserts it inside tx. Active
+// reservations overlapping the slot make it fail with repository.ErrConflict.
+func insertReservationTx(ctx context.Context, tx *sqlx.Tx, reservation *model.MeetingReservation) (int64, error) {
+	start := reservation.StartAt.UTC()
+	end := reservation.EndAt.UTC()
+	if !end.After(start) {
+		return 0, repository.ErrInvalidInput
+	}
+	intakeAnswers, err := encodeIntakeAnswers(reservation.IntakeAnswers)
+	if err != nil {
+		return 0, fmt.Errorf("encode intake answers: %w", err)
+	}
+
+	if reservation.Status.IsActive() {
+		if err := lockReservationDaysTx(ctx, tx, start, end); err != nil {
+			return 0, err
+		}
+
+		var conflicts []reservationRow
+		if err := tx.SelectContext(ctx, &conflicts, conflictReservationsQuery+"\nFOR UPDATE", end, start); err != nil {
+			return 0, fmt.Errorf("select conflicting reservations: %w", err)
+		}
+		if len(conflicts) > 0 {
+			return 0, repository.ErrConflict
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, insertReservationQuery,
 		strings.TrimSpace(reservation.Name),
 		strings.ToLower(strings.TrimSpace(reservation.Email)),
 		strings.TrimSpace(reservation.Topic),
 		strings.TrimSpace(reservation.Message),
-		reservation.StartAt.UTC(),
-		reservation.EndAt.UTC(),
+		nullIfEmpty(reservation.Locale),
+		nullIfEmpty(reservation.VisitorTimezone),
+		intakeAnswers,
+		start,
+		end,
 		reservation.DurationMinutes,
 		strings.TrimSpace(reservation.GoogleEventID),
 		strings.TrimSpace(reservation.GoogleCalendarStatus),
 		string(reservation.Status),
+		reservation.RequiresApproval,
 		sql.NullTime{Time: timePtrValue(reservation.ConfirmationSentAt), Valid: reservation.ConfirmationSentAt != nil},
 		sql.NullTime{Time: timePtrValue(reservation.LastNotificationSentAt), Valid: reservation.LastNotificationSentAt != nil},
 		strings.TrimSpace(reservation.LookupHash),
 		strings.TrimSpace(reservation.CancellationReason),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("insert meeting_reservations: %w", err)
+		return 0, fmt.Errorf("insert meeting_reservations: %w", err)
 	}
 
-	return r.FindReservationByLookupHash(ctx, reservation.LookupHash)
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("meeting_reservations last insert id: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, insertStatusHistoryQuery, id, nil, string(reservation.Status), model.ReservationActorVisitor, nil); err != nil {
+		return 0, fmt.Errorf("insert meeting_reservation_status_history: %w", err)
+	}
+	return id, nil
+}
+
+// lockReservationDaysTx takes an exclusive lock on one booking_slot_locks row per UTC day the
+// range touches. Overlapping ranges always share a day, so concurrent claims for the same slot
+// serialise here; locking days in ascending order keeps multi-day claims deadlock-free.
+func lockReservationDaysTx(ctx context.Context, tx *sqlx.Tx, start, end time.Time) error {
+	const lockDayQuery = `
+INSERT INTO booking_slot_locks (slot_date, locked_at)
+VALUES (?, NOW(3))
+ON DUPLICATE KEY UPDATE locked_at = NOW(3)`
+
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	for day.Before(end) {
+		if _, err := tx.ExecContext(ctx, lockDayQuery, day.Format("2006-01-02")); err != nil {
+			return fmt.Errorf("lock booking_slot_locks day=%s: %w", day.Format("2006-01-02"), err)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return nil
 }
 
 func (r *meetingReservationRepository) FindReservationByLookupHash(ctx context.Context, lookupHash string) (*model.MeetingReservation, error) {
@@ -263,11 +451,28 @@ func (r *meetingReservationRepository) ListReservations(ctx context.Context, fil
 		conditions = append(conditions, "start_at >= ? AND start_at < ?")
 		args = append(args, start, end)
 	}
+	if filter.StartFrom != nil {
+		conditions = append(conditions, "start_at >= ?")
+		args = append(args, filter.StartFrom.UTC())
+	}
+	if filter.StartBefore != nil {
+		conditions = append(conditions, "start_at < ?")
+		args = append(args, filter.StartBefore.UTC())
+	}
+	if filter.After != nil {
+		after := filter.After.StartAt.UTC()
+		conditions = append(conditions, "(start_at < ? OR (start_at = ? AND id < ?))")
+		args = append(args, after, after, filter.After.ID)
+	}
 
 	if len(conditions) > 0 {
 		query = query + "\nWHERE " + strings.Join(conditions, " AND ")
 	}
 	query += "\nORDER BY start_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += "\nLIMIT ?"
+		args = append(args, filter.Limit)
+	}
 
 	var rows []reservationRow
 	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
@@ -307,76 +512,169 @@ func (r *meetingReservationRepository) MarkConfirmationSent(ctx context.Context,
 	return r.findByID(ctx, id)
 }
 
-func (r *meetingReservationRepository) CancelReservation(ctx context.Context, id uint64, reason string) (*model.MeetingReservation, error) {
-	if id == 0 {
+// RescheduleReservation claims the new slot the same way insertReservationTx does: the days it
+// touches are locked, then overlapping active reservations other than this one are selected for
+// update. A taken slot or a reservation that is no longer active yields repository.ErrConflict.
+func (r *meetingReservationRepository) RescheduleReservation(ctx context.Context, id uint64, start, end time.Time, googleEventID string) (*model.MeetingReservation, error) {
+	if id == 0 || !end.After(start) {
 		return nil, repository.ErrInvalidInput
 	}
+	start = start.UTC()
+	end = end.UTC()
 
-	_, err := r.db.ExecContext(ctx, cancelReservationQuery, strings.TrimSpace(reason), id)
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("cancel meeting_reservations id=%d: %w", id, err)
+		return nil, fmt.Errorf("begin tx: %w", err)
 	}
+	defer rollbackOnError(tx, &err)
 
+	if err = lockReservationDaysTx(ctx, tx, start, end); err != nil {
+		return nil, err
+	}
+
+	var current string
+	err = tx.GetContext(ctx, &current, "SELECT status FROM meeting_reservations WHERE id = ? FOR UPDATE", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrNotFound
+			return nil, err
+		}
+		return nil, fmt.Errorf("lock meeting_reservations id=%d: %w", id, err)
+	}
+	if !model.MeetingReservationStatus(current).IsActive() {
+		err = repository.ErrConflict
+		return nil, err
+	}
+
+	var conflicts []reservationRow
+	if err = tx.SelectContext(ctx, &conflicts, conflictReservationsQuery+"\nFOR UPDATE", end, start); err != nil {
+		return nil, fmt.Errorf("select conflicting reservations: %w", err)
+	}
+	for _, conflict := range conflicts {
+		if conflict.ID != id {
+			err = repository.ErrConflict
+			return nil, err
+		}
+	}
+
+	durationMinutes := int(end.Sub(start) / time.Minute)
+	if _, err = tx.ExecContext(ctx, rescheduleReservationQuery,
+		start,
+		end,
+		durationMinutes,
+		strings.TrimSpace(googleEventID),
+		id,
+	); err != nil {
+		return nil, fmt.Errorf("reschedule meeting_reservations id=%d: %w", id, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit meeting_reservations reschedule id=%d: %w", id, err)
+	}
 	return r.findByID(ctx, id)
 }
 
-func (r *meetingReservationRepository) UpdateReservationStatus(ctx context.Context, id uint64, status model.MeetingReservationStatus, reason string) (*model.MeetingReservation, error) {
-	if id == 0 {
+func (r *meetingReservationRepository) TransitionReservationStatus(ctx context.Context, change *model.MeetingReservationStatusChange) (*model.MeetingReservation, error) {
+	if change == nil || change.ReservationID == 0 || !change.ToStatus.Valid() {
 		return nil, repository.ErrInvalidInput
 	}
 
-	statusValue := strings.TrimSpace(string(status))
-	if statusValue == "" {
-		return nil, repository.ErrInvalidInput
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
 	}
+	defer rollbackOnError(tx, &err)
 
+	var current string
+	err = tx.GetContext(ctx, &current, "SELECT status FROM meeting_reservations WHERE id = ? FOR UPDATE", change.ReservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrNotFound
+			return nil, err
+		}
+		return nil, fmt.Errorf("lock meeting_reservations id=%d: %w", change.ReservationID, err)
+	}
+	if model.MeetingReservationStatus(current) != change.FromStatus {
+		err = repository.ErrConflict
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(change.Reason)
 	var (
 		query string
 		args  []any
 	)
-
-	switch status {
-	case model.MeetingReservationStatusCancelled:
+	switch {
+	case change.ToStatus.IsCancelled():
 		query = `
 UPDATE meeting_reservations
 SET
-	status = 'cancelled',
+	status = ?,
 	cancellation_reason = ?,
 	google_calendar_status = 'cancelled',
 	updated_at = NOW(3)
 WHERE id = ?`
-		args = []any{strings.TrimSpace(reason), id}
-	case model.MeetingReservationStatusConfirmed:
+		args = []any{string(change.ToStatus), reason, change.ReservationID}
+	case change.ToStatus == model.MeetingReservationStatusConfirmed:
 		query = `
 UPDATE meeting_reservations
 SET
-	status = 'confirmed',
-	cancellation_reason = NULL,
+	status = ?,
 	google_calendar_status = CASE
 		WHEN google_calendar_status IS NULL OR google_calendar_status = '' THEN 'confirmed'
 		ELSE google_calendar_status
 	END,
 	updated_at = NOW(3)
 WHERE id = ?`
-		args = []any{id}
-	case model.MeetingReservationStatusPending:
+		args = []any{string(change.ToStatus), change.ReservationID}
+	default:
 		query = `
 UPDATE meeting_reservations
 SET
-	status = 'pending',
-	cancellation_reason = NULL,
+	status = ?,
 	updated_at = NOW(3)
 WHERE id = ?`
-		args = []any{id}
-	default:
-		return nil, repository.ErrInvalidInput
+		args = []any{string(change.ToStatus), change.ReservationID}
+	}
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("update meeting_reservations status id=%d: %w", change.ReservationID, err)
 	}
 
-	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
-		return nil, fmt.Errorf("update meeting_reservations status id=%d: %w", id, err)
+	if _, err = tx.ExecContext(ctx, insertStatusHistoryQuery,
+		change.ReservationID,
+		nullIfEmpty(string(change.FromStatus)),
+		string(change.ToStatus),
+		strings.TrimSpace(change.Actor),
+		nullIfEmpty(reason),
+	); err != nil {
+		return nil, fmt.Errorf("insert meeting_reservation_status_history: %w", err)
 	}
 
-	return r.findByID(ctx, id)
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit meeting_reservations status id=%d: %w", change.ReservationID, err)
+	}
+	return r.findByID(ctx, change.ReservationID)
+}
+
+func (r *meetingReservationRepository) ListStatusHistory(ctx context.Context, reservationID uint64) ([]model.MeetingReservationStatusChange, error) {
+	var rows []statusHistoryRow
+	if err := r.db.SelectContext(ctx, &rows, listStatusHistoryQuery, reservationID); err != nil {
+		return nil, fmt.Errorf("select meeting_reservation_status_history reservation_id=%d: %w", reservationID, err)
+	}
+
+	history := make([]model.MeetingReservationStatusChange, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, model.MeetingReservationStatusChange{
+			ID:            row.ID,
+			ReservationID: row.ReservationID,
+			FromStatus:    model.MeetingReservationStatus(strings.TrimSpace(row.FromStatus.String)),
+			ToStatus:      model.MeetingReservationStatus(strings.TrimSpace(row.ToStatus)),
+			Actor:         strings.TrimSpace(row.Actor),
+			Reason:        strings.TrimSpace(row.Reason.String),
+			CreatedAt:     row.CreatedAt.UTC(),
+		})
+	}
+	return history, nil
 }
 
 func (r *meetingReservationRepository) findByID(ctx context.Context, id uint64) (*model.MeetingReservation, error) {
@@ -387,12 +685,16 @@ SELECT
 	email,
 	topic,
 	message,
+	locale,
+	visitor_timezone,
+	intake_answers,
 	start_at,
 	end_at,
 	duration_minutes,
 	google_event_id,
 	google_calendar_status,
 	status,
+	requires_approval,
 	confirmation_sent_at,
 	last_notification_sent_at,
 	lookup_hash,
@@ -435,12 +737,16 @@ func mapReservationRow(row reservationRow) model.MeetingReservation {
 		Email:                  strings.TrimSpace(row.Email.String),
 		Topic:                  strings.TrimSpace(row.Topic.String),
 		Message:                strings.TrimSpace(row.Message.String),
+		Locale:                 strings.TrimSpace(row.Locale.String),
+		VisitorTimezone:        strings.TrimSpace(row.VisitorTimezone.String),
+		IntakeAnswers:          decodeIntakeAnswers(row.IntakeAnswersJSON),
 		StartAt:                row.StartAt.UTC(),
 		EndAt:                  row.EndAt.UTC(),
 		DurationMinutes:        row.DurationMinutes,
 		GoogleEventID:          strings.TrimSpace(row.GoogleEventID.String),
 		GoogleCalendarStatus:   strings.TrimSpace(row.GoogleCalendarStatus.String),
 		Status:                 model.MeetingReservationStatus(strings.TrimSpace(row.Status)),
+		RequiresApproval:       row.RequiresApproval,
 		ConfirmationSentAt:     confirmationSentAt,
 		LastNotificationSentAt: lastNotificationSentAt,
 		CancellationReason:     strings.TrimSpace(row.CancellationReason.String),
@@ -469,25 +775,25 @@ func (r *meetingNotificationRepository) RecordNotification(ctx context.Context,
 	if notification == nil {
 		return nil, repository.ErrInvalidInput
 	}
+	return r.insert(ctx, notification, strings.TrimSpace(notification.Status))
+}
 
-	_, err := r.db.ExecContext(ctx, insertNotificationQuery,
-		notification.ReservationID,
-		strings.TrimSpace(notification.Type),
-		strings.TrimSpace(notification.Status),
-		nullIfEmpty(notification.ErrorMessage),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("insert meeting_notifications: %w", err)
+func (r *meetingNotificationRepository) ClaimNotification(ctx context.Context, notification *model.MeetingNotification) (*model.MeetingNotification, error) {
+	if notification == nil || strings.TrimSpace(notification.DedupeKey) == "" {
+		return nil, repository.ErrInvalidInput
 	}
+	return r.insert(ctx, notification, "pending")
+}
 
-	rows, err := r.ListNotifications(ctx, notification.ReservationID)
-	if err != nil {
-		return nil, err
-	}
-	if len(rows) == 0 {
-		return nil, repository.ErrNotFound
+func (r *meetingNotificationRepository) UpdateNotificationStatus(ctx context.Context, id uint64, status, errorMessage string) (*model.MeetingNotification, error) {
+	const query = `
+UPDATE meeting_notifications
+SET status = ?, error_message = ?
+WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, strings.TrimSpace(status), nullIfEmpty(errorMessage), id); err != nil {
+		return nil, fmt.Errorf("update meeting_notifications id=%d: %w", id, err)
 	}
-	return &rows[len(rows)-1], nil
+	return r.findByID(ctx, id)
 }
 
 func (r *meetingNotificationRepository) ListNotifications(ctx context.Context, reservationID uint64) ([]model.MeetingNotification, error) {
@@ -498,18 +804,192 @@ func (r *meetingNotificationRepository) ListNotifications(ctx context.Context, r
 
 	result := make([]model.MeetingNotification, 0, len(rows))
 	for _, row := range rows {
-		result = append(result, model.MeetingNotification{
-			ID:            row.ID,
-			ReservationID: row.ReservationID,
-			Type:          strings.TrimSpace(row.Type),
-			Status:        strings.TrimSpace(row.Status),
-			ErrorMessage:  strings.TrimSpace(row.ErrorMessage.String),
-			CreatedAt:     row.CreatedAt.UTC(),
+		result = append(result, mapNotificationRow(row))
+	}
+	return result, nil
+}
+
+func (r *meetingNotificationRepository) ClaimRetryableNotifications(ctx context.Context, types []string, now, pendingBefore time.Time, limit int, lease time.Duration) ([]model.MeetingNotification, error) {
+	if limit <= 0 || len(types) == 0 {
+		return []model.MeetingNotification{}, nil
+	}
+
+	query, args, err := sqlx.In(selectRetryableNotificationsQuery, types, now.UTC(), pendingBefore.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("retryable notifications query compose: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	var rows []notificationRow
+	if selectErr := tx.SelectContext(ctx, &rows, query, args...); selectErr != nil {
+		err = fmt.Errorf("select retryable meeting_notifications: %w", selectErr)
+		return nil, err
+	}
+	if len(rows) == 0 {
+		err = tx.Commit()
+		return []model.MeetingNotification{}, err
+	}
+
+	leaseUntil := now.Add(lease).UTC()
+	ids := make([]any, 0, len(rows)+1)
+	ids = append(ids, leaseUntil)
+	placeholders := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+		placeholders = append(placeholders, "?")
+	}
+	update := `UPDATE meeting_notifications SET next_attempt_at = ? WHERE id IN (` + strings.Join(placeholders, ",") + `)`
+	if _, execErr := tx.ExecContext(ctx, update, ids...); execErr != nil {
+		err = fmt.Errorf("lease meeting_notifications: %w", execErr)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit meeting_notifications lease: %w", err)
+	}
+
+	notifications := make([]model.MeetingNotification, 0, len(rows))
+	for _, row := range rows {
+		notification := mapNotificationRow(row)
+		next := leaseUntil
+		notification.NextAttemptAt = &next
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+func (r *meetingNotificationRepository) RecordNotificationAttempt(ctx context.Context, attempt *model.MeetingNotificationAttempt, status string, nextAttemptAt *time.Time) (*model.MeetingNotification, error) {
+	if attempt == nil {
+		return nil, repository.ErrInvalidInput
+	}
+	attemptedAt := attempt.AttemptedAt
+	if attemptedAt.IsZero() {
+		attemptedAt = time.Now()
+	}
+	var next sql.NullTime
+	if nextAttemptAt != nil {
+		next = sql.NullTime{Time: nextAttemptAt.UTC(), Valid: true}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackOnError(tx, &err)
+
+	res, execErr := tx.ExecContext(ctx, insertNotificationAttemptQuery,
+		attempt.Attempt,
+		strings.TrimSpace(attempt.Status),
+		nullIfEmpty(attempt.ErrorMessage),
+		attemptedAt.UTC(),
+		attempt.NotificationID,
+	)
+	if execErr != nil {
+		err = fmt.Errorf("insert meeting_notification_attempts notification_id=%d: %w", attempt.NotificationID, execErr)
+		return nil, err
+	}
+	if affected, affErr := res.RowsAffected(); affErr == nil && affected == 0 {
+		err = repository.ErrNotFound
+		return nil, err
+	}
+
+	const update = `
+UPDATE meeting_notifications
+SET status = ?, error_message = ?, attempts = GREATEST(attempts, ?), next_attempt_at = ?
+WHERE id = ?`
+	if _, execErr = tx.ExecContext(ctx, update,
+		strings.TrimSpace(status),
+		nullIfEmpty(attempt.ErrorMessage),
+		attempt.Attempt,
+		next,
+		attempt.NotificationID,
+	); execErr != nil {
+		err = fmt.Errorf("update meeting_notifications id=%d: %w", attempt.NotificationID, execErr)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit meeting_notification_attempts: %w", err)
+	}
+	return r.findByID(ctx, attempt.NotificationID)
+}
+
+func (r *meetingNotificationRepository) ListNotificationAttempts(ctx context.Context, reservationID uint64) ([]model.MeetingNotificationAttempt, error) {
+	var rows []notificationAttemptRow
+	if err := r.db.SelectContext(ctx, &rows, listNotificationAttemptsQuery, reservationID); err != nil {
+		return nil, fmt.Errorf("select meeting_notification_attempts reservation_id=%d: %w", reservationID, err)
+	}
+
+	result := make([]model.MeetingNotificationAttempt, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.MeetingNotificationAttempt{
+			ID:             row.ID,
+			NotificationID: row.NotificationID,
+			ReservationID:  row.ReservationID,
+			Attempt:        row.Attempt,
+			Status:         strings.TrimSpace(row.Status),
+			ErrorMessage:   strings.TrimSpace(row.ErrorMessage.String),
+			AttemptedAt:    row.AttemptedAt.UTC(),
 		})
 	}
 	return result, nil
 }
 
+func (r *meetingNotificationRepository) insert(ctx context.Context, notification *model.MeetingNotification, status string) (*model.MeetingNotification, error) {
+	res, err := r.db.ExecContext(ctx, insertNotificationQuery,
+		notification.ReservationID,
+		strings.TrimSpace(notification.Type),
+		status,
+		nullIfEmpty(notification.ErrorMessage),
+		nullIfEmpty(notification.DedupeKey),
+	)
+	if err != nil {
+		var mysqlErr *mysqlerr.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("insert meeting_notifications: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("meeting_notifications last insert id: %w", err)
+	}
+	return r.findByID(ctx, uint64(id))
+}
+
+func (r *meetingNotificationRepository) findByID(ctx context.Context, id uint64) (*model.MeetingNotification, error) {
+	var row notificationRow
+	if err := r.db.GetContext(ctx, &row, selectNotificationsBaseQuery+"\nWHERE id = ?", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("select meeting_notifications id=%d: %w", id, err)
+	}
+	notification := mapNotificationRow(row)
+	return &notification, nil
+}
+
+func mapNotificationRow(row notificationRow) model.MeetingNotification {
+	return model.MeetingNotification{
+		ID:            row.ID,
+		ReservationID: row.ReservationID,
+		Type:          strings.TrimSpace(row.Type),
+		Status:        strings.TrimSpace(row.Status),
+		ErrorMessage:  strings.TrimSpace(row.ErrorMessage.String),
+		DedupeKey:     strings.TrimSpace(row.DedupeKey.String),
+		Attempts:      row.Attempts,
+		NextAttemptAt: nullableTime(row.NextAttemptAt),
+		CreatedAt:     row.CreatedAt.UTC(),
+	}
+}
+
 func nullIfEmpty(value string) sql.NullString {
 	if strings.TrimSpace(value) == "" {
 		return sql.NullString{}
@@ -0,0 +1,148 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type notificationTemplateRepository struct {
+	db *sqlx.DB
+}
+
+// NewNotificationTemplateRepository returns a MySQL-backed notification template repository.
+func NewNotificationTemplateRepository(db *sqlx.DB) repository.NotificationTemplateRepository {
+	return &notificationTemplateRepository{db: db}
+}
+
+const selectNotificationTemplatesBaseQuery = `
+SELECT
+	template_key,
+	subject_ja,
+	subject_en,
+	text_body_ja,
+	text_body_en,
+	html_body_ja,
+	html_body_en,
+	updated_at
+FROM notification_templates`
+
+const upsertNotificationTemplateQuery = `
+INSERT INTO notification_templates (
+	template_key,
+	subject_ja,
+	subject_en,
+	text_body_ja,
+	text_body_en,
+	html_body_ja,
+	html_body_en,
+	updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, NOW(3))
+ON DUPLICATE KEY UPDATE
+	subject_ja = VALUES(subject_ja),
+	subject_en = VALUES(subject_en),
+	text_body_ja = VALUES(text_body_ja),
+	text_body_en = VALUES(text_body_en),
+	html_body_ja = VALUES(html_body_ja),
+	html_body_en = VALUES(html_body_en),
+	updated_at = NOW(3)`
+
+type notificationTemplateRow struct {
+	Key        string         `db:"template_key"`
+	SubjectJa  sql.NullString `db:"subject_ja"`
+	SubjectEn  sql.NullString `db:"subject_en"`
+	TextBodyJa sql.NullString `db:"text_body_ja"`
+	TextBodyEn sql.NullString `db:"text_body_en"`
+	HTMLBodyJa sql.NullString `db:"html_body_ja"`
+	HTMLBodyEn sql.NullString `db:"html_body_en"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+func (r *notificationTemplateRepository) ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error) {
+	var rows []notificationTemplateRow
+	if err := r.db.SelectContext(ctx, &rows, selectNotificationTemplatesBaseQuery+"\nORDER BY template_key"); err != nil {
+		return nil, fmt.Errorf("select notification_templates: %w", err)
+	}
+
+	templates := make([]model.NotificationTemplate, 0, len(rows))
+	for _, row := range rows {
+		templates = append(templates, mapNotificationTemplateRow(row))
+	}
+	return templates, nil
+}
+
+func (r *notificationTemplateRepository) GetTemplate(ctx context.Context, key model.NotificationTemplateKey) (*model.NotificationTemplate, error) {
+	var row notificationTemplateRow
+	if err := r.db.GetContext(ctx, &row, selectNotificationTemplatesBaseQuery+"\nWHERE template_key = ?", string(key)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("select notification_templates key=%s: %w", key, err)
+	}
+	template := mapNotificationTemplateRow(row)
+	return &template, nil
+}
+
+func (r *notificationTemplateRepository) SaveTemplate(ctx context.Context, template *model.NotificationTemplate) (*model.NotificationTemplate, error) {
+	if template == nil || strings.TrimSpace(string(template.Key)) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	// Bodies are stored verbatim so whitespace inside the template sources survives a round trip.
+	if _, err := r.db.ExecContext(ctx, upsertNotificationTemplateQuery,
+		string(template.Key),
+		nullString(template.Subject.Ja),
+		nullString(template.Subject.En),
+		rawNullString(template.TextBody.Ja),
+		rawNullString(template.TextBody.En),
+		rawNullString(template.HTMLBody.Ja),
+		rawNullString(template.HTMLBody.En),
+	); err != nil {
+		return nil, fmt.Errorf("upsert notification_templates key=%s: %w", template.Key, err)
+	}
+	return r.GetTemplate(ctx, template.Key)
+}
+
+func (r *notificationTemplateRepository) DeleteTemplate(ctx context.Context, key model.NotificationTemplateKey) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM notification_templates WHERE template_key = ?`, string(key))
+	if err != nil {
+		return fmt.Errorf("delete notification_templates key=%s: %w", key, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected delete notification_templates key=%s: %w", key, err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func rawNullString(value string) sql.NullString {
+	if strings.TrimSpace(value) == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value, Valid: true}
+}
+
+func mapNotificationTemplateRow(row notificationTemplateRow) model.NotificationTemplate {
+	updatedAt := row.UpdatedAt.UTC()
+	return model.NotificationTemplate{
+		Key:        model.NotificationTemplateKey(strings.TrimSpace(row.Key)),
+		Subject:    toLocalizedText(row.SubjectJa, row.SubjectEn),
+		TextBody:   model.NewLocalizedText(row.TextBodyJa.String, row.TextBodyEn.String),
+		HTMLBody:   model.NewLocalizedText(row.HTMLBodyJa.String, row.HTMLBodyEn.String),
+		Customized: true,
+		UpdatedAt:  &updatedAt,
+	}
+}
+
+var _ repository.NotificationTemplateRepository = (*notificationTemplateRepository)(nil)
@@ -0,0 +1,179 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type reservationDriftRepository struct {
+	db *sqlx.DB
+}
+
+// NewReservationDriftRepository returns a MySQL-backed reservation drift repository.
+func NewReservationDriftRepository(db *sqlx.DB) repository.ReservationDriftRepository {
+	return &reservationDriftRepository{db: db}
+}
+
+const selectDriftsBaseQuery = `
+SELECT
+	id,
+	reservation_id,
+	google_event_id,
+	kind,
+	action,
+	stored_start_at,
+	stored_end_at,
+	event_start_at,
+	event_end_at,
+	detail,
+	dedupe_key,
+	detected_at,
+	resolved_at
+FROM reservation_calendar_drifts`
+
+const insertDriftQuery = `
+INSERT INTO reservation_calendar_drifts (
+	reservation_id,
+	google_event_id,
+	kind,
+	action,
+	stored_start_at,
+	stored_end_at,
+	event_start_at,
+	event_end_at,
+	detail,
+	dedupe_key,
+	detected_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+type driftRow struct {
+	ID            uint64         `db:"id"`
+	ReservationID uint64         `db:"reservation_id"`
+	GoogleEventID sql.NullString `db:"google_event_id"`
+	Kind          string         `db:"kind"`
+	Action        string         `db:"action"`
+	StoredStartAt time.Time      `db:"stored_start_at"`
+	StoredEndAt   time.Time      `db:"stored_end_at"`
+	EventStartAt  sql.NullTime   `db:"event_start_at"`
+	EventEndAt    sql.NullTime   `db:"event_end_at"`
+	Detail        sql.NullString `db:"detail"`
+	DedupeKey     string         `db:"dedupe_key"`
+	DetectedAt    time.Time      `db:"detected_at"`
+	ResolvedAt    sql.NullTime   `db:"resolved_at"`
+}
+
+func (r *reservationDriftRepository) RecordDrift(ctx context.Context, drift *model.ReservationDrift) (*model.ReservationDrift, error) {
+	if drift == nil || drift.ReservationID == 0 || strings.TrimSpace(drift.DedupeKey) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	detectedAt := drift.DetectedAt.UTC()
+	if drift.DetectedAt.IsZero() {
+		detectedAt = time.Now().UTC()
+	}
+	res, err := r.db.ExecContext(ctx, insertDriftQuery,
+		drift.ReservationID,
+		nullIfEmpty(drift.GoogleEventID),
+		string(drift.Kind),
+		string(drift.Action),
+		drift.StoredStartAt.UTC(),
+		drift.StoredEndAt.UTC(),
+		sql.NullTime{Time: timePtrValue(drift.EventStartAt), Valid: drift.EventStartAt != nil},
+		sql.NullTime{Time: timePtrValue(drift.EventEndAt), Valid: drift.EventEndAt != nil},
+		nullIfEmpty(drift.Detail),
+		strings.TrimSpace(drift.DedupeKey),
+		detectedAt,
+	)
+	if err != nil {
+		var mysqlErr *mysqlerr.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("insert reservation_calendar_drifts: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("reservation_calendar_drifts last insert id: %w", err)
+	}
+	return r.findByID(ctx, uint64(id))
+}
+
+func (r *reservationDriftRepository) ListDrifts(ctx context.Context, includeResolved bool) ([]model.ReservationDrift, error) {
+	query := selectDriftsBaseQuery
+	if !includeResolved {
+		query += "\nWHERE resolved_at IS NULL"
+	}
+	query += "\nORDER BY id DESC"
+
+	var rows []driftRow
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("select reservation_calendar_drifts: %w", err)
+	}
+
+	drifts := make([]model.ReservationDrift, 0, len(rows))
+	for _, row := range rows {
+		drifts = append(drifts, mapDriftRow(row))
+	}
+	return drifts, nil
+}
+
+func (r *reservationDriftRepository) ResolveDrift(ctx context.Context, id uint64, resolvedAt time.Time) (*model.ReservationDrift, error) {
+	const query = `UPDATE reservation_calendar_drifts SET resolved_at = COALESCE(resolved_at, ?) WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, resolvedAt.UTC(), id); err != nil {
+		return nil, fmt.Errorf("resolve reservation_calendar_drifts id=%d: %w", id, err)
+	}
+	return r.findByID(ctx, id)
+}
+
+func (r *reservationDriftRepository) findByID(ctx context.Context, id uint64) (*model.ReservationDrift, error) {
+	var row driftRow
+	if err := r.db.GetContext(ctx, &row, selectDriftsBaseQuery+"\nWHERE id = ?", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("select reservation_calendar_drifts id=%d: %w", id, err)
+	}
+	drift := mapDriftRow(row)
+	return &drift, nil
+}
+
+func mapDriftRow(row driftRow) model.ReservationDrift {
+	drift := model.ReservationDrift{
+		ID:            row.ID,
+		ReservationID: row.ReservationID,
+		GoogleEventID: strings.TrimSpace(row.GoogleEventID.String),
+		Kind:          model.ReservationDriftKind(strings.TrimSpace(row.Kind)),
+		Action:        model.ReservationDriftAction(strings.TrimSpace(row.Action)),
+		StoredStartAt: row.StoredStartAt.UTC(),
+		StoredEndAt:   row.StoredEndAt.UTC(),
+		Detail:        strings.TrimSpace(row.Detail.String),
+		DedupeKey:     strings.TrimSpace(row.DedupeKey),
+		DetectedAt:    row.DetectedAt.UTC(),
+	}
+	if row.EventStartAt.Valid {
+		start := row.EventStartAt.Time.UTC()
+		drift.EventStartAt = &start
+	}
+	if row.EventEndAt.Valid {
+		end := row.EventEndAt.Time.UTC()
+		drift.EventEndAt = &end
+	}
+	if row.ResolvedAt.Valid {
+		resolved := row.ResolvedAt.Time.UTC()
+		drift.ResolvedAt = &resolved
+	}
+	return drift
+}
+
+var _ repository.ReservationDriftRepository = (*reservationDriftRepository)(nil)
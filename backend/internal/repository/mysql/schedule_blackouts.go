@@ -0,0 +1,197 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type scheduleBlackoutRepository struct {
+	db *sqlx.DB
+}
+
+// NewScheduleBlackoutRepository returns a MySQL-backed blackout repository.
+func NewScheduleBlackoutRepository(db *sqlx.DB) repository.ScheduleBlackoutRepository {
+	return &scheduleBlackoutRepository{db: db}
+}
+
+const listBlackoutsQuery = `
+SELECT
+	id,
+	start_time,
+	end_time,
+	reason,
+	recurrence_rule,
+	timezone,
+	created_at,
+	updated_at
+FROM schedule_blackouts
+ORDER BY start_time ASC, id ASC`
+
+const getBlackoutQuery = `
+SELECT
+	id,
+	start_time,
+	end_time,
+	reason,
+	recurrence_rule,
+	timezone,
+	created_at,
+	updated_at
+FROM schedule_blackouts
+WHERE id = ?`
+
+const insertBlackoutQuery = `
+INSERT INTO schedule_blackouts (
+	start_time,
+	end_time,
+	reason,
+	recurrence_rule,
+	timezone,
+	created_at,
+	updated_at
+) VALUES (?, ?, ?, ?, ?, NOW(3), NOW(3))`
+
+const updateBlackoutQuery = `
+UPDATE schedule_blackouts
+SET
+	start_time = ?,
+	end_time = ?,
+	reason = ?,
+	recurrence_rule = ?,
+	timezone = ?,
+	updated_at = NOW(3)
+WHERE id = ?`
+
+const deleteBlackoutQuery = `DELETE FROM schedule_blackouts WHERE id = ?`
+
+type blackoutRow struct {
+	ID             uint64         `db:"id"`
+	StartTime      time.Time      `db:"start_time"`
+	EndTime        time.Time      `db:"end_time"`
+	Reason         sql.NullString `db:"reason"`
+	RecurrenceRule sql.NullString `db:"recurrence_rule"`
+	Timezone       sql.NullString `db:"timezone"`
+	CreatedAt      sql.NullTime   `db:"created_at"`
+	UpdatedAt      sql.NullTime   `db:"updated_at"`
+}
+
+func (r *scheduleBlackoutRepository) ListBlackouts(ctx context.Context) ([]model.ScheduleBlackout, error) {
+	var rows []blackoutRow
+	if err := r.db.SelectContext(ctx, &rows, listBlackoutsQuery); err != nil {
+		return nil, fmt.Errorf("select schedule_blackouts: %w", err)
+	}
+
+	result := make([]model.ScheduleBlackout, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mapBlackoutRow(row))
+	}
+	return result, nil
+}
+
+func (r *scheduleBlackoutRepository) GetBlackout(ctx context.Context, id uint64) (*model.ScheduleBlackout, error) {
+	var row blackoutRow
+	if err := r.db.GetContext(ctx, &row, getBlackoutQuery, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("get schedule_blackouts id=%d: %w", id, err)
+	}
+	blackout := mapBlackoutRow(row)
+	return &blackout, nil
+}
+
+func (r *scheduleBlackoutRepository) CreateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error) {
+	if blackout == nil || !blackout.EndTime.After(blackout.StartTime) {
+		return nil, repository.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx, insertBlackoutQuery,
+		blackout.StartTime.UTC(),
+		blackout.EndTime.UTC(),
+		nullString(blackout.Reason),
+		nullString(blackout.Recurrence),
+		nullString(blackout.Timezone),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert schedule_blackouts: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("schedule_blackouts last insert id: %w", err)
+	}
+	return r.GetBlackout(ctx, uint64(id))
+}
+
+func (r *scheduleBlackoutRepository) UpdateBlackout(ctx context.Context, blackout *model.ScheduleBlackout) (*model.ScheduleBlackout, error) {
+	if blackout == nil || blackout.ID == 0 || !blackout.EndTime.After(blackout.StartTime) {
+		return nil, repository.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx, updateBlackoutQuery,
+		blackout.StartTime.UTC(),
+		blackout.EndTime.UTC(),
+		nullString(blackout.Reason),
+		nullString(blackout.Recurrence),
+		nullString(blackout.Timezone),
+		blackout.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update schedule_blackouts id=%d: %w", blackout.ID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected update schedule_blackouts id=%d: %w", blackout.ID, err)
+	}
+	if affected == 0 {
+		// MySQL reports zero rows when nothing changed, so confirm the row exists.
+		if _, err := r.GetBlackout(ctx, blackout.ID); err != nil {
+			return nil, err
+		}
+	}
+	return r.GetBlackout(ctx, blackout.ID)
+}
+
+func (r *scheduleBlackoutRepository) DeleteBlackout(ctx context.Context, id uint64) error {
+	res, err := r.db.ExecContext(ctx, deleteBlackoutQuery, id)
+	if err != nil {
+		return fmt.Errorf("delete schedule_blackouts id=%d: %w", id, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected delete schedule_blackouts id=%d: %w", id, err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func mapBlackoutRow(row blackoutRow) model.ScheduleBlackout {
+	createdAt := row.CreatedAt.Time.UTC()
+	if !row.CreatedAt.Valid {
+		createdAt = timeNowUTC()
+	}
+	updatedAt := createdAt
+	if row.UpdatedAt.Valid {
+		updatedAt = row.UpdatedAt.Time.UTC()
+	}
+	return model.ScheduleBlackout{
+		ID:         row.ID,
+		StartTime:  row.StartTime.UTC(),
+		EndTime:    row.EndTime.UTC(),
+		Reason:     nullableString(row.Reason),
+		Recurrence: nullableString(row.RecurrenceRule),
+		Timezone:   nullableString(row.Timezone),
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}
+}
+
+var _ repository.ScheduleBlackoutRepository = (*scheduleBlackoutRepository)(nil)
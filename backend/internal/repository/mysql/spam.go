@@ -0,0 +1,172 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type spamRepository struct {
+	db *sqlx.DB
+}
+
+// NewSpamRepository returns a MySQL-backed store for spam fingerprints and verdicts.
+func NewSpamRepository(db *sqlx.DB) repository.SpamRepository {
+	return &spamRepository{db: db}
+}
+
+// spamFeedbackLimit bounds how many verdicts a lookup returns; the newest ones decide.
+const spamFeedbackLimit = 50
+
+const (
+	insertSpamFingerprintQuery = `
+INSERT INTO spam_fingerprints (
+	content_hash,
+	email,
+	source,
+	created_at
+) VALUES (?, ?, ?, ?)`
+
+	countSpamFingerprintsQuery = `
+SELECT COUNT(*)
+FROM spam_fingerprints
+WHERE content_hash = ?
+  AND created_at >= ?`
+
+	insertSpamFeedbackQuery = `
+INSERT INTO spam_feedback (
+	contact_id,
+	content_hash,
+	email,
+	verdict,
+	created_at
+) VALUES (?, ?, ?, ?, NOW(3))`
+
+	selectSpamFeedbackBaseQuery = `
+SELECT
+	id,
+	contact_id,
+	content_hash,
+	email,
+	verdict,
+	created_at
+FROM spam_feedback`
+)
+
+type spamFeedbackRow struct {
+	ID          uint64         `db:"id"`
+	ContactID   sql.NullInt64  `db:"contact_id"`
+	ContentHash sql.NullString `db:"content_hash"`
+	Email       string         `db:"email"`
+	Verdict     string         `db:"verdict"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+func (r *spamRepository) RecordSpamFingerprint(ctx context.Context, fingerprint *model.SpamFingerprint) error {
+	if fingerprint == nil || strings.TrimSpace(fingerprint.ContentHash) == "" {
+		return repository.ErrInvalidInput
+	}
+	createdAt := fingerprint.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	if _, err := r.db.ExecContext(ctx, insertSpamFingerprintQuery,
+		strings.TrimSpace(fingerprint.ContentHash),
+		strings.ToLower(strings.TrimSpace(fingerprint.Email)),
+		strings.TrimSpace(fingerprint.Source),
+		createdAt.UTC(),
+	); err != nil {
+		return fmt.Errorf("insert spam_fingerprints: %w", err)
+	}
+	return nil
+}
+
+func (r *spamRepository) CountSpamFingerprints(ctx context.Context, contentHash string, since time.Time) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countSpamFingerprintsQuery, strings.TrimSpace(contentHash), since.UTC()); err != nil {
+		return 0, fmt.Errorf("count spam_fingerprints: %w", err)
+	}
+	return count, nil
+}
+
+func (r *spamRepository) RecordSpamFeedback(ctx context.Context, feedback *model.SpamFeedback) (*model.SpamFeedback, error) {
+	if feedback == nil || strings.TrimSpace(feedback.Email) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+	var contactID sql.NullInt64
+	if id, err := parseContactID(feedback.ContactID); err == nil {
+		contactID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	res, err := r.db.ExecContext(ctx, insertSpamFeedbackQuery,
+		contactID,
+		nullIfEmpty(feedback.ContentHash),
+		strings.ToLower(strings.TrimSpace(feedback.Email)),
+		string(feedback.Verdict),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert spam_feedback: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("spam_feedback last insert id: %w", err)
+	}
+
+	var row spamFeedbackRow
+	if err := r.db.GetContext(ctx, &row, selectSpamFeedbackBaseQuery+"\nWHERE id = ?", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("select spam_feedback id=%d: %w", id, err)
+	}
+	stored := mapSpamFeedbackRow(row)
+	return &stored, nil
+}
+
+func (r *spamRepository) ListSpamFeedback(ctx context.Context, contentHash, email string) ([]model.SpamFeedback, error) {
+	contentHash = strings.TrimSpace(contentHash)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if contentHash == "" && email == "" {
+		return []model.SpamFeedback{}, nil
+	}
+
+	query := selectSpamFeedbackBaseQuery + `
+WHERE (content_hash = ? AND content_hash <> '') OR (email = ? AND email <> '')
+ORDER BY id DESC
+LIMIT ?`
+	var rows []spamFeedbackRow
+	if err := r.db.SelectContext(ctx, &rows, query, contentHash, email, spamFeedbackLimit); err != nil {
+		return nil, fmt.Errorf("select spam_feedback: %w", err)
+	}
+
+	result := make([]model.SpamFeedback, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mapSpamFeedbackRow(row))
+	}
+	return result, nil
+}
+
+func mapSpamFeedbackRow(row spamFeedbackRow) model.SpamFeedback {
+	feedback := model.SpamFeedback{
+		ID:          row.ID,
+		ContentHash: strings.TrimSpace(row.ContentHash.String),
+		Email:       strings.TrimSpace(row.Email),
+		Verdict:     model.SpamVerdict(strings.TrimSpace(row.Verdict)),
+		CreatedAt:   row.CreatedAt.UTC(),
+	}
+	if row.ContactID.Valid {
+		feedback.ContactID = strconv.FormatInt(row.ContactID.Int64, 10)
+	}
+	return feedback
+}
+
+var _ repository.SpamRepository = (*spamRepository)(nil)
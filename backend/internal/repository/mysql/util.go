@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -66,3 +67,24 @@ func rollbackOnError(tx *sqlx.Tx, err *error) {
 		_ = tx.Rollback()
 	}
 }
+
+// encodeIntakeAnswers returns the JSON stored in intake_answers columns, or nil for no answers.
+func encodeIntakeAnswers(answers []model.IntakeAnswer) ([]byte, error) {
+	if len(answers) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(answers)
+}
+
+// decodeIntakeAnswers reads an intake_answers column. The column is only written by
+// encodeIntakeAnswers, so unreadable content is treated as no answers.
+func decodeIntakeAnswers(payload []byte) []model.IntakeAnswer {
+	if len(payload) == 0 {
+		return nil
+	}
+	var answers []model.IntakeAnswer
+	if err := json.Unmarshal(payload, &answers); err != nil {
+		return nil
+	}
+	return answers
+}
@@ -0,0 +1,288 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+type waitlistRepository struct {
+	db *sqlx.DB
+}
+
+// NewWaitlistRepository returns a MySQL-backed waitlist repository.
+func NewWaitlistRepository(db *sqlx.DB) repository.WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+const selectWaitlistEntriesBaseQuery = `
+SELECT
+	id,
+	name,
+	email,
+	topic,
+	locale,
+	preferred_dates,
+	status,
+	offer_reservation_id,
+	offer_start_at,
+	offer_end_at,
+	offer_expires_at,
+	offer_token_hash,
+	created_at,
+	updated_at
+FROM waitlist_entries`
+
+const insertWaitlistEntryQuery = `
+INSERT INTO waitlist_entries (
+	name,
+	email,
+	topic,
+	locale,
+	preferred_dates,
+	status,
+	created_at,
+	updated_at
+) VALUES (?, ?, ?, ?, ?, ?, NOW(3), NOW(3))`
+
+const updateWaitlistEntryQuery = `
+UPDATE waitlist_entries
+SET
+	status = ?,
+	offer_reservation_id = ?,
+	offer_start_at = ?,
+	offer_end_at = ?,
+	offer_expires_at = ?,
+	offer_token_hash = ?,
+	updated_at = NOW(3)
+WHERE id = ?`
+
+type waitlistEntryRow struct {
+	ID                 uint64         `db:"id"`
+	Name               string         `db:"name"`
+	Email              string         `db:"email"`
+	Topic              sql.NullString `db:"topic"`
+	Locale             sql.NullString `db:"locale"`
+	PreferredDatesJSON []byte         `db:"preferred_dates"`
+	Status             string         `db:"status"`
+	OfferReservationID sql.NullInt64  `db:"offer_reservation_id"`
+	OfferStartAt       sql.NullTime   `db:"offer_start_at"`
+	OfferEndAt         sql.NullTime   `db:"offer_end_at"`
+	OfferExpiresAt     sql.NullTime   `db:"offer_expires_at"`
+	OfferTokenHash     sql.NullString `db:"offer_token_hash"`
+	CreatedAt          time.Time      `db:"created_at"`
+	UpdatedAt          time.Time      `db:"updated_at"`
+}
+
+func (r *waitlistRepository) CreateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error) {
+	if entry == nil || strings.TrimSpace(entry.Email) == "" || len(entry.PreferredDates) == 0 {
+		return nil, repository.ErrInvalidInput
+	}
+
+	dates, err := json.Marshal(entry.PreferredDates)
+	if err != nil {
+		return nil, fmt.Errorf("encode waitlist preferred dates: %w", err)
+	}
+	status := entry.Status
+	if status == "" {
+		status = model.WaitlistStatusWaiting
+	}
+
+	res, err := r.db.ExecContext(ctx, insertWaitlistEntryQuery,
+		entry.Name,
+		entry.Email,
+		nullString(entry.Topic),
+		nullString(entry.Locale),
+		dates,
+		string(status),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert waitlist_entries: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("waitlist_entries last insert id: %w", err)
+	}
+	return r.getEntry(ctx, uint64(id))
+}
+
+func (r *waitlistRepository) ListEntries(ctx context.Context, statuses []model.WaitlistStatus) ([]model.WaitlistEntry, error) {
+	query := selectWaitlistEntriesBaseQuery + "\nORDER BY created_at ASC, id ASC"
+	var args []any
+	if len(statuses) > 0 {
+		values := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, string(status))
+		}
+		var err error
+		query, args, err = sqlx.In(selectWaitlistEntriesBaseQuery+"\nWHERE status IN (?)\nORDER BY created_at ASC, id ASC", values)
+		if err != nil {
+			return nil, fmt.Errorf("build waitlist_entries query: %w", err)
+		}
+		query = r.db.Rebind(query)
+	}
+
+	var rows []waitlistEntryRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("select waitlist_entries: %w", err)
+	}
+
+	entries := make([]model.WaitlistEntry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := mapWaitlistEntryRow(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepository) FindOfferByReservation(ctx context.Context, reservationID uint64) (*model.WaitlistEntry, error) {
+	return r.findOne(ctx, "\nWHERE offer_reservation_id = ? AND status = ?\nORDER BY id ASC\nLIMIT 1",
+		reservationID, string(model.WaitlistStatusOffered))
+}
+
+func (r *waitlistRepository) FindEntryByOfferToken(ctx context.Context, tokenHash string) (*model.WaitlistEntry, error) {
+	if strings.TrimSpace(tokenHash) == "" {
+		return nil, repository.ErrNotFound
+	}
+	return r.findOne(ctx, "\nWHERE offer_token_hash = ?", tokenHash)
+}
+
+func (r *waitlistRepository) UpdateEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error) {
+	if entry == nil || entry.ID == 0 || entry.Status == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	affected, err := r.update(ctx, entry, "")
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		// MySQL reports zero rows when nothing changed, so confirm the row exists.
+		if _, err := r.getEntry(ctx, entry.ID); err != nil {
+			return nil, err
+		}
+	}
+	return r.getEntry(ctx, entry.ID)
+}
+
+func (r *waitlistRepository) TransitionEntry(ctx context.Context, entry *model.WaitlistEntry, from model.WaitlistStatus, tokenHash string) (*model.WaitlistEntry, error) {
+	if entry == nil || entry.ID == 0 || entry.Status == "" || from == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	affected, err := r.update(ctx, entry, "\n  AND status = ?\n  AND offer_token_hash <=> ?", string(from), nullIfEmpty(tokenHash))
+	if err != nil {
+		return nil, err
+	}
+	stored, err := r.getEntry(ctx, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Zero rows also means nothing changed, which only counts as a match if the entry already
+	// looks the way the caller wanted it.
+	if affected == 0 && (stored.Status != entry.Status || stored.OfferTokenHash != entry.OfferTokenHash) {
+		return nil, repository.ErrConflict
+	}
+	return stored, nil
+}
+
+// update writes the entry's status and offer, restricted further by the optional condition.
+func (r *waitlistRepository) update(ctx context.Context, entry *model.WaitlistEntry, condition string, conditionArgs ...any) (int64, error) {
+	var offerReservationID sql.NullInt64
+	if entry.OfferReservationID != 0 {
+		offerReservationID = sql.NullInt64{Int64: int64(entry.OfferReservationID), Valid: true}
+	}
+
+	args := []any{
+		string(entry.Status),
+		offerReservationID,
+		nullTime(entry.OfferStartAt),
+		nullTime(entry.OfferEndAt),
+		nullTime(entry.OfferExpiresAt),
+		nullIfEmpty(entry.OfferTokenHash),
+		entry.ID,
+	}
+	res, err := r.db.ExecContext(ctx, updateWaitlistEntryQuery+condition, append(args, conditionArgs...)...)
+	if err != nil {
+		var mysqlErr *mysqlerr.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return 0, repository.ErrDuplicate
+		}
+		return 0, fmt.Errorf("update waitlist_entries id=%d: %w", entry.ID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected update waitlist_entries id=%d: %w", entry.ID, err)
+	}
+	return affected, nil
+}
+
+func (r *waitlistRepository) getEntry(ctx context.Context, id uint64) (*model.WaitlistEntry, error) {
+	return r.findOne(ctx, "\nWHERE id = ?", id)
+}
+
+func (r *waitlistRepository) findOne(ctx context.Context, where string, args ...any) (*model.WaitlistEntry, error) {
+	var row waitlistEntryRow
+	if err := r.db.GetContext(ctx, &row, selectWaitlistEntriesBaseQuery+where, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("get waitlist_entries: %w", err)
+	}
+	entry, err := mapWaitlistEntryRow(row)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func mapWaitlistEntryRow(row waitlistEntryRow) (model.WaitlistEntry, error) {
+	var dates []string
+	if len(row.PreferredDatesJSON) > 0 {
+		if err := json.Unmarshal(row.PreferredDatesJSON, &dates); err != nil {
+			return model.WaitlistEntry{}, fmt.Errorf("decode waitlist_entries id=%d preferred dates: %w", row.ID, err)
+		}
+	}
+	entry := model.WaitlistEntry{
+		ID:             row.ID,
+		Name:           row.Name,
+		Email:          row.Email,
+		Topic:          nullableString(row.Topic),
+		Locale:         nullableString(row.Locale),
+		PreferredDates: dates,
+		Status:         model.WaitlistStatus(row.Status),
+		OfferStartAt:   utcTimePtr(row.OfferStartAt),
+		OfferEndAt:     utcTimePtr(row.OfferEndAt),
+		OfferExpiresAt: utcTimePtr(row.OfferExpiresAt),
+		OfferTokenHash: nullableString(row.OfferTokenHash),
+		CreatedAt:      row.CreatedAt.UTC(),
+		UpdatedAt:      row.UpdatedAt.UTC(),
+	}
+	if row.OfferReservationID.Valid {
+		entry.OfferReservationID = uint64(row.OfferReservationID.Int64)
+	}
+	return entry, nil
+}
+
+func utcTimePtr(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	t := value.Time.UTC()
+	return &t
+}
+
+var _ repository.WaitlistRepository = (*waitlistRepository)(nil)
@@ -1,6 +1,9 @@
 package provider
 
 import (
+	"errors"
+	"log"
+
 	"cloud.google.com/go/firestore"
 	"github.com/jmoiron/sqlx"
 
@@ -196,14 +199,40 @@ func NewAdminContactRepository(repo repository.ContactRepository) repository.Adm
 }
 
 // NewAvailabilityRepository selects the appropriate implementation for schedule computation.
-func NewAvailabilityRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.AvailabilityRepository {
+// The in-memory implementation reads blackouts from the provided repository so admin edits
+// are reflected without a database.
+func NewAvailabilityRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig, blackouts repository.ScheduleBlackoutRepository) repository.AvailabilityRepository {
 	switch {
 	case db != nil:
 		return repoMySQL.NewAvailabilityRepository(db)
 	case client != nil:
 		return repoFirestore.NewAvailabilityRepository(client, prefix(cfg))
 	default:
-		return inmemory.NewAvailabilityRepository()
+		return inmemory.NewAvailabilityRepositoryWithBlackouts(blackouts)
+	}
+}
+
+// NewScheduleBlackoutRepository selects an appropriate blackout repository implementation.
+func NewScheduleBlackoutRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.ScheduleBlackoutRepository {
+	switch {
+	case db != nil:
+		return repoMySQL.NewScheduleBlackoutRepository(db)
+	case client != nil:
+		return repoFirestore.NewScheduleBlackoutRepository(client, prefix(cfg))
+	default:
+		return inmemory.NewScheduleBlackoutRepository()
+	}
+}
+
+// NewWaitlistRepository selects an appropriate waitlist repository implementation.
+func NewWaitlistRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.WaitlistRepository {
+	switch {
+	case db != nil:
+		return repoMySQL.NewWaitlistRepository(db)
+	case client != nil:
+		return repoFirestore.NewWaitlistRepository(client, prefix(cfg))
+	default:
+		return inmemory.NewWaitlistRepository()
 	}
 }
 
@@ -220,12 +249,20 @@ func NewBlogRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppCon
 }
 
 // NewMeetingReservationRepository selects an appropriate reservation repository implementation.
-func NewMeetingReservationRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.MeetingReservationRepository {
-	if db != nil {
-		return repoMySQL.NewMeetingReservationRepository(db)
+// Reservations are only persisted in MySQL, whose row locks keep two requests from claiming the
+// same slot. There is no Firestore implementation, so a Firestore-backed deployment is refused
+// instead of keeping bookings in process memory, where they vanish on restart and separate
+// instances can double-book. Without any store the in-memory repository serves local runs.
+func NewMeetingReservationRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) (repository.MeetingReservationRepository, error) {
+	switch {
+	case db != nil:
+		return repoMySQL.NewMeetingReservationRepository(db), nil
+	case client != nil:
+		return nil, errors.New("meeting reservations require MySQL: db_driver \"firestore\" has no reservation store; set db_driver to \"mysql\"")
+	default:
+		log.Println("WARNING: no database configured; meeting reservations are kept in memory and lost on restart")
+		return inmemory.NewMeetingReservationRepository(), nil
 	}
-	// Meeting reservations rely on SQL features; fall back to in-memory when a database is unavailable.
-	return inmemory.NewMeetingReservationRepository()
 }
 
 // NewMeetingNotificationRepository selects an appropriate notification repository implementation.
@@ -236,6 +273,58 @@ func NewMeetingNotificationRepository(db *sqlx.DB, client *firestore.Client, cfg
 	return inmemory.NewMeetingNotificationRepository()
 }
 
+// NewContactNotificationRepository selects where contact notification deliveries are recorded.
+func NewContactNotificationRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.ContactNotificationRepository {
+	if db != nil {
+		return repoMySQL.NewContactNotificationRepository(db)
+	}
+	return inmemory.NewContactNotificationRepository()
+}
+
+// NewSpamRepository selects where the spam filter keeps fingerprints and verdicts.
+func NewSpamRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.SpamRepository {
+	if db != nil {
+		return repoMySQL.NewSpamRepository(db)
+	}
+	return inmemory.NewSpamRepository()
+}
+
+// NewBookingOutboxRepository selects an outbox implementation that shares storage with reservations.
+func NewBookingOutboxRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig, reservations repository.MeetingReservationRepository) repository.BookingOutboxRepository {
+	if db != nil {
+		return repoMySQL.NewBookingOutboxRepository(db)
+	}
+	// Without SQL the reservations are in-memory, so their outbox must live alongside them.
+	return inmemory.NewBookingOutboxRepository(reservations)
+}
+
+// NewCalendarFeedTokenRepository selects an appropriate calendar feed token repository implementation.
+func NewCalendarFeedTokenRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.CalendarFeedTokenRepository {
+	if db != nil {
+		return repoMySQL.NewCalendarFeedTokenRepository(db)
+	}
+	// The feed is built from reservations, which only persist in SQL.
+	return inmemory.NewCalendarFeedTokenRepository()
+}
+
+// NewReservationDriftRepository selects an appropriate reservation drift repository implementation.
+func NewReservationDriftRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.ReservationDriftRepository {
+	if db != nil {
+		return repoMySQL.NewReservationDriftRepository(db)
+	}
+	// Drifts refer to reservations, which only persist in SQL.
+	return inmemory.NewReservationDriftRepository()
+}
+
+// NewNotificationTemplateRepository selects an appropriate notification template repository implementation.
+func NewNotificationTemplateRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.NotificationTemplateRepository {
+	if db != nil {
+		return repoMySQL.NewNotificationTemplateRepository(db)
+	}
+	// Without SQL the built-in templates are used and admin edits last until restart.
+	return inmemory.NewNotificationTemplateRepository()
+}
+
 // NewBlacklistRepository selects an appropriate blacklist repository implementation based on the Firestore client.
 func NewBlacklistRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.BlacklistRepository {
 	switch {
@@ -248,6 +337,18 @@ func NewBlacklistRepository(db *sqlx.DB, client *firestore.Client, cfg *config.A
 	}
 }
 
+// NewIdempotencyRepository selects the store for Idempotency-Key records.
+func NewIdempotencyRepository(db *sqlx.DB, client *firestore.Client, cfg *config.AppConfig) repository.IdempotencyRepository {
+	switch {
+	case db != nil:
+		return repoMySQL.NewIdempotencyRepository(db)
+	case client != nil:
+		return repoFirestore.NewIdempotencyRepository(client, prefix(cfg))
+	default:
+		return inmemory.NewIdempotencyRepository()
+	}
+}
+
 func prefix(cfg *config.AppConfig) string {
 	if cfg == nil {
 		return ""
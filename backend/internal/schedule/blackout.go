@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+)
+
+// ExpandBlackouts converts blackouts into busy windows overlapping [from, to).
+// Recurring blackouts are expanded into one window per occurrence; a blackout whose
+// rule cannot be parsed degrades to its first occurrence.
+func ExpandBlackouts(blackouts []model.ScheduleBlackout, from, to time.Time) []model.TimeWindow {
+	windows := make([]model.TimeWindow, 0, len(blackouts))
+	for _, blackout := range blackouts {
+		duration := blackout.EndTime.Sub(blackout.StartTime)
+		if duration <= 0 {
+			continue
+		}
+
+		starts := []time.Time{blackout.StartTime}
+		if strings.TrimSpace(blackout.Recurrence) != "" {
+			if rule, err := ParseRule(blackout.Recurrence); err == nil {
+				dtstart := blackout.StartTime.In(loadLocation(blackout.Timezone))
+				starts = rule.Between(dtstart, from.Add(-duration), to)
+			}
+		}
+
+		for _, start := range starts {
+			end := start.Add(duration)
+			if !start.Before(to) || !end.After(from) {
+				continue
+			}
+			windows = append(windows, model.TimeWindow{
+				Start:  start.UTC(),
+				End:    end.UTC(),
+				Source: model.BusyWindowSourceBlackout,
+			})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	return windows
+}
+
+func loadLocation(name string) *time.Location {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,401 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency enumerates the supported RRULE frequencies.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+// maxPeriods bounds expansion so malformed or unbounded rules cannot loop forever.
+const maxPeriods = 5000
+
+// ErrInvalidRule indicates an RRULE that cannot be parsed or is unsupported.
+var ErrInvalidRule = errors.New("schedule: invalid recurrence rule")
+
+// WeekdayNum is a BYDAY entry such as "WE" (N == 0) or "-1FR" (last Friday of the month).
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Rule is the subset of RFC 5545 recurrence rules used for blackouts and external calendars.
+type Rule struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=WE". The "RRULE:" prefix is optional.
+func ParseRule(value string) (Rule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "RRULE:"), "rrule:")
+	if value == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		raw = strings.ToUpper(strings.TrimSpace(raw))
+
+		switch key {
+		case "FREQ":
+			switch Frequency(raw) {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+				rule.Frequency = Frequency(raw)
+			default:
+				return Rule{}, fmt.Errorf("%w: unsupported frequency %q", ErrInvalidRule, raw)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(raw)
+			if err != nil || interval <= 0 {
+				return Rule{}, fmt.Errorf("%w: invalid interval %q", ErrInvalidRule, raw)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(raw)
+			if err != nil || count <= 0 {
+				return Rule{}, fmt.Errorf("%w: invalid count %q", ErrInvalidRule, raw)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRuleTime(raw)
+			if err != nil {
+				return Rule{}, fmt.Errorf("%w: invalid until %q", ErrInvalidRule, raw)
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, code := range strings.Split(raw, ",") {
+				day, err := parseWeekdayNum(code)
+				if err != nil {
+					return Rule{}, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, entry := range strings.Split(raw, ",") {
+				day, err := strconv.Atoi(strings.TrimSpace(entry))
+				if err != nil || day == 0 || day > 31 || day < -31 {
+					return Rule{}, fmt.Errorf("%w: invalid month day %q", ErrInvalidRule, entry)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		case "WKST":
+			// Weeks always start on Monday (the RFC 5545 default); other values are accepted but ignored.
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Frequency != FrequencyMonthly && len(rule.ByMonthDay) > 0 {
+		return Rule{}, fmt.Errorf("%w: BYMONTHDAY requires FREQ=MONTHLY", ErrInvalidRule)
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Frequency != FrequencyMonthly {
+			return Rule{}, fmt.Errorf("%w: numbered BYDAY requires FREQ=MONTHLY", ErrInvalidRule)
+		}
+	}
+	return rule, nil
+}
+
+// String renders the rule back into RRULE syntax without the "RRULE:" prefix.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			code := weekdayCode(day.Weekday)
+			if day.N != 0 {
+				code = strconv.Itoa(day.N) + code
+			}
+			codes = append(codes, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between returns the occurrence start times in [from, to) for a series beginning at dtstart.
+// Expansion happens in dtstart's location so wall-clock times survive DST changes.
+func (r Rule) Between(dtstart, from, to time.Time) []time.Time {
+	if !to.After(from) {
+		return nil
+	}
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	var (
+		result  []time.Time
+		emitted int
+	)
+	first := 0
+	if r.Count == 0 {
+		// Without COUNT, earlier periods cannot affect the result, so jump close to from.
+		first = r.periodsBefore(dtstart, from) / interval
+		if first > 0 {
+			first--
+		}
+	}
+	for period := first; period < first+maxPeriods; period++ {
+		candidates := r.periodCandidates(dtstart, period*interval)
+		if len(candidates) == 0 && r.periodStart(dtstart, period*interval).After(to) {
+			break
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return result
+			}
+			if r.Count > 0 && emitted >= r.Count {
+				return result
+			}
+			emitted++
+			if !candidate.Before(to) {
+				return result
+			}
+			if !candidate.Before(from) {
+				result = append(result, candidate)
+			}
+		}
+	}
+	return result
+}
+
+// periodsBefore approximates how many whole frequency units separate dtstart from t.
+func (r Rule) periodsBefore(dtstart, t time.Time) int {
+	if !t.After(dtstart) {
+		return 0
+	}
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return int(t.Sub(startOfWeek(dtstart)).Hours() / (24 * 7))
+	case FrequencyMonthly:
+		return (t.Year()-dtstart.Year())*12 + int(t.Month()) - int(dtstart.Month())
+	default:
+		return int(t.Sub(dtstart).Hours() / 24)
+	}
+}
+
+func (r Rule) periodStart(dtstart time.Time, offset int) time.Time {
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return startOfWeek(dtstart).AddDate(0, 0, offset*7)
+	case FrequencyMonthly:
+		return time.Date(dtstart.Year(), dtstart.Month()+time.Month(offset), 1, 0, 0, 0, 0, dtstart.Location())
+	default:
+		return dtstart.AddDate(0, 0, offset)
+	}
+}
+
+func (r Rule) periodCandidates(dtstart time.Time, offset int) []time.Time {
+	loc := dtstart.Location()
+	hour, minute, second := dtstart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, dtstart.Nanosecond(), loc)
+	}
+
+	var candidates []time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		day := dtstart.AddDate(0, 0, offset)
+		if r.matchesWeekday(day.Weekday()) {
+			candidates = append(candidates, day)
+		}
+	case FrequencyWeekly:
+		weekStart := startOfWeek(dtstart).AddDate(0, 0, offset*7)
+		if len(r.ByDay) == 0 {
+			shift := (int(dtstart.Weekday()) + 6) % 7
+			day := weekStart.AddDate(0, 0, shift)
+			candidates = append(candidates, at(day.Year(), day.Month(), day.Day()))
+			break
+		}
+		for _, entry := range r.ByDay {
+			shift := (int(entry.Weekday) + 6) % 7
+			day := weekStart.AddDate(0, 0, shift)
+			candidates = append(candidates, at(day.Year(), day.Month(), day.Day()))
+		}
+	case FrequencyMonthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		year, month := first.Year(), first.Month()
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+
+		switch {
+		case len(r.ByMonthDay) > 0:
+			for _, day := range r.ByMonthDay {
+				if day < 0 {
+					day = daysInMonth + day + 1
+				}
+				if day < 1 || day > daysInMonth {
+					continue
+				}
+				candidate := at(year, month, day)
+				if r.matchesWeekday(candidate.Weekday()) {
+					candidates = append(candidates, candidate)
+				}
+			}
+		case len(r.ByDay) > 0:
+			for _, entry := range r.ByDay {
+				for _, day := range monthWeekdays(year, month, daysInMonth, loc, entry) {
+					candidates = append(candidates, at(year, month, day))
+				}
+			}
+		default:
+			if dtstart.Day() <= daysInMonth {
+				candidates = append(candidates, at(year, month, dtstart.Day()))
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return dedupeTimes(candidates)
+}
+
+// matchesWeekday applies BYDAY as a filter for daily rules and BYMONTHDAY-based monthly rules.
+func (r Rule) matchesWeekday(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 || (r.Frequency == FrequencyMonthly && len(r.ByMonthDay) == 0) {
+		return true
+	}
+	for _, entry := range r.ByDay {
+		if entry.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func monthWeekdays(year int, month time.Month, daysInMonth int, loc *time.Location, entry WeekdayNum) []int {
+	var days []int
+	for day := 1; day <= daysInMonth; day++ {
+		if time.Date(year, month, day, 0, 0, 0, 0, loc).Weekday() == entry.Weekday {
+			days = append(days, day)
+		}
+	}
+	switch {
+	case entry.N == 0:
+		return days
+	case entry.N > 0 && entry.N <= len(days):
+		return []int{days[entry.N-1]}
+	case entry.N < 0 && -entry.N <= len(days):
+		return []int{days[len(days)+entry.N]}
+	default:
+		return nil
+	}
+}
+
+func startOfWeek(t time.Time) time.Time {
+	shift := (int(t.Weekday()) + 6) % 7
+	day := t.AddDate(0, 0, -shift)
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func dedupeTimes(values []time.Time) []time.Time {
+	if len(values) < 2 {
+		return values
+	}
+	result := values[:1]
+	for _, value := range values[1:] {
+		if !value.Equal(result[len(result)-1]) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func parseWeekdayNum(code string) (WeekdayNum, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 2 {
+		return WeekdayNum{}, fmt.Errorf("%w: invalid weekday %q", ErrInvalidRule, code)
+	}
+	weekday, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("%w: invalid weekday %q", ErrInvalidRule, code)
+	}
+	entry := WeekdayNum{Weekday: weekday}
+	if prefix := code[:len(code)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n > 5 || n < -5 {
+			return WeekdayNum{}, fmt.Errorf("%w: invalid weekday ordinal %q", ErrInvalidRule, code)
+		}
+		entry.N = n
+	}
+	return entry, nil
+}
+
+func weekdayCode(weekday time.Weekday) string {
+	for code, value := range weekdayCodes {
+		if value == weekday {
+			return code
+		}
+	}
+	return ""
+}
+
+func parseRuleTime(value string) (time.Time, error) {
+	layouts := []string{"20060102T150405Z", "20060102T150405", "20060102"}
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// A date-only UNTIL includes the whole day.
+				return parsed.Add(24*time.Hour - time.Nanosecond), nil
+			}
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported time format %q", value)
+}
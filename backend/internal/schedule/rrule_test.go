@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/model"
+)
+
+func TestParseRuleRoundTrip(t *testing.T) {
+	rule, err := ParseRule("RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;UNTIL=20250101T000000Z")
+	require.NoError(t, err)
+	require.Equal(t, FrequencyMonthly, rule.Frequency)
+	require.Equal(t, 2, rule.Interval)
+	require.Equal(t, []WeekdayNum{{Weekday: time.Friday, N: -1}}, rule.ByDay)
+	require.Equal(t, "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;UNTIL=20250101T000000Z", rule.String())
+
+	_, err = ParseRule("FREQ=YEARLY")
+	require.ErrorIs(t, err, ErrInvalidRule)
+	_, err = ParseRule("FREQ=WEEKLY;BYDAY=2WE")
+	require.ErrorIs(t, err, ErrInvalidRule)
+}
+
+func TestRuleBetweenWeekly(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	rule, err := ParseRule("FREQ=WEEKLY;BYDAY=WE")
+	require.NoError(t, err)
+
+	dtstart := time.Date(2024, 5, 1, 12, 0, 0, 0, tokyo) // Wednesday
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, tokyo)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, tokyo)
+
+	occurrences := rule.Between(dtstart, from, to)
+	require.Len(t, occurrences, 4)
+	for _, occurrence := range occurrences {
+		require.Equal(t, time.Wednesday, occurrence.Weekday())
+		require.Equal(t, 12, occurrence.Hour())
+	}
+	require.Equal(t, 5, occurrences[0].Day())
+}
+
+func TestRuleBetweenMonthlyCountAndMonthDay(t *testing.T) {
+	rule, err := ParseRule("FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3")
+	require.NoError(t, err)
+
+	dtstart := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	occurrences := rule.Between(dtstart, dtstart, dtstart.AddDate(2, 0, 0))
+	require.Len(t, occurrences, 3)
+	require.Equal(t, time.March, occurrences[1].Month())
+	require.Equal(t, time.May, occurrences[2].Month())
+}
+
+func TestRuleBetweenDailyFarFuture(t *testing.T) {
+	rule, err := ParseRule("FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR")
+	require.NoError(t, err)
+
+	dtstart := time.Date(2000, 1, 3, 8, 0, 0, 0, time.UTC)
+	from := time.Date(2040, 1, 2, 0, 0, 0, 0, time.UTC) // Monday
+	occurrences := rule.Between(dtstart, from, from.AddDate(0, 0, 7))
+	require.Len(t, occurrences, 5)
+}
+
+func TestExpandBlackouts(t *testing.T) {
+	start := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	blackouts := []model.ScheduleBlackout{
+		{ID: 1, StartTime: start, EndTime: start.Add(time.Hour), Recurrence: "FREQ=WEEKLY", Timezone: "Asia/Tokyo"},
+		{ID: 2, StartTime: start.AddDate(0, 0, 1), EndTime: start.AddDate(0, 0, 1).Add(2 * time.Hour)},
+		{ID: 3, StartTime: start.AddDate(1, 0, 0), EndTime: start.AddDate(1, 0, 0).Add(time.Hour)},
+	}
+
+	// The window starts mid-occurrence so the overlapping occurrence must still be returned.
+	from := start.AddDate(0, 0, 7).Add(30 * time.Minute)
+	to := from.AddDate(0, 0, 7)
+	windows := ExpandBlackouts(blackouts, from, to)
+	require.Len(t, windows, 2)
+	require.True(t, windows[0].Start.Equal(start.AddDate(0, 0, 7)))
+	require.True(t, windows[1].Start.Equal(start.AddDate(0, 0, 14)))
+	require.Equal(t, model.BusyWindowSourceBlackout, windows[0].Source)
+
+	windows = ExpandBlackouts(blackouts, start, start.AddDate(0, 0, 2))
+	require.Len(t, windows, 2)
+}
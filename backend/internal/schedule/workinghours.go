@@ -0,0 +1,207 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/model"
+)
+
+// ErrInvalidWorkingHours indicates a working hours definition that cannot be applied.
+var ErrInvalidWorkingHours = errors.New("schedule: invalid working hours")
+
+const (
+	dateLayout    = "2006-01-02"
+	minutesPerDay = 24 * 60
+)
+
+// DefaultWorkingHours returns a schedule open from startHour to endHour on every weekday.
+func DefaultWorkingHours(startHour, endHour int) model.WorkingHours {
+	interval := model.WorkingInterval{
+		Start: formatClock(startHour * 60),
+		End:   formatClock(endHour * 60),
+	}
+	weekly := make([]model.WeekdayWorkingHours, 0, 7)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		weekly = append(weekly, model.WeekdayWorkingHours{
+			Weekday:   day,
+			Intervals: []model.WorkingInterval{interval},
+		})
+	}
+	return model.WorkingHours{Weekly: weekly}
+}
+
+// NormalizeWorkingHours validates hours and returns a canonical copy with trimmed values,
+// zero-padded clock times, weekdays and overrides sorted, and intervals ordered by start.
+func NormalizeWorkingHours(hours model.WorkingHours) (model.WorkingHours, error) {
+	result := model.WorkingHours{
+		Weekly:    make([]model.WeekdayWorkingHours, 0, len(hours.Weekly)),
+		Overrides: make([]model.WorkingHoursOverride, 0, len(hours.Overrides)),
+	}
+
+	seenDays := make(map[time.Weekday]struct{}, len(hours.Weekly))
+	for _, day := range hours.Weekly {
+		if day.Weekday < time.Sunday || day.Weekday > time.Saturday {
+			return model.WorkingHours{}, fmt.Errorf("%w: weekday %d out of range", ErrInvalidWorkingHours, day.Weekday)
+		}
+		if _, ok := seenDays[day.Weekday]; ok {
+			return model.WorkingHours{}, fmt.Errorf("%w: duplicate weekday %s", ErrInvalidWorkingHours, day.Weekday)
+		}
+		seenDays[day.Weekday] = struct{}{}
+
+		intervals, err := normalizeIntervals(day.Intervals)
+		if err != nil {
+			return model.WorkingHours{}, fmt.Errorf("%w (%s)", err, day.Weekday)
+		}
+		result.Weekly = append(result.Weekly, model.WeekdayWorkingHours{Weekday: day.Weekday, Intervals: intervals})
+	}
+
+	seenDates := make(map[string]struct{}, len(hours.Overrides))
+	for _, override := range hours.Overrides {
+		date := strings.TrimSpace(override.Date)
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return model.WorkingHours{}, fmt.Errorf("%w: override date %q must be YYYY-MM-DD", ErrInvalidWorkingHours, override.Date)
+		}
+		if _, ok := seenDates[date]; ok {
+			return model.WorkingHours{}, fmt.Errorf("%w: duplicate override date %s", ErrInvalidWorkingHours, date)
+		}
+		seenDates[date] = struct{}{}
+
+		intervals, err := normalizeIntervals(override.Intervals)
+		if err != nil {
+			return model.WorkingHours{}, fmt.Errorf("%w (%s)", err, date)
+		}
+		result.Overrides = append(result.Overrides, model.WorkingHoursOverride{
+			Date:      date,
+			Intervals: intervals,
+			Note:      strings.TrimSpace(override.Note),
+		})
+	}
+
+	sort.Slice(result.Weekly, func(i, j int) bool {
+		return result.Weekly[i].Weekday < result.Weekly[j].Weekday
+	})
+	sort.Slice(result.Overrides, func(i, j int) bool {
+		return result.Overrides[i].Date < result.Overrides[j].Date
+	})
+	return result, nil
+}
+
+// WindowsOn returns the open windows for the calendar date of day in loc. Overrides take
+// precedence over the weekly schedule. Invalid intervals are skipped.
+func WindowsOn(hours model.WorkingHours, day time.Time, loc *time.Location) []model.TimeWindow {
+	local := day.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	intervals := intervalsFor(hours, midnight)
+	windows := make([]model.TimeWindow, 0, len(intervals))
+	for _, interval := range intervals {
+		start, err := ParseClock(interval.Start)
+		if err != nil {
+			continue
+		}
+		end, err := ParseClock(interval.End)
+		if err != nil || end <= start {
+			continue
+		}
+		windows = append(windows, model.TimeWindow{
+			Start: clockOn(midnight, start, loc),
+			End:   clockOn(midnight, end, loc),
+		})
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	return windows
+}
+
+// Contains reports whether [start, end) falls entirely inside one open window on start's date.
+func Contains(hours model.WorkingHours, start, end time.Time, loc *time.Location) bool {
+	for _, window := range WindowsOn(hours, start, loc) {
+		if !start.Before(window.Start) && !end.After(window.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock converts "HH:MM" into minutes after midnight. "24:00" is accepted as end of day.
+func ParseClock(value string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 2 || parts[0] == "" || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidWorkingHours, value)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidWorkingHours, value)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidWorkingHours, value)
+	}
+	total := hour*60 + minute
+	if hour < 0 || total > minutesPerDay {
+		return 0, fmt.Errorf("%w: time %q is out of range", ErrInvalidWorkingHours, value)
+	}
+	return total, nil
+}
+
+func intervalsFor(hours model.WorkingHours, midnight time.Time) []model.WorkingInterval {
+	date := midnight.Format(dateLayout)
+	for _, override := range hours.Overrides {
+		if strings.TrimSpace(override.Date) == date {
+			return override.Intervals
+		}
+	}
+	for _, day := range hours.Weekly {
+		if day.Weekday == midnight.Weekday() {
+			return day.Intervals
+		}
+	}
+	return nil
+}
+
+func normalizeIntervals(intervals []model.WorkingInterval) ([]model.WorkingInterval, error) {
+	type span struct{ start, end int }
+
+	spans := make([]span, 0, len(intervals))
+	for _, interval := range intervals {
+		start, err := ParseClock(interval.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := ParseClock(interval.End)
+		if err != nil {
+			return nil, err
+		}
+		if end <= start {
+			return nil, fmt.Errorf("%w: interval %s-%s must end after it starts", ErrInvalidWorkingHours, interval.Start, interval.End)
+		}
+		spans = append(spans, span{start: start, end: end})
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	result := make([]model.WorkingInterval, 0, len(spans))
+	for i, current := range spans {
+		if i > 0 && current.start < spans[i-1].end {
+			return nil, fmt.Errorf("%w: intervals %s-%s and %s-%s overlap", ErrInvalidWorkingHours,
+				formatClock(spans[i-1].start), formatClock(spans[i-1].end), formatClock(current.start), formatClock(current.end))
+		}
+		result = append(result, model.WorkingInterval{Start: formatClock(current.start), End: formatClock(current.end)})
+	}
+	return result, nil
+}
+
+// clockOn resolves minutes after midnight on the given date, letting time.Date normalise
+// 24:00 into the next day and DST gaps into valid instants.
+func clockOn(midnight time.Time, minutes int, loc *time.Location) time.Time {
+	return time.Date(midnight.Year(), midnight.Month(), midnight.Day(), minutes/60, minutes%60, 0, 0, loc)
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,67 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/model"
+)
+
+func TestNormalizeWorkingHours(t *testing.T) {
+	hours, err := NormalizeWorkingHours(model.WorkingHours{
+		Weekly: []model.WeekdayWorkingHours{
+			{Weekday: time.Friday, Intervals: []model.WorkingInterval{{Start: "13:15", End: "17:00"}, {Start: "9:30", End: "12:00"}}},
+			{Weekday: time.Monday, Intervals: []model.WorkingInterval{{Start: "10:00", End: "24:00"}}},
+		},
+		Overrides: []model.WorkingHoursOverride{
+			{Date: " 2024-06-05 ", Note: " holiday "},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Monday, hours.Weekly[0].Weekday)
+	require.Equal(t, []model.WorkingInterval{{Start: "09:30", End: "12:00"}, {Start: "13:15", End: "17:00"}}, hours.Weekly[1].Intervals)
+	require.Equal(t, "2024-06-05", hours.Overrides[0].Date)
+	require.Equal(t, "holiday", hours.Overrides[0].Note)
+
+	invalid := []model.WorkingHours{
+		{Weekly: []model.WeekdayWorkingHours{{Weekday: time.Monday, Intervals: []model.WorkingInterval{{Start: "10:00", End: "12:00"}, {Start: "11:30", End: "13:00"}}}}},
+		{Weekly: []model.WeekdayWorkingHours{{Weekday: time.Monday}, {Weekday: time.Monday}}},
+		{Weekly: []model.WeekdayWorkingHours{{Weekday: 7}}},
+		{Weekly: []model.WeekdayWorkingHours{{Weekday: time.Monday, Intervals: []model.WorkingInterval{{Start: "18:00", End: "09:00"}}}}},
+		{Weekly: []model.WeekdayWorkingHours{{Weekday: time.Monday, Intervals: []model.WorkingInterval{{Start: "09:60", End: "10:00"}}}}},
+		{Overrides: []model.WorkingHoursOverride{{Date: "06/05/2024"}}},
+	}
+	for _, hours := range invalid {
+		_, err := NormalizeWorkingHours(hours)
+		require.ErrorIs(t, err, ErrInvalidWorkingHours)
+	}
+}
+
+func TestWindowsOnAppliesOverrides(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	hours := model.WorkingHours{
+		Weekly: []model.WeekdayWorkingHours{
+			{Weekday: time.Wednesday, Intervals: []model.WorkingInterval{{Start: "09:30", End: "12:00"}, {Start: "13:00", End: "18:00"}}},
+		},
+		Overrides: []model.WorkingHoursOverride{
+			{Date: "2024-06-12"},
+			{Date: "2024-06-15", Intervals: []model.WorkingInterval{{Start: "10:00", End: "11:00"}}},
+		},
+	}
+
+	windows := WindowsOn(hours, time.Date(2024, 6, 5, 15, 0, 0, 0, tokyo), tokyo)
+	require.Len(t, windows, 2)
+	require.True(t, windows[0].Start.Equal(time.Date(2024, 6, 5, 9, 30, 0, 0, tokyo)))
+	require.True(t, windows[1].End.Equal(time.Date(2024, 6, 5, 18, 0, 0, 0, tokyo)))
+
+	require.Empty(t, WindowsOn(hours, time.Date(2024, 6, 12, 0, 0, 0, 0, tokyo), tokyo))
+	require.Empty(t, WindowsOn(hours, time.Date(2024, 6, 13, 0, 0, 0, 0, tokyo), tokyo))
+	require.Len(t, WindowsOn(hours, time.Date(2024, 6, 15, 0, 0, 0, 0, tokyo), tokyo), 1)
+
+	require.True(t, Contains(hours, time.Date(2024, 6, 5, 11, 30, 0, 0, tokyo), time.Date(2024, 6, 5, 12, 0, 0, 0, tokyo), tokyo))
+	require.False(t, Contains(hours, time.Date(2024, 6, 5, 11, 30, 0, 0, tokyo), time.Date(2024, 6, 5, 13, 30, 0, 0, tokyo), tokyo))
+}
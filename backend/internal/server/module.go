@@ -31,6 +31,9 @@ func newHTTPServer(
 	researchHandler *handler.ResearchHandler,
 	contactHandler *handler.ContactHandler,
 	bookingHandler *handler.BookingHandler,
+	waitlistHandler *handler.WaitlistHandler,
+	calendarFeedHandler *handler.CalendarFeedHandler,
+	notificationTemplateHandler *handler.NotificationTemplateHandler,
 	authHandler *handler.AuthHandler,
 	adminAuthHandler *handler.AdminAuthHandler,
 	sessionMiddleware *middleware.AdminSessionMiddleware,
@@ -39,9 +42,10 @@ func newHTTPServer(
 	adminModeGuard *middleware.AdminModeGuard,
 	adminRateLimiter *middleware.AdminRateLimiter,
 	securityHandler *handler.SecurityHandler,
+	idempotency *middleware.IdempotencyMiddleware,
 	metrics *telemetry.Metrics,
 ) *http.Server {
-	registerRoutes(engine, healthHandler, profileHandler, projectHandler, researchHandler, contactHandler, bookingHandler, authHandler, adminAuthHandler, sessionMiddleware, adminHandler, adminGuard, adminModeGuard, adminRateLimiter, securityHandler)
+	registerRoutes(engine, healthHandler, profileHandler, projectHandler, researchHandler, contactHandler, bookingHandler, waitlistHandler, calendarFeedHandler, notificationTemplateHandler, authHandler, adminAuthHandler, sessionMiddleware, adminHandler, adminGuard, adminModeGuard, adminRateLimiter, securityHandler, idempotency)
 	if metrics != nil {
 		metrics.Register(engine)
 	}
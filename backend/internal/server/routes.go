@@ -15,6 +15,9 @@ func registerRoutes(
 	researchHandler *handler.ResearchHandler,
 	contactHandler *handler.ContactHandler,
 	bookingHandler *handler.BookingHandler,
+	waitlistHandler *handler.WaitlistHandler,
+	calendarFeedHandler *handler.CalendarFeedHandler,
+	notificationTemplateHandler *handler.NotificationTemplateHandler,
 	authHandler *handler.AuthHandler,
 	adminAuthHandler *handler.AdminAuthHandler,
 	sessionMiddleware *middleware.AdminSessionMiddleware,
@@ -23,7 +26,11 @@ func registerRoutes(
 	adminModeGuard *middleware.AdminModeGuard,
 	adminRateLimiter *middleware.AdminRateLimiter,
 	securityHandler *handler.SecurityHandler,
+	idempotency *middleware.IdempotencyMiddleware,
 ) {
+	// Public POST endpoints accept an Idempotency-Key so retries do not book or submit twice.
+	idempotent := idempotency.Handler()
+
 	api := r.Group("/api")
 	{
 		api.GET("/health", healthHandler.Ping)
@@ -33,9 +40,15 @@ func registerRoutes(
 		api.GET("/research", researchHandler.ListResearch)
 		api.GET("/contact/availability", contactHandler.GetAvailability)
 		api.GET("/contact/config", contactHandler.GetConfig)
-		api.POST("/contact", contactHandler.SubmitContact)
-		api.POST("/contact/bookings", bookingHandler.CreateBooking)
+		api.POST("/contact", idempotent, contactHandler.SubmitContact)
+		api.POST("/contact/bookings", idempotent, bookingHandler.CreateBooking)
 		api.GET("/contact/bookings/:lookupHash", bookingHandler.GetReservation)
+		api.POST("/contact/bookings/:lookupHash/cancel", idempotent, bookingHandler.CancelReservation)
+		api.POST("/contact/bookings/:lookupHash/reschedule", idempotent, bookingHandler.RescheduleReservation)
+		api.POST("/contact/waitlist", idempotent, waitlistHandler.JoinWaitlist)
+		api.GET("/contact/waitlist/offer", waitlistHandler.GetOffer)
+		api.POST("/contact/waitlist/claim", idempotent, waitlistHandler.ClaimOffer)
+		api.GET("/feeds/reservations.ics", calendarFeedHandler.ReservationsFeed)
 		api.GET("/auth/login", authHandler.Login)
 		api.GET("/auth/callback", authHandler.Callback)
 		if securityHandler != nil {
@@ -57,9 +70,14 @@ func registerRoutes(
 		publicV1.GET("/research", researchHandler.ListResearch)
 		publicV1.GET("/contact/availability", contactHandler.GetAvailability)
 		publicV1.GET("/contact/config", contactHandler.GetConfig)
-		publicV1.POST("/contact", contactHandler.SubmitContact)
-		publicV1.POST("/contact/bookings", bookingHandler.CreateBooking)
+		publicV1.POST("/contact", idempotent, contactHandler.SubmitContact)
+		publicV1.POST("/contact/bookings", idempotent, bookingHandler.CreateBooking)
 		publicV1.GET("/contact/bookings/:lookupHash", bookingHandler.GetReservation)
+		publicV1.POST("/contact/bookings/:lookupHash/cancel", idempotent, bookingHandler.CancelReservation)
+		publicV1.POST("/contact/bookings/:lookupHash/reschedule", idempotent, bookingHandler.RescheduleReservation)
+		publicV1.POST("/contact/waitlist", idempotent, waitlistHandler.JoinWaitlist)
+		publicV1.GET("/contact/waitlist/offer", waitlistHandler.GetOffer)
+		publicV1.POST("/contact/waitlist/claim", idempotent, waitlistHandler.ClaimOffer)
 	}
 
 	admin := api.Group("/admin")
@@ -114,17 +132,39 @@ func registerRoutes(
 		admin.PUT("/meeting-url", adminHandler.UpdateMeetingURLTemplate)
 
 		admin.GET("/contacts", adminHandler.ListContacts)
+		admin.GET("/contacts/export", adminHandler.ExportContacts)
 		admin.GET("/contacts/:id", adminHandler.GetContact)
 		admin.PUT("/contacts/:id", adminHandler.UpdateContact)
 		admin.DELETE("/contacts/:id", adminHandler.DeleteContact)
+		admin.POST("/contacts/:id/spam", adminHandler.MarkContactSpam)
+		admin.POST("/contacts/:id/ham", adminHandler.MarkContactHam)
 
 		admin.GET("/blacklist", adminHandler.ListBlacklist)
 		admin.POST("/blacklist", adminHandler.CreateBlacklist)
 		admin.PUT("/blacklist/:id", adminHandler.UpdateBlacklist)
 		admin.DELETE("/blacklist/:id", adminHandler.DeleteBlacklist)
+		admin.GET("/blackouts", adminHandler.ListBlackouts)
+		admin.POST("/blackouts", adminHandler.CreateBlackout)
+		admin.GET("/blackouts/:id", adminHandler.GetBlackout)
+		admin.PUT("/blackouts/:id", adminHandler.UpdateBlackout)
+		admin.DELETE("/blackouts/:id", adminHandler.DeleteBlackout)
 
 		admin.GET("/reservations", adminHandler.ListReservations)
+		admin.GET("/reservations/export", adminHandler.ExportReservations)
+		admin.GET("/reservations/drift", adminHandler.ListReservationDrift)
+		admin.POST("/reservations/drift/:id/resolve", adminHandler.ResolveReservationDrift)
 		admin.PUT("/reservations/:id", adminHandler.UpdateReservationStatus)
 		admin.POST("/reservations/:id/retry", adminHandler.RetryReservationNotification)
+
+		admin.GET("/calendar-feed/tokens", calendarFeedHandler.ListTokens)
+		admin.POST("/calendar-feed/tokens", calendarFeedHandler.IssueToken)
+		admin.DELETE("/calendar-feed/tokens/:id", calendarFeedHandler.RevokeToken)
+
+		admin.GET("/notification-templates", notificationTemplateHandler.ListTemplates)
+		admin.GET("/notification-templates/:key", notificationTemplateHandler.GetTemplate)
+		admin.PUT("/notification-templates/:key", notificationTemplateHandler.UpdateTemplate)
+		admin.DELETE("/notification-templates/:key", notificationTemplateHandler.ResetTemplate)
+		admin.POST("/notification-templates/:key/preview", notificationTemplateHandler.PreviewTemplate)
+		admin.POST("/notification-templates/:key/test", notificationTemplateHandler.SendTestNotification)
 	}
 }
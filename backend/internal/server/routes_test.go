@@ -14,9 +14,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 
+	"github.com/takumi/personal-website/internal/captcha"
 	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/handler"
 	"github.com/takumi/personal-website/internal/logging"
+	"github.com/takumi/personal-website/internal/mail"
 	"github.com/takumi/personal-website/internal/middleware"
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository/inmemory"
@@ -40,7 +42,7 @@ func TestRegisterRoutes(t *testing.T) {
 	)
 	projectSvc := service.NewProjectService(inmemory.NewProjectDocumentRepository())
 	researchSvc := service.NewResearchService(inmemory.NewResearchDocumentRepository())
-	contactSvc := service.NewContactService(inmemory.NewContactRepository(), inmemory.NewContactFormSettingsRepository())
+	contactSvc := service.NewContactService(inmemory.NewContactRepository(), inmemory.NewContactFormSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, nil, nil)
 	availabilitySvc := &stubAvailabilityService{
 		response: &model.AvailabilityResponse{
 			Timezone:    "Asia/Tokyo",
@@ -89,6 +91,25 @@ func TestRegisterRoutes(t *testing.T) {
 
 	sessionManager := &stubSessionManager{}
 	adminSvc := &stubAdminService{}
+	mailer := &recordingMailClient{}
+	feedSvc, err := service.NewCalendarFeedService(
+		inmemory.NewCalendarFeedTokenRepository(),
+		inmemory.NewMeetingReservationRepository(),
+		inmemory.NewScheduleBlackoutRepository(),
+		appCfg,
+	)
+	require.NoError(t, err)
+	templateSvc, err := service.NewNotificationTemplateService(inmemory.NewNotificationTemplateRepository(), mailer, appCfg)
+	require.NoError(t, err)
+	waitlistSvc, err := service.NewWaitlistService(
+		inmemory.NewWaitlistRepository(),
+		inmemory.NewBookingOutboxRepository(inmemory.NewMeetingReservationRepository()),
+		inmemory.NewBlacklistRepository(),
+		inmemory.NewContactFormSettingsRepository(),
+		captcha.NewFakeVerifier("fail"),
+		appCfg,
+	)
+	require.NoError(t, err)
 
 	registerRoutes(
 		engine,
@@ -98,6 +119,9 @@ func TestRegisterRoutes(t *testing.T) {
 		handler.NewResearchHandler(researchSvc),
 		handler.NewContactHandler(contactSvc, availabilitySvc, appCfg),
 		handler.NewBookingHandler(&stubBookingService{}),
+		handler.NewWaitlistHandler(waitlistSvc),
+		handler.NewCalendarFeedHandler(feedSvc),
+		handler.NewNotificationTemplateHandler(templateSvc),
 		handler.NewAuthHandler(&stubAuthService{}),
 		handler.NewAdminAuthHandler(&stubAdminAuthService{}, sessionManager, appCfg.Auth),
 		middleware.NewAdminSessionMiddleware(sessionManager, appCfg.Auth),
@@ -106,6 +130,7 @@ func TestRegisterRoutes(t *testing.T) {
 		middleware.NewAdminModeGuard(),
 		middleware.NewAdminRateLimiter(noopLifecycle{}, appCfg),
 		nil,
+		middleware.NewIdempotencyMiddleware(noopLifecycle{}, inmemory.NewIdempotencyRepository(), appCfg),
 	)
 
 	t.Run("health route ok", func(t *testing.T) {
@@ -144,6 +169,58 @@ func TestRegisterRoutes(t *testing.T) {
 		require.Contains(t, rec.Body.String(), `"supportEmail"`)
 	})
 
+	t.Run("waitlist routes join once and reject unknown offers", func(t *testing.T) {
+		body, err := json.Marshal(model.WaitlistRequest{
+			Name:           "Grace Hopper",
+			Email:          "grace@example.com",
+			PreferredDates: []string{time.Now().Add(72 * time.Hour).UTC().Format("2006-01-02")},
+			RecaptchaToken: "test-token",
+		})
+		require.NoError(t, err)
+
+		rec := performRequest(engine, http.MethodPost, "/api/contact/waitlist", body)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.Contains(t, rec.Body.String(), `"status":"waiting"`)
+
+		rec = performRequest(engine, http.MethodPost, "/api/v1/public/contact/waitlist", body)
+		require.Equal(t, http.StatusConflict, rec.Code)
+
+		rec = performRequest(engine, http.MethodGet, "/api/contact/waitlist/offer?token=unknown", nil)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("reservations feed requires a live token", func(t *testing.T) {
+		rec := performRequest(engine, http.MethodGet, "/api/feeds/reservations.ics", nil)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		issued, err := feedSvc.IssueToken(context.Background(), "phone")
+		require.NoError(t, err)
+		rec = performRequest(engine, http.MethodGet, issued.FeedPath, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Header().Get("Content-Type"), "text/calendar")
+		require.True(t, strings.HasPrefix(rec.Body.String(), "BEGIN:VCALENDAR\r\n"))
+
+		_, err = feedSvc.RevokeToken(context.Background(), issued.ID)
+		require.NoError(t, err)
+		rec = performRequest(engine, http.MethodGet, issued.FeedPath, nil)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("notification template test-send goes to the signed-in admin", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/admin/notification-templates/booking_reminder/test?mode=admin", strings.NewReader(`{"locale":"en"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin-session-stub")
+
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, mailer.sent, 1)
+		require.Equal(t, []string{"admin@example.com"}, mailer.sent[0].To)
+		require.True(t, strings.HasPrefix(mailer.sent[0].Subject, "[Test] Reminder: meeting at "))
+	})
+
 	t.Run("booking lookup route returns reservation", func(t *testing.T) {
 		t.Helper()
 		rec := performRequest(engine, http.MethodGet, "/api/contact/bookings/lookup-123", nil)
@@ -151,6 +228,26 @@ func TestRegisterRoutes(t *testing.T) {
 		require.Contains(t, rec.Body.String(), `"lookup-123"`)
 	})
 
+	t.Run("public booking cancel route", func(t *testing.T) {
+		t.Helper()
+		rec := performRequest(engine, http.MethodPost, "/api/v1/public/contact/bookings/lookup-123/cancel", []byte(`{"reason":"conflict"}`))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"lookup-123"`)
+	})
+
+	t.Run("public booking reschedule route", func(t *testing.T) {
+		t.Helper()
+		body, err := json.Marshal(model.RescheduleRequest{
+			StartTime:       time.Now().Add(4 * time.Hour).UTC(),
+			DurationMinutes: 30,
+		})
+		require.NoError(t, err)
+
+		rec := performRequest(engine, http.MethodPost, "/api/v1/public/contact/bookings/lookup-123/reschedule", body)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"lookup-123"`)
+	})
+
 	t.Run("profile route returns data", func(t *testing.T) {
 		t.Helper()
 		rec := performRequest(engine, http.MethodGet, "/api/profile", nil)
@@ -177,9 +274,10 @@ func TestRegisterRoutes(t *testing.T) {
 	t.Run("contact route accepts payload", func(t *testing.T) {
 		t.Helper()
 		body, err := json.Marshal(model.ContactRequest{
-			Name:    "Ada Lovelace",
-			Email:   "ada@example.com",
-			Message: "I'd like to learn more about your research.",
+			Name:           "Ada Lovelace",
+			Email:          "ada@example.com",
+			Message:        "I'd like to learn more about your research.",
+			RecaptchaToken: "test-token",
 		})
 		require.NoError(t, err)
 
@@ -188,6 +286,25 @@ func TestRegisterRoutes(t *testing.T) {
 		require.Contains(t, rec.Body.String(), `"data"`)
 	})
 
+	t.Run("contact route rejects failed human verification", func(t *testing.T) {
+		t.Helper()
+		body, err := json.Marshal(model.ContactRequest{
+			Name:           "Bot",
+			Email:          "bot@example.com",
+			Message:        "spam",
+			RecaptchaToken: "fail",
+		})
+		require.NoError(t, err)
+
+		rec := performRequest(engine, http.MethodPost, "/api/contact", body)
+		require.Equal(t, http.StatusForbidden, rec.Code)
+
+		body, err = json.Marshal(model.ContactRequest{Name: "Bot", Email: "bot@example.com", Message: "spam"})
+		require.NoError(t, err)
+		rec = performRequest(engine, http.MethodPost, "/api/contact", body)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
 	t.Run("availability route returns data", func(t *testing.T) {
 		t.Helper()
 		rec := performRequest(engine, http.MethodGet, "/api/contact/availability", nil)
@@ -253,7 +370,7 @@ func TestSecurityAndObservabilityFlow(t *testing.T) {
 		require.GreaterOrEqual(t, len(parts), 3)
 		require.Equal(t, payload.Data.Token, parts[0])
 
-		body := []byte(`{"name":"Tester","email":"tester@example.com","message":"hello"}`)
+		body := []byte(`{"name":"Tester","email":"tester@example.com","message":"hello","recaptchaToken":"test-token"}`)
 
 		// Missing header should be rejected.
 		failRec := httptest.NewRecorder()
@@ -429,7 +546,7 @@ func newSecurityTestEngine(t *testing.T, cfg *config.AppConfig) *gin.Engine {
 	)
 	projectSvc := service.NewProjectService(inmemory.NewProjectDocumentRepository())
 	researchSvc := service.NewResearchService(inmemory.NewResearchDocumentRepository())
-	contactSvc := service.NewContactService(inmemory.NewContactRepository(), inmemory.NewContactFormSettingsRepository())
+	contactSvc := service.NewContactService(inmemory.NewContactRepository(), inmemory.NewContactFormSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, nil, nil)
 	availabilitySvc := &stubAvailabilityService{
 		response: &model.AvailabilityResponse{
 			Timezone:    "Asia/Tokyo",
@@ -467,6 +584,25 @@ func newSecurityTestEngine(t *testing.T, cfg *config.AppConfig) *gin.Engine {
 	sessionManager := &stubSessionManager{}
 	adminSvc := &stubAdminService{}
 	securityHandler := handler.NewSecurityHandler(csrfManager, cfg)
+	mailer := &recordingMailClient{}
+	feedSvc, err := service.NewCalendarFeedService(
+		inmemory.NewCalendarFeedTokenRepository(),
+		inmemory.NewMeetingReservationRepository(),
+		inmemory.NewScheduleBlackoutRepository(),
+		appCfg,
+	)
+	require.NoError(t, err)
+	templateSvc, err := service.NewNotificationTemplateService(inmemory.NewNotificationTemplateRepository(), mailer, appCfg)
+	require.NoError(t, err)
+	waitlistSvc, err := service.NewWaitlistService(
+		inmemory.NewWaitlistRepository(),
+		inmemory.NewBookingOutboxRepository(inmemory.NewMeetingReservationRepository()),
+		inmemory.NewBlacklistRepository(),
+		inmemory.NewContactFormSettingsRepository(),
+		captcha.NewFakeVerifier("fail"),
+		appCfg,
+	)
+	require.NoError(t, err)
 
 	registerRoutes(
 		engine,
@@ -476,6 +612,9 @@ func newSecurityTestEngine(t *testing.T, cfg *config.AppConfig) *gin.Engine {
 		handler.NewResearchHandler(researchSvc),
 		handler.NewContactHandler(contactSvc, availabilitySvc, appCfg),
 		handler.NewBookingHandler(&stubBookingService{}),
+		handler.NewWaitlistHandler(waitlistSvc),
+		handler.NewCalendarFeedHandler(feedSvc),
+		handler.NewNotificationTemplateHandler(templateSvc),
 		handler.NewAuthHandler(&stubAuthService{}),
 		handler.NewAdminAuthHandler(&stubAdminAuthService{}, sessionManager, appCfg.Auth),
 		middleware.NewAdminSessionMiddleware(sessionManager, appCfg.Auth),
@@ -484,6 +623,7 @@ func newSecurityTestEngine(t *testing.T, cfg *config.AppConfig) *gin.Engine {
 		middleware.NewAdminModeGuard(),
 		middleware.NewAdminRateLimiter(noopLifecycle{}, cfg),
 		securityHandler,
+		middleware.NewIdempotencyMiddleware(noopLifecycle{}, inmemory.NewIdempotencyRepository(), cfg),
 	)
 
 	if metrics != nil {
@@ -588,7 +728,7 @@ func (s *stubBookingService) Book(context.Context, model.BookingRequest) (*model
 			DurationMinutes:        45,
 			GoogleEventID:          "evt-123",
 			GoogleCalendarStatus:   "confirmed",
-			Status:                 model.MeetingReservationStatusPending,
+			Status:                 model.MeetingReservationStatusRequested,
 			CreatedAt:              now,
 			UpdatedAt:              now,
 			LastNotificationSentAt: &now,
@@ -607,6 +747,10 @@ func (s *stubBookingService) CancelReservation(context.Context, string, string)
 	return s.Book(context.Background(), model.BookingRequest{})
 }
 
+func (s *stubBookingService) RescheduleReservation(context.Context, string, model.RescheduleRequest) (*model.BookingResult, error) {
+	return s.Book(context.Background(), model.BookingRequest{})
+}
+
 type stubAdminService struct{}
 
 func (s *stubAdminService) GetProfile(context.Context) (*model.AdminProfile, error) {
@@ -908,6 +1052,10 @@ func (s *stubAdminService) DeleteContactMessage(context.Context, string) error {
 	return nil
 }
 
+func (s *stubAdminService) ClassifyContactMessage(context.Context, string, model.SpamVerdict) (*model.ContactMessage, error) {
+	return &model.ContactMessage{}, nil
+}
+
 func (s *stubAdminService) GetContactSettings(context.Context) (*model.ContactFormSettingsV2, error) {
 	now := time.Now().UTC()
 	return &model.ContactFormSettingsV2{
@@ -1048,6 +1196,26 @@ func (s *stubAdminService) IsEmailBlacklisted(context.Context, string) (bool, er
 	return false, nil
 }
 
+func (s *stubAdminService) ListBlackouts(context.Context) ([]model.ScheduleBlackout, error) {
+	return nil, nil
+}
+
+func (s *stubAdminService) GetBlackout(context.Context, uint64) (*model.ScheduleBlackout, error) {
+	return &model.ScheduleBlackout{}, nil
+}
+
+func (s *stubAdminService) CreateBlackout(context.Context, adminsvc.BlackoutInput) (*model.ScheduleBlackout, error) {
+	return &model.ScheduleBlackout{}, nil
+}
+
+func (s *stubAdminService) UpdateBlackout(context.Context, uint64, adminsvc.BlackoutInput) (*model.ScheduleBlackout, error) {
+	return &model.ScheduleBlackout{}, nil
+}
+
+func (s *stubAdminService) DeleteBlackout(context.Context, uint64) error {
+	return nil
+}
+
 func (s *stubAdminService) ListReservations(context.Context, adminsvc.ReservationFilter) ([]model.MeetingReservation, error) {
 	return []model.MeetingReservation{}, nil
 }
@@ -1056,10 +1224,38 @@ func (s *stubAdminService) UpdateReservationStatus(context.Context, uint64, mode
 	return &model.MeetingReservation{}, nil
 }
 
+func (s *stubAdminService) ListReservationHistory(context.Context, uint64) ([]model.MeetingReservationStatusChange, error) {
+	return []model.MeetingReservationStatusChange{}, nil
+}
+
+func (s *stubAdminService) SuggestBlacklist(context.Context, *model.MeetingReservation) (*model.BlacklistSuggestion, error) {
+	return nil, nil
+}
+
+func (s *stubAdminService) ExportReservations(context.Context, adminsvc.ReservationExport) (*adminsvc.Export, error) {
+	return nil, nil
+}
+
+func (s *stubAdminService) ExportContactMessages(context.Context, adminsvc.ContactExport) (*adminsvc.Export, error) {
+	return nil, nil
+}
+
+func (s *stubAdminService) ListReservationDrift(context.Context, bool) ([]model.ReservationDrift, error) {
+	return nil, nil
+}
+
+func (s *stubAdminService) ResolveReservationDrift(context.Context, uint64) (*model.ReservationDrift, error) {
+	return &model.ReservationDrift{}, nil
+}
+
 func (s *stubAdminService) ListReservationNotifications(context.Context, uint64) ([]model.MeetingNotification, error) {
 	return []model.MeetingNotification{}, nil
 }
 
+func (s *stubAdminService) ListReservationNotificationAttempts(context.Context, uint64) ([]model.MeetingNotificationAttempt, error) {
+	return []model.MeetingNotificationAttempt{}, nil
+}
+
 func (s *stubAdminService) RetryReservationNotification(context.Context, uint64) (*model.MeetingReservation, error) {
 	return &model.MeetingReservation{}, nil
 }
@@ -1121,3 +1317,12 @@ func (s *stubAdminService) UpdateMeetingURLTemplate(context.Context, string) (*a
 		UpdatedAt: time.Now().UTC(),
 	}, nil
 }
+
+type recordingMailClient struct {
+	sent []mail.Message
+}
+
+func (m *recordingMailClient) Send(_ context.Context, message mail.Message) error {
+	m.sent = append(m.sent, message)
+	return nil
+}
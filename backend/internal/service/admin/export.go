@@ -0,0 +1,487 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/service/support"
+)
+
+// ExportFormat selects how exported rows are encoded.
+type ExportFormat string
+
+const (
+	// ExportFormatCSV is UTF-8 CSV with a byte order mark so Excel detects the encoding.
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatNDJSON writes one JSON object per line.
+	ExportFormatNDJSON ExportFormat = "ndjson"
+	// ExportFormatICS writes one VEVENT per reservation and ignores the column selection.
+	ExportFormatICS ExportFormat = "ics"
+)
+
+// exportFlushRows is how many rows are written between flushes of a streamed response.
+const exportFlushRows = 100
+
+// exportPageSize is how many rows each repository query of an export reads.
+const exportPageSize = 500
+
+// ReservationExport selects the reservations to export and how. An empty Format means CSV and
+// empty Columns means every column.
+type ReservationExport struct {
+	Filter  ReservationFilter
+	Format  ExportFormat
+	Columns []string
+}
+
+// ContactExport selects the contact messages to export and how.
+type ContactExport struct {
+	Filter  ContactFilter
+	Format  ExportFormat
+	Columns []string
+}
+
+// ContactFilter captures optional filters for contact message exports. Date and the created
+// range are matched against the submission time.
+type ContactFilter struct {
+	Status        []model.ContactStatus
+	Email         string
+	Date          *time.Time
+	CreatedFrom   *time.Time
+	CreatedBefore *time.Time
+}
+
+// Export is a validated export whose first page of rows is already loaded, so invalid options
+// and a failing query surface before the response starts. Write streams it, reading the
+// remaining pages as it goes, and flushes w periodically when it is an http.Flusher.
+type Export struct {
+	ContentType string
+	Extension   string
+	write       func(w io.Writer) error
+}
+
+// Write encodes the export to w.
+func (e *Export) Write(w io.Writer) error {
+	return e.write(w)
+}
+
+type exportColumn[T any] struct {
+	name  string
+	value func(item *T) any
+}
+
+var reservationExportColumns = []exportColumn[model.MeetingReservation]{
+	{"id", func(r *model.MeetingReservation) any { return r.ID }},
+	{"status", func(r *model.MeetingReservation) any { return string(r.Status) }},
+	{"name", func(r *model.MeetingReservation) any { return r.Name }},
+	{"email", func(r *model.MeetingReservation) any { return r.Email }},
+	{"topic", func(r *model.MeetingReservation) any { return r.Topic }},
+	{"message", func(r *model.MeetingReservation) any { return r.Message }},
+	{"startAt", func(r *model.MeetingReservation) any { return r.StartAt }},
+	{"endAt", func(r *model.MeetingReservation) any { return r.EndAt }},
+	{"durationMinutes", func(r *model.MeetingReservation) any { return r.DurationMinutes }},
+	{"locale", func(r *model.MeetingReservation) any { return r.Locale }},
+	{"visitorTimezone", func(r *model.MeetingReservation) any { return r.VisitorTimezone }},
+	{"intakeAnswers", func(r *model.MeetingReservation) any { return r.IntakeAnswers }},
+	{"requiresApproval", func(r *model.MeetingReservation) any { return r.RequiresApproval }},
+	{"cancellationReason", func(r *model.MeetingReservation) any { return r.CancellationReason }},
+	{"googleEventId", func(r *model.MeetingReservation) any { return r.GoogleEventID }},
+	{"confirmationSentAt", func(r *model.MeetingReservation) any { return r.ConfirmationSentAt }},
+	{"createdAt", func(r *model.MeetingReservation) any { return r.CreatedAt }},
+	{"updatedAt", func(r *model.MeetingReservation) any { return r.UpdatedAt }},
+}
+
+var contactExportColumns = []exportColumn[model.ContactMessage]{
+	{"id", func(m *model.ContactMessage) any { return m.ID }},
+	{"status", func(m *model.ContactMessage) any { return string(m.Status) }},
+	{"name", func(m *model.ContactMessage) any { return m.Name }},
+	{"email", func(m *model.ContactMessage) any { return m.Email }},
+	{"topic", func(m *model.ContactMessage) any { return m.Topic }},
+	{"message", func(m *model.ContactMessage) any { return m.Message }},
+	{"locale", func(m *model.ContactMessage) any { return m.Locale }},
+	{"intakeAnswers", func(m *model.ContactMessage) any { return m.IntakeAnswers }},
+	{"adminNote", func(m *model.ContactMessage) any { return m.AdminNote }},
+	{"createdAt", func(m *model.ContactMessage) any { return m.CreatedAt }},
+	{"updatedAt", func(m *model.ContactMessage) any { return m.UpdatedAt }},
+}
+
+// ExportReservations pages through the reservations matching the export filter, which is applied
+// by the repository query. Times are written in the configured contact timezone.
+func (s *service) ExportReservations(ctx context.Context, export ReservationExport) (*Export, error) {
+	format, err := normalizeExportFormat(export.Format)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := selectExportColumns(reservationExportColumns, export.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := reservationListFilter(export.Filter)
+	filter.Limit = exportPageSize
+	reservations, err := newExportRows(func(last *model.MeetingReservation) ([]model.MeetingReservation, error) {
+		page := filter
+		if last != nil {
+			page.After = &repository.MeetingReservationCursor{StartAt: last.StartAt, ID: last.ID}
+		}
+		rows, err := s.reservations.ListReservations(ctx, page)
+		if err != nil {
+			return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservations", err)
+		}
+		return rows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if format == ExportFormatICS {
+		return &Export{
+			ContentType: `text/calendar; charset="utf-8"`,
+			Extension:   "ics",
+			write: func(w io.Writer) error {
+				return writeReservationsICS(w, s.bookingCfg, reservations, time.Now().UTC())
+			},
+		}, nil
+	}
+	return newTableExport(format, columns, reservations, s.exportLocation()), nil
+}
+
+// ExportContactMessages pages through the contact messages matching the export filter, which is
+// applied by the repository query. Contact messages have no calendar representation, so ICS is
+// rejected.
+func (s *service) ExportContactMessages(ctx context.Context, export ContactExport) (*Export, error) {
+	format, err := normalizeExportFormat(export.Format)
+	if err != nil {
+		return nil, err
+	}
+	if format == ExportFormatICS {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "contact messages cannot be exported as ics", nil)
+	}
+	columns, err := selectExportColumns(contactExportColumns, export.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := export.Filter.listFilter()
+	filter.Limit = exportPageSize
+	messages, err := newExportRows(func(last *model.ContactMessage) ([]model.ContactMessage, error) {
+		page := filter
+		if last != nil {
+			page.After = &repository.ContactMessageCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}
+		rows, err := s.contacts.ListContactMessages(ctx, page)
+		if err != nil {
+			return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load contact messages", err)
+		}
+		return rows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newTableExport(format, columns, messages, s.exportLocation()), nil
+}
+
+// listFilter narrows the created range to the filter's day when Date is set.
+func (f ContactFilter) listFilter() repository.ContactMessageListFilter {
+	filter := repository.ContactMessageListFilter{
+		Status:        f.Status,
+		Email:         f.Email,
+		CreatedFrom:   f.CreatedFrom,
+		CreatedBefore: f.CreatedBefore,
+	}
+	if f.Date != nil {
+		day := f.Date.UTC()
+		since := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		until := since.Add(24 * time.Hour)
+		if filter.CreatedFrom == nil || filter.CreatedFrom.Before(since) {
+			filter.CreatedFrom = &since
+		}
+		if filter.CreatedBefore == nil || filter.CreatedBefore.After(until) {
+			filter.CreatedBefore = &until
+		}
+	}
+	return filter
+}
+
+// exportRows reads an export one repository page at a time. The first page is loaded by
+// newExportRows; each later page resumes after the last row of the previous one.
+type exportRows[T any] struct {
+	first []T
+	next  func(last *T) ([]T, error)
+}
+
+func newExportRows[T any](load func(last *T) ([]T, error)) (*exportRows[T], error) {
+	first, err := load(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &exportRows[T]{first: first, next: load}, nil
+}
+
+// each calls fn for every row in order, querying the next page once the previous one is written.
+func (r *exportRows[T]) each(fn func(n int, row *T) error) error {
+	n := 0
+	page := r.first
+	for {
+		for i := range page {
+			n++
+			if err := fn(n, &page[i]); err != nil {
+				return err
+			}
+		}
+		if len(page) < exportPageSize {
+			return nil
+		}
+		next, err := r.next(&page[len(page)-1])
+		if err != nil {
+			return err
+		}
+		page = next
+	}
+}
+
+func (s *service) exportLocation() *time.Location {
+	if loc, err := time.LoadLocation(strings.TrimSpace(s.timezone)); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+func normalizeExportFormat(format ExportFormat) (ExportFormat, error) {
+	switch normalized := ExportFormat(strings.ToLower(strings.TrimSpace(string(format)))); normalized {
+	case "":
+		return ExportFormatCSV, nil
+	case ExportFormatCSV, ExportFormatNDJSON, ExportFormatICS:
+		return normalized, nil
+	default:
+		return "", errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("unsupported export format %q", format), nil)
+	}
+}
+
+// selectExportColumns returns the requested columns in the requested order, or every column
+// when none are requested. Repeated names are written once.
+func selectExportColumns[T any](available []exportColumn[T], names []string) ([]exportColumn[T], error) {
+	if len(names) == 0 {
+		return available, nil
+	}
+
+	byName := make(map[string]exportColumn[T], len(available))
+	for _, column := range available {
+		byName[strings.ToLower(column.name)] = column
+	}
+	selected := make([]exportColumn[T], 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			continue
+		}
+		column, ok := byName[key]
+		if !ok {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("unknown export column %q", name), nil)
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		selected = append(selected, column)
+	}
+	if len(selected) == 0 {
+		return available, nil
+	}
+	return selected, nil
+}
+
+func newTableExport[T any](format ExportFormat, columns []exportColumn[T], rows *exportRows[T], loc *time.Location) *Export {
+	if format == ExportFormatNDJSON {
+		return &Export{
+			ContentType: "application/x-ndjson; charset=utf-8",
+			Extension:   "ndjson",
+			write: func(w io.Writer) error {
+				return writeNDJSON(w, columns, rows, loc)
+			},
+		}
+	}
+	return &Export{
+		ContentType: "text/csv; charset=utf-8",
+		Extension:   "csv",
+		write: func(w io.Writer) error {
+			return writeCSV(w, columns, rows, loc)
+		},
+	}
+}
+
+// utf8BOM lets Excel (notably the Japanese edition) recognise the CSV as UTF-8.
+const utf8BOM = "\ufeff"
+
+func writeCSV[T any](w io.Writer, columns []exportColumn[T], rows *exportRows[T], loc *time.Location) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.name
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	err := rows.each(func(n int, row *T) error {
+		for i, column := range columns {
+			record[i] = csvValue(column.value(row), loc)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		if n%exportFlushRows == 0 {
+			return flushCSV(writer, w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flushCSV(writer, w)
+}
+
+func flushCSV(writer *csv.Writer, w io.Writer) error {
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	flushResponse(w)
+	return nil
+}
+
+// csvValue renders a cell. Text starting with a formula character is prefixed with an
+// apostrophe so spreadsheet applications do not evaluate visitor-supplied input.
+func csvValue(value any, loc *time.Location) string {
+	switch v := value.(type) {
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case time.Time:
+		return formatExportTime(v, loc)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatExportTime(*v, loc)
+	case []model.IntakeAnswer:
+		parts := make([]string, 0, len(v))
+		for _, answer := range v {
+			parts = append(parts, fmt.Sprintf("%s: %s", answer.Label, strings.Join(answer.Values, ", ")))
+		}
+		return csvValue(strings.Join(parts, "\n"), loc)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func writeNDJSON[T any](w io.Writer, columns []exportColumn[T], rows *exportRows[T], loc *time.Location) error {
+	var line bytes.Buffer
+	err := rows.each(func(n int, row *T) error {
+		line.Reset()
+		line.WriteByte('{')
+		for i, column := range columns {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			key, _ := json.Marshal(column.name)
+			value, err := json.Marshal(jsonValue(column.value(row), loc))
+			if err != nil {
+				return err
+			}
+			line.Write(key)
+			line.WriteByte(':')
+			line.Write(value)
+		}
+		line.WriteString("}\n")
+		if _, err := w.Write(line.Bytes()); err != nil {
+			return err
+		}
+		if n%exportFlushRows == 0 {
+			flushResponse(w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	flushResponse(w)
+	return nil
+}
+
+func jsonValue(value any, loc *time.Location) any {
+	switch v := value.(type) {
+	case time.Time:
+		return formatExportTime(v, loc)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return formatExportTime(*v, loc)
+	case []model.IntakeAnswer:
+		if v == nil {
+			return []model.IntakeAnswer{}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func formatExportTime(t time.Time, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+func writeReservationsICS(w io.Writer, cfg config.BookingConfig, reservations *exportRows[model.MeetingReservation], stamp time.Time) error {
+	encoder, err := ics.NewEncoder(w, ics.Calendar{Name: "Reservations"})
+	if err != nil {
+		return err
+	}
+	err = reservations.each(func(n int, reservation *model.MeetingReservation) error {
+		if err := encoder.Encode(support.ReservationEvent(cfg, reservation, stamp)); err != nil {
+			return err
+		}
+		if n%exportFlushRows == 0 {
+			flushResponse(w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	flushResponse(w)
+	return nil
+}
+
+func flushResponse(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
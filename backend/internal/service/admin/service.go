@@ -9,9 +9,12 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/errs"
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/schedule"
 	"github.com/takumi/personal-website/internal/service/support"
 )
 
@@ -36,6 +39,9 @@ type Service interface {
 	GetContactMessage(ctx context.Context, id string) (*model.ContactMessage, error)
 	UpdateContactMessage(ctx context.Context, id string, input ContactUpdateInput) (*model.ContactMessage, error)
 	DeleteContactMessage(ctx context.Context, id string) error
+	// ClassifyContactMessage records an admin's spam or ham verdict for the spam filter to learn
+	// from and moves the message into or out of the spam status.
+	ClassifyContactMessage(ctx context.Context, id string, verdict model.SpamVerdict) (*model.ContactMessage, error)
 
 	GetContactSettings(ctx context.Context) (*model.ContactFormSettingsV2, error)
 	UpdateContactSettings(ctx context.Context, input ContactSettingsInput) (*model.ContactFormSettingsV2, error)
@@ -49,6 +55,12 @@ type Service interface {
 	RemoveBlacklistEntry(ctx context.Context, id int64) error
 	IsEmailBlacklisted(ctx context.Context, email string) (bool, error)
 
+	ListBlackouts(ctx context.Context) ([]model.ScheduleBlackout, error)
+	GetBlackout(ctx context.Context, id uint64) (*model.ScheduleBlackout, error)
+	CreateBlackout(ctx context.Context, input BlackoutInput) (*model.ScheduleBlackout, error)
+	UpdateBlackout(ctx context.Context, id uint64, input BlackoutInput) (*model.ScheduleBlackout, error)
+	DeleteBlackout(ctx context.Context, id uint64) error
+
 	ListTechCatalog(ctx context.Context, includeInactive bool) ([]model.TechCatalogEntry, error)
 	CreateTechCatalogEntry(ctx context.Context, input TechCatalogInput) (*model.TechCatalogEntry, error)
 	UpdateTechCatalogEntry(ctx context.Context, id uint64, input TechCatalogUpdateInput) (*model.TechCatalogEntry, error)
@@ -61,8 +73,15 @@ type Service interface {
 
 	ListReservations(ctx context.Context, filter ReservationFilter) ([]model.MeetingReservation, error)
 	UpdateReservationStatus(ctx context.Context, id uint64, status model.MeetingReservationStatus, reason string) (*model.MeetingReservation, error)
+	ListReservationHistory(ctx context.Context, reservationID uint64) ([]model.MeetingReservationStatusChange, error)
+	SuggestBlacklist(ctx context.Context, reservation *model.MeetingReservation) (*model.BlacklistSuggestion, error)
+	ListReservationDrift(ctx context.Context, includeResolved bool) ([]model.ReservationDrift, error)
+	ResolveReservationDrift(ctx context.Context, id uint64) (*model.ReservationDrift, error)
 	ListReservationNotifications(ctx context.Context, reservationID uint64) ([]model.MeetingNotification, error)
+	ListReservationNotificationAttempts(ctx context.Context, reservationID uint64) ([]model.MeetingNotificationAttempt, error)
 	RetryReservationNotification(ctx context.Context, reservationID uint64) (*model.MeetingReservation, error)
+	ExportReservations(ctx context.Context, export ReservationExport) (*Export, error)
+	ExportContactMessages(ctx context.Context, export ContactExport) (*Export, error)
 
 	Summary(ctx context.Context) (*model.AdminSummary, error)
 }
@@ -75,9 +94,17 @@ type service struct {
 	contactCfg    repository.AdminContactSettingsRepository
 	home          repository.AdminHomePageConfigRepository
 	blacklist     repository.BlacklistRepository
+	blackouts     repository.ScheduleBlackoutRepository
 	techCatalog   repository.TechCatalogRepository
 	reservations  repository.MeetingReservationRepository
 	notifications repository.MeetingNotificationRepository
+	outbox        repository.BookingOutboxRepository
+	drifts        repository.ReservationDriftRepository
+	contactEmails repository.ContactNotificationRepository
+	spam          repository.SpamRepository
+	calendar      calendar.Client
+	bookingCfg    config.BookingConfig
+	timezone      string
 }
 
 // NewService wires repositories into the admin service.
@@ -89,11 +116,18 @@ func NewService(
 	contactCfg repository.AdminContactSettingsRepository,
 	home repository.AdminHomePageConfigRepository,
 	blacklist repository.BlacklistRepository,
+	blackouts repository.ScheduleBlackoutRepository,
 	techCatalog repository.TechCatalogRepository,
 	reservations repository.MeetingReservationRepository,
 	notifications repository.MeetingNotificationRepository,
+	outbox repository.BookingOutboxRepository,
+	drifts repository.ReservationDriftRepository,
+	contactNotifications repository.ContactNotificationRepository,
+	spam repository.SpamRepository,
+	calendarClient calendar.Client,
+	cfg *config.AppConfig,
 ) (Service, error) {
-	if profile == nil || projects == nil || research == nil || contacts == nil || contactCfg == nil || home == nil || blacklist == nil || techCatalog == nil || reservations == nil || notifications == nil {
+	if profile == nil || projects == nil || research == nil || contacts == nil || contactCfg == nil || home == nil || blacklist == nil || blackouts == nil || techCatalog == nil || reservations == nil || notifications == nil || outbox == nil || drifts == nil || contactNotifications == nil || spam == nil || calendarClient == nil || cfg == nil {
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "admin service: missing dependencies", nil)
 	}
 
@@ -105,17 +139,28 @@ func NewService(
 		contactCfg:    contactCfg,
 		home:          home,
 		blacklist:     blacklist,
+		blackouts:     blackouts,
 		techCatalog:   techCatalog,
 		reservations:  reservations,
 		notifications: notifications,
+		outbox:        outbox,
+		drifts:        drifts,
+		contactEmails: contactNotifications,
+		spam:          spam,
+		calendar:      calendarClient,
+		bookingCfg:    cfg.Booking,
+		timezone:      cfg.Contact.Timezone,
 	}, nil
 }
 
-// ReservationFilter captures optional filters for reservation listing.
+// ReservationFilter captures optional filters for reservation listing. StartFrom and
+// StartBefore bound the meeting start to [StartFrom, StartBefore).
 type ReservationFilter struct {
-	Status []model.MeetingReservationStatus
-	Email  string
-	Date   *time.Time
+	Status      []model.MeetingReservationStatus
+	Email       string
+	Date        *time.Time
+	StartFrom   *time.Time
+	StartBefore *time.Time
 }
 
 // ProfileInput captures administrator-provided profile data (v2 schema).
@@ -483,9 +528,11 @@ func normalizeContactTopics(inputs []ContactTopicInput) []model.ContactTopicV2 {
 			continue
 		}
 		result = append(result, model.ContactTopicV2{
-			ID:          id,
-			Label:       normalizeLocalized(item.Label),
-			Description: normalizeLocalized(item.Description),
+			ID:               id,
+			Label:            normalizeLocalized(item.Label),
+			Description:      normalizeLocalized(item.Description),
+			RequiresApproval: item.RequiresApproval,
+			Questions:        normalizeIntakeQuestions(item.Questions),
 		})
 	}
 	if len(result) == 0 {
@@ -494,6 +541,111 @@ func normalizeContactTopics(inputs []ContactTopicInput) []model.ContactTopicV2 {
 	return result
 }
 
+// maxIntakeQuestions and maxIntakeOptions keep intake forms short enough to fill in.
+const (
+	maxIntakeQuestions = 20
+	maxIntakeOptions   = 30
+)
+
+func normalizeIntakeQuestions(questions []model.IntakeQuestion) []model.IntakeQuestion {
+	if len(questions) == 0 {
+		return nil
+	}
+	result := make([]model.IntakeQuestion, 0, len(questions))
+	for _, question := range questions {
+		normalized := model.IntakeQuestion{
+			ID:       strings.TrimSpace(question.ID),
+			Type:     model.IntakeQuestionType(strings.ToLower(strings.TrimSpace(string(question.Type)))),
+			Label:    normalizeLocalized(question.Label),
+			Required: question.Required,
+		}
+		for _, option := range question.Options {
+			normalized.Options = append(normalized.Options, model.IntakeQuestionOption{
+				Value: strings.TrimSpace(option.Value),
+				Label: normalizeLocalized(option.Label),
+			})
+		}
+		result = append(result, normalized)
+	}
+	return result
+}
+
+func validateIntakeQuestions(topicID string, questions []model.IntakeQuestion) error {
+	invalid := func(message string) error {
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("topic %s: %s", topicID, message), nil)
+	}
+	if len(questions) > maxIntakeQuestions {
+		return invalid(fmt.Sprintf("at most %d questions are allowed", maxIntakeQuestions))
+	}
+	seen := make(map[string]struct{}, len(questions))
+	for _, question := range normalizeIntakeQuestions(questions) {
+		if question.ID == "" {
+			return invalid("question id is required")
+		}
+		if utf8.RuneCountInString(question.ID) > 32 {
+			return invalid("question id must be 32 characters or fewer")
+		}
+		if _, exists := seen[question.ID]; exists {
+			return invalid("question ids must be unique")
+		}
+		seen[question.ID] = struct{}{}
+		if strings.TrimSpace(question.Label.Ja) == "" && strings.TrimSpace(question.Label.En) == "" {
+			return invalid(fmt.Sprintf("question %s label is required in at least one language", question.ID))
+		}
+
+		switch question.Type {
+		case model.IntakeQuestionText:
+			if len(question.Options) > 0 {
+				return invalid(fmt.Sprintf("text question %s cannot have options", question.ID))
+			}
+		case model.IntakeQuestionSelect, model.IntakeQuestionCheckbox:
+			if question.Type == model.IntakeQuestionSelect && len(question.Options) == 0 {
+				return invalid(fmt.Sprintf("select question %s needs at least one option", question.ID))
+			}
+			if len(question.Options) > maxIntakeOptions {
+				return invalid(fmt.Sprintf("question %s can have at most %d options", question.ID, maxIntakeOptions))
+			}
+			values := make(map[string]struct{}, len(question.Options))
+			for _, option := range question.Options {
+				if option.Value == "" {
+					return invalid(fmt.Sprintf("question %s option value is required", question.ID))
+				}
+				if _, exists := values[option.Value]; exists {
+					return invalid(fmt.Sprintf("question %s option values must be unique", question.ID))
+				}
+				values[option.Value] = struct{}{}
+				if strings.TrimSpace(option.Label.Ja) == "" && strings.TrimSpace(option.Label.En) == "" {
+					return invalid(fmt.Sprintf("question %s option label is required in at least one language", question.ID))
+				}
+			}
+		default:
+			return invalid(fmt.Sprintf("question %s type must be text, select, or checkbox", question.ID))
+		}
+	}
+	return nil
+}
+
+// maxConflictCalendars leaves room for the booking calendar in a single FreeBusy request.
+const maxConflictCalendars = 49
+
+// normalizeCalendarIDs trims calendar IDs and drops blanks and duplicates, keeping the order.
+func normalizeCalendarIDs(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, raw := range ids {
+		id := strings.TrimSpace(raw)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
+
 func isValidResearchKind(kind model.ResearchKind) bool {
 	switch kind {
 	case model.ResearchKindResearch, model.ResearchKindBlog:
@@ -634,14 +786,20 @@ type ContactSettingsInput struct {
 	GoogleCalendarID   string
 	BookingWindowDays  int
 	MeetingURLTemplate string
-	ExpectedUpdatedAt  time.Time
+	// ConflictCalendarIDs replaces the calendars consulted for busy time; nil keeps the stored list.
+	ConflictCalendarIDs []string
+	// WorkingHours replaces the booking schedule when set; nil keeps the stored schedule.
+	WorkingHours      *model.WorkingHours
+	ExpectedUpdatedAt time.Time
 }
 
 // ContactTopicInput represents a configurable contact topic row.
 type ContactTopicInput struct {
-	ID          string
-	Label       model.LocalizedText
-	Description model.LocalizedText
+	ID               string
+	Label            model.LocalizedText
+	Description      model.LocalizedText
+	RequiresApproval bool
+	Questions        []model.IntakeQuestion
 }
 
 // HomeSettingsInput captures administrator-provided home page configuration data.
@@ -675,11 +833,20 @@ type HomeChipSourceInput struct {
 }
 
 func (s *service) ListContactMessages(ctx context.Context) ([]model.ContactMessage, error) {
-	return s.contacts.ListContactMessages(ctx)
+	return s.contacts.ListContactMessages(ctx, repository.ContactMessageListFilter{})
 }
 
 func (s *service) GetContactMessage(ctx context.Context, id string) (*model.ContactMessage, error) {
-	return s.contacts.GetContactMessage(ctx, strings.TrimSpace(id))
+	message, err := s.contacts.GetContactMessage(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := s.contactEmails.ListContactNotifications(ctx, message.ID)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load contact notifications", err)
+	}
+	message.Notifications = notifications
+	return message, nil
 }
 
 func (s *service) UpdateContactMessage(ctx context.Context, id string, input ContactUpdateInput) (*model.ContactMessage, error) {
@@ -697,6 +864,36 @@ func (s *service) UpdateContactMessage(ctx context.Context, id string, input Con
 	return s.contacts.UpdateContactMessage(ctx, message)
 }
 
+func (s *service) ClassifyContactMessage(ctx context.Context, id string, verdict model.SpamVerdict) (*model.ContactMessage, error) {
+	if verdict != model.SpamVerdictSpam && verdict != model.SpamVerdictHam {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "verdict must be spam or ham", nil)
+	}
+	message, err := s.contacts.GetContactMessage(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.spam.RecordSpamFeedback(ctx, &model.SpamFeedback{
+		ContactID:   message.ID,
+		ContentHash: model.SpamContentHash(message.Message),
+		Email:       message.Email,
+		Verdict:     verdict,
+	}); err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to record spam verdict", err)
+	}
+
+	switch {
+	case verdict == model.SpamVerdictSpam:
+		message.Status = model.ContactStatusSpam
+	case message.Status == model.ContactStatusSpam:
+		// A message released from spam goes back to the review queue.
+		message.Status = model.ContactStatusPending
+	default:
+		return message, nil
+	}
+	return s.contacts.UpdateContactMessage(ctx, message)
+}
+
 func (s *service) DeleteContactMessage(ctx context.Context, id string) error {
 	return s.contacts.DeleteContactMessage(ctx, strings.TrimSpace(id))
 }
@@ -729,6 +926,29 @@ func (s *service) UpdateContactSettings(ctx context.Context, input ContactSettin
 		MeetingURLTemplate: strings.TrimSpace(input.MeetingURLTemplate),
 	}
 
+	if input.WorkingHours != nil {
+		hours, err := schedule.NormalizeWorkingHours(*input.WorkingHours)
+		if err != nil {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, err.Error(), err)
+		}
+		document.WorkingHours = hours
+	}
+	if input.ConflictCalendarIDs != nil {
+		document.ConflictCalendarIDs = normalizeCalendarIDs(input.ConflictCalendarIDs)
+	}
+	if input.WorkingHours == nil || input.ConflictCalendarIDs == nil {
+		current, err := s.contactCfg.GetContactFormSettings(ctx)
+		if err != nil {
+			return nil, support.MapRepositoryError(err, "contact settings")
+		}
+		if input.WorkingHours == nil {
+			document.WorkingHours = current.WorkingHours
+		}
+		if input.ConflictCalendarIDs == nil {
+			document.ConflictCalendarIDs = current.ConflictCalendarIDs
+		}
+	}
+
 	updated, err := s.contactCfg.UpdateContactFormSettings(ctx, document, input.ExpectedUpdatedAt.UTC())
 	if err != nil {
 		return nil, support.MapRepositoryError(err, "contact settings")
@@ -828,6 +1048,117 @@ func (s *service) IsEmailBlacklisted(ctx context.Context, email string) (bool, e
 	return false, err
 }
 
+// BlackoutInput captures a one-off or recurring period during which bookings are blocked.
+// Recurrence accepts an RFC 5545 RRULE (e.g. "FREQ=WEEKLY;BYDAY=MO") and is expanded in Timezone.
+type BlackoutInput struct {
+	StartTime  time.Time
+	EndTime    time.Time
+	Reason     string
+	Recurrence string
+	Timezone   string
+}
+
+func (s *service) ListBlackouts(ctx context.Context) ([]model.ScheduleBlackout, error) {
+	blackouts, err := s.blackouts.ListBlackouts(ctx)
+	if err != nil {
+		return nil, support.MapRepositoryError(err, "blackout")
+	}
+	return blackouts, nil
+}
+
+func (s *service) GetBlackout(ctx context.Context, id uint64) (*model.ScheduleBlackout, error) {
+	if id == 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "blackout id is required", nil)
+	}
+	blackout, err := s.blackouts.GetBlackout(ctx, id)
+	if err != nil {
+		return nil, support.MapRepositoryError(err, "blackout")
+	}
+	return blackout, nil
+}
+
+func (s *service) CreateBlackout(ctx context.Context, input BlackoutInput) (*model.ScheduleBlackout, error) {
+	blackout, appErr := s.buildBlackout(input)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	created, err := s.blackouts.CreateBlackout(ctx, blackout)
+	if err != nil {
+		return nil, support.MapRepositoryError(err, "blackout")
+	}
+	return created, nil
+}
+
+func (s *service) UpdateBlackout(ctx context.Context, id uint64, input BlackoutInput) (*model.ScheduleBlackout, error) {
+	if id == 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "blackout id is required", nil)
+	}
+
+	blackout, appErr := s.buildBlackout(input)
+	if appErr != nil {
+		return nil, appErr
+	}
+	blackout.ID = id
+
+	updated, err := s.blackouts.UpdateBlackout(ctx, blackout)
+	if err != nil {
+		return nil, support.MapRepositoryError(err, "blackout")
+	}
+	return updated, nil
+}
+
+func (s *service) DeleteBlackout(ctx context.Context, id uint64) error {
+	if id == 0 {
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "blackout id is required", nil)
+	}
+	if err := s.blackouts.DeleteBlackout(ctx, id); err != nil {
+		return support.MapRepositoryError(err, "blackout")
+	}
+	return nil
+}
+
+func (s *service) buildBlackout(input BlackoutInput) (*model.ScheduleBlackout, *errs.AppError) {
+	if input.StartTime.IsZero() || input.EndTime.IsZero() {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "startTime and endTime are required", nil)
+	}
+	if !input.EndTime.After(input.StartTime) {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "endTime must be after startTime", nil)
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if utf8.RuneCountInString(reason) > 255 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reason must be 255 characters or fewer", nil)
+	}
+
+	timezone := strings.TrimSpace(input.Timezone)
+	if timezone == "" {
+		timezone = strings.TrimSpace(s.timezone)
+	}
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "timezone is invalid", err)
+		}
+	}
+
+	recurrence := strings.TrimSpace(input.Recurrence)
+	if recurrence != "" {
+		rule, err := schedule.ParseRule(recurrence)
+		if err != nil {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, err.Error(), err)
+		}
+		recurrence = rule.String()
+	}
+
+	return &model.ScheduleBlackout{
+		StartTime:  input.StartTime.UTC(),
+		EndTime:    input.EndTime.UTC(),
+		Reason:     reason,
+		Recurrence: recurrence,
+		Timezone:   timezone,
+	}, nil
+}
+
 func (s *service) ListTechCatalog(ctx context.Context, includeInactive bool) ([]model.TechCatalogEntry, error) {
 	entries, err := s.techCatalog.ListTechCatalog(ctx, includeInactive)
 	if err != nil {
@@ -946,46 +1277,288 @@ func (s *service) UpdateMeetingURLTemplate(ctx context.Context, template string)
 }
 
 func (s *service) ListReservations(ctx context.Context, filter ReservationFilter) ([]model.MeetingReservation, error) {
-	repoFilter := repository.MeetingReservationListFilter{
-		Status: filter.Status,
-		Email:  filter.Email,
-	}
-	if filter.Date != nil {
-		repoFilter.Date = filter.Date
-	}
-
-	reservations, err := s.reservations.ListReservations(ctx, repoFilter)
+	reservations, err := s.reservations.ListReservations(ctx, reservationListFilter(filter))
 	if err != nil {
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservations", err)
 	}
 	return reservations, nil
 }
 
+func reservationListFilter(filter ReservationFilter) repository.MeetingReservationListFilter {
+	return repository.MeetingReservationListFilter{
+		Status:      filter.Status,
+		Email:       filter.Email,
+		Date:        filter.Date,
+		StartFrom:   filter.StartFrom,
+		StartBefore: filter.StartBefore,
+	}
+}
+
+// UpdateReservationStatus applies an administrator's status change. Only moves allowed by the
+// reservation transition table are accepted; rescheduling needs a new time and goes through the
+// visitor flow, and completed or no_show can only be recorded once the meeting has started.
 func (s *service) UpdateReservationStatus(ctx context.Context, id uint64, status model.MeetingReservationStatus, reason string) (*model.MeetingReservation, error) {
 	if id == 0 {
 		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservation id must be provided", nil)
 	}
-
-	switch status {
-	case model.MeetingReservationStatusPending,
-		model.MeetingReservationStatusConfirmed,
-		model.MeetingReservationStatusCancelled:
-	default:
+	if !status.Valid() {
 		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "unsupported reservation status", nil)
 	}
+	if status == model.MeetingReservationStatusRescheduled {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservations are rescheduled by changing their time", nil)
+	}
+
+	previous, err := s.reservations.FindReservationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "reservation not found", err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservation", err)
+	}
+	if previous.Status == status {
+		return previous, nil
+	}
+	if !previous.Status.CanTransitionTo(status) {
+		return nil, errs.New(errs.CodeConflict, http.StatusConflict, fmt.Sprintf("cannot change reservation from %s to %s", previous.Status, status), nil)
+	}
+	if (status == model.MeetingReservationStatusCompleted || status == model.MeetingReservationStatusNoShow) && time.Now().Before(previous.StartAt) {
+		return nil, errs.New(errs.CodeConflict, http.StatusConflict, "reservation has not started yet", nil)
+	}
 
-	reservation, err := s.reservations.UpdateReservationStatus(ctx, id, status, reason)
+	reservation, err := s.reservations.TransitionReservationStatus(ctx, &model.MeetingReservationStatusChange{
+		ReservationID: id,
+		FromStatus:    previous.Status,
+		ToStatus:      status,
+		Actor:         model.ReservationActorOwner,
+		Reason:        strings.TrimSpace(reason),
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "reservation not found", err)
 		}
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, errs.New(errs.CodeConflict, http.StatusConflict, "reservation was changed concurrently; reload and try again", err)
+		}
 		if errors.Is(err, repository.ErrInvalidInput) {
 			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "invalid reservation status", err)
 		}
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to update reservation", err)
 	}
 
-	return reservation, nil
+	synced, err := s.syncReservationEvent(ctx, previous, reservation)
+	if err != nil {
+		return synced, err
+	}
+	if reservation.Status.IsCancelled() {
+		// The freed slot is offered to the waitlist by the booking outbox.
+		if err := s.enqueueOutboxJob(ctx, reservation.ID, model.OutboxJobOfferWaitlist); err != nil {
+			return nil, err
+		}
+	}
+	return synced, nil
+}
+
+// syncReservationEvent mirrors a status transition onto the Google Calendar event.
+// Calendar failures do not roll back the status change; they are recorded as failed
+// calendar_invite notifications so the administrator can see what happened. Deciding a
+// request that awaits approval queues the approved or declined email on the booking outbox.
+// Completed and no_show leave the calendar untouched.
+func (s *service) syncReservationEvent(ctx context.Context, previous, current *model.MeetingReservation) (*model.MeetingReservation, error) {
+	decided := current.RequiresApproval && previous.Status == model.MeetingReservationStatusRequested
+
+	var syncErr error
+	switch current.Status {
+	case model.MeetingReservationStatusCancelledByVisitor, model.MeetingReservationStatusCancelledByOwner:
+		if strings.TrimSpace(current.GoogleEventID) != "" {
+			syncErr = s.callCalendar(ctx, func(callCtx context.Context) error {
+				err := s.calendar.DeleteEvent(callCtx, s.bookingCfg.CalendarID, current.GoogleEventID)
+				if errors.Is(err, calendar.ErrEventNotFound) {
+					return nil
+				}
+				return err
+			})
+			if err := s.recordNotification(ctx, current.ID, "calendar_invite", deliveryStatus(syncErr), syncErr); err != nil {
+				return nil, err
+			}
+		}
+		if decided {
+			return current, s.enqueueOutboxJob(ctx, current.ID, model.OutboxJobSendDecline)
+		}
+		if err := s.recordNotification(ctx, current.ID, "cancellation_email", "pending", nil); err != nil {
+			return nil, err
+		}
+		return current, nil
+	case model.MeetingReservationStatusConfirmed:
+		var updated *model.MeetingReservation
+		syncErr = s.callCalendar(ctx, func(callCtx context.Context) error {
+			var err error
+			updated, err = s.ensureReservationEvent(callCtx, current)
+			return err
+		})
+		if updated != nil {
+			current = updated
+		}
+	default:
+		return current, nil
+	}
+
+	if err := s.recordNotification(ctx, current.ID, "calendar_invite", deliveryStatus(syncErr), syncErr); err != nil {
+		return nil, err
+	}
+	if decided {
+		return current, s.enqueueOutboxJob(ctx, current.ID, model.OutboxJobSendApproval)
+	}
+	return current, nil
+}
+
+func (s *service) ListReservationHistory(ctx context.Context, reservationID uint64) ([]model.MeetingReservationStatusChange, error) {
+	if reservationID == 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservation id must be provided", nil)
+	}
+
+	history, err := s.reservations.ListStatusHistory(ctx, reservationID)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservation history", err)
+	}
+	return history, nil
+}
+
+// SuggestBlacklist returns a suggestion to blacklist the reservation's visitor when the
+// reservation is a no-show and the visitor has missed at least the configured number of
+// meetings. It returns nil when the threshold is not reached, is disabled, or the email is
+// already blacklisted.
+func (s *service) SuggestBlacklist(ctx context.Context, reservation *model.MeetingReservation) (*model.BlacklistSuggestion, error) {
+	threshold := s.bookingCfg.NoShowBlacklistThreshold
+	if reservation == nil || reservation.Status != model.MeetingReservationStatusNoShow || threshold <= 0 {
+		return nil, nil
+	}
+	email := strings.ToLower(strings.TrimSpace(reservation.Email))
+	if email == "" {
+		return nil, nil
+	}
+
+	noShows, err := s.reservations.ListReservations(ctx, repository.MeetingReservationListFilter{
+		Status: []model.MeetingReservationStatus{model.MeetingReservationStatusNoShow},
+		Email:  email,
+	})
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to count no-shows", err)
+	}
+	if len(noShows) < threshold {
+		return nil, nil
+	}
+
+	blacklisted, err := s.IsEmailBlacklisted(ctx, email)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to check blacklist", err)
+	}
+	if blacklisted {
+		return nil, nil
+	}
+	return &model.BlacklistSuggestion{Email: email, NoShowCount: len(noShows)}, nil
+}
+
+// ListReservationDrift returns the differences the calendar reconciler found between
+// reservations and their Google Calendar events, newest first. Resolved entries are only
+// included on request.
+func (s *service) ListReservationDrift(ctx context.Context, includeResolved bool) ([]model.ReservationDrift, error) {
+	drifts, err := s.drifts.ListDrifts(ctx, includeResolved)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservation drift", err)
+	}
+	return drifts, nil
+}
+
+// ResolveReservationDrift marks a drift entry as reviewed. Resolving it again is a no-op.
+func (s *service) ResolveReservationDrift(ctx context.Context, id uint64) (*model.ReservationDrift, error) {
+	if id == 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "drift id must be provided", nil)
+	}
+
+	drift, err := s.drifts.ResolveDrift(ctx, id, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "reservation drift not found", err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to resolve reservation drift", err)
+	}
+	return drift, nil
+}
+
+func (s *service) enqueueOutboxJob(ctx context.Context, reservationID uint64, kind model.OutboxJobKind) error {
+	maxAttempts := s.bookingCfg.OutboxMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 8
+	}
+	err := s.outbox.EnqueueJobs(ctx, reservationID, []model.OutboxJob{{
+		Kind:          kind,
+		MaxAttempts:   maxAttempts,
+		NextAttemptAt: time.Now().UTC(),
+	}})
+	if err != nil {
+		return errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to queue reservation notification", err)
+	}
+	return nil
+}
+
+// ensureReservationEvent makes sure an active event exists and matches the reservation time,
+// recreating it when the original was deleted from the calendar.
+func (s *service) ensureReservationEvent(ctx context.Context, reservation *model.MeetingReservation) (*model.MeetingReservation, error) {
+	calendarID := s.bookingCfg.CalendarID
+	if eventID := strings.TrimSpace(reservation.GoogleEventID); eventID != "" {
+		event, err := s.calendar.GetEvent(ctx, calendarID, eventID)
+		switch {
+		case err == nil && event.Status != "cancelled":
+			if event.Start.Equal(reservation.StartAt) && event.End.Equal(reservation.EndAt) {
+				return reservation, nil
+			}
+			_, err = s.calendar.UpdateEvent(ctx, calendarID, eventID, calendar.EventInput{
+				Start: reservation.StartAt,
+				End:   reservation.EndAt,
+			})
+			return reservation, err
+		case err != nil && !errors.Is(err, calendar.ErrEventNotFound):
+			return nil, err
+		}
+	}
+
+	event, err := s.calendar.CreateEvent(ctx, calendarID, support.CalendarEventInput(s.bookingCfg, reservation, reservation.StartAt, reservation.EndAt))
+	if err != nil {
+		return nil, err
+	}
+	return s.reservations.RescheduleReservation(ctx, reservation.ID, reservation.StartAt, reservation.EndAt, event.ID)
+}
+
+func (s *service) callCalendar(ctx context.Context, call func(ctx context.Context) error) error {
+	timeout := s.bookingCfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = 8 * time.Second
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return call(callCtx)
+}
+
+func (s *service) recordNotification(ctx context.Context, reservationID uint64, notificationType, status string, cause error) error {
+	notification := &model.MeetingNotification{
+		ReservationID: reservationID,
+		Type:          notificationType,
+		Status:        status,
+	}
+	if cause != nil {
+		notification.ErrorMessage = cause.Error()
+	}
+	if _, err := s.notifications.RecordNotification(ctx, notification); err != nil {
+		return errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to record notification history", err)
+	}
+	return nil
+}
+
+func deliveryStatus(err error) string {
+	if err != nil {
+		return "failed"
+	}
+	return "sent"
 }
 
 func (s *service) ListReservationNotifications(ctx context.Context, reservationID uint64) ([]model.MeetingNotification, error) {
@@ -1000,6 +1573,18 @@ func (s *service) ListReservationNotifications(ctx context.Context, reservationI
 	return notifications, nil
 }
 
+func (s *service) ListReservationNotificationAttempts(ctx context.Context, reservationID uint64) ([]model.MeetingNotificationAttempt, error) {
+	if reservationID == 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservation id must be provided", nil)
+	}
+
+	attempts, err := s.notifications.ListNotificationAttempts(ctx, reservationID)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservation notification attempts", err)
+	}
+	return attempts, nil
+}
+
 func (s *service) RetryReservationNotification(ctx context.Context, reservationID uint64) (*model.MeetingReservation, error) {
 	if reservationID == 0 {
 		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservation id must be provided", nil)
@@ -1013,13 +1598,9 @@ func (s *service) RetryReservationNotification(ctx context.Context, reservationI
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservation", err)
 	}
 
-	notification := &model.MeetingNotification{
-		ReservationID: reservation.ID,
-		Type:          "confirmation_email",
-		Status:        "pending",
-	}
-	if _, err := s.notifications.RecordNotification(ctx, notification); err != nil && !errors.Is(err, repository.ErrInvalidInput) {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to record retry notification", err)
+	// The confirmation is resent by a fresh outbox job, which owns its retries.
+	if err := s.enqueueOutboxJob(ctx, reservation.ID, model.OutboxJobSendConfirmation); err != nil {
+		return nil, err
 	}
 
 	return reservation, nil
@@ -1057,7 +1638,7 @@ func (s *service) Summary(ctx context.Context) (*model.AdminSummary, error) {
 		publishedResearch++
 	}
 
-	contacts, err := s.contacts.ListContactMessages(ctx)
+	contacts, err := s.contacts.ListContactMessages(ctx, repository.ContactMessageListFilter{})
 	if err != nil {
 		return nil, err
 	}
@@ -1245,7 +1826,8 @@ func validateContactUpdateInput(input ContactUpdateInput) error {
 	case model.ContactStatusPending,
 		model.ContactStatusInReview,
 		model.ContactStatusResolved,
-		model.ContactStatusArchived:
+		model.ContactStatusArchived,
+		model.ContactStatusSpam:
 		// ok
 	case "":
 		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "contact status is required", nil)
@@ -1288,6 +1870,14 @@ func validateContactSettingsInput(input ContactSettingsInput) error {
 	if len(strings.TrimSpace(input.MeetingURLTemplate)) > 0 && len(input.MeetingURLTemplate) > 4000 {
 		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "meetingUrlTemplate must be 4000 characters or fewer", nil)
 	}
+	if len(normalizeCalendarIDs(input.ConflictCalendarIDs)) > maxConflictCalendars {
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("conflictCalendarIds must list %d calendars or fewer", maxConflictCalendars), nil)
+	}
+	for _, id := range input.ConflictCalendarIDs {
+		if len(strings.TrimSpace(id)) > 255 {
+			return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "conflict calendar ids must be 255 characters or fewer", nil)
+		}
+	}
 	seen := make(map[string]struct{}, len(input.Topics))
 	for _, topic := range input.Topics {
 		id := strings.TrimSpace(topic.ID)
@@ -1305,6 +1895,9 @@ func validateContactSettingsInput(input ContactSettingsInput) error {
 		if strings.TrimSpace(label.Ja) == "" && strings.TrimSpace(label.En) == "" {
 			return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "topic label is required in at least one language", nil)
 		}
+		if err := validateIntakeQuestions(id, topic.Questions); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1,12 +1,19 @@
 package admin
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/errs"
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
@@ -72,6 +79,62 @@ func TestService_AddBlacklistEntryDuplicate(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestService_BlackoutLifecycle(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t)
+	ctx := context.Background()
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	created, err := svc.CreateBlackout(ctx, BlackoutInput{
+		StartTime:  start,
+		EndTime:    start.Add(2 * time.Hour),
+		Reason:     "  Weekly planning  ",
+		Recurrence: "rrule:freq=weekly;byday=mo",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+	require.Equal(t, "Weekly planning", created.Reason)
+	require.Equal(t, "FREQ=WEEKLY;BYDAY=MO", created.Recurrence)
+	require.Equal(t, "Asia/Tokyo", created.Timezone)
+
+	updated, err := svc.UpdateBlackout(ctx, created.ID, BlackoutInput{
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Timezone:  "UTC",
+	})
+	require.NoError(t, err)
+	require.Empty(t, updated.Recurrence)
+	require.Equal(t, "UTC", updated.Timezone)
+
+	list, err := svc.ListBlackouts(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	require.NoError(t, svc.DeleteBlackout(ctx, created.ID))
+	_, err = svc.GetBlackout(ctx, created.ID)
+	var appErr *errs.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusNotFound, appErr.Status)
+}
+
+func TestService_CreateBlackoutValidation(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t)
+	ctx := context.Background()
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.CreateBlackout(ctx, BlackoutInput{StartTime: start, EndTime: start})
+	require.Error(t, err)
+
+	_, err = svc.CreateBlackout(ctx, BlackoutInput{StartTime: start, EndTime: start.Add(time.Hour), Recurrence: "FREQ=YEARLY"})
+	require.Error(t, err)
+
+	_, err = svc.CreateBlackout(ctx, BlackoutInput{StartTime: start, EndTime: start.Add(time.Hour), Timezone: "Mars/Olympus"})
+	require.Error(t, err)
+}
+
 func TestService_Summary(t *testing.T) {
 	t.Parallel()
 
@@ -185,6 +248,28 @@ func TestService_UpdateContactMessageInvalidStatus(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestService_ClassifyContactMessage(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	original, err := svc.GetContactMessage(ctx, "contact-1")
+	require.NoError(t, err)
+
+	marked, err := svc.ClassifyContactMessage(ctx, "contact-1", model.SpamVerdictSpam)
+	require.NoError(t, err)
+	require.Equal(t, model.ContactStatusSpam, marked.Status)
+
+	released, err := svc.ClassifyContactMessage(ctx, "contact-1", model.SpamVerdictHam)
+	require.NoError(t, err)
+	require.Equal(t, model.ContactStatusPending, released.Status)
+	require.Equal(t, original.Message, released.Message)
+
+	_, err = svc.ClassifyContactMessage(ctx, "contact-1", "maybe")
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+}
+
 func TestService_UpdateContactSettings(t *testing.T) {
 	t.Parallel()
 
@@ -236,7 +321,537 @@ func TestService_UpdateContactSettings(t *testing.T) {
 	require.Equal(t, errs.CodeConflict, appErr.Code)
 }
 
+func TestService_UpdateContactSettingsIntakeQuestions(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	current, err := svc.GetContactSettings(ctx)
+	require.NoError(t, err)
+
+	question := model.IntakeQuestion{
+		ID:       " role ",
+		Type:     "Select",
+		Label:    model.NewLocalizedText("役職", "Role"),
+		Required: true,
+		Options:  []model.IntakeQuestionOption{{Value: " engineer ", Label: model.NewLocalizedText("エンジニア", "Engineer")}},
+	}
+	input := ContactSettingsInput{
+		ID:        current.ID,
+		HeroTitle: current.HeroTitle,
+		Topics: []ContactTopicInput{{
+			ID:        "consulting",
+			Label:     model.NewLocalizedText("開発相談", "Consulting"),
+			Questions: []model.IntakeQuestion{question},
+		}},
+		ConsentText:       current.ConsentText,
+		SupportEmail:      current.SupportEmail,
+		CalendarTimezone:  current.CalendarTimezone,
+		BookingWindowDays: current.BookingWindowDays,
+		ExpectedUpdatedAt: current.UpdatedAt,
+	}
+
+	updated, err := svc.UpdateContactSettings(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, []model.IntakeQuestion{{
+		ID:       "role",
+		Type:     model.IntakeQuestionSelect,
+		Label:    model.NewLocalizedText("役職", "Role"),
+		Required: true,
+		Options:  []model.IntakeQuestionOption{{Value: "engineer", Label: model.NewLocalizedText("エンジニア", "Engineer")}},
+	}}, updated.Topics[0].Questions)
+
+	input.ExpectedUpdatedAt = updated.UpdatedAt
+	for name, broken := range map[string]model.IntakeQuestion{
+		"unknown type":       {ID: "role", Type: "radio", Label: question.Label},
+		"select without opt": {ID: "role", Type: model.IntakeQuestionSelect, Label: question.Label},
+		"text with options":  {ID: "role", Type: model.IntakeQuestionText, Label: question.Label, Options: question.Options},
+		"missing label":      {ID: "role", Type: model.IntakeQuestionText},
+	} {
+		input.Topics[0].Questions = []model.IntakeQuestion{broken}
+		_, err = svc.UpdateContactSettings(ctx, input)
+		require.Equal(t, http.StatusBadRequest, errs.From(err).Status, name)
+	}
+}
+
+func TestService_UpdateContactSettingsWorkingHours(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	current, err := svc.GetContactSettings(ctx)
+	require.NoError(t, err)
+
+	topics := make([]ContactTopicInput, 0, len(current.Topics))
+	for _, topic := range current.Topics {
+		topics = append(topics, ContactTopicInput{ID: topic.ID, Label: topic.Label, Description: topic.Description})
+	}
+	input := ContactSettingsInput{
+		ID:                current.ID,
+		HeroTitle:         current.HeroTitle,
+		Topics:            topics,
+		ConsentText:       current.ConsentText,
+		SupportEmail:      current.SupportEmail,
+		CalendarTimezone:  current.CalendarTimezone,
+		BookingWindowDays: current.BookingWindowDays,
+		WorkingHours: &model.WorkingHours{
+			Weekly: []model.WeekdayWorkingHours{
+				{Weekday: time.Tuesday, Intervals: []model.WorkingInterval{{Start: "13:00", End: "17:45"}, {Start: "9:00", End: "12:00"}}},
+			},
+			Overrides: []model.WorkingHoursOverride{{Date: "2024-12-31"}},
+		},
+		ConflictCalendarIDs: []string{" lab@group.calendar.google.com ", "lab@group.calendar.google.com", ""},
+		ExpectedUpdatedAt:   current.UpdatedAt,
+	}
+
+	updated, err := svc.UpdateContactSettings(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, []model.WorkingInterval{{Start: "09:00", End: "12:00"}, {Start: "13:00", End: "17:45"}}, updated.WorkingHours.Weekly[0].Intervals)
+	require.Len(t, updated.WorkingHours.Overrides, 1)
+	require.Equal(t, []string{"lab@group.calendar.google.com"}, updated.ConflictCalendarIDs)
+
+	// Omitting working hours and conflict calendars keeps the stored values.
+	input.WorkingHours = nil
+	input.ConflictCalendarIDs = nil
+	input.ExpectedUpdatedAt = updated.UpdatedAt
+	kept, err := svc.UpdateContactSettings(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, updated.WorkingHours, kept.WorkingHours)
+	require.Equal(t, updated.ConflictCalendarIDs, kept.ConflictCalendarIDs)
+
+	input.WorkingHours = &model.WorkingHours{
+		Weekly: []model.WeekdayWorkingHours{
+			{Weekday: time.Tuesday, Intervals: []model.WorkingInterval{{Start: "09:00", End: "12:00"}, {Start: "11:00", End: "13:00"}}},
+		},
+	}
+	input.ExpectedUpdatedAt = kept.UpdatedAt
+	_, err = svc.UpdateContactSettings(ctx, input)
+	require.Error(t, err)
+	require.Equal(t, errs.CodeInvalidInput, errs.From(err).Code)
+}
+
+func TestService_UpdateReservationStatusCancelsCalendarEvent(t *testing.T) {
+	t.Parallel()
+
+	cal := &stubCalendarClient{}
+	svc := newTestServiceWithCalendar(t, cal)
+	ctx := context.Background()
+
+	reservation, err := svc.UpdateReservationStatus(ctx, 2, model.MeetingReservationStatusCancelledByOwner, "owner unavailable")
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusCancelledByOwner, reservation.Status)
+	require.Equal(t, []string{"evt-lucas"}, cal.deleted)
+
+	notifications, err := svc.ListReservationNotifications(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	require.Equal(t, "calendar_invite", notifications[0].Type)
+	require.Equal(t, "sent", notifications[0].Status)
+	require.Equal(t, "cancellation_email", notifications[1].Type)
+	require.Equal(t, "pending", notifications[1].Status)
+}
+
+func TestService_UpdateReservationStatusRecreatesDeletedEvent(t *testing.T) {
+	t.Parallel()
+
+	cal := &stubCalendarClient{getErr: calendar.ErrEventNotFound}
+	svc := newTestServiceWithCalendar(t, cal)
+	ctx := context.Background()
+
+	reservation, err := svc.UpdateReservationStatus(ctx, 1, model.MeetingReservationStatusConfirmed, "")
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusConfirmed, reservation.Status)
+	require.Equal(t, "evt-new", reservation.GoogleEventID)
+	require.Len(t, cal.created, 1)
+	require.Equal(t, []string{"akari@example.com"}, cal.created[0].Attendees)
+}
+
+func TestService_RecreatedEventMatchesBookingEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cal := &stubCalendarClient{getErr: calendar.ErrEventNotFound}
+	reservations := inmemory.NewMeetingReservationRepository()
+	svc := newTestServiceWithReservations(t, cal, reservations, inmemory.NewBookingOutboxRepository(reservations))
+
+	start := time.Now().Add(48 * time.Hour).UTC()
+	requested, err := reservations.CreateReservation(ctx, &model.MeetingReservation{
+		LookupHash:    "intake",
+		Name:          "Hana",
+		Email:         "hana@example.com",
+		Locale:        model.LocaleJa,
+		StartAt:       start,
+		EndAt:         start.Add(time.Hour),
+		Status:        model.MeetingReservationStatusRequested,
+		GoogleEventID: "evt-deleted",
+		IntakeAnswers: []model.IntakeAnswer{{QuestionID: "company", Label: "Company", Values: []string{"Acme"}}},
+	})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateReservationStatus(ctx, requested.ID, model.MeetingReservationStatusConfirmed, "")
+	require.NoError(t, err)
+	require.Len(t, cal.created, 1)
+	require.Equal(t, "Hana 様とのご相談", cal.created[0].Summary)
+	require.Contains(t, cal.created[0].Description, "Intake:\nCompany: Acme\n")
+}
+
+func TestService_UpdateReservationStatusEnforcesTransitions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reservations := inmemory.NewMeetingReservationRepository()
+	svc := newTestServiceWithReservations(t, &stubCalendarClient{}, reservations, inmemory.NewBookingOutboxRepository(reservations))
+
+	// Requested reservations cannot be marked as held, and rescheduling needs a new time.
+	_, err := svc.UpdateReservationStatus(ctx, 1, model.MeetingReservationStatusCompleted, "")
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+	_, err = svc.UpdateReservationStatus(ctx, 2, model.MeetingReservationStatusRescheduled, "")
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+	_, err = svc.UpdateReservationStatus(ctx, 2, "cancelled", "")
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+
+	start := time.Now().Add(24 * time.Hour).UTC()
+	upcoming, err := reservations.CreateReservation(ctx, &model.MeetingReservation{
+		LookupHash: "upcoming",
+		Name:       "Ada",
+		Email:      "ada@example.com",
+		StartAt:    start,
+		EndAt:      start.Add(time.Hour),
+		Status:     model.MeetingReservationStatusConfirmed,
+	})
+	require.NoError(t, err)
+	_, err = svc.UpdateReservationStatus(ctx, upcoming.ID, model.MeetingReservationStatusNoShow, "")
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+
+	// Completed and no_show may be swapped, but cancellations are final.
+	_, err = svc.UpdateReservationStatus(ctx, 2, model.MeetingReservationStatusNoShow, "")
+	require.NoError(t, err)
+	reservation, err := svc.UpdateReservationStatus(ctx, 2, model.MeetingReservationStatusCompleted, "marked by mistake")
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusCompleted, reservation.Status)
+
+	_, err = svc.UpdateReservationStatus(ctx, 1, model.MeetingReservationStatusCancelledByOwner, "")
+	require.NoError(t, err)
+	_, err = svc.UpdateReservationStatus(ctx, 1, model.MeetingReservationStatusConfirmed, "")
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+
+	history, err := svc.ListReservationHistory(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, model.MeetingReservationStatusConfirmed, history[0].FromStatus)
+	require.Equal(t, model.MeetingReservationStatusNoShow, history[0].ToStatus)
+	require.Equal(t, model.ReservationActorOwner, history[0].Actor)
+	require.Equal(t, model.MeetingReservationStatusCompleted, history[1].ToStatus)
+	require.Equal(t, "marked by mistake", history[1].Reason)
+
+	history, err = svc.ListReservationHistory(ctx, upcoming.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Empty(t, history[0].FromStatus)
+	require.Equal(t, model.MeetingReservationStatusConfirmed, history[0].ToStatus)
+}
+
+func TestService_SuggestBlacklistAfterRepeatedNoShows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reservations := inmemory.NewMeetingReservationRepository()
+	svc := newTestServiceWithReservations(t, &stubCalendarClient{}, reservations, inmemory.NewBookingOutboxRepository(reservations))
+
+	start := time.Date(2024, 4, 1, 1, 0, 0, 0, time.UTC)
+	var missed []*model.MeetingReservation
+	for i := 0; i < 2; i++ {
+		stored, err := reservations.CreateReservation(ctx, &model.MeetingReservation{
+			LookupHash: fmt.Sprintf("ghost-%d", i),
+			Name:       "Ghost",
+			Email:      "Ghost@example.com",
+			StartAt:    start.AddDate(0, 0, i),
+			EndAt:      start.AddDate(0, 0, i).Add(time.Hour),
+			Status:     model.MeetingReservationStatusConfirmed,
+		})
+		require.NoError(t, err)
+		missed = append(missed, stored)
+	}
+
+	first, err := svc.UpdateReservationStatus(ctx, missed[0].ID, model.MeetingReservationStatusNoShow, "")
+	require.NoError(t, err)
+	suggestion, err := svc.SuggestBlacklist(ctx, first)
+	require.NoError(t, err)
+	require.Nil(t, suggestion)
+
+	second, err := svc.UpdateReservationStatus(ctx, missed[1].ID, model.MeetingReservationStatusNoShow, "")
+	require.NoError(t, err)
+	suggestion, err = svc.SuggestBlacklist(ctx, second)
+	require.NoError(t, err)
+	require.Equal(t, &model.BlacklistSuggestion{Email: "ghost@example.com", NoShowCount: 2}, suggestion)
+
+	_, err = svc.AddBlacklistEntry(ctx, BlacklistInput{Email: "ghost@example.com", Reason: "no-shows"})
+	require.NoError(t, err)
+	suggestion, err = svc.SuggestBlacklist(ctx, second)
+	require.NoError(t, err)
+	require.Nil(t, suggestion)
+}
+
+func TestService_UpdateReservationStatusRecordsCalendarFailure(t *testing.T) {
+	t.Parallel()
+
+	cal := &stubCalendarClient{deleteErr: errors.New("calendar unavailable")}
+	svc := newTestServiceWithCalendar(t, cal)
+	ctx := context.Background()
+
+	reservation, err := svc.UpdateReservationStatus(ctx, 1, model.MeetingReservationStatusCancelledByOwner, "")
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusCancelledByOwner, reservation.Status)
+
+	notifications, err := svc.ListReservationNotifications(ctx, 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, notifications)
+	require.Equal(t, "failed", notifications[0].Status)
+	require.Contains(t, notifications[0].ErrorMessage, "calendar unavailable")
+}
+
+func TestService_RetryReservationNotificationQueuesConfirmation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reservations := inmemory.NewMeetingReservationRepository()
+	outbox := inmemory.NewBookingOutboxRepository(reservations)
+	svc := newTestServiceWithReservations(t, &stubCalendarClient{}, reservations, outbox)
+
+	_, err := svc.RetryReservationNotification(ctx, 1)
+	require.NoError(t, err)
+	jobs, err := outbox.ListJobs(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, model.OutboxJobSendConfirmation, jobs[0].Kind)
+
+	notifications, err := svc.ListReservationNotifications(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+}
+
+func TestService_UpdateReservationStatusDecidesApprovalRequests(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reservations := inmemory.NewMeetingReservationRepository()
+	outbox := inmemory.NewBookingOutboxRepository(reservations)
+	svc := newTestServiceWithReservations(t, &stubCalendarClient{}, reservations, outbox)
+
+	start := time.Date(2030, 6, 3, 1, 0, 0, 0, time.UTC)
+	approved, err := reservations.CreateReservation(ctx, &model.MeetingReservation{
+		LookupHash:       "approve-me",
+		Name:             "Ada",
+		Email:            "ada@example.com",
+		StartAt:          start,
+		EndAt:            start.Add(time.Hour),
+		GoogleEventID:    "evt-tentative",
+		Status:           model.MeetingReservationStatusRequested,
+		RequiresApproval: true,
+	})
+	require.NoError(t, err)
+	declined, err := reservations.CreateReservation(ctx, &model.MeetingReservation{
+		LookupHash:       "decline-me",
+		Name:             "Grace",
+		Email:            "grace@example.com",
+		StartAt:          start.Add(2 * time.Hour),
+		EndAt:            start.Add(3 * time.Hour),
+		GoogleEventID:    "evt-other",
+		Status:           model.MeetingReservationStatusRequested,
+		RequiresApproval: true,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateReservationStatus(ctx, approved.ID, model.MeetingReservationStatusConfirmed, "")
+	require.NoError(t, err)
+	jobs, err := outbox.ListJobs(ctx, approved.ID)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, model.OutboxJobSendApproval, jobs[0].Kind)
+
+	_, err = svc.UpdateReservationStatus(ctx, declined.ID, model.MeetingReservationStatusCancelledByOwner, "fully booked")
+	require.NoError(t, err)
+	jobs, err = outbox.ListJobs(ctx, declined.ID)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	require.Equal(t, model.OutboxJobSendDecline, jobs[0].Kind)
+	require.Equal(t, model.OutboxJobOfferWaitlist, jobs[1].Kind)
+
+	notifications, err := svc.ListReservationNotifications(ctx, declined.ID)
+	require.NoError(t, err)
+	for _, notification := range notifications {
+		require.NotEqual(t, "cancellation_email", notification.Type)
+	}
+}
+
+type stubCalendarClient struct {
+	getErr    error
+	deleteErr error
+	created   []calendar.EventInput
+	deleted   []string
+}
+
+func (s *stubCalendarClient) ListBusyWindows(context.Context, []string, time.Time, time.Time) ([]model.TimeWindow, error) {
+	return nil, nil
+}
+
+func (s *stubCalendarClient) CreateEvent(_ context.Context, _ string, input calendar.EventInput) (*calendar.Event, error) {
+	s.created = append(s.created, input)
+	return &calendar.Event{ID: "evt-new"}, nil
+}
+
+func (s *stubCalendarClient) GetEvent(_ context.Context, _ string, eventID string) (*calendar.Event, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	return &calendar.Event{ID: eventID, Status: "confirmed"}, nil
+}
+
+func (s *stubCalendarClient) UpdateEvent(_ context.Context, _ string, eventID string, _ calendar.EventInput) (*calendar.Event, error) {
+	return &calendar.Event{ID: eventID}, nil
+}
+
+func (s *stubCalendarClient) DeleteEvent(_ context.Context, _ string, eventID string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	s.deleted = append(s.deleted, eventID)
+	return nil
+}
+
+func TestService_ExportReservations(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t)
+	ctx := context.Background()
+	from := time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	export, err := svc.ExportReservations(ctx, ReservationExport{Columns: []string{"id", "name", "startAt", "status"}})
+	require.NoError(t, err)
+	require.Equal(t, "csv", export.Extension)
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out))
+	require.Equal(t, "\ufeffid,name,startAt,status\r\n"+
+		"2,Lucas Chen,2024-05-12T22:00:00+09:00,confirmed\r\n"+
+		"1,Akari Yamada,2024-05-10T19:00:00+09:00,requested\r\n", out.String())
+
+	export, err = svc.ExportReservations(ctx, ReservationExport{
+		Filter:  ReservationFilter{StartFrom: &from},
+		Format:  ExportFormatNDJSON,
+		Columns: []string{"id", "email", "intakeAnswers"},
+	})
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, export.Write(&out))
+	require.Equal(t, `{"id":2,"email":"lucas@example.com","intakeAnswers":[]}`+"\n", out.String())
+
+	export, err = svc.ExportReservations(ctx, ReservationExport{Format: ExportFormatICS})
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, export.Write(&out))
+	require.Contains(t, out.String(), "UID:meeting-reservation-1@personal-website\r\n")
+	require.Contains(t, out.String(), "STATUS:TENTATIVE\r\n")
+	require.True(t, strings.HasSuffix(out.String(), "END:VCALENDAR\r\n"))
+
+	_, err = svc.ExportReservations(ctx, ReservationExport{Columns: []string{"lookupHash"}})
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+	_, err = svc.ExportReservations(ctx, ReservationExport{Format: "xlsx"})
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+}
+
+// pageCountingReservations records the filter of every list query.
+type pageCountingReservations struct {
+	repository.MeetingReservationRepository
+	filters []repository.MeetingReservationListFilter
+}
+
+func (p *pageCountingReservations) ListReservations(ctx context.Context, filter repository.MeetingReservationListFilter) ([]model.MeetingReservation, error) {
+	p.filters = append(p.filters, filter)
+	return p.MeetingReservationRepository.ListReservations(ctx, filter)
+}
+
+func TestService_ExportReservationsPagesThroughRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	base := inmemory.NewMeetingReservationRepository()
+	reservations := &pageCountingReservations{MeetingReservationRepository: base}
+	svc := newTestServiceWithReservations(t, &stubCalendarClient{}, reservations, inmemory.NewBookingOutboxRepository(base))
+
+	// Two pages of cancelled reservations share one start time, so paging has to break ties by id.
+	start := time.Date(2031, 1, 6, 1, 0, 0, 0, time.UTC)
+	for i := 0; i < exportPageSize+20; i++ {
+		_, err := base.CreateReservation(ctx, &model.MeetingReservation{
+			LookupHash: fmt.Sprintf("export-%d", i),
+			Name:       "Bulk",
+			Email:      "bulk@example.com",
+			StartAt:    start,
+			EndAt:      start.Add(time.Hour),
+			Status:     model.MeetingReservationStatusCancelledByOwner,
+		})
+		require.NoError(t, err)
+	}
+
+	export, err := svc.ExportReservations(ctx, ReservationExport{
+		Filter:  ReservationFilter{Status: []model.MeetingReservationStatus{model.MeetingReservationStatusCancelledByOwner}},
+		Format:  ExportFormatNDJSON,
+		Columns: []string{"id"},
+	})
+	require.NoError(t, err)
+	require.Len(t, reservations.filters, 1, "only the first page is read before streaming")
+
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, exportPageSize+20)
+	seen := make(map[string]struct{}, len(lines))
+	for _, line := range lines {
+		seen[line] = struct{}{}
+	}
+	require.Len(t, seen, len(lines))
+
+	require.Len(t, reservations.filters, 2)
+	for _, filter := range reservations.filters {
+		require.Equal(t, exportPageSize, filter.Limit)
+		require.Equal(t, []model.MeetingReservationStatus{model.MeetingReservationStatusCancelledByOwner}, filter.Status)
+	}
+	require.Nil(t, reservations.filters[0].After)
+	require.NotNil(t, reservations.filters[1].After)
+}
+
+func TestService_ExportContactMessages(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	export, err := svc.ExportContactMessages(ctx, ContactExport{
+		Filter:  ContactFilter{Status: []model.ContactStatus{model.ContactStatusInReview}},
+		Columns: []string{"email", "adminNote"},
+	})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out))
+	require.Equal(t, "\ufeffemail,adminNote\r\nlucas@example.com,メールで詳細ヒアリング中\r\n", out.String())
+
+	require.Equal(t, "'=HYPERLINK(1)", csvValue("=HYPERLINK(1)", time.UTC))
+
+	_, err = svc.ExportContactMessages(ctx, ContactExport{Format: ExportFormatICS})
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+}
+
 func newTestService(t *testing.T) Service {
+	return newTestServiceWithCalendar(t, &stubCalendarClient{})
+}
+
+func newTestServiceWithCalendar(t *testing.T, cal calendar.Client) Service {
+	reservations := inmemory.NewMeetingReservationRepository()
+	return newTestServiceWithReservations(t, cal, reservations, inmemory.NewBookingOutboxRepository(reservations))
+}
+
+func newTestServiceWithReservations(t *testing.T, cal calendar.Client, reservations repository.MeetingReservationRepository, outbox repository.BookingOutboxRepository) Service {
 	profileRepo := inmemory.NewProfileRepository()
 	adminProfileRepo, ok := profileRepo.(repository.AdminProfileRepository)
 	if !ok {
@@ -275,7 +890,6 @@ func newTestService(t *testing.T) Service {
 
 	bl := inmemory.NewBlacklistRepository()
 	techCatalog := inmemory.NewTechCatalogRepository()
-	reservations := inmemory.NewMeetingReservationRepository()
 	notifications := inmemory.NewMeetingNotificationRepository()
 
 	svc, err := NewService(
@@ -286,9 +900,19 @@ func newTestService(t *testing.T) Service {
 		adminContactSettingsRepo,
 		adminHomeRepo,
 		bl,
+		inmemory.NewScheduleBlackoutRepository(),
 		techCatalog,
 		reservations,
 		notifications,
+		outbox,
+		inmemory.NewReservationDriftRepository(),
+		inmemory.NewContactNotificationRepository(),
+		inmemory.NewSpamRepository(),
+		cal,
+		&config.AppConfig{
+			Booking: config.BookingConfig{CalendarID: "primary", NoShowBlacklistThreshold: 2},
+			Contact: config.ContactConfig{Timezone: "Asia/Tokyo"},
+		},
 	)
 	require.NoError(t, err)
 
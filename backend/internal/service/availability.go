@@ -2,22 +2,29 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"net/http"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/takumi/personal-website/internal/calendar"
 	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/errs"
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
 	"github.com/takumi/personal-website/internal/repository/inmemory"
+	"github.com/takumi/personal-website/internal/schedule"
 	"github.com/takumi/personal-website/internal/service/support"
 )
 
-// AvailabilityOptions allows handlers to customise the scheduling horizon.
+// AvailabilityOptions allows handlers to customise the scheduling horizon. Timezone, when set,
+// is the visitor's IANA zone: StartDate's calendar date and the day buckets are read in it.
 type AvailabilityOptions struct {
 	StartDate time.Time
 	Days      int
+	Timezone  string
 }
 
 // AvailabilityService calculates bookable contact slots.
@@ -28,15 +35,29 @@ type AvailabilityService interface {
 type availabilityService struct {
 	repo         repository.AvailabilityRepository
 	fallbackRepo repository.AvailabilityRepository
+	settings     repository.ContactFormSettingsRepository
+	calendar     calendar.Client
+	calendarCB   *circuitBreaker
 	cfg          config.ContactConfig
+	bookingCfg   config.BookingConfig
+	clock        Clock
 }
 
 // NewAvailabilityService wires availability logic to the repository and configuration.
-func NewAvailabilityService(repo repository.AvailabilityRepository, cfg *config.AppConfig) AvailabilityService {
+// Working hours come from the contact settings when configured there; otherwise the static
+// workday hours from the contact configuration apply. Busy time from the Google booking and
+// conflict calendars is applied as well, so the slots offered are the ones Book accepts. Calendar
+// calls go through the booking retry settings and their own circuit breaker.
+func NewAvailabilityService(repo repository.AvailabilityRepository, settings repository.ContactFormSettingsRepository, calendarClient calendar.Client, cfg *config.AppConfig) AvailabilityService {
 	return &availabilityService{
 		repo:         repo,
 		fallbackRepo: inmemory.NewAvailabilityRepository(),
+		settings:     settings,
+		calendar:     calendarClient,
+		calendarCB:   newCircuitBreaker(cfg.Booking.CircuitFailureThresh, time.Duration(cfg.Booking.CircuitOpenSeconds)*time.Second),
 		cfg:          cfg.Contact,
+		bookingCfg:   cfg.Booking,
+		clock:        realClock{},
 	}
 }
 
@@ -55,8 +76,13 @@ func (s *availabilityService) GetAvailability(ctx context.Context, opts Availabi
 		slotDuration = 30 * time.Minute
 	}
 
-	if s.cfg.WorkdayEndHour <= s.cfg.WorkdayStartHour {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "invalid contact workday configuration", nil)
+	settings, err := loadContactSettings(ctx, s.settings)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := resolveWorkingHours(settings, s.cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	buffer := time.Duration(s.cfg.BufferMinutes) * time.Minute
@@ -72,14 +98,23 @@ func (s *availabilityService) GetAvailability(ctx context.Context, opts Availabi
 		horizon = 14
 	}
 
+	viewLoc, viewTimezone := loc, s.cfg.Timezone
+	if strings.TrimSpace(opts.Timezone) != "" {
+		viewLoc, err = loadVisitorLocation(opts.Timezone)
+		if err != nil {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "tz must be a valid IANA timezone", err)
+		}
+		viewTimezone = viewLoc.String()
+	}
+
 	startDate := opts.StartDate
 	if startDate.IsZero() {
-		startDate = time.Now().In(loc)
-	} else {
+		startDate = time.Now().In(viewLoc)
+	} else if opts.Timezone == "" {
 		startDate = startDate.In(loc)
 	}
 
-	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, viewLoc)
 	endDate := startDate.AddDate(0, 0, horizon)
 
 	busyWindows, err := s.repo.ListBusyWindows(ctx, startDate.UTC(), endDate.UTC())
@@ -95,28 +130,217 @@ func (s *availabilityService) GetAvailability(ctx context.Context, opts Availabi
 		}
 	}
 
+	busyWindows = append(busyWindows, s.listCalendarBusy(ctx, settings, startDate.UTC(), endDate.UTC())...)
+
 	expanded := expandAndMergeWindows(busyWindows, buffer, loc)
+	limits := resolveBookingLimits(settings, s.clock.Now())
 
 	days := make([]model.AvailabilityDay, 0, horizon)
+	dayIndex := make(map[string]int, horizon)
 	for day := 0; day < horizon; day++ {
-		current := startDate.AddDate(0, 0, day)
-		dayStart := time.Date(current.Year(), current.Month(), current.Day(), s.cfg.WorkdayStartHour, 0, 0, 0, loc)
-		dayEnd := time.Date(current.Year(), current.Month(), current.Day(), s.cfg.WorkdayEndHour, 0, 0, 0, loc)
-
-		slots := buildSlots(dayStart, dayEnd, slotDuration, expanded)
+		date := startDate.AddDate(0, 0, day).Format("2006-01-02")
+		dayIndex[date] = day
 		days = append(days, model.AvailabilityDay{
-			Date:  dayStart.Format("2006-01-02"),
-			Slots: slots,
+			Date:  date,
+			Slots: make([]model.AvailabilitySlot, 0, 16),
 		})
 	}
 
+	// Working hours are laid out on the business calendar; every business day overlapping the
+	// visitor's range contributes slots, which are then bucketed by the visitor's date.
+	first := startDate.In(loc)
+	for current := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); current.Before(endDate); current = current.AddDate(0, 0, 1) {
+		slots := make([]model.AvailabilitySlot, 0, 16)
+		for _, window := range schedule.WindowsOn(hours, current, loc) {
+			slots = append(slots, buildSlots(window.Start, window.End, slotDuration, expanded)...)
+		}
+		applyBookingLimits(slots, limits)
+		for _, slot := range slots {
+			if slot.Start.Before(startDate) || !slot.Start.Before(endDate) {
+				continue
+			}
+			slot.Start = slot.Start.In(viewLoc)
+			slot.End = slot.End.In(viewLoc)
+			index := dayIndex[slot.Start.Format("2006-01-02")]
+			days[index].Slots = append(days[index].Slots, slot)
+		}
+	}
+
 	return &model.AvailabilityResponse{
-		Timezone:    s.cfg.Timezone,
-		GeneratedAt: time.Now().In(loc),
-		Days:        days,
+		Timezone:         viewTimezone,
+		BusinessTimezone: s.cfg.Timezone,
+		GeneratedAt:      s.clock.Now().In(viewLoc),
+		Days:             days,
 	}, nil
 }
 
+// loadVisitorLocation resolves a visitor-supplied IANA zone name. An empty name yields nil;
+// "Local" is rejected because it would expose the server's own zone.
+func loadVisitorLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(name, "Local") {
+		return nil, fmt.Errorf("timezone %q is not an IANA zone", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// listCalendarBusy fetches the FreeBusy windows of the booking and conflict calendars. Availability
+// degrades instead of failing: calendars FreeBusy reports errors for are skipped by the client,
+// and when the call itself fails (missing authorization, timeouts, an open breaker) the failure
+// is logged and slots are computed from the local busy windows alone. Book still checks Google,
+// so a slot offered this way is refused there if it turns out to be taken.
+func (s *availabilityService) listCalendarBusy(ctx context.Context, settings *model.ContactFormSettingsV2, from, to time.Time) []model.TimeWindow {
+	if s.calendar == nil {
+		return nil
+	}
+	calendarIDs := busyCalendarIDs(s.bookingCfg.CalendarID, settings)
+	if len(calendarIDs) == 0 {
+		return nil
+	}
+
+	var windows []model.TimeWindow
+	err := retryCall(ctx, s.bookingCfg, s.clock, s.calendarCB, "calendar availability", func(callCtx context.Context) error {
+		var err error
+		windows, err = s.calendar.ListBusyWindows(callCtx, calendarIDs, from, to)
+		return err
+	})
+	if err != nil {
+		log.Printf("availability: serving slots without Google busy time of %s: %v", strings.Join(calendarIDs, ", "), err)
+		return nil
+	}
+	return windows
+}
+
+// busyCalendarIDs lists the Google calendars whose busy time blocks slots: the booking calendar
+// first, then the conflict calendars from the contact settings, without blanks or duplicates.
+func busyCalendarIDs(bookingCalendarID string, settings *model.ContactFormSettingsV2) []string {
+	candidates := []string{bookingCalendarID}
+	if settings != nil {
+		candidates = append(candidates, settings.ConflictCalendarIDs...)
+	}
+
+	ids := make([]string, 0, len(candidates))
+	seen := make(map[string]struct{}, len(candidates))
+	for _, candidate := range candidates {
+		id := strings.TrimSpace(candidate)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// loadContactSettings reads the live contact settings. A nil repository or missing settings
+// yields nil so callers fall back to the static contact configuration.
+func loadContactSettings(ctx context.Context, repo repository.ContactFormSettingsRepository) (*model.ContactFormSettingsV2, error) {
+	if repo == nil {
+		return nil, nil
+	}
+	settings, err := repo.GetContactFormSettings(ctx)
+	if err != nil {
+		if support.ShouldFallback(err) {
+			return nil, nil
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load contact settings", err)
+	}
+	return settings, nil
+}
+
+// resolveWorkingHours prefers the weekly schedule stored in the contact settings and falls
+// back to the configured workday hours for every day. Date overrides apply in both cases.
+func resolveWorkingHours(settings *model.ContactFormSettingsV2, cfg config.ContactConfig) (model.WorkingHours, error) {
+	var hours model.WorkingHours
+	if settings != nil {
+		hours = settings.WorkingHours
+	}
+	if len(hours.Weekly) > 0 {
+		return hours, nil
+	}
+
+	if cfg.WorkdayEndHour <= cfg.WorkdayStartHour {
+		return model.WorkingHours{}, errs.New(errs.CodeInternal, http.StatusInternalServerError, "invalid contact workday configuration", nil)
+	}
+	fallback := schedule.DefaultWorkingHours(cfg.WorkdayStartHour, cfg.WorkdayEndHour)
+	fallback.Overrides = hours.Overrides
+	return fallback, nil
+}
+
+// minimumBookingNotice is the shortest lead time accepted even when no lead hours are configured.
+const minimumBookingNotice = 15 * time.Minute
+
+// bookingLimits bounds how early and how far ahead a slot may start. A zero latest means no
+// upper bound.
+type bookingLimits struct {
+	earliest  time.Time
+	latest    time.Time
+	leadHours int
+	days      int
+}
+
+// resolveBookingLimits derives limits from the live MinimumLeadHours and BookingWindowDays.
+func resolveBookingLimits(settings *model.ContactFormSettingsV2, now time.Time) bookingLimits {
+	limits := bookingLimits{earliest: now.Add(minimumBookingNotice)}
+	if settings == nil {
+		return limits
+	}
+	if settings.MinimumLeadHours > 0 {
+		lead := time.Duration(settings.MinimumLeadHours) * time.Hour
+		if lead > minimumBookingNotice {
+			limits.earliest = now.Add(lead)
+		}
+		limits.leadHours = settings.MinimumLeadHours
+	}
+	if settings.BookingWindowDays > 0 {
+		limits.latest = now.AddDate(0, 0, settings.BookingWindowDays)
+		limits.days = settings.BookingWindowDays
+	}
+	return limits
+}
+
+// check returns the reason a slot starting at start violates the limits, or "" when allowed.
+func (l bookingLimits) check(start time.Time) model.AvailabilitySlotReason {
+	if start.Before(l.earliest) {
+		return model.AvailabilitySlotReasonMinimumLead
+	}
+	if !l.latest.IsZero() && start.After(l.latest) {
+		return model.AvailabilitySlotReasonBookingWindow
+	}
+	return ""
+}
+
+func (l bookingLimits) leadMessage() string {
+	if l.leadHours > 0 {
+		return fmt.Sprintf("reservation must be made at least %d hours in advance", l.leadHours)
+	}
+	return "reservation must be at least 15 minutes in the future"
+}
+
+func (l bookingLimits) windowMessage() string {
+	return fmt.Sprintf("reservation must be within %d days from now", l.days)
+}
+
+// applyBookingLimits marks free slots outside the limits as unavailable with a reason.
+func applyBookingLimits(slots []model.AvailabilitySlot, limits bookingLimits) {
+	for i := range slots {
+		if !slots[i].IsBookable {
+			continue
+		}
+		if reason := limits.check(slots[i].Start); reason != "" {
+			slots[i].IsBookable = false
+			slots[i].Status = model.AvailabilitySlotStatusUnavailable
+			slots[i].Reason = reason
+		}
+	}
+}
+
 func buildSlots(dayStart, dayEnd time.Time, slotDuration time.Duration, busy []model.TimeWindow) []model.AvailabilitySlot {
 	slots := make([]model.AvailabilitySlot, 0, 16)
 	for cursor := dayStart; !cursor.Add(slotDuration).After(dayEnd); cursor = cursor.Add(slotDuration) {
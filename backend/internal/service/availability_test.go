@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -9,7 +11,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/infra/google"
 	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
 )
 
 func TestAvailabilityService_RespectsBuffer(t *testing.T) {
@@ -27,7 +33,7 @@ func TestAvailabilityService_RespectsBuffer(t *testing.T) {
 		},
 	}
 
-	svc := NewAvailabilityService(repo, &config.AppConfig{
+	svc := NewAvailabilityService(repo, nil, nil, &config.AppConfig{
 		Contact: config.ContactConfig{
 			Timezone:         "Asia/Tokyo",
 			SlotDurationMin:  30,
@@ -38,6 +44,8 @@ func TestAvailabilityService_RespectsBuffer(t *testing.T) {
 		},
 	})
 
+	svc.(*availabilityService).clock = fixedClock{now: busyStart.AddDate(0, 0, -1)}
+
 	resp, err := svc.GetAvailability(context.Background(), AvailabilityOptions{StartDate: busyStart})
 	require.NoError(t, err)
 	require.Len(t, resp.Days, 1)
@@ -72,7 +80,7 @@ func TestAvailabilityService_HonoursDaysOverride(t *testing.T) {
 
 	repo := &stubAvailabilityRepo{}
 
-	svc := NewAvailabilityService(repo, &config.AppConfig{
+	svc := NewAvailabilityService(repo, nil, nil, &config.AppConfig{
 		Contact: config.ContactConfig{
 			Timezone:         "Asia/Tokyo",
 			SlotDurationMin:  60,
@@ -120,7 +128,7 @@ func TestAvailabilityService_FallbackWhenRepositoryUnavailable(t *testing.T) {
 		},
 	}
 
-	svc := NewAvailabilityService(repo, &config.AppConfig{
+	svc := NewAvailabilityService(repo, nil, nil, &config.AppConfig{
 		Contact: config.ContactConfig{
 			Timezone:         "Asia/Tokyo",
 			SlotDurationMin:  30,
@@ -141,3 +149,287 @@ func TestAvailabilityService_FallbackWhenRepositoryUnavailable(t *testing.T) {
 	require.Len(t, resp.Days, 1)
 	require.NotEmpty(t, resp.Days[0].Slots)
 }
+
+func TestAvailabilityService_ExpandsRecurringBlackouts(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	blackouts := inmemory.NewScheduleBlackoutRepository()
+	firstMonday := time.Date(2024, time.May, 6, 12, 0, 0, 0, loc)
+	_, err = blackouts.CreateBlackout(context.Background(), &model.ScheduleBlackout{
+		StartTime:  firstMonday.UTC(),
+		EndTime:    firstMonday.Add(time.Hour).UTC(),
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+		Timezone:   "Asia/Tokyo",
+	})
+	require.NoError(t, err)
+
+	svc := NewAvailabilityService(inmemory.NewAvailabilityRepositoryWithBlackouts(blackouts), nil, nil, &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:         "Asia/Tokyo",
+			SlotDurationMin:  60,
+			WorkdayStartHour: 10,
+			WorkdayEndHour:   14,
+			HorizonDays:      1,
+		},
+	})
+
+	svc.(*availabilityService).clock = fixedClock{now: time.Date(2024, time.June, 1, 0, 0, 0, 0, loc)}
+
+	resp, err := svc.GetAvailability(context.Background(), AvailabilityOptions{
+		StartDate: time.Date(2024, time.June, 3, 0, 0, 0, 0, loc), // Monday, four weeks later
+		Days:      2,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Days, 2)
+
+	for _, slot := range resp.Days[0].Slots {
+		if slot.Start.In(loc).Hour() == 12 {
+			require.False(t, slot.IsBookable)
+			require.Equal(t, model.AvailabilitySlotStatusBlackout, slot.Status)
+			continue
+		}
+		require.True(t, slot.IsBookable)
+	}
+	for _, slot := range resp.Days[1].Slots {
+		require.True(t, slot.IsBookable)
+	}
+}
+
+func TestAvailabilityService_UsesWeeklyWorkingHoursAndOverrides(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	settings := inmemory.NewContactFormSettingsRepository()
+	current, err := settings.GetContactFormSettings(context.Background())
+	require.NoError(t, err)
+	current.WorkingHours = model.WorkingHours{
+		Weekly: []model.WeekdayWorkingHours{
+			{Weekday: time.Monday, Intervals: []model.WorkingInterval{{Start: "09:30", End: "11:00"}, {Start: "14:00", End: "15:00"}}},
+			{Weekday: time.Tuesday, Intervals: []model.WorkingInterval{{Start: "10:00", End: "12:00"}}},
+		},
+		Overrides: []model.WorkingHoursOverride{
+			{Date: "2024-06-04", Intervals: []model.WorkingInterval{{Start: "16:00", End: "16:30"}}},
+		},
+	}
+	_, err = settings.(repository.AdminContactSettingsRepository).UpdateContactFormSettings(context.Background(), current, current.UpdatedAt)
+	require.NoError(t, err)
+
+	svc := NewAvailabilityService(&stubAvailabilityRepo{}, settings, nil, &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:         "Asia/Tokyo",
+			SlotDurationMin:  30,
+			WorkdayStartHour: 9,
+			WorkdayEndHour:   18,
+		},
+	})
+
+	resp, err := svc.GetAvailability(context.Background(), AvailabilityOptions{
+		StartDate: time.Date(2024, time.June, 3, 0, 0, 0, 0, loc), // Monday
+		Days:      3,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Days, 3)
+
+	starts := func(day model.AvailabilityDay) []string {
+		result := make([]string, 0, len(day.Slots))
+		for _, slot := range day.Slots {
+			result = append(result, slot.Start.In(loc).Format("15:04"))
+		}
+		return result
+	}
+	require.Equal(t, []string{"09:30", "10:00", "10:30", "14:00", "14:30"}, starts(resp.Days[0]))
+	require.Equal(t, []string{"16:00"}, starts(resp.Days[1]))
+	require.Empty(t, resp.Days[2].Slots)
+}
+
+func TestAvailabilityService_AppliesLeadTimeAndBookingWindow(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	settings := inmemory.NewContactFormSettingsRepository()
+	current, err := settings.GetContactFormSettings(context.Background())
+	require.NoError(t, err)
+	current.MinimumLeadHours = 24
+	current.BookingWindowDays = 2
+	_, err = settings.(repository.AdminContactSettingsRepository).UpdateContactFormSettings(context.Background(), current, current.UpdatedAt)
+	require.NoError(t, err)
+
+	svc := NewAvailabilityService(&stubAvailabilityRepo{}, settings, nil, &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:         "Asia/Tokyo",
+			SlotDurationMin:  60,
+			WorkdayStartHour: 10,
+			WorkdayEndHour:   13,
+		},
+	})
+	now := time.Date(2024, time.June, 3, 11, 0, 0, 0, loc)
+	svc.(*availabilityService).clock = fixedClock{now: now}
+
+	resp, err := svc.GetAvailability(context.Background(), AvailabilityOptions{StartDate: now, Days: 4})
+	require.NoError(t, err)
+	require.Len(t, resp.Days, 4)
+
+	for _, slot := range resp.Days[0].Slots {
+		require.False(t, slot.IsBookable)
+		require.Equal(t, model.AvailabilitySlotStatusUnavailable, slot.Status)
+		require.Equal(t, model.AvailabilitySlotReasonMinimumLead, slot.Reason)
+	}
+
+	// June 4: 10:00 is within 24 hours, 11:00 onwards is bookable.
+	require.Equal(t, model.AvailabilitySlotReasonMinimumLead, resp.Days[1].Slots[0].Reason)
+	require.True(t, resp.Days[1].Slots[1].IsBookable)
+	require.Empty(t, resp.Days[1].Slots[1].Reason)
+
+	// June 5: the window closes at 11:00, two days after now.
+	require.True(t, resp.Days[2].Slots[1].IsBookable)
+	require.False(t, resp.Days[2].Slots[2].IsBookable)
+	require.Equal(t, model.AvailabilitySlotReasonBookingWindow, resp.Days[2].Slots[2].Reason)
+
+	for _, slot := range resp.Days[3].Slots {
+		require.Equal(t, model.AvailabilitySlotReasonBookingWindow, slot.Reason)
+	}
+}
+
+func TestAvailabilityService_AppliesGoogleBusyFromConflictCalendars(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	settings := inmemory.NewContactFormSettingsRepository()
+	current, err := settings.GetContactFormSettings(context.Background())
+	require.NoError(t, err)
+	current.MinimumLeadHours = 0
+	current.ConflictCalendarIDs = []string{"lab@group.calendar.google.com", "primary"}
+	_, err = settings.(repository.AdminContactSettingsRepository).UpdateContactFormSettings(context.Background(), current, current.UpdatedAt)
+	require.NoError(t, err)
+
+	day := time.Date(2024, time.June, 3, 0, 0, 0, 0, loc)
+	calendarClient := &stubCalendarClient{busy: []model.TimeWindow{
+		{Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)},
+	}}
+	svc := NewAvailabilityService(&stubAvailabilityRepo{}, settings, calendarClient, &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:         "Asia/Tokyo",
+			SlotDurationMin:  60,
+			WorkdayStartHour: 9,
+			WorkdayEndHour:   12,
+		},
+		Booking: config.BookingConfig{CalendarID: "primary"},
+	})
+	svc.(*availabilityService).clock = fixedClock{now: day.AddDate(0, 0, -1)}
+
+	resp, err := svc.GetAvailability(context.Background(), AvailabilityOptions{StartDate: day, Days: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"primary", "lab@group.calendar.google.com"}, calendarClient.listedIDs)
+
+	slots := resp.Days[0].Slots
+	require.Len(t, slots, 3)
+	require.True(t, slots[0].IsBookable)
+	require.False(t, slots[1].IsBookable)
+	require.Equal(t, model.AvailabilitySlotStatusReserved, slots[1].Status)
+	require.True(t, slots[2].IsBookable)
+
+	// Without Google authorization the slots are still served from local data.
+	calendarClient.listErr = google.ErrTokenNotFound
+	resp, err = svc.GetAvailability(context.Background(), AvailabilityOptions{StartDate: day, Days: 1})
+	require.NoError(t, err)
+	require.True(t, resp.Days[0].Slots[1].IsBookable)
+}
+
+func TestAvailabilityService_DegradesWhenCalendarUnavailable(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	calendarClient := &stubCalendarClient{listErr: errors.New("freebusy timeout")}
+	svc := NewAvailabilityService(&stubAvailabilityRepo{windows: []model.TimeWindow{
+		{Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)},
+	}}, nil, calendarClient, &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:         "UTC",
+			SlotDurationMin:  60,
+			WorkdayStartHour: 9,
+			WorkdayEndHour:   12,
+		},
+		Booking: config.BookingConfig{
+			CalendarID:           "primary",
+			MaxRetries:           2,
+			InitialBackoff:       time.Millisecond,
+			CircuitFailureThresh: 2,
+			CircuitOpenSeconds:   60,
+		},
+	})
+	svc.(*availabilityService).clock = fixedClock{now: day.AddDate(0, 0, -1)}
+
+	resp, err := svc.GetAvailability(context.Background(), AvailabilityOptions{StartDate: day, Days: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, calendarClient.listCalls)
+	slots := resp.Days[0].Slots
+	require.Len(t, slots, 3)
+	require.True(t, slots[0].IsBookable)
+	require.False(t, slots[1].IsBookable)
+
+	// The retries opened the breaker, so the next request skips Google entirely.
+	_, err = svc.GetAvailability(context.Background(), AvailabilityOptions{StartDate: day, Days: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, calendarClient.listCalls)
+}
+
+func TestAvailabilityService_BucketsSlotsInVisitorTimezone(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	settings := inmemory.NewContactFormSettingsRepository()
+	current, err := settings.GetContactFormSettings(context.Background())
+	require.NoError(t, err)
+	current.WorkingHours = model.WorkingHours{
+		Weekly: []model.WeekdayWorkingHours{
+			{Weekday: time.Monday, Intervals: []model.WorkingInterval{{Start: "09:00", End: "12:00"}}},
+		},
+	}
+	_, err = settings.(repository.AdminContactSettingsRepository).UpdateContactFormSettings(context.Background(), current, current.UpdatedAt)
+	require.NoError(t, err)
+
+	svc := NewAvailabilityService(&stubAvailabilityRepo{}, settings, nil, &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:        "Asia/Tokyo",
+			SlotDurationMin: 60,
+		},
+	})
+	svc.(*availabilityService).clock = fixedClock{now: time.Date(2024, time.May, 20, 0, 0, 0, 0, tokyo)}
+
+	// Monday 09:00-12:00 in Tokyo is Sunday evening in New York.
+	resp, err := svc.GetAvailability(context.Background(), AvailabilityOptions{
+		StartDate: time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC),
+		Days:      1,
+		Timezone:  "America/New_York",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "America/New_York", resp.Timezone)
+	require.Equal(t, "Asia/Tokyo", resp.BusinessTimezone)
+	require.Len(t, resp.Days, 1)
+	require.Equal(t, "2024-06-02", resp.Days[0].Date)
+
+	starts := make([]string, 0, len(resp.Days[0].Slots))
+	for _, slot := range resp.Days[0].Slots {
+		require.Equal(t, newYork.String(), slot.Start.Location().String())
+		starts = append(starts, slot.Start.Format("2006-01-02 15:04"))
+	}
+	require.Equal(t, []string{"2024-06-02 20:00", "2024-06-02 21:00", "2024-06-02 22:00"}, starts)
+
+	for _, tz := range []string{"Mars/Olympus", "Local"} {
+		_, err = svc.GetAvailability(context.Background(), AvailabilityOptions{Days: 1, Timezone: tz})
+		require.Equal(t, http.StatusBadRequest, errs.From(err).Status, tz)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	mailpkg "net/mail"
 	"sort"
@@ -14,12 +15,16 @@ import (
 	"time"
 
 	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/captcha"
 	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/errs"
 	"github.com/takumi/personal-website/internal/infra/google"
 	"github.com/takumi/personal-website/internal/mail"
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/schedule"
+	"github.com/takumi/personal-website/internal/service/support"
 )
 
 // BookingService coordinates scheduling between Google Calendar, persistence, and notifications.
@@ -27,6 +32,7 @@ type BookingService interface {
 	Book(ctx context.Context, req model.BookingRequest) (*model.BookingResult, error)
 	LookupReservation(ctx context.Context, lookupHash string) (*model.BookingResult, error)
 	CancelReservation(ctx context.Context, lookupHash, reason string) (*model.BookingResult, error)
+	RescheduleReservation(ctx context.Context, lookupHash string, req model.RescheduleRequest) (*model.BookingResult, error)
 }
 
 type Clock interface {
@@ -40,8 +46,13 @@ func (realClock) Now() time.Time { return time.Now() }
 type bookingService struct {
 	reservations   repository.MeetingReservationRepository
 	notifications  repository.MeetingNotificationRepository
+	templates      *notificationRenderer
+	outbox         repository.BookingOutboxRepository
 	availability   repository.AvailabilityRepository
 	blacklist      repository.BlacklistRepository
+	settings       repository.ContactFormSettingsRepository
+	captcha        captcha.Verifier
+	spam           *SpamFilter
 	calendar       calendar.Client
 	mailer         mail.Client
 	cfg            config.BookingConfig
@@ -56,13 +67,18 @@ type bookingService struct {
 func NewBookingService(
 	reservations repository.MeetingReservationRepository,
 	notifications repository.MeetingNotificationRepository,
+	templates repository.NotificationTemplateRepository,
+	outbox repository.BookingOutboxRepository,
 	availability repository.AvailabilityRepository,
 	blacklist repository.BlacklistRepository,
+	settings repository.ContactFormSettingsRepository,
+	verifier captcha.Verifier,
+	spam *SpamFilter,
 	calendar calendar.Client,
 	mailer mail.Client,
 	cfg *config.AppConfig,
 ) (BookingService, error) {
-	if reservations == nil || notifications == nil || availability == nil || blacklist == nil || calendar == nil || mailer == nil || cfg == nil {
+	if reservations == nil || notifications == nil || templates == nil || outbox == nil || availability == nil || blacklist == nil || settings == nil || verifier == nil || calendar == nil || mailer == nil || cfg == nil {
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "booking service: missing dependencies", nil)
 	}
 
@@ -85,14 +101,22 @@ func NewBookingService(
 	if bookingCfg.CircuitOpenSeconds <= 0 {
 		bookingCfg.CircuitOpenSeconds = 60
 	}
+	if bookingCfg.OutboxMaxAttempts <= 0 {
+		bookingCfg.OutboxMaxAttempts = 8
+	}
 
 	openDuration := time.Duration(bookingCfg.CircuitOpenSeconds) * time.Second
 
 	return &bookingService{
 		reservations:   reservations,
 		notifications:  notifications,
+		templates:      newNotificationRenderer(templates, bookingCfg),
+		outbox:         outbox,
 		availability:   availability,
 		blacklist:      blacklist,
+		settings:       settings,
+		captcha:        verifier,
+		spam:           spam,
 		calendar:       calendar,
 		mailer:         mailer,
 		cfg:            bookingCfg,
@@ -122,9 +146,6 @@ func (s *bookingService) Book(ctx context.Context, req model.BookingRequest) (*m
 
 	now := s.clock.Now().In(loc)
 	startLocal := req.StartTime.In(loc)
-	if startLocal.Before(now.Add(15 * time.Minute)) {
-		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservation must be at least 15 minutes in the future", nil)
-	}
 
 	duration := time.Duration(req.DurationMinutes) * time.Minute
 	if duration <= 0 {
@@ -132,18 +153,33 @@ func (s *bookingService) Book(ctx context.Context, req model.BookingRequest) (*m
 	}
 	endLocal := startLocal.Add(duration)
 
-	if strings.TrimSpace(req.RecaptchaToken) == "" {
-		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "recaptcha token is required", nil)
+	visitorLoc, err := loadVisitorLocation(req.Timezone)
+	if err != nil {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "timezone must be a valid IANA timezone", err)
+	}
+	var visitorTimezone string
+	if visitorLoc != nil {
+		visitorTimezone = visitorLoc.String()
 	}
 
-	bufferMinutes := s.contactCfg.BufferMinutes
-	if bufferMinutes <= 0 {
-		bufferMinutes = 30
+	settings, err := loadContactSettings(ctx, s.settings)
+	if err != nil {
+		return nil, err
 	}
-	buffer := time.Duration(bufferMinutes) * time.Minute
 
-	windowStart := startLocal.Add(-buffer)
-	windowEnd := endLocal.Add(buffer)
+	if err := s.ensureBookingRules(settings, startLocal, endLocal, now, loc); err != nil {
+		return nil, err
+	}
+
+	locale := s.templates.locale(req.Locale)
+	answers, err := resolveIntakeAnswers(findContactTopic(settings, topic), req.Answers, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyHuman(ctx, s.captcha, req.RecaptchaToken, req.RemoteIP, captchaActionBooking); err != nil {
+		return nil, err
+	}
 
 	if _, err := s.blacklist.FindBlacklistEntryByEmail(ctx, email); err == nil {
 		return nil, errs.New(errs.CodeUnauthorized, http.StatusForbidden, "email address is blocked from scheduling", nil)
@@ -151,127 +187,208 @@ func (s *bookingService) Book(ctx context.Context, req model.BookingRequest) (*m
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to validate blacklist status", err)
 	}
 
-	busyWindows, err := s.availability.ListBusyWindows(ctx, windowStart.UTC(), windowEnd.UTC())
+	newReservation := model.MeetingReservation{
+		Name:            name,
+		Email:           email,
+		Topic:           topic,
+		Message:         agenda,
+		Locale:          locale,
+		VisitorTimezone: visitorTimezone,
+		IntakeAnswers:   answers,
+		StartAt:         startLocal.UTC(),
+		EndAt:           endLocal.UTC(),
+		DurationMinutes: req.DurationMinutes,
+		Status:          model.MeetingReservationStatusRequested,
+		// Requests for approval-required topics keep holding the slot while they stay requested.
+		RequiresApproval: topicRequiresApproval(settings, topic),
+	}
+
+	screened := &SpamSubmission{
+		Source:        model.SpamSourceBooking,
+		Name:          name,
+		Email:         email,
+		Message:       agenda,
+		Website:       req.Website,
+		FormStartedAt: req.FormStartedAt,
+	}
+	held, err := s.screenSpam(ctx, screened, &newReservation, startLocal)
 	if err != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load local busy windows", err)
+		return nil, err
+	}
+	if held {
+		// Like a contact message held as spam, the sender sees an ordinary request; nothing is
+		// claimed or sent and the booking waits in the contact inbox for review.
+		return s.buildResult(&newReservation, ""), nil
 	}
 
-	var externalBusy []model.TimeWindow
-	err = s.withRetry(ctx, s.calendarCB, "calendar availability", func(callCtx context.Context) error {
-		var err error
-		externalBusy, err = s.calendar.ListBusyWindows(callCtx, s.cfg.CalendarID, windowStart.UTC(), windowEnd.UTC())
-		return err
-	})
-	if err != nil {
-		if errors.Is(err, google.ErrTokenNotFound) {
-			return nil, errs.New(errs.CodeInternal, http.StatusServiceUnavailable, "calendar integration requires administrator authorization", err)
-		}
+	if err := s.ensureSlotAvailable(ctx, settings, startLocal, endLocal, loc, nil); err != nil {
 		return nil, err
 	}
 
-	conflicts := detectConflicts(startLocal, endLocal, append(busyWindows, externalBusy...), buffer, loc)
-	if conflicts {
-		return nil, errs.New(errs.CodeConflict, http.StatusConflict, "requested slot conflicts with existing reservations", nil)
+	lookupHash, err := generateLookupHash()
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to allocate reservation identifier", err)
 	}
+	newReservation.LookupHash = lookupHash
 
-	conflictingReservations, err := s.reservations.ListConflictingReservations(ctx, startLocal.UTC(), endLocal.UTC())
+	// The calendar event and emails are delivered by the outbox dispatcher, so a slow or failing
+	// integration can neither orphan an event nor fail a booking that has already been stored.
+	stored, err := s.outbox.CreateReservationWithJobs(ctx, &newReservation, bookingJobs(s.cfg, &newReservation, s.clock.Now()))
 	if err != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to validate reservation conflicts", err)
-	}
-	if len(conflictingReservations) > 0 {
-		return nil, errs.New(errs.CodeConflict, http.StatusConflict, "requested slot conflicts with existing reservations", nil)
+		if errors.Is(err, repository.ErrConflict) {
+			// Another request claimed an overlapping slot between the availability check and the insert.
+			return nil, errs.New(errs.CodeConflict, http.StatusConflict, "requested slot conflicts with existing reservations", err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to persist reservation", err)
 	}
+	s.spam.Record(ctx, screened)
 
-	var calendarEvent *calendar.Event
-	err = s.withRetry(ctx, s.calendarCB, "calendar booking", func(callCtx context.Context) error {
-		input := calendar.EventInput{
-			Summary:     s.reservationSummary(name),
-			Description: buildEventDescription(name, email, agenda),
-			Start:       startLocal,
-			End:         endLocal,
-			Attendees:   []string{email},
-		}
+	return s.buildResult(stored, stored.GoogleEventID), nil
+}
 
-		var err error
-		calendarEvent, err = s.calendar.CreateEvent(callCtx, s.cfg.CalendarID, input)
-		return err
-	})
-	if err != nil {
-		if errors.Is(err, google.ErrTokenNotFound) {
-			return nil, errs.New(errs.CodeInternal, http.StatusServiceUnavailable, "calendar integration requires administrator authorization", err)
-		}
-		return nil, err
+// screenSpam runs the booking through the spam filter. A booking scored as spam takes no slot;
+// it is stored as a spam contact message so an admin can still review it, and held is true.
+func (s *bookingService) screenSpam(ctx context.Context, submission *SpamSubmission, reservation *model.MeetingReservation, startLocal time.Time) (held bool, err error) {
+	assessment := s.spam.Assess(ctx, submission)
+	if !assessment.Spam {
+		return false, nil
+	}
+
+	if err := s.spam.Hold(ctx, &model.ContactRequest{
+		Name:          reservation.Name,
+		Email:         reservation.Email,
+		Message:       reservation.Message,
+		Topic:         reservation.Topic,
+		Locale:        reservation.Locale,
+		IntakeAnswers: reservation.IntakeAnswers,
+		Spam:          assessment,
+		AdminNote:     fmt.Sprintf("Booking request for %s (%d minutes) held by the spam filter", startLocal.Format(time.RFC3339), reservation.DurationMinutes),
+	}); err != nil {
+		return false, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to queue booking request", err)
 	}
+	s.spam.Record(ctx, submission)
+	return true, nil
+}
 
-	meetURL := calendarEvent.HangoutLink
-	if meetURL == "" {
-		meetURL = calendarEvent.HTMLLink
+// bookingJobs lists the side effects of a new reservation. Approval-required requests get a
+// "request received" email instead of the confirmation, plus an expiry job that only becomes
+// due at the approval deadline.
+func bookingJobs(cfg config.BookingConfig, reservation *model.MeetingReservation, now time.Time) []model.OutboxJob {
+	now = now.UTC()
+	kinds := []model.OutboxJobKind{model.OutboxJobCreateCalendarEvent, model.OutboxJobSendConfirmation}
+	if reservation.RequiresApproval {
+		kinds = []model.OutboxJobKind{model.OutboxJobCreateCalendarEvent, model.OutboxJobSendRequestReceived}
+	}
+	if strings.TrimSpace(cfg.NotificationReceiver) != "" {
+		kinds = append(kinds, model.OutboxJobNotifyOwner)
+	}
+
+	jobs := make([]model.OutboxJob, 0, len(kinds)+1)
+	for _, kind := range kinds {
+		jobs = append(jobs, model.OutboxJob{
+			Kind:          kind,
+			MaxAttempts:   cfg.OutboxMaxAttempts,
+			NextAttemptAt: now,
+		})
+	}
+	if reservation.RequiresApproval {
+		jobs = append(jobs, model.OutboxJob{
+			Kind:          model.OutboxJobExpireApproval,
+			MaxAttempts:   cfg.OutboxMaxAttempts,
+			NextAttemptAt: approvalDeadline(cfg, now, reservation.StartAt),
+		})
+	}
+	return jobs
+}
+
+// ensureBookingRules applies the contact settings loaded for the request to a slot: the minimum
+// lead time, the booking window, and the working hours.
+func (s *bookingService) ensureBookingRules(settings *model.ContactFormSettingsV2, startLocal, endLocal, now time.Time, loc *time.Location) error {
+	limits := resolveBookingLimits(settings, now)
+	switch limits.check(startLocal) {
+	case model.AvailabilitySlotReasonMinimumLead:
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, limits.leadMessage(), nil)
+	case model.AvailabilitySlotReasonBookingWindow:
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, limits.windowMessage(), nil)
 	}
 
-	lookupHash, err := generateLookupHash()
+	hours, err := resolveWorkingHours(settings, s.contactCfg)
 	if err != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to allocate reservation identifier", err)
+		return err
+	}
+	if !schedule.Contains(hours, startLocal, endLocal, loc) {
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "requested slot is outside working hours", nil)
 	}
+	return nil
+}
 
-	newReservation := model.MeetingReservation{
-		LookupHash:           lookupHash,
-		Name:                 name,
-		Email:                email,
-		Topic:                topic,
-		Message:              agenda,
-		StartAt:              startLocal.UTC(),
-		EndAt:                endLocal.UTC(),
-		DurationMinutes:      req.DurationMinutes,
-		GoogleEventID:        calendarEvent.ID,
-		GoogleCalendarStatus: "confirmed",
-		Status:               model.MeetingReservationStatusPending,
-	}
-
-	stored, err := s.reservations.CreateReservation(ctx, &newReservation)
+// ensureSlotAvailable runs the local, Google Calendar (booking and conflict calendars), and
+// reservation conflict checks for a slot.
+// When current is set, windows belonging to that reservation are ignored so it can be moved.
+func (s *bookingService) ensureSlotAvailable(ctx context.Context, settings *model.ContactFormSettingsV2, startLocal, endLocal time.Time, loc *time.Location, current *model.MeetingReservation) error {
+	bufferMinutes := s.contactCfg.BufferMinutes
+	if bufferMinutes <= 0 {
+		bufferMinutes = 30
+	}
+	buffer := time.Duration(bufferMinutes) * time.Minute
+
+	windowStart := startLocal.Add(-buffer)
+	windowEnd := endLocal.Add(buffer)
+
+	busyWindows, err := s.availability.ListBusyWindows(ctx, windowStart.UTC(), windowEnd.UTC())
 	if err != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to persist reservation", err)
+		return errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load local busy windows", err)
 	}
 
-	sentAt := s.clock.Now().In(loc)
-	notificationStatus := "sent"
-	var notificationError string
-	mailErr := s.withRetry(ctx, s.mailCB, "notification email", func(callCtx context.Context) error {
-		message := mail.Message{
-			From:    s.cfg.NotificationSender,
-			To:      []string{email},
-			CC:      buildNotificationCC(s.cfg.NotificationReceiver),
-			Subject: fmt.Sprintf("Meeting request confirmed: %s", startLocal.Format(time.RFC1123)),
-			Body:    buildConfirmationBody(name, startLocal, duration, agenda, meetURL),
-		}
-		return s.mailer.Send(callCtx, message)
+	calendarIDs := busyCalendarIDs(s.cfg.CalendarID, settings)
+
+	var externalBusy []model.TimeWindow
+	err = s.withRetry(ctx, s.calendarCB, "calendar availability", func(callCtx context.Context) error {
+		var err error
+		externalBusy, err = s.calendar.ListBusyWindows(callCtx, calendarIDs, windowStart.UTC(), windowEnd.UTC())
+		return err
 	})
-	if mailErr != nil {
-		notificationStatus = "failed"
-		notificationError = mailErr.Error()
+	if err != nil {
+		return mapCalendarError(err)
 	}
 
-	if _, err := s.notifications.RecordNotification(ctx, &model.MeetingNotification{
-		ReservationID: stored.ID,
-		Type:          "confirmation_email",
-		Status:        notificationStatus,
-		ErrorMessage:  notificationError,
-	}); err != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to record notification history", err)
+	busy := excludeReservationWindows(append(busyWindows, externalBusy...), current)
+	if detectConflicts(startLocal, endLocal, busy, buffer, loc) {
+		return errs.New(errs.CodeConflict, http.StatusConflict, "requested slot conflicts with existing reservations", nil)
 	}
 
-	if mailErr != nil {
-		if errors.Is(mailErr, google.ErrTokenNotFound) {
-			return nil, errs.New(errs.CodeInternal, http.StatusServiceUnavailable, "email integration requires administrator authorization", mailErr)
+	conflictingReservations, err := s.reservations.ListConflictingReservations(ctx, startLocal.UTC(), endLocal.UTC())
+	if err != nil {
+		return errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to validate reservation conflicts", err)
+	}
+	for _, conflict := range conflictingReservations {
+		if current != nil && conflict.ID == current.ID {
+			continue
 		}
-		return nil, mailErr
+		return errs.New(errs.CodeConflict, http.StatusConflict, "requested slot conflicts with existing reservations", nil)
 	}
+	return nil
+}
 
-	updatedReservation, err := s.reservations.MarkConfirmationSent(ctx, stored.ID, sentAt)
-	if err != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to update confirmation status", err)
+func excludeReservationWindows(windows []model.TimeWindow, current *model.MeetingReservation) []model.TimeWindow {
+	if current == nil {
+		return windows
 	}
+	filtered := make([]model.TimeWindow, 0, len(windows))
+	for _, window := range windows {
+		if window.Start.Equal(current.StartAt) && window.End.Equal(current.EndAt) {
+			continue
+		}
+		filtered = append(filtered, window)
+	}
+	return filtered
+}
 
-	return s.buildResult(updatedReservation, calendarEvent.ID), nil
+func mapCalendarError(err error) error {
+	if errors.Is(err, google.ErrTokenNotFound) {
+		return errs.New(errs.CodeInternal, http.StatusServiceUnavailable, "calendar integration requires administrator authorization", err)
+	}
+	return err
 }
 
 func generateLookupHash() (string, error) {
@@ -311,84 +428,387 @@ func (s *bookingService) buildResult(reservation *model.MeetingReservation, cale
 	}
 
 	copyReservation := *reservation
-	return &model.BookingResult{
+	result := &model.BookingResult{
 		Reservation:      copyReservation,
 		CalendarEventID:  calendarEventID,
 		SupportEmail:     s.supportEmail,
 		CalendarTimezone: s.calendarTZName,
+		CalendarTime:     zonedTimeRange(reservation, s.calendarTZName),
+	}
+	if reservation.VisitorTimezone != "" && reservation.VisitorTimezone != s.calendarTZName {
+		visitorTime := zonedTimeRange(reservation, reservation.VisitorTimezone)
+		result.VisitorTime = &visitorTime
 	}
+	return result
 }
 
-func (s *bookingService) reservationSummary(name string) string {
-	if template := strings.TrimSpace(s.cfg.MeetTemplate); template != "" {
-		return fmt.Sprintf("%s - %s", template, name)
+// zonedTimeRange expresses the reservation in the named zone, falling back to UTC when the
+// name cannot be loaded.
+func zonedTimeRange(reservation *model.MeetingReservation, timezone string) model.ZonedTimeRange {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return model.ZonedTimeRange{
+		Timezone: loc.String(),
+		Start:    reservation.StartAt.In(loc),
+		End:      reservation.EndAt.In(loc),
 	}
-	return fmt.Sprintf("Consultation with %s", name)
 }
 
 func (s *bookingService) LookupReservation(ctx context.Context, lookupHash string) (*model.BookingResult, error) {
-	hash := strings.TrimSpace(lookupHash)
-	if hash == "" {
-		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "lookup hash is required", nil)
-	}
-
-	reservation, err := s.reservations.FindReservationByLookupHash(ctx, hash)
+	reservation, err := s.findReservation(ctx, lookupHash)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "reservation not found", err)
-		}
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to retrieve reservation", err)
+		return nil, err
 	}
 	return s.buildResult(reservation, reservation.GoogleEventID), nil
 }
 
 func (s *bookingService) CancelReservation(ctx context.Context, lookupHash, reason string) (*model.BookingResult, error) {
-	hash := strings.TrimSpace(lookupHash)
-	if hash == "" {
-		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "lookup hash is required", nil)
+	reservation, err := s.findReservation(ctx, lookupHash)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status.IsCancelled() {
+		return nil, errs.New(errs.CodeConflict, http.StatusConflict, "reservation has already been cancelled", nil)
+	}
+	if !reservation.Status.IsActive() {
+		return nil, errs.New(errs.CodeConflict, http.StatusConflict, "reservation has already taken place", nil)
 	}
 
-	reservation, err := s.reservations.FindReservationByLookupHash(ctx, hash)
+	// The status changes first: if a concurrent change wins, the reservation and its calendar
+	// event are both left as they were.
+	updated, err := s.reservations.TransitionReservationStatus(ctx, &model.MeetingReservationStatusChange{
+		ReservationID: reservation.ID,
+		FromStatus:    reservation.Status,
+		ToStatus:      model.MeetingReservationStatusCancelledByVisitor,
+		Actor:         model.ReservationActorVisitor,
+		Reason:        strings.TrimSpace(reason),
+	})
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "reservation not found", err)
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, errs.New(errs.CodeConflict, http.StatusConflict, "reservation was changed while cancelling; reload and try again", err)
 		}
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to retrieve reservation", err)
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to cancel reservation", err)
 	}
 
-	updated, err := s.reservations.CancelReservation(ctx, reservation.ID, reason)
-	if err != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to cancel reservation", err)
+	notificationStatus := "sent"
+	var notificationError string
+	message, mailErr := s.cancellationMessage(ctx, updated)
+	if mailErr == nil {
+		mailErr = s.withRetry(ctx, s.mailCB, "notification email", func(callCtx context.Context) error {
+			return s.mailer.Send(callCtx, message)
+		})
+	}
+	if mailErr != nil {
+		// The reservation is already cancelled, so a failed email is recorded instead of failing the request.
+		notificationStatus = "failed"
+		notificationError = mailErr.Error()
 	}
 
 	if _, recordErr := s.notifications.RecordNotification(ctx, &model.MeetingNotification{
 		ReservationID: updated.ID,
 		Type:          "cancellation_email",
-		Status:        "pending",
+		Status:        notificationStatus,
+		ErrorMessage:  notificationError,
 	}); recordErr != nil {
-		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to record cancellation notification", recordErr)
+		// The cancellation already happened; failing here would only make the visitor retry it.
+		log.Printf("booking: record cancellation notification for reservation %d: %v", updated.ID, recordErr)
+	}
+
+	// The outbox dispatcher deletes the calendar event and offers the freed slot to the waitlist.
+	// The cancellation has already happened, so a failure to queue them is only logged.
+	jobs := []model.OutboxJob{waitlistOfferJob(s.cfg, s.clock.Now())}
+	if strings.TrimSpace(updated.GoogleEventID) != "" {
+		jobs = append(jobs, model.OutboxJob{
+			Kind:          model.OutboxJobDeleteCalendarEvent,
+			MaxAttempts:   s.cfg.OutboxMaxAttempts,
+			NextAttemptAt: s.clock.Now().UTC(),
+		})
+	}
+	if err := s.outbox.EnqueueJobs(ctx, updated.ID, jobs); err != nil {
+		log.Printf("booking: queue cancellation jobs for reservation %d: %v", updated.ID, err)
 	}
 
 	return s.buildResult(updated, updated.GoogleEventID), nil
 }
 
+func (s *bookingService) cancellationMessage(ctx context.Context, reservation *model.MeetingReservation) (mail.Message, error) {
+	loc, err := time.LoadLocation(s.contactCfg.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return cancellationMessage(ctx, s.templates, s.cfg, reservation, loc, s.clock.Now())
+}
+
+// cancellationMessage composes the visitor's cancellation email, copying the owner and attaching
+// the CANCEL invite that removes the meeting from the visitor's calendar.
+func cancellationMessage(ctx context.Context, templates *notificationRenderer, cfg config.BookingConfig, reservation *model.MeetingReservation, loc *time.Location, now time.Time) (mail.Message, error) {
+	locale := templates.locale(reservation.Locale)
+	data := reservationNotificationData(reservation, locale, loc)
+	data.Reason = strings.TrimSpace(reservation.CancellationReason)
+
+	message, err := templates.compose(ctx, model.NotificationTemplateBookingCancellation, locale, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+	message.From = cfg.NotificationSender
+	message.To = []string{reservation.Email}
+	message.CC = buildNotificationCC(cfg.NotificationReceiver)
+	message.Attachments = []mail.Attachment{
+		meetingInvite(ics.MethodCancel, reservation, cfg, "", now),
+	}
+	return message, nil
+}
+
+// rescheduleMessage composes the visitor's reschedule email with an updated invite. previousStart
+// is the already formatted time the meeting moved from.
+func rescheduleMessage(ctx context.Context, templates *notificationRenderer, cfg config.BookingConfig, reservation *model.MeetingReservation, previousStart, meetURL string, loc *time.Location, now time.Time) (mail.Message, error) {
+	locale := templates.locale(reservation.Locale)
+	data := reservationNotificationData(reservation, locale, loc)
+	data.PreviousStart = previousStart
+	data.MeetURL = meetURL
+
+	message, err := templates.compose(ctx, model.NotificationTemplateBookingReschedule, locale, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+	message.From = cfg.NotificationSender
+	message.To = []string{reservation.Email}
+	message.CC = buildNotificationCC(cfg.NotificationReceiver)
+	message.Attachments = []mail.Attachment{
+		meetingInvite(ics.MethodRequest, reservation, cfg, meetURL, now),
+	}
+	return message, nil
+}
+
+func (s *bookingService) RescheduleReservation(ctx context.Context, lookupHash string, req model.RescheduleRequest) (*model.BookingResult, error) {
+	if req.StartTime.IsZero() {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "start time is required", nil)
+	}
+	if req.DurationMinutes < 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "durationMinutes must be greater than zero", nil)
+	}
+	if req.DurationMinutes > 240 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "durationMinutes exceeds maximum allowed duration", nil)
+	}
+
+	reservation, err := s.findReservation(ctx, lookupHash)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status.IsCancelled() {
+		return nil, errs.New(errs.CodeConflict, http.StatusConflict, "cancelled reservations cannot be rescheduled", nil)
+	}
+	if !reservation.Status.IsActive() {
+		return nil, errs.New(errs.CodeConflict, http.StatusConflict, "reservation has already taken place", nil)
+	}
+
+	loc, err := time.LoadLocation(s.contactCfg.Timezone)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "booking service: invalid timezone configuration", err)
+	}
+
+	now := s.clock.Now().In(loc)
+	if !reservation.StartAt.After(now) {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservation has already started and can no longer be rescheduled", nil)
+	}
+
+	startLocal := req.StartTime.In(loc)
+
+	durationMinutes := req.DurationMinutes
+	if durationMinutes == 0 {
+		durationMinutes = reservation.DurationMinutes
+	}
+	duration := time.Duration(durationMinutes) * time.Minute
+	if duration <= 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "duration must be greater than zero", nil)
+	}
+	endLocal := startLocal.Add(duration)
+
+	if startLocal.Equal(reservation.StartAt) && endLocal.Equal(reservation.EndAt) {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "reservation is already scheduled for the requested time", nil)
+	}
+
+	settings, err := loadContactSettings(ctx, s.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureBookingRules(settings, startLocal, endLocal, now, loc); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureSlotAvailable(ctx, settings, startLocal, endLocal, loc, reservation); err != nil {
+		return nil, err
+	}
+
+	input := support.CalendarEventInput(s.cfg, reservation, startLocal, endLocal)
+
+	// The new slot is claimed before Google is touched, so a lost race never moves the event.
+	updated, err := s.reservations.RescheduleReservation(ctx, reservation.ID, startLocal.UTC(), endLocal.UTC(), reservation.GoogleEventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, s.rescheduleConflict(ctx, reservation.ID, err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to persist rescheduled reservation", err)
+	}
+
+	// Without an event ID the create_calendar_event job is still pending. It reads the stored
+	// slot when it runs, so creating an event here would only give the meeting a second one.
+	calendarEvent := &calendar.Event{}
+	if strings.TrimSpace(reservation.GoogleEventID) != "" {
+		calendarEvent, err = s.moveCalendarEvent(ctx, reservation.GoogleEventID, input)
+		if err != nil {
+			// Hand the old slot back so the reservation still matches its unchanged event.
+			if _, revertErr := s.reservations.RescheduleReservation(ctx, reservation.ID, reservation.StartAt, reservation.EndAt, reservation.GoogleEventID); revertErr != nil {
+				log.Printf("booking: restore slot of reservation %d after calendar failure: %v", reservation.ID, revertErr)
+			}
+			return nil, mapCalendarError(err)
+		}
+	}
+	if calendarEvent.ID != "" && calendarEvent.ID != updated.GoogleEventID {
+		// The event had to be recreated; the move itself is stored, so only log a failed link.
+		linked, err := s.reservations.RescheduleReservation(ctx, updated.ID, updated.StartAt, updated.EndAt, calendarEvent.ID)
+		if err != nil {
+			log.Printf("booking: store recreated event %s for reservation %d: %v", calendarEvent.ID, updated.ID, err)
+		} else {
+			updated = linked
+		}
+	}
+
+	// Requests still awaiting confirmation stay requested; confirmed meetings move to rescheduled.
+	// The new time is already stored, so a failed history write is only logged.
+	if updated.Status.CanTransitionTo(model.MeetingReservationStatusRescheduled) {
+		transitioned, err := s.reservations.TransitionReservationStatus(ctx, &model.MeetingReservationStatusChange{
+			ReservationID: updated.ID,
+			FromStatus:    updated.Status,
+			ToStatus:      model.MeetingReservationStatusRescheduled,
+			Actor:         model.ReservationActorVisitor,
+		})
+		if err != nil {
+			log.Printf("booking: record reschedule of reservation %d: %v", updated.ID, err)
+		} else {
+			updated = transitioned
+		}
+	}
+
+	meetURL := calendarEvent.HangoutLink
+	if meetURL == "" {
+		meetURL = calendarEvent.HTMLLink
+	}
+
+	notificationStatus := "sent"
+	var notificationError string
+	previousStart := formatReservationTime(reservation.StartAt, s.templates.locale(updated.Locale), loc, reservation.VisitorTimezone)
+	message, mailErr := rescheduleMessage(ctx, s.templates, s.cfg, updated, previousStart, meetURL, loc, s.clock.Now())
+	if mailErr == nil {
+		mailErr = s.withRetry(ctx, s.mailCB, "notification email", func(callCtx context.Context) error {
+			return s.mailer.Send(callCtx, message)
+		})
+	}
+	if mailErr != nil {
+		// The reservation has already moved, so a failed email is recorded for retry instead of failing the request.
+		notificationStatus = "failed"
+		notificationError = mailErr.Error()
+	}
+
+	if _, err := s.notifications.RecordNotification(ctx, &model.MeetingNotification{
+		ReservationID: updated.ID,
+		Type:          "reschedule_email",
+		Status:        notificationStatus,
+		ErrorMessage:  notificationError,
+	}); err != nil {
+		log.Printf("booking: record reschedule notification for reservation %d: %v", updated.ID, err)
+	}
+
+	return s.buildResult(updated, calendarEvent.ID), nil
+}
+
+// moveCalendarEvent moves the reservation's event to the slot in input, creating a fresh event
+// when the original was deleted from the calendar.
+func (s *bookingService) moveCalendarEvent(ctx context.Context, eventID string, input calendar.EventInput) (*calendar.Event, error) {
+	var event *calendar.Event
+	err := s.withRetry(ctx, s.calendarCB, "calendar reschedule", func(callCtx context.Context) error {
+		var err error
+		event, err = s.calendar.UpdateEvent(callCtx, s.cfg.CalendarID, strings.TrimSpace(eventID), input)
+		return err
+	})
+	if err == nil {
+		return event, nil
+	}
+	if !errors.Is(err, calendar.ErrEventNotFound) {
+		return nil, err
+	}
+
+	err = s.withRetry(ctx, s.calendarCB, "calendar booking", func(callCtx context.Context) error {
+		var err error
+		event, err = s.calendar.CreateEvent(callCtx, s.cfg.CalendarID, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// rescheduleConflict explains a lost slot claim: the reservation was cancelled or completed in
+// the meantime, or another booking took the requested slot first.
+func (s *bookingService) rescheduleConflict(ctx context.Context, id uint64, err error) error {
+	if current, findErr := s.reservations.FindReservationByID(ctx, id); findErr == nil {
+		switch {
+		case current.Status.IsCancelled():
+			return errs.New(errs.CodeConflict, http.StatusConflict, "cancelled reservations cannot be rescheduled", err)
+		case !current.Status.IsActive():
+			return errs.New(errs.CodeConflict, http.StatusConflict, "reservation has already taken place", err)
+		}
+	}
+	return errs.New(errs.CodeConflict, http.StatusConflict, "requested slot conflicts with existing reservations", err)
+}
+
+func (s *bookingService) findReservation(ctx context.Context, lookupHash string) (*model.MeetingReservation, error) {
+	hash := strings.TrimSpace(lookupHash)
+	if hash == "" {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "lookup hash is required", nil)
+	}
+
+	reservation, err := s.reservations.FindReservationByLookupHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "reservation not found", err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to retrieve reservation", err)
+	}
+	return reservation, nil
+}
+
 func (s *bookingService) withRetry(ctx context.Context, breaker *circuitBreaker, operation string, call func(ctx context.Context) error) error {
-	attempts := s.cfg.MaxRetries
+	return retryCall(ctx, s.cfg, s.clock, breaker, operation, call)
+}
+
+// retryCall runs an external call with the configured per-attempt timeout, retrying retryable
+// failures with exponential backoff while the breaker allows it.
+func retryCall(ctx context.Context, cfg config.BookingConfig, clock Clock, breaker *circuitBreaker, operation string, call func(ctx context.Context) error) error {
+	attempts := cfg.MaxRetries
 	if attempts < 1 {
 		attempts = 1
 	}
-	backoff := s.cfg.InitialBackoff
+	backoff := cfg.InitialBackoff
 	if backoff <= 0 {
 		backoff = 750 * time.Millisecond
 	}
-	timeout := s.cfg.RequestTimeout
+	multiplier := cfg.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	timeout := cfg.RequestTimeout
 	if timeout <= 0 {
 		timeout = 8 * time.Second
 	}
 
 	var lastErr error
 	for attempt := 1; attempt <= attempts; attempt++ {
-		if !breaker.Allow(s.clock.Now()) {
+		if !breaker.Allow(clock.Now()) {
 			return errs.New(errs.CodeInternal, http.StatusServiceUnavailable, fmt.Sprintf("%s temporarily unavailable", operation), lastErr)
 		}
 
@@ -407,7 +827,7 @@ func (s *bookingService) withRetry(ctx context.Context, breaker *circuitBreaker,
 		}
 
 		lastErr = err
-		breaker.Failure(s.clock.Now())
+		breaker.Failure(clock.Now())
 		if !isRetryable(err) || attempt == attempts {
 			return errs.New(errs.CodeInternal, http.StatusBadGateway, fmt.Sprintf("failed to execute %s", operation), err)
 		}
@@ -417,7 +837,7 @@ func (s *bookingService) withRetry(ctx context.Context, breaker *circuitBreaker,
 		case <-ctx.Done():
 			return errs.New(errs.CodeInternal, http.StatusGatewayTimeout, fmt.Sprintf("%s aborted due to context cancellation", operation), ctx.Err())
 		}
-		backoff = time.Duration(float64(backoff) * s.cfg.BackoffMultiplier)
+		backoff = time.Duration(float64(backoff) * multiplier)
 	}
 
 	if lastErr == nil {
@@ -481,6 +901,9 @@ func isRetryable(err error) bool {
 	if err == nil {
 		return false
 	}
+	if errors.Is(err, calendar.ErrEventNotFound) {
+		return false
+	}
 	return true
 }
 
@@ -557,30 +980,3 @@ func buildNotificationCC(receiver string) []string {
 	}
 	return []string{receiver}
 }
-
-func buildConfirmationBody(name string, start time.Time, duration time.Duration, agenda, meetURL string) string {
-	var builder strings.Builder
-	builder.WriteString(fmt.Sprintf("Hi %s,\n\n", name))
-	builder.WriteString(fmt.Sprintf("Your meeting has been scheduled for %s (duration: %.0f minutes).\n", start.Format(time.RFC1123), duration.Minutes()))
-	if agenda != "" {
-		builder.WriteString("\nAgenda:\n")
-		builder.WriteString(agenda)
-		builder.WriteString("\n")
-	}
-	if meetURL != "" {
-		builder.WriteString(fmt.Sprintf("\nJoin via Google Meet: %s\n", meetURL))
-	}
-	builder.WriteString("\nThank you,\nPortfolio Site\n")
-	return builder.String()
-}
-
-func buildEventDescription(name, email, agenda string) string {
-	var builder strings.Builder
-	builder.WriteString(fmt.Sprintf("Meeting with %s (%s)\n", name, email))
-	if agenda != "" {
-		builder.WriteString("\nAgenda:\n")
-		builder.WriteString(agenda)
-		builder.WriteString("\n")
-	}
-	return builder.String()
-}
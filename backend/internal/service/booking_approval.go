@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/mail"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/service/support"
+)
+
+// Bookings for topics marked RequiresApproval hold their slot as a requested reservation with a
+// tentative calendar event that has no attendees. The visitor is told the request was received;
+// the approved or declined email follows the administrator's decision, and requests still
+// undecided at their deadline are cancelled by the expire_approval job.
+
+// approvalExpiredReason is stored as the cancellation reason of requests that lapsed unapproved.
+const approvalExpiredReason = "approval expired"
+
+const defaultApprovalExpiry = 48 * time.Hour
+
+// topicRequiresApproval reports whether the configured topic with the given ID needs approval.
+func topicRequiresApproval(settings *model.ContactFormSettingsV2, topic string) bool {
+	if configured := findContactTopic(settings, topic); configured != nil {
+		return configured.RequiresApproval
+	}
+	return false
+}
+
+// approvalDeadline is when an unanswered request lapses: the configured expiry after it was
+// made, but never later than the meeting itself.
+func approvalDeadline(cfg config.BookingConfig, requestedAt, start time.Time) time.Time {
+	expiry := cfg.ApprovalExpiry
+	if expiry <= 0 {
+		expiry = defaultApprovalExpiry
+	}
+	deadline := requestedAt.Add(expiry)
+	if start.Before(deadline) {
+		return start
+	}
+	return deadline
+}
+
+func (d *OutboxDispatcher) sendRequestReceived(ctx context.Context, reservation *model.MeetingReservation) error {
+	if !support.AwaitingApproval(reservation) {
+		return nil
+	}
+
+	locale := d.templates.locale(reservation.Locale)
+	data := reservationNotificationData(reservation, locale, d.location())
+	deadline, err := d.approvalDeadline(ctx, reservation)
+	if err != nil {
+		return err
+	}
+	if !deadline.IsZero() {
+		data.Deadline = formatNotificationTime(deadline.In(d.location()), locale)
+	}
+	message, err := d.templates.compose(ctx, model.NotificationTemplateBookingRequested, locale, data)
+	if err != nil {
+		return err
+	}
+	message.From = d.cfg.NotificationSender
+	message.To = []string{reservation.Email}
+	return d.mailer.Send(ctx, message)
+}
+
+// sendApproval turns the tentative event into the real invitation and sends the approved email.
+// A request cancelled or already finished before the job runs gets no email.
+func (d *OutboxDispatcher) sendApproval(ctx context.Context, reservation *model.MeetingReservation) error {
+	if reservation.Status != model.MeetingReservationStatusConfirmed && reservation.Status != model.MeetingReservationStatusRescheduled {
+		return nil
+	}
+
+	if eventID := strings.TrimSpace(reservation.GoogleEventID); eventID != "" {
+		_, err := d.calendar.UpdateEvent(ctx, d.cfg.CalendarID, eventID, calendar.EventInput{
+			Summary:   support.CalendarEventSummary(d.cfg, reservation),
+			Attendees: []string{reservation.Email},
+		})
+		if err != nil && !errors.Is(err, calendar.ErrEventNotFound) {
+			return err
+		}
+	}
+
+	meetURL, err := d.meetingLink(ctx, reservation)
+	if err != nil {
+		return err
+	}
+
+	locale := d.templates.locale(reservation.Locale)
+	data := reservationNotificationData(reservation, locale, d.location())
+	data.MeetURL = meetURL
+	message, err := d.templates.compose(ctx, model.NotificationTemplateBookingApproved, locale, data)
+	if err != nil {
+		return err
+	}
+	message.From = d.cfg.NotificationSender
+	message.To = []string{reservation.Email}
+	message.Attachments = []mail.Attachment{
+		meetingInvite(ics.MethodRequest, reservation, d.cfg, meetURL, d.clock.Now()),
+	}
+	if err := d.mailer.Send(ctx, message); err != nil {
+		return err
+	}
+
+	_, err = d.reservations.MarkConfirmationSent(ctx, reservation.ID, d.clock.Now())
+	return err
+}
+
+func (d *OutboxDispatcher) sendDecline(ctx context.Context, reservation *model.MeetingReservation) error {
+	if !reservation.Status.IsCancelled() {
+		return nil
+	}
+
+	locale := d.templates.locale(reservation.Locale)
+	data := reservationNotificationData(reservation, locale, d.location())
+	if reason := strings.TrimSpace(reservation.CancellationReason); reason == approvalExpiredReason {
+		data.Expired = true
+	} else {
+		data.Reason = reason
+	}
+	message, err := d.templates.compose(ctx, model.NotificationTemplateBookingDeclined, locale, data)
+	if err != nil {
+		return err
+	}
+	message.From = d.cfg.NotificationSender
+	message.To = []string{reservation.Email}
+	return d.mailer.Send(ctx, message)
+}
+
+// expireApproval cancels a request nobody answered in time, releasing its slot, and queues the
+// declined email and a waitlist offer for the slot. Decided requests are left alone. The event is
+// deleted only after the request has expired, so an approval that wins the race keeps its event;
+// when a later step fails, the retried job finds the request expired and finishes the cleanup.
+func (d *OutboxDispatcher) expireApproval(ctx context.Context, reservation *model.MeetingReservation) error {
+	switch {
+	case support.AwaitingApproval(reservation):
+		expired, err := d.reservations.TransitionReservationStatus(ctx, &model.MeetingReservationStatusChange{
+			ReservationID: reservation.ID,
+			FromStatus:    reservation.Status,
+			ToStatus:      model.MeetingReservationStatusCancelledByOwner,
+			Actor:         model.ReservationActorSystem,
+			Reason:        approvalExpiredReason,
+		})
+		if errors.Is(err, repository.ErrConflict) {
+			// The request was decided while the job was running.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		reservation = expired
+	case !approvalExpired(reservation):
+		return nil
+	}
+
+	if eventID := strings.TrimSpace(reservation.GoogleEventID); eventID != "" {
+		err := d.calendar.DeleteEvent(ctx, d.cfg.CalendarID, eventID)
+		if err != nil && !errors.Is(err, calendar.ErrEventNotFound) {
+			return err
+		}
+	}
+
+	return d.outbox.EnqueueJobs(ctx, reservation.ID, []model.OutboxJob{
+		{
+			Kind:          model.OutboxJobSendDecline,
+			MaxAttempts:   d.cfg.OutboxMaxAttempts,
+			NextAttemptAt: d.clock.Now().UTC(),
+		},
+		waitlistOfferJob(d.cfg, d.clock.Now()),
+	})
+}
+
+// approvalExpired reports whether the reservation was cancelled by the approval expiry job.
+func approvalExpired(reservation *model.MeetingReservation) bool {
+	return reservation.Status == model.MeetingReservationStatusCancelledByOwner &&
+		strings.TrimSpace(reservation.CancellationReason) == approvalExpiredReason
+}
+
+// approvalDeadline reads the deadline from the reservation's expire_approval job.
+func (d *OutboxDispatcher) approvalDeadline(ctx context.Context, reservation *model.MeetingReservation) (time.Time, error) {
+	jobs, err := d.outbox.ListJobs(ctx, reservation.ID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, job := range jobs {
+		if job.Kind == model.OutboxJobExpireApproval && job.Status == model.OutboxJobStatusPending {
+			return job.NextAttemptAt, nil
+		}
+	}
+	return time.Time{}, nil
+}
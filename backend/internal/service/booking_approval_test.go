@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func TestBookingService_ApprovalRequestHoldsSlotUntilItExpires(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	notifications := newStubNotificationRepository()
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-hold"}}
+	mailer := &stubMailClient{}
+	settings := newStubContactSettingsRepository()
+	settings.settings.Topics = []model.ContactTopicV2{
+		{ID: "consulting"},
+		{ID: "speaking", RequiresApproval: true},
+	}
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{
+			CalendarID:           "primary",
+			NotificationReceiver: "owner@example.com",
+			ApprovalExpiry:       24 * time.Hour,
+		},
+	}
+
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, settings, captcha.NewFakeVerifier("fail"), nil, calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	result, err := svc.Book(context.Background(), model.BookingRequest{
+		Name:            "Ada",
+		Email:           "ada@example.com",
+		Topic:           "Speaking",
+		Locale:          "en",
+		StartTime:       now.Add(72 * time.Hour),
+		DurationMinutes: 30,
+		RecaptchaToken:  "test-token",
+	})
+	require.NoError(t, err)
+	require.True(t, result.Reservation.RequiresApproval)
+	require.Equal(t, model.MeetingReservationStatusRequested, result.Reservation.Status)
+
+	kinds := make([]model.OutboxJobKind, 0, len(outbox.jobs))
+	for _, job := range outbox.jobs {
+		kinds = append(kinds, job.Kind)
+	}
+	require.Equal(t, []model.OutboxJobKind{
+		model.OutboxJobCreateCalendarEvent,
+		model.OutboxJobSendRequestReceived,
+		model.OutboxJobNotifyOwner,
+		model.OutboxJobExpireApproval,
+	}, kinds)
+	require.True(t, outbox.jobs[3].NextAttemptAt.Equal(now.Add(24*time.Hour)))
+
+	dispatcher := newTestDispatcher(t, outbox, reservations, notifications, calendarClient, mailer, cfg, now)
+	claimed, err := dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, claimed)
+
+	require.Len(t, calendarClient.created, 1)
+	require.Equal(t, "[Pending approval] Consultation with Ada", calendarClient.created[0].Summary)
+	require.Empty(t, calendarClient.created[0].Attendees)
+	require.Len(t, mailer.sent, 2)
+	require.Equal(t, "Meeting request received: Sat, 04 May 2024 09:00:00 UTC", mailer.sent[0].Subject)
+	require.Contains(t, mailer.sent[0].Body, "You will receive an answer by Thu, 02 May 2024 09:00:00 UTC.")
+	require.Empty(t, mailer.sent[0].Attachments)
+	require.Contains(t, mailer.sent[1].Body, "承認期限")
+	require.Equal(t, model.MeetingReservationStatusRequested, reservations.entries[result.Reservation.ID].Status)
+
+	dispatcher.clock = fixedClock{now: now.Add(24 * time.Hour)}
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	expired := reservations.entries[result.Reservation.ID]
+	require.Equal(t, model.MeetingReservationStatusCancelledByOwner, expired.Status)
+	require.Equal(t, approvalExpiredReason, expired.CancellationReason)
+	require.Equal(t, []string{"evt-hold"}, calendarClient.deleted)
+
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mailer.sent, 3)
+	require.True(t, strings.HasPrefix(mailer.sent[2].Subject, "Meeting request declined: "))
+	require.Contains(t, mailer.sent[2].Body, "was not approved in time")
+	require.NotContains(t, mailer.sent[2].Body, approvalExpiredReason)
+}
+
+func TestOutboxDispatcher_ExpireApprovalRace(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary"},
+	}
+	newRequest := func(reservations *stubReservationRepository) *model.MeetingReservation {
+		request := &model.MeetingReservation{
+			ID:               1,
+			Email:            "ada@example.com",
+			StartAt:          now.Add(72 * time.Hour),
+			EndAt:            now.Add(72*time.Hour + 30*time.Minute),
+			GoogleEventID:    "evt-hold",
+			Status:           model.MeetingReservationStatusRequested,
+			RequiresApproval: true,
+		}
+		reservations.seq = 1
+		reservations.entries[1] = cloneReservation(request)
+		return request
+	}
+
+	t.Run("approval wins", func(t *testing.T) {
+		reservations := newStubReservationRepository()
+		outbox := newStubOutboxRepository(reservations)
+		calendarClient := &stubCalendarClient{}
+		dispatcher := newTestDispatcher(t, outbox, reservations, newStubNotificationRepository(), calendarClient, &stubMailClient{}, cfg, now)
+		stale := newRequest(reservations)
+		// The owner approved the request after the expiry job loaded it.
+		reservations.entries[1].Status = model.MeetingReservationStatusConfirmed
+
+		require.NoError(t, dispatcher.expireApproval(context.Background(), stale))
+		require.Equal(t, model.MeetingReservationStatusConfirmed, reservations.entries[1].Status)
+		require.Empty(t, calendarClient.deleted)
+		require.Empty(t, outbox.jobs)
+	})
+
+	t.Run("failed event deletion is retried", func(t *testing.T) {
+		reservations := newStubReservationRepository()
+		outbox := newStubOutboxRepository(reservations)
+		calendarClient := &stubCalendarClient{deleteErr: errors.New("calendar unavailable")}
+		dispatcher := newTestDispatcher(t, outbox, reservations, newStubNotificationRepository(), calendarClient, &stubMailClient{}, cfg, now)
+		request := newRequest(reservations)
+
+		require.Error(t, dispatcher.expireApproval(context.Background(), request))
+		require.Equal(t, model.MeetingReservationStatusCancelledByOwner, reservations.entries[1].Status)
+		require.Empty(t, outbox.jobs)
+
+		calendarClient.deleteErr = nil
+		require.NoError(t, dispatcher.expireApproval(context.Background(), cloneReservation(reservations.entries[1])))
+		require.Equal(t, []string{"evt-hold"}, calendarClient.deleted)
+		require.Len(t, outbox.jobs, 2)
+		require.Equal(t, model.OutboxJobSendDecline, outbox.jobs[0].Kind)
+	})
+}
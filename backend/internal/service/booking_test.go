@@ -3,21 +3,25 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/captcha"
 	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/errs"
 	"github.com/takumi/personal-website/internal/infra/google"
 	"github.com/takumi/personal-website/internal/mail"
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
 )
 
 func TestBookingService_Success(t *testing.T) {
@@ -26,6 +30,7 @@ func TestBookingService_Success(t *testing.T) {
 	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
 	reservations := newStubReservationRepository()
 	notifications := newStubNotificationRepository()
+	outbox := newStubOutboxRepository(reservations)
 	availability := &stubAvailabilityRepository{}
 	blacklist := &stubBlacklistRepository{}
 	calendar := &stubCalendarClient{
@@ -55,7 +60,8 @@ func TestBookingService_Success(t *testing.T) {
 		},
 	}
 
-	svc, err := NewBookingService(reservations, notifications, availability, blacklist, calendar, mailer, cfg)
+	settings := newStubContactSettingsRepository()
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), outbox, availability, blacklist, settings, captcha.NewFakeVerifier("fail"), nil, calendar, mailer, cfg)
 	require.NoError(t, err)
 	svc.(*bookingService).clock = fixedClock{now: now}
 
@@ -65,18 +71,55 @@ func TestBookingService_Success(t *testing.T) {
 		StartTime:       now.Add(2 * time.Hour),
 		DurationMinutes: 45,
 		Agenda:          "Discuss portfolio improvements",
+		Locale:          "en-GB",
 		RecaptchaToken:  "test-token",
 	})
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	require.Equal(t, "evt-123", result.CalendarEventID)
 	require.NotEmpty(t, result.Reservation.LookupHash)
+	require.Equal(t, model.LocaleEn, result.Reservation.Locale)
+	require.Equal(t, model.MeetingReservationStatusRequested, result.Reservation.Status)
 	require.Equal(t, "support@example.com", result.SupportEmail)
 	require.Equal(t, "UTC", result.CalendarTimezone)
 	require.Len(t, reservations.created, 1)
-	require.Len(t, notifications.recorded, 1)
-	require.Len(t, mailer.sent, 1)
+	require.Len(t, outbox.jobs, 3)
+	require.Equal(t, 1, settings.loads)
+
+	// Side effects only happen once the dispatcher runs.
+	require.Zero(t, calendar.createCalls)
+	require.Empty(t, mailer.sent)
+
+	dispatcher := newTestDispatcher(t, outbox, reservations, notifications, calendar, mailer, cfg, now)
+	claimed, err := dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, claimed)
+
+	require.Equal(t, 1, calendar.createCalls)
+	require.Len(t, mailer.sent, 2)
 	require.Equal(t, "test@example.com", mailer.sent[0].To[0])
+	require.Contains(t, mailer.sent[0].Subject, "Meeting request confirmed: ")
+	require.Contains(t, mailer.sent[0].HTMLBody, "<p>Hi Test User,</p>")
+	require.Len(t, mailer.sent[0].Attachments, 1)
+	invite := string(mailer.sent[0].Attachments[0].Data)
+	require.Contains(t, mailer.sent[0].Attachments[0].ContentType, "method=REQUEST")
+	require.Contains(t, invite, fmt.Sprintf("UID:meeting-reservation-%d@personal-website", result.Reservation.ID))
+	require.Equal(t, "owner@example.com", mailer.sent[1].To[0])
+	require.Contains(t, mailer.sent[1].Subject, "新しい予約: Test User")
+	require.Empty(t, mailer.sent[1].Attachments)
+	require.Len(t, notifications.recorded, 3)
+
+	stored := reservations.entries[result.Reservation.ID]
+	require.Equal(t, "evt-123", stored.GoogleEventID)
+	require.Equal(t, model.MeetingReservationStatusConfirmed, stored.Status)
+	require.Equal(t, []model.MeetingReservationStatusChange{{
+		ReservationID: result.Reservation.ID,
+		FromStatus:    model.MeetingReservationStatusRequested,
+		ToStatus:      model.MeetingReservationStatusConfirmed,
+		Actor:         model.ReservationActorSystem,
+	}}, reservations.history)
+	for _, job := range outbox.jobs {
+		require.Equal(t, model.OutboxJobStatusDone, job.Status)
+	}
 }
 
 func TestBookingService_LookupReservation(t *testing.T) {
@@ -111,7 +154,7 @@ func TestBookingService_LookupReservation(t *testing.T) {
 		},
 	}
 
-	svc, err := NewBookingService(reservations, notifications, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, &stubCalendarClient{}, &stubMailClient{}, cfg)
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, &stubCalendarClient{}, &stubMailClient{}, cfg)
 	require.NoError(t, err)
 
 	result, err := svc.LookupReservation(context.Background(), "lookup-hash")
@@ -122,6 +165,98 @@ func TestBookingService_LookupReservation(t *testing.T) {
 	require.Equal(t, "support@example.com", result.SupportEmail)
 }
 
+func TestBookingService_RecordsVisitorTimezone(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, tokyo)
+	reservations := newStubReservationRepository()
+	notifications := newStubNotificationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-tz"}}
+	mailer := &stubMailClient{}
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:         "Asia/Tokyo",
+			CalendarTimezone: "Asia/Tokyo",
+		},
+		Booking: config.BookingConfig{
+			CalendarID:         "primary",
+			NotificationSender: "noreply@example.com",
+			MaxRetries:         1,
+		},
+	}
+
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	req := model.BookingRequest{
+		Name:            "Visitor",
+		Email:           "visitor@example.com",
+		StartTime:       now.Add(2 * time.Hour),
+		DurationMinutes: 30,
+		Locale:          "en",
+		Timezone:        "Mars/Olympus",
+		RecaptchaToken:  "test-token",
+	}
+	_, err = svc.Book(context.Background(), req)
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+
+	req.Timezone = "America/New_York"
+	result, err := svc.Book(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "America/New_York", result.Reservation.VisitorTimezone)
+	require.Equal(t, "Asia/Tokyo", result.CalendarTime.Timezone)
+	require.Equal(t, "11:00", result.CalendarTime.Start.Format("15:04"))
+	require.NotNil(t, result.VisitorTime)
+	require.Equal(t, "2024-04-30 22:00", result.VisitorTime.Start.Format("2006-01-02 15:04"))
+
+	looked, err := svc.LookupReservation(context.Background(), result.Reservation.LookupHash)
+	require.NoError(t, err)
+	require.NotNil(t, looked.VisitorTime)
+	require.Equal(t, "America/New_York", looked.VisitorTime.Timezone)
+
+	dispatcher := newTestDispatcher(t, outbox, reservations, notifications, calendarClient, mailer, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, mailer.sent)
+	require.Contains(t, mailer.sent[0].Subject, "Wed, 01 May 2024 11:00:00 JST (America/New_York: Tue, 30 Apr 2024 22:00:00 EDT)")
+}
+
+func TestBookingService_RejectsFailedHumanVerification(t *testing.T) {
+	t.Parallel()
+
+	reservations := newStubReservationRepository()
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary"},
+	}
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, &stubCalendarClient{}, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Hour)
+	req := model.BookingRequest{
+		Name:            "Bot",
+		Email:           "bot@example.com",
+		StartTime:       start,
+		DurationMinutes: 30,
+		RecaptchaToken:  "fail",
+	}
+
+	_, err = svc.Book(context.Background(), req)
+	appErr := errs.From(err)
+	require.Equal(t, http.StatusForbidden, appErr.Status)
+
+	req.RecaptchaToken = ""
+	_, err = svc.Book(context.Background(), req)
+	appErr = errs.From(err)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+	require.Empty(t, reservations.created)
+}
+
 func TestBookingService_Blacklist(t *testing.T) {
 	t.Parallel()
 
@@ -155,7 +290,7 @@ func TestBookingService_Blacklist(t *testing.T) {
 		},
 	}
 
-	svc, err := NewBookingService(reservations, notifications, availability, blacklist, calendar, mailer, cfg)
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), availability, blacklist, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendar, mailer, cfg)
 	require.NoError(t, err)
 	svc.(*bookingService).clock = fixedClock{now: now}
 
@@ -173,6 +308,262 @@ func TestBookingService_Blacklist(t *testing.T) {
 	require.Empty(t, mailer.sent)
 }
 
+func TestBookingService_ConcurrentRequestsClaimSlotOnce(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := inmemory.NewMeetingReservationRepository()
+	outbox := inmemory.NewBookingOutboxRepository(reservations)
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, &stubCalendarClient{}, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	const attempts = 8
+	var wg sync.WaitGroup
+	statuses := make([]int, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := svc.Book(context.Background(), model.BookingRequest{
+				Name:            fmt.Sprintf("Racer %d", i),
+				Email:           fmt.Sprintf("racer%d@example.com", i),
+				StartTime:       now.Add(time.Duration(24*60+i) * time.Minute),
+				DurationMinutes: 30,
+				RecaptchaToken:  "test-token",
+			})
+			if err != nil {
+				statuses[i] = errs.From(err).Status
+				return
+			}
+			statuses[i] = http.StatusCreated
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, status := range statuses {
+		if status == http.StatusCreated {
+			created++
+			continue
+		}
+		require.Equal(t, http.StatusConflict, status)
+	}
+	require.Equal(t, 1, created)
+
+	tomorrow := now.AddDate(0, 0, 1)
+	booked, err := reservations.ListReservations(context.Background(), repository.MeetingReservationListFilter{Date: &tomorrow})
+	require.NoError(t, err)
+	require.Len(t, booked, 1)
+}
+
+func TestBookingService_ConcurrentReschedulesClaimSlotOnce(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := inmemory.NewMeetingReservationRepository()
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	const attempts = 8
+	for i := 0; i < attempts; i++ {
+		start := now.Add(time.Duration(48+i) * time.Hour)
+		_, err := reservations.CreateReservation(context.Background(), &model.MeetingReservation{
+			LookupHash:      fmt.Sprintf("racer-%d", i),
+			Name:            fmt.Sprintf("Racer %d", i),
+			Email:           fmt.Sprintf("racer%d@example.com", i),
+			StartAt:         start,
+			EndAt:           start.Add(30 * time.Minute),
+			DurationMinutes: 30,
+			GoogleEventID:   fmt.Sprintf("evt-%d", i),
+			Status:          model.MeetingReservationStatusConfirmed,
+		})
+		require.NoError(t, err)
+	}
+
+	// Every request passes the read-only conflict check before any of them moves, so only the
+	// repository's claim can keep the slot from being taken twice.
+	checked := &sync.WaitGroup{}
+	checked.Add(attempts)
+	racing := &barrierReservationRepository{MeetingReservationRepository: reservations, checked: checked}
+
+	svc, err := NewBookingService(racing, inmemory.NewMeetingNotificationRepository(), inmemory.NewNotificationTemplateRepository(), inmemory.NewBookingOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, &stubCalendarClient{}, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	target := now.Add(24 * time.Hour)
+	var wg sync.WaitGroup
+	statuses := make([]int, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := svc.RescheduleReservation(context.Background(), fmt.Sprintf("racer-%d", i), model.RescheduleRequest{StartTime: target})
+			if err != nil {
+				statuses[i] = errs.From(err).Status
+				return
+			}
+			statuses[i] = http.StatusOK
+		}(i)
+	}
+	wg.Wait()
+
+	moved := 0
+	for _, status := range statuses {
+		if status == http.StatusOK {
+			moved++
+			continue
+		}
+		require.Equal(t, http.StatusConflict, status)
+	}
+	require.Equal(t, 1, moved)
+
+	claimed, err := reservations.ListConflictingReservations(context.Background(), target, target.Add(30*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+}
+
+// barrierReservationRepository holds each conflict check until all racing requests have made one.
+type barrierReservationRepository struct {
+	repository.MeetingReservationRepository
+	checked *sync.WaitGroup
+}
+
+func (r *barrierReservationRepository) ListConflictingReservations(ctx context.Context, start, end time.Time) ([]model.MeetingReservation, error) {
+	conflicts, err := r.MeetingReservationRepository.ListConflictingReservations(ctx, start, end)
+	r.checked.Done()
+	r.checked.Wait()
+	return conflicts, err
+}
+
+func TestBookingService_LostSlotClaimReturnsConflict(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	outbox.createErr = repository.ErrConflict
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary"},
+	}
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, &stubCalendarClient{}, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	_, err = svc.Book(context.Background(), model.BookingRequest{
+		Name:            "Second",
+		Email:           "second@example.com",
+		StartTime:       now.Add(2 * time.Hour),
+		DurationMinutes: 30,
+		RecaptchaToken:  "test-token",
+	})
+	require.Error(t, err)
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+	require.Empty(t, outbox.jobs)
+}
+
+func TestBookingService_OutsideWorkingHours(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC) // Wednesday
+	reservations := newStubReservationRepository()
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-123"}}
+	settings := newStubContactSettingsRepository()
+	settings.settings.WorkingHours = model.WorkingHours{
+		Weekly: []model.WeekdayWorkingHours{
+			{Weekday: time.Wednesday, Intervals: []model.WorkingInterval{{Start: "10:00", End: "11:30"}, {Start: "14:00", End: "16:00"}}},
+		},
+		Overrides: []model.WorkingHoursOverride{{Date: "2024-05-02"}},
+	}
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC", BufferMinutes: 30},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, settings, captcha.NewFakeVerifier("fail"), nil, calendarClient, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	for _, start := range []time.Time{
+		time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC), // runs past the end of the morning interval
+		time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), // lunch gap
+		time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC), // closed by override
+	} {
+		_, err = svc.Book(context.Background(), model.BookingRequest{
+			Name:            "Late User",
+			Email:           "late@example.com",
+			StartTime:       start,
+			DurationMinutes: 60,
+			RecaptchaToken:  "test-token",
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+	}
+	require.Empty(t, reservations.created)
+
+	_, err = svc.Book(context.Background(), model.BookingRequest{
+		Name:            "Afternoon User",
+		Email:           "afternoon@example.com",
+		StartTime:       time.Date(2024, 5, 1, 14, 30, 0, 0, time.UTC),
+		DurationMinutes: 60,
+		RecaptchaToken:  "test-token",
+	})
+	require.NoError(t, err)
+}
+
+func TestBookingService_EnforcesLeadTimeAndBookingWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	settings := newStubContactSettingsRepository()
+	settings.settings.MinimumLeadHours = 24
+	settings.settings.BookingWindowDays = 14
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC", BufferMinutes: 30},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, settings, captcha.NewFakeVerifier("fail"), nil, &stubCalendarClient{event: &calendar.Event{ID: "evt-123"}}, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	book := func(start time.Time) error {
+		_, err := svc.Book(context.Background(), model.BookingRequest{
+			Name:            "Lead User",
+			Email:           "lead@example.com",
+			StartTime:       start,
+			DurationMinutes: 30,
+			RecaptchaToken:  "test-token",
+		})
+		return err
+	}
+
+	err = book(now.Add(23 * time.Hour))
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+	require.Contains(t, errs.From(err).Message, "24 hours")
+
+	err = book(now.AddDate(0, 0, 15))
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+	require.Contains(t, errs.From(err).Message, "14 days")
+	require.Empty(t, reservations.created)
+
+	require.NoError(t, book(now.AddDate(0, 0, 3)))
+}
+
 func TestBookingService_BufferConflict(t *testing.T) {
 	t.Parallel()
 
@@ -211,7 +602,7 @@ func TestBookingService_BufferConflict(t *testing.T) {
 		},
 	}
 
-	svc, err := NewBookingService(reservations, notifications, availability, blacklist, calendar, mailer, cfg)
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), availability, blacklist, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendar, mailer, cfg)
 	require.NoError(t, err)
 	svc.(*bookingService).clock = fixedClock{now: now}
 
@@ -228,14 +619,13 @@ func TestBookingService_BufferConflict(t *testing.T) {
 	require.Empty(t, reservations.created)
 }
 
-func TestBookingService_CalendarFailure(t *testing.T) {
+func TestBookingService_CalendarFailureStillBooks(t *testing.T) {
 	t.Parallel()
 
 	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
 	reservations := newStubReservationRepository()
 	notifications := newStubNotificationRepository()
-	availability := &stubAvailabilityRepository{}
-	blacklist := &stubBlacklistRepository{}
+	outbox := newStubOutboxRepository(reservations)
 	calendar := &stubCalendarClient{
 		event:     &calendar.Event{ID: "evt-789"},
 		createErr: errors.New("calendar unavailable"),
@@ -248,33 +638,65 @@ func TestBookingService_CalendarFailure(t *testing.T) {
 			BufferMinutes: 30,
 		},
 		Booking: config.BookingConfig{
-			CalendarID:         "primary",
-			MaxRetries:         2,
-			RequestTimeout:     20 * time.Millisecond,
-			InitialBackoff:     5 * time.Millisecond,
-			BackoffMultiplier:  1.0,
-			MeetTemplate:       "Portfolio Intro Session",
-			NotificationSender: "noreply@example.com",
+			CalendarID:           "primary",
+			MaxRetries:           2,
+			RequestTimeout:       20 * time.Millisecond,
+			MeetTemplate:         "Portfolio Intro Session",
+			NotificationSender:   "noreply@example.com",
+			OutboxMaxAttempts:    2,
+			OutboxInitialBackoff: time.Minute,
 		},
 	}
 
-	svc, err := NewBookingService(reservations, notifications, availability, blacklist, calendar, mailer, cfg)
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendar, mailer, cfg)
 	require.NoError(t, err)
 	svc.(*bookingService).clock = fixedClock{now: now}
 
-	_, err = svc.Book(context.Background(), model.BookingRequest{
+	result, err := svc.Book(context.Background(), model.BookingRequest{
 		Name:            "Retry User",
 		Email:           "retry@example.com",
 		StartTime:       now.Add(2 * time.Hour),
 		DurationMinutes: 30,
 		RecaptchaToken:  "test-token",
 	})
-	require.Error(t, err)
-	appErr := errs.From(err)
-	require.Equal(t, http.StatusBadGateway, appErr.Status)
-	require.Equal(t, 2, calendar.createCalls)
-	require.Empty(t, reservations.created)
+	require.NoError(t, err)
+	require.Len(t, reservations.created, 1)
+	require.Len(t, outbox.jobs, 2)
+
+	dispatcher := newTestDispatcher(t, outbox, reservations, notifications, calendar, mailer, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, calendar.createCalls)
+	require.Equal(t, model.OutboxJobStatusPending, outbox.jobs[0].Status)
+	require.Equal(t, 1, outbox.jobs[0].Attempts)
+	require.Equal(t, "calendar unavailable", outbox.jobs[0].LastError)
+	// The confirmation waits for the calendar job without spending an attempt.
+	require.Zero(t, outbox.jobs[1].Attempts)
 	require.Empty(t, mailer.sent)
+
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, calendar.createCalls, "retries must wait for the backoff")
+
+	dispatcher.clock = fixedClock{now: now.Add(time.Minute)}
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, calendar.createCalls)
+	require.Equal(t, model.OutboxJobStatusDead, outbox.jobs[0].Status)
+
+	// Once the calendar job is dead-lettered the visitor still gets a confirmation.
+	dispatcher.clock = fixedClock{now: now.Add(2 * time.Minute)}
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, model.OutboxJobStatusDone, outbox.jobs[1].Status)
+	require.Empty(t, reservations.entries[result.Reservation.ID].GoogleEventID)
+
+	require.Len(t, notifications.recorded, 2)
+	require.Equal(t, "calendar_invite", notifications.recorded[0].Type)
+	require.Equal(t, "failed", notifications.recorded[0].Status)
+	require.Equal(t, "confirmation_email", notifications.recorded[1].Type)
+	require.Equal(t, "sent", notifications.recorded[1].Status)
 }
 
 func TestBookingService_CalendarAuthRequired(t *testing.T) {
@@ -306,7 +728,7 @@ func TestBookingService_CalendarAuthRequired(t *testing.T) {
 		},
 	}
 
-	svc, err := NewBookingService(reservations, notifications, availability, blacklist, calendar, mailer, cfg)
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), availability, blacklist, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendar, mailer, cfg)
 	require.NoError(t, err)
 	svc.(*bookingService).clock = fixedClock{now: now}
 
@@ -323,6 +745,376 @@ func TestBookingService_CalendarAuthRequired(t *testing.T) {
 	require.Contains(t, strings.ToLower(appErr.Message), "authorization")
 }
 
+func TestBookingService_RescheduleReservation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	notifications := newStubNotificationRepository()
+	existing := &model.MeetingReservation{
+		ID:              1,
+		LookupHash:      "lookup-hash",
+		Name:            "Existing User",
+		Email:           "existing@example.com",
+		StartAt:         now.Add(3 * time.Hour),
+		EndAt:           now.Add(3*time.Hour + 30*time.Minute),
+		DurationMinutes: 30,
+		GoogleEventID:   "evt-existing",
+		Status:          model.MeetingReservationStatusConfirmed,
+	}
+	reservations.seq = 1
+	reservations.entries[1] = existing
+	reservations.index["lookup-hash"] = 1
+	// The reservation's own slot (and its Google event) must not block the move.
+	reservations.conflicts = []model.MeetingReservation{*existing}
+	availability := &stubAvailabilityRepository{
+		windows: []model.TimeWindow{
+			{Start: existing.StartAt, End: existing.EndAt, Source: model.BusyWindowSourceReservation},
+		},
+	}
+	calendarClient := &stubCalendarClient{
+		busy: []model.TimeWindow{{Start: existing.StartAt, End: existing.EndAt}},
+	}
+	mailer := &stubMailClient{}
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{
+			Timezone:      "UTC",
+			BufferMinutes: 30,
+		},
+		Booking: config.BookingConfig{
+			CalendarID:         "primary",
+			MaxRetries:         1,
+			RequestTimeout:     100 * time.Millisecond,
+			InitialBackoff:     10 * time.Millisecond,
+			BackoffMultiplier:  1,
+			NotificationSender: "noreply@example.com",
+		},
+	}
+
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), availability, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	newStart := existing.StartAt.Add(30 * time.Minute)
+	result, err := svc.RescheduleReservation(context.Background(), "lookup-hash", model.RescheduleRequest{StartTime: newStart})
+	require.NoError(t, err)
+	require.True(t, result.Reservation.StartAt.Equal(newStart))
+	require.Equal(t, 30, result.Reservation.DurationMinutes)
+	require.Equal(t, "evt-existing", result.CalendarEventID)
+	require.Equal(t, model.MeetingReservationStatusRescheduled, result.Reservation.Status)
+	require.Len(t, calendarClient.updated, 1)
+	require.Zero(t, calendarClient.createCalls)
+	require.Len(t, mailer.sent, 1)
+	require.Len(t, mailer.sent[0].Attachments, 1)
+	require.Contains(t, string(mailer.sent[0].Attachments[0].Data), "UID:meeting-reservation-1@personal-website")
+	require.Contains(t, string(mailer.sent[0].Attachments[0].Data), "DTSTART:"+newStart.UTC().Format("20060102T150405Z"))
+	require.Len(t, notifications.recorded, 1)
+	require.Equal(t, "reschedule_email", notifications.recorded[0].Type)
+	require.Equal(t, "sent", notifications.recorded[0].Status)
+}
+
+func TestBookingService_RescheduleLeavesEventCreationToOutbox(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-1"}}
+	mailer := &stubMailClient{}
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{
+			CalendarID:         "primary",
+			MaxRetries:         1,
+			RequestTimeout:     100 * time.Millisecond,
+			InitialBackoff:     10 * time.Millisecond,
+			BackoffMultiplier:  1,
+			NotificationSender: "noreply@example.com",
+		},
+	}
+
+	// The create_calendar_event job has not run yet, so the reservation has no event.
+	stored, err := outbox.CreateReservationWithJobs(context.Background(), &model.MeetingReservation{
+		LookupHash:      "lookup-hash",
+		Name:            "Early User",
+		Email:           "early@example.com",
+		StartAt:         now.Add(3 * time.Hour),
+		EndAt:           now.Add(3*time.Hour + 30*time.Minute),
+		DurationMinutes: 30,
+		Status:          model.MeetingReservationStatusRequested,
+	}, []model.OutboxJob{{Kind: model.OutboxJobCreateCalendarEvent, NextAttemptAt: now}})
+	require.NoError(t, err)
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	newStart := stored.StartAt.Add(time.Hour)
+	result, err := svc.RescheduleReservation(context.Background(), "lookup-hash", model.RescheduleRequest{StartTime: newStart})
+	require.NoError(t, err)
+	require.True(t, result.Reservation.StartAt.Equal(newStart))
+	require.Empty(t, result.CalendarEventID)
+	require.Zero(t, calendarClient.createCalls)
+	require.Empty(t, calendarClient.updated)
+
+	// The pending job creates the only event, at the new time.
+	dispatcher := newTestDispatcher(t, outbox, reservations, newStubNotificationRepository(), calendarClient, mailer, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, calendarClient.createCalls)
+	require.True(t, calendarClient.created[0].Start.Equal(newStart))
+	require.Equal(t, "evt-1", reservations.entries[stored.ID].GoogleEventID)
+}
+
+func TestBookingService_RescheduleReservationConflict(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	reservations.seq = 1
+	reservations.entries[1] = &model.MeetingReservation{
+		ID:              1,
+		LookupHash:      "lookup-hash",
+		Name:            "Existing User",
+		Email:           "existing@example.com",
+		StartAt:         now.Add(3 * time.Hour),
+		EndAt:           now.Add(3*time.Hour + 30*time.Minute),
+		DurationMinutes: 30,
+		GoogleEventID:   "evt-existing",
+		Status:          model.MeetingReservationStatusConfirmed,
+	}
+	reservations.index["lookup-hash"] = 1
+	newStart := now.Add(6 * time.Hour)
+	reservations.conflicts = []model.MeetingReservation{
+		{ID: 2, StartAt: newStart, EndAt: newStart.Add(30 * time.Minute), Status: model.MeetingReservationStatusConfirmed},
+	}
+	calendarClient := &stubCalendarClient{}
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC", BufferMinutes: 30},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	_, err = svc.RescheduleReservation(context.Background(), "lookup-hash", model.RescheduleRequest{StartTime: newStart})
+	require.Error(t, err)
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+	require.Empty(t, calendarClient.updated)
+	require.True(t, reservations.entries[1].StartAt.Equal(now.Add(3*time.Hour)))
+}
+
+func TestBookingService_RescheduleLostSlotClaimReturnsConflict(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	reservations.seq = 1
+	reservations.entries[1] = &model.MeetingReservation{
+		ID:              1,
+		LookupHash:      "lookup-hash",
+		Email:           "existing@example.com",
+		StartAt:         now.Add(3 * time.Hour),
+		EndAt:           now.Add(3*time.Hour + 30*time.Minute),
+		DurationMinutes: 30,
+		GoogleEventID:   "evt-existing",
+		Status:          model.MeetingReservationStatusConfirmed,
+	}
+	reservations.index["lookup-hash"] = 1
+	reservations.rescheduleErr = repository.ErrConflict
+	calendarClient := &stubCalendarClient{}
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	_, err = svc.RescheduleReservation(context.Background(), "lookup-hash", model.RescheduleRequest{StartTime: now.Add(6 * time.Hour)})
+	appErr := errs.From(err)
+	require.Equal(t, http.StatusConflict, appErr.Status)
+	require.Equal(t, "requested slot conflicts with existing reservations", appErr.Message)
+	require.Empty(t, calendarClient.updated)
+	require.Zero(t, calendarClient.createCalls)
+}
+
+func TestBookingService_RescheduleRestoresSlotWhenCalendarFails(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	notifications := newStubNotificationRepository()
+	oldStart := now.Add(3 * time.Hour)
+	reservations.seq = 1
+	reservations.entries[1] = &model.MeetingReservation{
+		ID:              1,
+		LookupHash:      "lookup-hash",
+		Email:           "existing@example.com",
+		StartAt:         oldStart,
+		EndAt:           oldStart.Add(30 * time.Minute),
+		DurationMinutes: 30,
+		GoogleEventID:   "evt-existing",
+		Status:          model.MeetingReservationStatusConfirmed,
+	}
+	reservations.index["lookup-hash"] = 1
+	calendarClient := &stubCalendarClient{updateErr: errors.New("calendar unavailable")}
+	mailer := &stubMailClient{}
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	_, err = svc.RescheduleReservation(context.Background(), "lookup-hash", model.RescheduleRequest{StartTime: now.Add(6 * time.Hour)})
+	require.Error(t, err)
+
+	stored := reservations.entries[1]
+	require.True(t, stored.StartAt.Equal(oldStart))
+	require.Equal(t, "evt-existing", stored.GoogleEventID)
+	require.Equal(t, model.MeetingReservationStatusConfirmed, stored.Status)
+	require.Zero(t, calendarClient.createCalls)
+	require.Empty(t, mailer.sent)
+	require.Empty(t, notifications.recorded)
+}
+
+func TestBookingService_CancelReservation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	notifications := newStubNotificationRepository()
+	reservations.seq = 1
+	reservations.entries[1] = &model.MeetingReservation{
+		ID:            1,
+		LookupHash:    "lookup-hash",
+		Email:         "existing@example.com",
+		StartAt:       now.Add(3 * time.Hour),
+		EndAt:         now.Add(3*time.Hour + 30*time.Minute),
+		GoogleEventID: "evt-existing",
+		Status:        model.MeetingReservationStatusConfirmed,
+	}
+	reservations.index["lookup-hash"] = 1
+	calendarClient := &stubCalendarClient{}
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	mailer := &stubMailClient{}
+
+	outbox := newStubOutboxRepository(reservations)
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	result, err := svc.CancelReservation(context.Background(), "lookup-hash", "  plans changed ")
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusCancelledByVisitor, result.Reservation.Status)
+	require.Equal(t, "plans changed", result.Reservation.CancellationReason)
+	require.Len(t, reservations.history, 1)
+	require.Equal(t, model.ReservationActorVisitor, reservations.history[0].Actor)
+	// The event is deleted by the outbox once the reservation is cancelled.
+	require.Empty(t, calendarClient.deleted)
+	require.Len(t, outbox.jobs, 2)
+	require.Equal(t, model.OutboxJobDeleteCalendarEvent, outbox.jobs[1].Kind)
+	require.Len(t, notifications.recorded, 1)
+	require.Equal(t, "cancellation_email", notifications.recorded[0].Type)
+	require.Equal(t, "sent", notifications.recorded[0].Status)
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, []string{"existing@example.com"}, mailer.sent[0].To)
+	require.Len(t, mailer.sent[0].Attachments, 1)
+	cancelInvite := string(mailer.sent[0].Attachments[0].Data)
+	require.Contains(t, cancelInvite, "METHOD:CANCEL")
+	require.Contains(t, cancelInvite, "STATUS:CANCELLED")
+	require.Contains(t, cancelInvite, "UID:meeting-reservation-1@personal-website")
+
+	_, err = svc.CancelReservation(context.Background(), "lookup-hash", "")
+	require.Error(t, err)
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+
+	dispatcher := newTestDispatcher(t, outbox, reservations, notifications, calendarClient, mailer, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"evt-existing"}, calendarClient.deleted)
+}
+
+func TestBookingService_CancelKeepsEventWhenStatusChangeLoses(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	reservations.transitionErr = repository.ErrConflict
+	reservations.seq = 1
+	reservations.entries[1] = &model.MeetingReservation{
+		ID:            1,
+		LookupHash:    "lookup-hash",
+		Email:         "existing@example.com",
+		StartAt:       now.Add(3 * time.Hour),
+		EndAt:         now.Add(3*time.Hour + 30*time.Minute),
+		GoogleEventID: "evt-existing",
+		Status:        model.MeetingReservationStatusConfirmed,
+	}
+	reservations.index["lookup-hash"] = 1
+	calendarClient := &stubCalendarClient{}
+	outbox := newStubOutboxRepository(reservations)
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	_, err = svc.CancelReservation(context.Background(), "lookup-hash", "")
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+	require.Empty(t, calendarClient.deleted)
+	require.Empty(t, outbox.jobs)
+}
+
+func TestBookingService_CancelSucceedsWhenNotificationRecordFails(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	notifications := newStubNotificationRepository()
+	notifications.err = errors.New("database unavailable")
+	reservations.seq = 1
+	reservations.entries[1] = &model.MeetingReservation{
+		ID:         1,
+		LookupHash: "lookup-hash",
+		Email:      "existing@example.com",
+		StartAt:    now.Add(3 * time.Hour),
+		EndAt:      now.Add(3*time.Hour + 30*time.Minute),
+		Status:     model.MeetingReservationStatusConfirmed,
+	}
+	reservations.index["lookup-hash"] = 1
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, &stubCalendarClient{}, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	result, err := svc.CancelReservation(context.Background(), "lookup-hash", "")
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusCancelledByVisitor, result.Reservation.Status)
+}
+
 type stubAvailabilityRepository struct {
 	windows []model.TimeWindow
 }
@@ -359,16 +1151,28 @@ func (s *stubBlacklistRepository) FindBlacklistEntryByEmail(ctx context.Context,
 }
 
 type stubCalendarClient struct {
+	mu          sync.Mutex
 	busy        []model.TimeWindow
 	event       *calendar.Event
 	listErr     error
 	createErr   error
+	updateErr   error
+	deleteErr   error
 	listCalls   int
+	listedIDs   []string
 	createCalls int
+	created     []calendar.EventInput
+	updated     []calendar.EventInput
+	deleted     []string
+	// onCreate runs after an event is created, before the caller sees it.
+	onCreate func()
 }
 
-func (s *stubCalendarClient) ListBusyWindows(context.Context, string, time.Time, time.Time) ([]model.TimeWindow, error) {
+func (s *stubCalendarClient) ListBusyWindows(_ context.Context, calendarIDs []string, _, _ time.Time) ([]model.TimeWindow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.listCalls++
+	s.listedIDs = append([]string(nil), calendarIDs...)
 	if s.listErr != nil {
 		return nil, s.listErr
 	}
@@ -382,20 +1186,53 @@ func (s *stubCalendarClient) ListBusyWindows(context.Context, string, time.Time,
 	return result, nil
 }
 
-func (s *stubCalendarClient) CreateEvent(context.Context, string, calendar.EventInput) (*calendar.Event, error) {
+func (s *stubCalendarClient) CreateEvent(_ context.Context, _ string, input calendar.EventInput) (*calendar.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.createCalls++
+	s.created = append(s.created, input)
 	if s.createErr != nil {
 		return nil, s.createErr
 	}
+	if s.onCreate != nil {
+		s.onCreate()
+	}
 	return s.event, nil
 }
 
+func (s *stubCalendarClient) GetEvent(_ context.Context, _ string, eventID string) (*calendar.Event, error) {
+	return &calendar.Event{ID: eventID, Status: "confirmed"}, nil
+}
+
+func (s *stubCalendarClient) UpdateEvent(_ context.Context, _ string, eventID string, input calendar.EventInput) (*calendar.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.updateErr != nil {
+		return nil, s.updateErr
+	}
+	s.updated = append(s.updated, input)
+	return &calendar.Event{ID: eventID}, nil
+}
+
+func (s *stubCalendarClient) DeleteEvent(_ context.Context, _ string, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	s.deleted = append(s.deleted, eventID)
+	return nil
+}
+
 type stubMailClient struct {
+	mu   sync.Mutex
 	sent []mail.Message
 	err  error
 }
 
 func (s *stubMailClient) Send(ctx context.Context, message mail.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.err != nil {
 		return s.err
 	}
@@ -404,13 +1241,15 @@ func (s *stubMailClient) Send(ctx context.Context, message mail.Message) error {
 }
 
 type stubReservationRepository struct {
-	created   []*model.MeetingReservation
-	conflicts []model.MeetingReservation
-	entries   map[uint64]*model.MeetingReservation
-	index     map[string]uint64
-	seq       uint64
-	markErr   error
-	cancelErr error
+	created       []*model.MeetingReservation
+	conflicts     []model.MeetingReservation
+	entries       map[uint64]*model.MeetingReservation
+	index         map[string]uint64
+	seq           uint64
+	history       []model.MeetingReservationStatusChange
+	markErr       error
+	rescheduleErr error
+	transitionErr error
 }
 
 func newStubReservationRepository() *stubReservationRepository {
@@ -482,6 +1321,12 @@ func (s *stubReservationRepository) ListReservations(ctx context.Context, filter
 				continue
 			}
 		}
+		if filter.StartFrom != nil && entry.StartAt.Before(*filter.StartFrom) {
+			continue
+		}
+		if filter.StartBefore != nil && !entry.StartAt.Before(*filter.StartBefore) {
+			continue
+		}
 		results = append(results, *cloneReservation(entry))
 	}
 
@@ -510,7 +1355,6 @@ func (s *stubReservationRepository) MarkConfirmationSent(ctx context.Context, id
 		return nil, repository.ErrNotFound
 	}
 	ts := sentAt.UTC()
-	entry.Status = model.MeetingReservationStatusConfirmed
 	entry.ConfirmationSentAt = &ts
 	entry.LastNotificationSentAt = &ts
 	entry.UpdatedAt = ts
@@ -518,49 +1362,64 @@ func (s *stubReservationRepository) MarkConfirmationSent(ctx context.Context, id
 	return cloneReservation(entry), nil
 }
 
-func (s *stubReservationRepository) CancelReservation(ctx context.Context, id uint64, reason string) (*model.MeetingReservation, error) {
-	if s.cancelErr != nil {
-		return nil, s.cancelErr
+func (s *stubReservationRepository) RescheduleReservation(ctx context.Context, id uint64, start, end time.Time, googleEventID string) (*model.MeetingReservation, error) {
+	if s.rescheduleErr != nil {
+		return nil, s.rescheduleErr
 	}
 	entry, ok := s.entries[id]
 	if !ok {
 		return nil, repository.ErrNotFound
 	}
-	entry.Status = model.MeetingReservationStatusCancelled
-	entry.CancellationReason = reason
-	entry.GoogleCalendarStatus = "cancelled"
+	if !entry.Status.IsActive() {
+		return nil, repository.ErrConflict
+	}
+	for _, other := range s.conflicts {
+		if other.ID != id {
+			return nil, repository.ErrConflict
+		}
+	}
+	entry.StartAt = start.UTC()
+	entry.EndAt = end.UTC()
+	entry.DurationMinutes = int(end.Sub(start) / time.Minute)
+	if googleEventID != "" {
+		entry.GoogleEventID = googleEventID
+	}
 	entry.UpdatedAt = time.Now().UTC()
 	s.entries[id] = entry
 	return cloneReservation(entry), nil
 }
 
-func (s *stubReservationRepository) UpdateReservationStatus(ctx context.Context, id uint64, status model.MeetingReservationStatus, reason string) (*model.MeetingReservation, error) {
-	entry, ok := s.entries[id]
+func (s *stubReservationRepository) TransitionReservationStatus(ctx context.Context, change *model.MeetingReservationStatusChange) (*model.MeetingReservation, error) {
+	if s.transitionErr != nil {
+		return nil, s.transitionErr
+	}
+	entry, ok := s.entries[change.ReservationID]
 	if !ok {
 		return nil, repository.ErrNotFound
 	}
-	switch status {
-	case model.MeetingReservationStatusCancelled:
-		entry.Status = model.MeetingReservationStatusCancelled
-		entry.CancellationReason = strings.TrimSpace(reason)
+	if entry.Status != change.FromStatus {
+		return nil, repository.ErrConflict
+	}
+	entry.Status = change.ToStatus
+	if change.ToStatus.IsCancelled() {
+		entry.CancellationReason = strings.TrimSpace(change.Reason)
 		entry.GoogleCalendarStatus = "cancelled"
-	case model.MeetingReservationStatusConfirmed:
-		entry.Status = model.MeetingReservationStatusConfirmed
-		entry.CancellationReason = ""
-		if strings.TrimSpace(entry.GoogleCalendarStatus) == "" {
-			entry.GoogleCalendarStatus = "confirmed"
-		}
-	case model.MeetingReservationStatusPending:
-		entry.Status = model.MeetingReservationStatusPending
-		entry.CancellationReason = ""
-	default:
-		return nil, repository.ErrInvalidInput
 	}
 	entry.UpdatedAt = time.Now().UTC()
-	s.entries[id] = entry
+	s.history = append(s.history, *change)
 	return cloneReservation(entry), nil
 }
 
+func (s *stubReservationRepository) ListStatusHistory(ctx context.Context, reservationID uint64) ([]model.MeetingReservationStatusChange, error) {
+	var history []model.MeetingReservationStatusChange
+	for _, change := range s.history {
+		if change.ReservationID == reservationID {
+			history = append(history, change)
+		}
+	}
+	return history, nil
+}
+
 func cloneReservation(reservation *model.MeetingReservation) *model.MeetingReservation {
 	if reservation == nil {
 		return nil
@@ -607,6 +1466,41 @@ func (s *stubNotificationRepository) ListNotifications(ctx context.Context, rese
 	return result, nil
 }
 
+func (s *stubNotificationRepository) ClaimNotification(ctx context.Context, notification *model.MeetingNotification) (*model.MeetingNotification, error) {
+	for _, entry := range s.recorded {
+		if entry.ReservationID == notification.ReservationID && entry.DedupeKey == notification.DedupeKey {
+			return nil, repository.ErrConflict
+		}
+	}
+	claim := *notification
+	claim.Status = "pending"
+	return s.RecordNotification(ctx, &claim)
+}
+
+func (s *stubNotificationRepository) UpdateNotificationStatus(ctx context.Context, id uint64, status, errorMessage string) (*model.MeetingNotification, error) {
+	for index := range s.recorded {
+		if s.recorded[index].ID == id {
+			s.recorded[index].Status = status
+			s.recorded[index].ErrorMessage = errorMessage
+			entry := s.recorded[index]
+			return &entry, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (s *stubNotificationRepository) ClaimRetryableNotifications(ctx context.Context, types []string, now, pendingBefore time.Time, limit int, lease time.Duration) ([]model.MeetingNotification, error) {
+	return []model.MeetingNotification{}, nil
+}
+
+func (s *stubNotificationRepository) RecordNotificationAttempt(ctx context.Context, attempt *model.MeetingNotificationAttempt, status string, nextAttemptAt *time.Time) (*model.MeetingNotification, error) {
+	return s.UpdateNotificationStatus(ctx, attempt.NotificationID, status, attempt.ErrorMessage)
+}
+
+func (s *stubNotificationRepository) ListNotificationAttempts(ctx context.Context, reservationID uint64) ([]model.MeetingNotificationAttempt, error) {
+	return []model.MeetingNotificationAttempt{}, nil
+}
+
 type fixedClock struct {
 	now time.Time
 }
@@ -614,3 +1508,36 @@ type fixedClock struct {
 func (f fixedClock) Now() time.Time {
 	return f.now
 }
+
+type stubContactSettingsRepository struct {
+	mu       sync.Mutex
+	settings *model.ContactFormSettingsV2
+	err      error
+	loads    int
+}
+
+// newStubContactSettingsRepository opens bookings around the clock so tests only exercise
+// the rules they configure explicitly.
+func newStubContactSettingsRepository() *stubContactSettingsRepository {
+	weekly := make([]model.WeekdayWorkingHours, 0, 7)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		weekly = append(weekly, model.WeekdayWorkingHours{
+			Weekday:   day,
+			Intervals: []model.WorkingInterval{{Start: "00:00", End: "24:00"}},
+		})
+	}
+	return &stubContactSettingsRepository{
+		settings: &model.ContactFormSettingsV2{ID: 1, WorkingHours: model.WorkingHours{Weekly: weekly}},
+	}
+}
+
+func (s *stubContactSettingsRepository) GetContactFormSettings(context.Context) (*model.ContactFormSettingsV2, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads++
+	if s.err != nil {
+		return nil, s.err
+	}
+	clone := *s.settings
+	return &clone, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/schedule"
+	"github.com/takumi/personal-website/internal/service/support"
+)
+
+// ReservationsFeedPath is the public route serving the private reservations feed.
+const ReservationsFeedPath = "/api/feeds/reservations.ics"
+
+const (
+	feedTokenBytes = 32
+	// The feed covers recent history so just-finished meetings stay visible, plus the future.
+	feedLookback = 30 * 24 * time.Hour
+	feedHorizon  = 365 * 24 * time.Hour
+)
+
+// CalendarFeedService issues revocable feed tokens and renders the owner's reservations and
+// blackouts as an iCalendar subscription.
+type CalendarFeedService interface {
+	ListTokens(ctx context.Context) ([]model.CalendarFeedToken, error)
+	IssueToken(ctx context.Context, label string) (*model.IssuedCalendarFeedToken, error)
+	RevokeToken(ctx context.Context, id uint64) (*model.CalendarFeedToken, error)
+	RenderReservationsFeed(ctx context.Context, token string) ([]byte, error)
+}
+
+type calendarFeedService struct {
+	tokens       repository.CalendarFeedTokenRepository
+	reservations repository.MeetingReservationRepository
+	blackouts    repository.ScheduleBlackoutRepository
+	cfg          config.BookingConfig
+	clock        Clock
+}
+
+// NewCalendarFeedService wires the feed service.
+func NewCalendarFeedService(
+	tokens repository.CalendarFeedTokenRepository,
+	reservations repository.MeetingReservationRepository,
+	blackouts repository.ScheduleBlackoutRepository,
+	cfg *config.AppConfig,
+) (CalendarFeedService, error) {
+	if tokens == nil || reservations == nil || blackouts == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "calendar feed service: missing dependencies", nil)
+	}
+	return &calendarFeedService{
+		tokens:       tokens,
+		reservations: reservations,
+		blackouts:    blackouts,
+		cfg:          cfg.Booking,
+		clock:        realClock{},
+	}, nil
+}
+
+func (s *calendarFeedService) ListTokens(ctx context.Context) ([]model.CalendarFeedToken, error) {
+	tokens, err := s.tokens.ListFeedTokens(ctx)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to list calendar feed tokens", err)
+	}
+	return tokens, nil
+}
+
+func (s *calendarFeedService) IssueToken(ctx context.Context, label string) (*model.IssuedCalendarFeedToken, error) {
+	label = strings.TrimSpace(label)
+	if len(label) > 255 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "label must be 255 characters or fewer", nil)
+	}
+
+	raw := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to generate calendar feed token", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(raw)
+
+	created, err := s.tokens.CreateFeedToken(ctx, &model.CalendarFeedToken{
+		Label:     label,
+		TokenHash: hashFeedToken(secret),
+	})
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to store calendar feed token", err)
+	}
+
+	return &model.IssuedCalendarFeedToken{
+		CalendarFeedToken: *created,
+		Token:             secret,
+		FeedPath:          ReservationsFeedPath + "?token=" + secret,
+	}, nil
+}
+
+func (s *calendarFeedService) RevokeToken(ctx context.Context, id uint64) (*model.CalendarFeedToken, error) {
+	revoked, err := s.tokens.RevokeFeedToken(ctx, id, s.clock.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "calendar feed token not found", err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to revoke calendar feed token", err)
+	}
+	return revoked, nil
+}
+
+func (s *calendarFeedService) RenderReservationsFeed(ctx context.Context, token string) ([]byte, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errs.New(errs.CodeUnauthorized, http.StatusUnauthorized, "calendar feed token is required", nil)
+	}
+	stored, err := s.tokens.FindFeedTokenByHash(ctx, hashFeedToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errs.New(errs.CodeUnauthorized, http.StatusUnauthorized, "invalid calendar feed token", nil)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to verify calendar feed token", err)
+	}
+	if stored.RevokedAt != nil {
+		return nil, errs.New(errs.CodeUnauthorized, http.StatusUnauthorized, "calendar feed token has been revoked", nil)
+	}
+
+	now := s.clock.Now().UTC()
+	from := now.Add(-feedLookback)
+	until := now.Add(feedHorizon)
+
+	reservations, err := s.reservations.ListReservations(ctx, repository.MeetingReservationListFilter{
+		Status:      model.ActiveMeetingReservationStatuses,
+		StartFrom:   &from,
+		StartBefore: &until,
+	})
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load reservations", err)
+	}
+	blackouts, err := s.blackouts.ListBlackouts(ctx)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load blackouts", err)
+	}
+
+	feed := ics.Calendar{
+		Name:   "Reservations",
+		Events: make([]ics.Event, 0, len(reservations)+len(blackouts)),
+	}
+	for i := range reservations {
+		feed.Events = append(feed.Events, support.ReservationEvent(s.cfg, &reservations[i], now))
+	}
+	feed.Events = append(feed.Events, blackoutEvents(blackouts, from, until, now)...)
+
+	if err := s.tokens.TouchFeedToken(ctx, stored.ID, now); err != nil {
+		log.Printf("calendar feed: record use of token %d: %v", stored.ID, err)
+	}
+	return feed.Bytes(), nil
+}
+
+// blackoutEvents expands recurring blackouts the same way availability does; each occurrence
+// is keyed by its start so edits to a series replace the affected entries.
+func blackoutEvents(blackouts []model.ScheduleBlackout, from, until, stamp time.Time) []ics.Event {
+	events := make([]ics.Event, 0, len(blackouts))
+	for _, blackout := range blackouts {
+		summary := "Blackout"
+		if reason := strings.TrimSpace(blackout.Reason); reason != "" {
+			summary = "Blackout: " + reason
+		}
+		sequence := 0
+		if !blackout.UpdatedAt.IsZero() {
+			sequence = int(blackout.UpdatedAt.Unix())
+		}
+		for _, window := range schedule.ExpandBlackouts([]model.ScheduleBlackout{blackout}, from, until) {
+			events = append(events, ics.Event{
+				UID:      fmt.Sprintf("schedule-blackout-%d-%d@personal-website", blackout.ID, window.Start.Unix()),
+				Sequence: sequence,
+				Stamp:    stamp,
+				Start:    window.Start,
+				End:      window.End,
+				Summary:  summary,
+				Status:   ics.StatusConfirmed,
+			})
+		}
+	}
+	return events
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
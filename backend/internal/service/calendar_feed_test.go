@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func TestCalendarFeedService_RendersReservationsAndBlackouts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	blackouts := inmemory.NewScheduleBlackoutRepository()
+	tokens := inmemory.NewCalendarFeedTokenRepository()
+
+	for _, reservation := range []model.MeetingReservation{
+		{LookupHash: "confirmed", Name: "Ada", Email: "ada@example.com", Topic: "Research", Message: "Discuss, plan; repeat", StartAt: now.Add(24 * time.Hour), EndAt: now.Add(25 * time.Hour), Status: model.MeetingReservationStatusConfirmed},
+		{LookupHash: "pending", Name: "Grace", Email: "grace@example.com", StartAt: now.Add(48 * time.Hour), EndAt: now.Add(49 * time.Hour), Status: model.MeetingReservationStatusRequested, RequiresApproval: true},
+		{LookupHash: "cancelled", Name: "Alan", Email: "alan@example.com", StartAt: now.Add(72 * time.Hour), EndAt: now.Add(73 * time.Hour), Status: model.MeetingReservationStatusCancelledByVisitor},
+	} {
+		_, err := reservations.CreateReservation(context.Background(), &reservation)
+		require.NoError(t, err)
+	}
+	_, err := blackouts.CreateBlackout(context.Background(), &model.ScheduleBlackout{
+		StartTime:  now.Add(2 * time.Hour),
+		EndTime:    now.Add(3 * time.Hour),
+		Reason:     "Lecture",
+		Recurrence: "FREQ=WEEKLY;COUNT=2",
+		Timezone:   "UTC",
+	})
+	require.NoError(t, err)
+
+	svc, err := NewCalendarFeedService(tokens, reservations, blackouts, &config.AppConfig{})
+	require.NoError(t, err)
+	svc.(*calendarFeedService).clock = fixedClock{now: now}
+
+	issued, err := svc.IssueToken(context.Background(), "phone")
+	require.NoError(t, err)
+	require.NotEmpty(t, issued.Token)
+	require.Equal(t, ReservationsFeedPath+"?token="+issued.Token, issued.FeedPath)
+	require.NotContains(t, issued.TokenHash, issued.Token)
+
+	body, err := svc.RenderReservationsFeed(context.Background(), issued.Token)
+	require.NoError(t, err)
+	feed := strings.ReplaceAll(string(body), "\r\n ", "")
+
+	require.NotContains(t, feed, "METHOD:")
+	require.Contains(t, feed, "UID:meeting-reservation-1@personal-website\r\n")
+	require.Contains(t, feed, `DESCRIPTION:Meeting with Ada (ada@example.com)\n\nAgenda:\nDiscuss\, plan\; repeat`+"\r\n")
+	require.Contains(t, feed, "SUMMARY:[Pending approval] Consultation with Grace\r\n")
+	require.Contains(t, feed, "STATUS:TENTATIVE\r\n")
+	require.NotContains(t, feed, "alan@example.com")
+	require.Equal(t, 2, strings.Count(feed, "SUMMARY:Blackout: Lecture\r\n"))
+
+	listed, err := tokens.ListFeedTokens(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, listed[0].LastUsedAt)
+
+	_, err = svc.RevokeToken(context.Background(), issued.ID)
+	require.NoError(t, err)
+	_, err = svc.RenderReservationsFeed(context.Background(), issued.Token)
+	require.Equal(t, http.StatusUnauthorized, errs.From(err).Status)
+
+	_, err = svc.RenderReservationsFeed(context.Background(), "not-a-token")
+	require.Equal(t, http.StatusUnauthorized, errs.From(err).Status)
+}
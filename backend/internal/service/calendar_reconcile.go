@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+// reconcileHorizon bounds how far ahead the reconciler compares reservations with their events.
+// Past meetings are left alone: their outcome is recorded as completed or no_show instead.
+const reconcileHorizon = 180 * 24 * time.Hour
+
+// Reasons recorded on status changes applied by the reconciler.
+const (
+	reconcileEventDeletedReason     = "calendar event was deleted"
+	reconcileAttendeeDeclinedReason = "visitor declined the calendar invitation"
+)
+
+// CalendarReconciler compares upcoming reservations with their Google Calendar events and
+// records every event that was deleted, moved, or declined by the visitor outside this app.
+// With auto-apply enabled the change is mirrored onto the reservation (cancelling it or moving
+// it to the event's new time); otherwise, or when a move would overlap another reservation, the
+// drift is flagged for the administrator to review.
+type CalendarReconciler struct {
+	reservations  repository.MeetingReservationRepository
+	notifications repository.MeetingNotificationRepository
+	outbox        repository.BookingOutboxRepository
+	drifts        repository.ReservationDriftRepository
+	calendar      calendar.Client
+	cfg           config.BookingConfig
+	clock         Clock
+}
+
+// NewCalendarReconciler wires the reconciler.
+func NewCalendarReconciler(
+	reservations repository.MeetingReservationRepository,
+	notifications repository.MeetingNotificationRepository,
+	outbox repository.BookingOutboxRepository,
+	drifts repository.ReservationDriftRepository,
+	calendarClient calendar.Client,
+	cfg *config.AppConfig,
+) (*CalendarReconciler, error) {
+	if reservations == nil || notifications == nil || outbox == nil || drifts == nil || calendarClient == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "calendar reconciler: missing dependencies", nil)
+	}
+	return &CalendarReconciler{
+		reservations:  reservations,
+		notifications: notifications,
+		outbox:        outbox,
+		drifts:        drifts,
+		calendar:      calendarClient,
+		cfg:           cfg.Booking,
+		clock:         realClock{},
+	}, nil
+}
+
+// Run reconciles every reconcile interval until ctx is cancelled.
+func (r *CalendarReconciler) Run(ctx context.Context) {
+	interval := r.cfg.CalendarReconcileInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.Reconcile(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("calendar reconcile: pass failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile checks every active upcoming reservation that has a calendar event and returns the
+// drifts recorded in this pass. Drifts already reported for the same event state are skipped.
+func (r *CalendarReconciler) Reconcile(ctx context.Context) ([]model.ReservationDrift, error) {
+	now := r.clock.Now().UTC()
+	until := now.Add(reconcileHorizon)
+	reservations, err := r.reservations.ListReservations(ctx, repository.MeetingReservationListFilter{
+		Status:      model.ActiveMeetingReservationStatuses,
+		StartFrom:   &now,
+		StartBefore: &until,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list upcoming reservations: %w", err)
+	}
+
+	var recorded []model.ReservationDrift
+	for i := range reservations {
+		reservation := &reservations[i]
+		if strings.TrimSpace(reservation.GoogleEventID) == "" {
+			continue
+		}
+
+		drift, err := r.detect(ctx, reservation)
+		if err != nil {
+			// One unreadable event must not hold back the rest of the pass.
+			log.Printf("calendar reconcile: check event of reservation %d: %v", reservation.ID, err)
+			continue
+		}
+		if drift == nil {
+			continue
+		}
+
+		stored, err := r.resolve(ctx, reservation, drift)
+		if err != nil {
+			log.Printf("calendar reconcile: reservation %d: %v", reservation.ID, err)
+			continue
+		}
+		if stored != nil {
+			recorded = append(recorded, *stored)
+		}
+	}
+	return recorded, nil
+}
+
+// detect fetches the reservation's event and describes how it differs, or returns nil when it
+// still matches. A deletion outranks a declined invitation, which outranks a move.
+func (r *CalendarReconciler) detect(ctx context.Context, reservation *model.MeetingReservation) (*model.ReservationDrift, error) {
+	var event *calendar.Event
+	err := r.callCalendar(ctx, func(callCtx context.Context) error {
+		var err error
+		event, err = r.calendar.GetEvent(callCtx, r.cfg.CalendarID, reservation.GoogleEventID)
+		return err
+	})
+	if err != nil && !errors.Is(err, calendar.ErrEventNotFound) {
+		return nil, err
+	}
+
+	drift := &model.ReservationDrift{
+		ReservationID: reservation.ID,
+		GoogleEventID: reservation.GoogleEventID,
+		StoredStartAt: reservation.StartAt.UTC(),
+		StoredEndAt:   reservation.EndAt.UTC(),
+		DetectedAt:    r.clock.Now().UTC(),
+	}
+	switch {
+	case err != nil || strings.EqualFold(event.Status, "cancelled"):
+		drift.Kind = model.ReservationDriftEventDeleted
+		drift.DedupeKey = fmt.Sprintf("%s:%s", drift.Kind, reservation.GoogleEventID)
+	case declinedBy(event, reservation.Email):
+		drift.Kind = model.ReservationDriftAttendeeDeclined
+		drift.Detail = strings.ToLower(strings.TrimSpace(reservation.Email))
+		drift.DedupeKey = fmt.Sprintf("%s:%s", drift.Kind, reservation.GoogleEventID)
+	case !event.Start.IsZero() && !event.End.IsZero() && (!event.Start.Equal(reservation.StartAt) || !event.End.Equal(reservation.EndAt)):
+		start := event.Start.UTC()
+		end := event.End.UTC()
+		drift.Kind = model.ReservationDriftEventMoved
+		drift.EventStartAt = &start
+		drift.EventEndAt = &end
+		drift.DedupeKey = fmt.Sprintf("%s:%s:%d-%d", drift.Kind, reservation.GoogleEventID, start.Unix(), end.Unix())
+	default:
+		return nil, nil
+	}
+	return drift, nil
+}
+
+func declinedBy(event *calendar.Event, email string) bool {
+	email = strings.TrimSpace(email)
+	for _, attendee := range event.Attendees {
+		if strings.EqualFold(strings.TrimSpace(attendee.Email), email) && strings.EqualFold(attendee.ResponseStatus, "declined") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve applies the drift when auto-apply is on and records it. It returns nil when the drift
+// was already reported or the reservation changed while it was being applied.
+func (r *CalendarReconciler) resolve(ctx context.Context, reservation *model.MeetingReservation, drift *model.ReservationDrift) (*model.ReservationDrift, error) {
+	drift.Action = model.ReservationDriftFlagged
+	if r.cfg.CalendarReconcileAutoApply {
+		applied, reason, err := r.apply(ctx, reservation, drift)
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if applied {
+			drift.Action = model.ReservationDriftApplied
+		} else {
+			drift.Detail = reason
+		}
+	}
+
+	stored, err := r.drifts.RecordDrift(ctx, drift)
+	if errors.Is(err, repository.ErrConflict) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("record drift: %w", err)
+	}
+	return stored, nil
+}
+
+// apply mirrors the drift onto the reservation. It reports false with a reason when the drift
+// has to be reviewed by hand instead.
+func (r *CalendarReconciler) apply(ctx context.Context, reservation *model.MeetingReservation, drift *model.ReservationDrift) (bool, string, error) {
+	switch drift.Kind {
+	case model.ReservationDriftEventDeleted:
+		if _, err := r.transition(ctx, reservation, model.MeetingReservationStatusCancelledByOwner, reconcileEventDeletedReason); err != nil {
+			return false, "", err
+		}
+		// The visitor has not been told yet; the pending email is picked up like an admin cancellation.
+		if _, err := r.notifications.RecordNotification(ctx, &model.MeetingNotification{
+			ReservationID: reservation.ID,
+			Type:          "cancellation_email",
+			Status:        "pending",
+		}); err != nil {
+			log.Printf("calendar reconcile: record cancellation email for reservation %d: %v", reservation.ID, err)
+		}
+		r.offerWaitlist(ctx, reservation.ID)
+		return true, "", nil
+	case model.ReservationDriftAttendeeDeclined:
+		if _, err := r.transition(ctx, reservation, model.MeetingReservationStatusCancelledByVisitor, reconcileAttendeeDeclinedReason); err != nil {
+			return false, "", err
+		}
+		err := r.callCalendar(ctx, func(callCtx context.Context) error {
+			return r.calendar.DeleteEvent(callCtx, r.cfg.CalendarID, reservation.GoogleEventID)
+		})
+		if err != nil && !errors.Is(err, calendar.ErrEventNotFound) {
+			log.Printf("calendar reconcile: remove declined event of reservation %d: %v", reservation.ID, err)
+		}
+		r.offerWaitlist(ctx, reservation.ID)
+		return true, "", nil
+	case model.ReservationDriftEventMoved:
+		// The move claims the new time the same way a visitor reschedule does.
+		start, end := *drift.EventStartAt, *drift.EventEndAt
+		updated, err := r.reservations.RescheduleReservation(ctx, reservation.ID, start, end, reservation.GoogleEventID)
+		if errors.Is(err, repository.ErrConflict) {
+			current, findErr := r.reservations.FindReservationByID(ctx, reservation.ID)
+			if findErr == nil && current.Status == reservation.Status {
+				return false, "new time overlaps another reservation", nil
+			}
+			return false, "", err
+		}
+		if err != nil {
+			return false, "", err
+		}
+		if updated.Status.CanTransitionTo(model.MeetingReservationStatusRescheduled) {
+			if _, err := r.transition(ctx, updated, model.MeetingReservationStatusRescheduled, "calendar event was moved"); err != nil {
+				return false, "", err
+			}
+		}
+		return true, "", nil
+	}
+	return false, "", nil
+}
+
+func (r *CalendarReconciler) transition(ctx context.Context, reservation *model.MeetingReservation, to model.MeetingReservationStatus, reason string) (*model.MeetingReservation, error) {
+	return r.reservations.TransitionReservationStatus(ctx, &model.MeetingReservationStatusChange{
+		ReservationID: reservation.ID,
+		FromStatus:    reservation.Status,
+		ToStatus:      to,
+		Actor:         model.ReservationActorSystem,
+		Reason:        reason,
+	})
+}
+
+// offerWaitlist queues the freed slot for the waitlist. The cancellation has already happened,
+// so a failure is only logged.
+func (r *CalendarReconciler) offerWaitlist(ctx context.Context, reservationID uint64) {
+	if err := r.outbox.EnqueueJobs(ctx, reservationID, []model.OutboxJob{waitlistOfferJob(r.cfg, r.clock.Now())}); err != nil {
+		log.Printf("calendar reconcile: queue waitlist offer for reservation %d: %v", reservationID, err)
+	}
+}
+
+func (r *CalendarReconciler) callCalendar(ctx context.Context, call func(ctx context.Context) error) error {
+	if r.cfg.RequestTimeout <= 0 {
+		return call(ctx)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, r.cfg.RequestTimeout)
+	defer cancel()
+	return call(callCtx)
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+// fakeEventCalendar serves events by ID; an ID without an entry reports ErrEventNotFound and
+// an ID listed in failures reports its error.
+type fakeEventCalendar struct {
+	stubCalendarClient
+	events   map[string]*calendar.Event
+	failures map[string]error
+}
+
+func (f *fakeEventCalendar) GetEvent(_ context.Context, _, eventID string) (*calendar.Event, error) {
+	if err := f.failures[eventID]; err != nil {
+		return nil, err
+	}
+	event, ok := f.events[eventID]
+	if !ok {
+		return nil, calendar.ErrEventNotFound
+	}
+	copied := *event
+	return &copied, nil
+}
+
+type reconcileFixture struct {
+	reservations  *stubReservationRepository
+	notifications *stubNotificationRepository
+	outbox        *stubOutboxRepository
+	drifts        repository.ReservationDriftRepository
+	calendar      *fakeEventCalendar
+	ids           map[string]uint64
+}
+
+// newReconcileFixture stores one confirmed reservation per scenario, each starting a day apart.
+func newReconcileFixture(t *testing.T, now time.Time) *reconcileFixture {
+	t.Helper()
+
+	fixture := &reconcileFixture{
+		reservations:  newStubReservationRepository(),
+		notifications: newStubNotificationRepository(),
+		drifts:        inmemory.NewReservationDriftRepository(),
+		calendar:      &fakeEventCalendar{events: make(map[string]*calendar.Event)},
+		ids:           make(map[string]uint64),
+	}
+	fixture.outbox = newStubOutboxRepository(fixture.reservations)
+
+	for i, name := range []string{"unchanged", "deleted", "moved", "declined"} {
+		start := now.Add(time.Duration(i+1) * 24 * time.Hour)
+		end := start.Add(30 * time.Minute)
+		created, err := fixture.reservations.CreateReservation(context.Background(), &model.MeetingReservation{
+			LookupHash:    name,
+			Email:         name + "@example.com",
+			StartAt:       start,
+			EndAt:         end,
+			Status:        model.MeetingReservationStatusConfirmed,
+			GoogleEventID: "evt-" + name,
+		})
+		require.NoError(t, err)
+		fixture.ids[name] = created.ID
+
+		event := &calendar.Event{ID: "evt-" + name, Status: "confirmed", Start: start, End: end, Attendees: []calendar.EventAttendee{
+			{Email: name + "@example.com", ResponseStatus: "accepted"},
+		}}
+		switch name {
+		case "deleted":
+			continue
+		case "moved":
+			event.Start = start.Add(2 * time.Hour)
+			event.End = end.Add(2 * time.Hour)
+		case "declined":
+			event.Attendees[0] = calendar.EventAttendee{Email: "Declined@Example.com", ResponseStatus: "declined"}
+		}
+		fixture.calendar.events[event.ID] = event
+	}
+	return fixture
+}
+
+func (f *reconcileFixture) reconciler(t *testing.T, now time.Time, autoApply bool) *CalendarReconciler {
+	t.Helper()
+
+	reconciler, err := NewCalendarReconciler(f.reservations, f.notifications, f.outbox, f.drifts, f.calendar, &config.AppConfig{
+		Booking: config.BookingConfig{CalendarID: "primary", OutboxMaxAttempts: 3, CalendarReconcileAutoApply: autoApply},
+	})
+	require.NoError(t, err)
+	reconciler.clock = fixedClock{now: now}
+	return reconciler
+}
+
+func driftsByReservation(drifts []model.ReservationDrift) map[uint64]model.ReservationDrift {
+	byReservation := make(map[uint64]model.ReservationDrift, len(drifts))
+	for _, drift := range drifts {
+		byReservation[drift.ReservationID] = drift
+	}
+	return byReservation
+}
+
+func TestCalendarReconciler_FlagsDriftOnceWithoutChangingReservations(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newReconcileFixture(t, now)
+	reconciler := fixture.reconciler(t, now, false)
+
+	recorded, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recorded, 3)
+
+	byReservation := driftsByReservation(recorded)
+	require.Equal(t, model.ReservationDriftEventDeleted, byReservation[fixture.ids["deleted"]].Kind)
+	require.Equal(t, model.ReservationDriftAttendeeDeclined, byReservation[fixture.ids["declined"]].Kind)
+	moved := byReservation[fixture.ids["moved"]]
+	require.Equal(t, model.ReservationDriftEventMoved, moved.Kind)
+	require.NotNil(t, moved.EventStartAt)
+	require.Equal(t, moved.StoredStartAt.Add(2*time.Hour), *moved.EventStartAt)
+	for _, drift := range recorded {
+		require.Equal(t, model.ReservationDriftFlagged, drift.Action)
+	}
+
+	for _, id := range fixture.ids {
+		stored, err := fixture.reservations.FindReservationByID(context.Background(), id)
+		require.NoError(t, err)
+		require.Equal(t, model.MeetingReservationStatusConfirmed, stored.Status)
+	}
+	require.Empty(t, fixture.reservations.history)
+	require.Empty(t, fixture.outbox.jobs)
+	require.Empty(t, fixture.calendar.deleted)
+
+	// The same state is not reported twice, but moving the event again is.
+	recorded, err = reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, recorded)
+
+	event := fixture.calendar.events["evt-moved"]
+	event.Start = event.Start.Add(time.Hour)
+	event.End = event.End.Add(time.Hour)
+	recorded, err = reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	require.Equal(t, fixture.ids["moved"], recorded[0].ReservationID)
+
+	open, err := fixture.drifts.ListDrifts(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, open, 4)
+}
+
+func TestCalendarReconciler_AutoApplyMirrorsCalendarChanges(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newReconcileFixture(t, now)
+	reconciler := fixture.reconciler(t, now, true)
+
+	recorded, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recorded, 3)
+	for _, drift := range recorded {
+		require.Equal(t, model.ReservationDriftApplied, drift.Action, drift.Kind)
+	}
+
+	deleted, err := fixture.reservations.FindReservationByID(context.Background(), fixture.ids["deleted"])
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusCancelledByOwner, deleted.Status)
+	require.Len(t, fixture.notifications.recorded, 1)
+	require.Equal(t, fixture.ids["deleted"], fixture.notifications.recorded[0].ReservationID)
+	require.Equal(t, "cancellation_email", fixture.notifications.recorded[0].Type)
+	require.Equal(t, "pending", fixture.notifications.recorded[0].Status)
+
+	declined, err := fixture.reservations.FindReservationByID(context.Background(), fixture.ids["declined"])
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusCancelledByVisitor, declined.Status)
+	require.Equal(t, []string{"evt-declined"}, fixture.calendar.deleted)
+
+	moved, err := fixture.reservations.FindReservationByID(context.Background(), fixture.ids["moved"])
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusRescheduled, moved.Status)
+	require.Equal(t, fixture.calendar.events["evt-moved"].Start, moved.StartAt)
+	require.Equal(t, fixture.calendar.events["evt-moved"].End, moved.EndAt)
+
+	require.Len(t, fixture.outbox.jobs, 2)
+	for _, job := range fixture.outbox.jobs {
+		require.Equal(t, model.OutboxJobOfferWaitlist, job.Kind)
+	}
+	for _, change := range fixture.reservations.history {
+		require.Equal(t, model.ReservationActorSystem, change.Actor)
+	}
+
+	recorded, err = reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, recorded)
+}
+
+func TestCalendarReconciler_FlagsMoveOntoAnotherReservation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newReconcileFixture(t, now)
+	fixture.calendar.events["evt-deleted"] = &calendar.Event{ID: "evt-deleted", Status: "cancelled"}
+	fixture.reservations.conflicts = []model.MeetingReservation{{ID: 99}}
+	reconciler := fixture.reconciler(t, now, true)
+	fixture.reservations.transitionErr = repository.ErrConflict
+
+	recorded, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+
+	// Deleted and declined cancellations lost a race with another change and are skipped;
+	// the move would overlap reservation 99 and is left for review.
+	require.Len(t, recorded, 1)
+	require.Equal(t, fixture.ids["moved"], recorded[0].ReservationID)
+	require.Equal(t, model.ReservationDriftFlagged, recorded[0].Action)
+	require.Equal(t, "new time overlaps another reservation", recorded[0].Detail)
+
+	moved, err := fixture.reservations.FindReservationByID(context.Background(), fixture.ids["moved"])
+	require.NoError(t, err)
+	require.Equal(t, now.Add(3*24*time.Hour), moved.StartAt)
+	require.Empty(t, fixture.outbox.jobs)
+}
+
+func TestCalendarReconciler_SkipsEventsThatCannotBeRead(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newReconcileFixture(t, now)
+	fixture.calendar.failures = map[string]error{"evt-unchanged": errors.New("backend error")}
+	reconciler := fixture.reconciler(t, now, false)
+
+	// The first reservation's event fails to load; the later drifts are still recorded.
+	recorded, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recorded, 3)
+	require.NotContains(t, driftsByReservation(recorded), fixture.ids["unchanged"])
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/errs"
+)
+
+const (
+	captchaActionBooking = "booking"
+	captchaActionContact = "contact"
+)
+
+// verifyHuman checks a client verification token and maps provider outcomes to API errors.
+func verifyHuman(ctx context.Context, verifier captcha.Verifier, token, remoteIP, action string) error {
+	err := verifier.Verify(ctx, captcha.Request{Token: token, RemoteIP: remoteIP, Action: action})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, captcha.ErrMissingToken):
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "recaptcha token is required", err)
+	case errors.Is(err, captcha.ErrRejected):
+		return errs.New(errs.CodeForbidden, http.StatusForbidden, "human verification failed", err)
+	default:
+		return errs.New(errs.CodeInternal, http.StatusServiceUnavailable, "human verification is temporarily unavailable", err)
+	}
+}
@@ -3,14 +3,28 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
 	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/mail"
 	"github.com/takumi/personal-website/internal/model"
 	"github.com/takumi/personal-website/internal/repository"
 )
 
+// Emails sent for a contact form submission, as recorded in contact_notifications.
+const (
+	contactNotificationOwnerAlert = "owner_alert"
+	contactNotificationAutoReply  = "auto_reply"
+)
+
+// errNoContactRecipient reports that a contact notification has no address to go to.
+var errNoContactRecipient = errors.New("no recipient configured")
+
 // ContactService handles contact form submissions and configuration retrieval.
 type ContactService interface {
 	SubmitContact(ctx context.Context, req *model.ContactRequest) (*model.ContactSubmission, error)
@@ -18,12 +32,25 @@ type ContactService interface {
 }
 
 type contactService struct {
-	repo     repository.ContactRepository
-	settings repository.ContactFormSettingsRepository
+	repo          repository.ContactRepository
+	settings      repository.ContactFormSettingsRepository
+	captcha       captcha.Verifier
+	spam          *SpamFilter
+	notifications repository.ContactNotificationRepository
+	cfg           *config.AppConfig
 }
 
-func NewContactService(repo repository.ContactRepository, settings repository.ContactFormSettingsRepository) ContactService {
-	return &contactService{repo: repo, settings: settings}
+// NewContactService wires the contact form service. Without a notification repository
+// submissions are only stored.
+func NewContactService(
+	repo repository.ContactRepository,
+	settings repository.ContactFormSettingsRepository,
+	verifier captcha.Verifier,
+	spam *SpamFilter,
+	notifications repository.ContactNotificationRepository,
+	cfg *config.AppConfig,
+) ContactService {
+	return &contactService{repo: repo, settings: settings, captcha: verifier, spam: spam, notifications: notifications, cfg: cfg}
 }
 
 func (s *contactService) SubmitContact(ctx context.Context, req *model.ContactRequest) (*model.ContactSubmission, error) {
@@ -33,15 +60,144 @@ func (s *contactService) SubmitContact(ctx context.Context, req *model.ContactRe
 	if strings.TrimSpace(req.Email) == "" {
 		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "email is required", nil)
 	}
+	req.Locale = model.NormalizeLocale(req.Locale)
+
+	if len(req.Answers) > 0 || strings.TrimSpace(req.Topic) != "" {
+		settings, err := s.GetContactSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		answers, err := resolveIntakeAnswers(findContactTopic(settings, req.Topic), req.Answers, req.Locale)
+		if err != nil {
+			return nil, err
+		}
+		req.IntakeAnswers = answers
+	}
+
+	if s.captcha == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "human verification not configured", nil)
+	}
+	if err := verifyHuman(ctx, s.captcha, req.RecaptchaToken, req.RemoteIP, captchaActionContact); err != nil {
+		return nil, err
+	}
+
+	// Spam is stored for review rather than dropped, but sends no emails.
+	screened := &SpamSubmission{
+		Source:        model.SpamSourceContact,
+		Name:          req.Name,
+		Email:         req.Email,
+		Message:       req.Message,
+		Website:       req.Website,
+		FormStartedAt: req.FormStartedAt,
+	}
+	req.Spam = s.spam.Assess(ctx, screened)
+	if req.Spam.Spam {
+		req.Status = model.ContactStatusSpam
+	}
 
 	submission, err := s.repo.CreateSubmission(ctx, req)
 	if err != nil {
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to queue contact request", err)
 	}
+	s.spam.Record(ctx, screened)
 
+	if req.Spam.Spam {
+		// The sender is not told their message was classified as spam.
+		submission.Status = string(model.ContactStatusPending)
+		return submission, nil
+	}
+	s.enqueueNotifications(ctx, submission.ID, req)
 	return submission, nil
 }
 
+// enqueueNotifications records the owner alert and the visitor auto-reply as pending. It only
+// runs for submissions that passed human verification and were not held as spam; the
+// notification retrier sends them, so a slow or failing mail server never holds up the visitor.
+// The submission is already stored, so recording problems are logged but never returned.
+func (s *contactService) enqueueNotifications(ctx context.Context, contactID string, req *model.ContactRequest) {
+	if s.notifications == nil || s.cfg == nil {
+		return
+	}
+	// Finish recording even if the visitor disconnects once the submission is stored.
+	ctx = context.WithoutCancel(ctx)
+
+	if s.cfg.Contact.OwnerAlert {
+		s.enqueue(ctx, contactID, contactNotificationOwnerAlert, contactOwnerRecipient(s.cfg))
+	}
+	if s.cfg.Contact.AutoReply {
+		s.enqueue(ctx, contactID, contactNotificationAutoReply, strings.TrimSpace(req.Email))
+	}
+}
+
+func (s *contactService) enqueue(ctx context.Context, contactID, notificationType, recipient string) {
+	notification := &model.ContactNotification{ContactID: contactID, Type: notificationType, Status: "pending"}
+	if recipient == "" {
+		notification.Status, notification.ErrorMessage = notificationStatusSkipped, errNoContactRecipient.Error()
+	}
+	if _, err := s.notifications.RecordContactNotification(ctx, notification); err != nil {
+		log.Printf("contact: record %s for contact %s: %v", notificationType, contactID, err)
+	}
+}
+
+// contactEmail composes the email a contact notification stands for from the stored message.
+// The owner alert follows the configured default language; the auto-reply answers in the
+// language the visitor wrote in and carries none of their text.
+func contactEmail(ctx context.Context, templates *notificationRenderer, cfg *config.AppConfig, notificationType string, message *model.ContactMessage) (mail.Message, error) {
+	var (
+		recipient string
+		key       model.NotificationTemplateKey
+		locale    string
+	)
+	switch notificationType {
+	case contactNotificationOwnerAlert:
+		recipient, key, locale = contactOwnerRecipient(cfg), model.NotificationTemplateOwnerContactNotice, templates.locale("")
+	case contactNotificationAutoReply:
+		recipient, key, locale = strings.TrimSpace(message.Email), model.NotificationTemplateContactAutoReply, templates.locale(message.Locale)
+	default:
+		return mail.Message{}, fmt.Errorf("unknown contact notification type %q", notificationType)
+	}
+	if recipient == "" {
+		return mail.Message{}, errNoContactRecipient
+	}
+
+	data := notificationData{
+		Name:    strings.TrimSpace(message.Name),
+		Email:   strings.TrimSpace(message.Email),
+		Topic:   strings.TrimSpace(message.Topic),
+		Message: strings.TrimSpace(message.Message),
+		Answers: formatIntakeAnswerLines(message.IntakeAnswers),
+	}
+	if notificationType == contactNotificationAutoReply {
+		// The auto-reply goes to an unverified address, so none of the visitor's text is handed
+		// to the template, even one an administrator edited to include it.
+		data = notificationData{}
+	}
+	email, err := templates.compose(ctx, key, locale, data)
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("compose: %w", err)
+	}
+	email.From = cfg.Booking.NotificationSender
+	email.To = []string{recipient}
+	return email, nil
+}
+
+// contactOwnerRecipient prefers the booking notification inbox and falls back to the public
+// support address.
+func contactOwnerRecipient(cfg *config.AppConfig) string {
+	if email := strings.TrimSpace(cfg.Booking.NotificationReceiver); email != "" {
+		return email
+	}
+	return strings.TrimSpace(cfg.Contact.SupportEmail)
+}
+
+func formatIntakeAnswerLines(answers []model.IntakeAnswer) string {
+	lines := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		lines = append(lines, fmt.Sprintf("%s: %s", answer.Label, strings.Join(answer.Values, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (s *contactService) GetContactSettings(ctx context.Context) (*model.ContactFormSettingsV2, error) {
 	if s.settings == nil {
 		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "contact settings repository not configured", nil)
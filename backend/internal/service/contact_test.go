@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+type contactNotificationFixture struct {
+	svc           ContactService
+	contacts      repository.AdminContactRepository
+	notifications repository.ContactNotificationRepository
+	mailer        *stubMailClient
+	retrier       *NotificationRetrier
+}
+
+// newContactNotificationFixture pairs the contact service with the retrier that sends the
+// notifications it queues.
+func newContactNotificationFixture(t *testing.T, cfg *config.AppConfig, now time.Time) *contactNotificationFixture {
+	t.Helper()
+
+	contacts := inmemory.NewContactRepository()
+	fixture := &contactNotificationFixture{
+		contacts:      contacts.(repository.AdminContactRepository),
+		notifications: inmemory.NewContactNotificationRepository(),
+		mailer:        &stubMailClient{},
+	}
+	fixture.svc = NewContactService(contacts, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, fixture.notifications, cfg)
+
+	// Contact emails are only sent by the retrier, which refuses to start without an interval.
+	cfg.Booking.NotificationRetryInterval = time.Minute
+	reservations := newStubReservationRepository()
+	dispatcher := newTestDispatcher(t, newStubOutboxRepository(reservations), reservations, newStubNotificationRepository(), &stubCalendarClient{}, fixture.mailer, cfg, now)
+	retrier, err := NewNotificationRetrier(inmemory.NewMeetingNotificationRepository(), reservations, fixture.notifications, fixture.contacts, dispatcher, cfg)
+	require.NoError(t, err)
+	retrier.clock = fixedClock{now: now}
+	fixture.retrier = retrier
+	return fixture
+}
+
+func contactRequest(locale string) *model.ContactRequest {
+	return &model.ContactRequest{
+		Name:           "Visitor",
+		Email:          "visitor@example.com",
+		Message:        "I would like to collaborate.",
+		Locale:         locale,
+		RecaptchaToken: "test-token",
+	}
+}
+
+func TestContactService_SendsOwnerAlertAndLocalizedAutoReply(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.AppConfig{
+		Booking: config.BookingConfig{NotificationSender: "noreply@example.com", DefaultLocale: "ja"},
+		Contact: config.ContactConfig{SupportEmail: "owner@example.com", OwnerAlert: true, AutoReply: true},
+	}
+	fixture := newContactNotificationFixture(t, cfg, time.Now().UTC())
+
+	submission, err := fixture.svc.SubmitContact(context.Background(), contactRequest("en"))
+	require.NoError(t, err)
+
+	// Nothing is sent on the request path; the notifications wait for the retrier.
+	require.Empty(t, fixture.mailer.sent)
+	queued, err := fixture.notifications.ListContactNotifications(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Len(t, queued, 2)
+	for _, notification := range queued {
+		require.Equal(t, "pending", notification.Status)
+	}
+
+	claimed, err := fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, claimed)
+
+	require.Len(t, fixture.mailer.sent, 2)
+	alert, reply := fixture.mailer.sent[0], fixture.mailer.sent[1]
+	require.Equal(t, []string{"owner@example.com"}, alert.To)
+	require.Equal(t, "新しいお問い合わせ: Visitor", alert.Subject)
+	require.Contains(t, alert.Body, "visitor@example.com")
+	require.Equal(t, []string{"visitor@example.com"}, reply.To)
+	require.Equal(t, "noreply@example.com", reply.From)
+	require.Equal(t, "We received your message", reply.Subject)
+	// The auto-reply goes to an address nobody verified, so it repeats none of the visitor's text.
+	require.NotContains(t, reply.Body, "I would like to collaborate.")
+	require.NotContains(t, reply.Body, "Visitor")
+
+	recorded, err := fixture.notifications.ListContactNotifications(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	require.Equal(t, contactNotificationOwnerAlert, recorded[0].Type)
+	require.Equal(t, contactNotificationAutoReply, recorded[1].Type)
+	for _, notification := range recorded {
+		require.Equal(t, notificationStatusSent, notification.Status)
+		require.Equal(t, 1, notification.Attempts)
+	}
+}
+
+func TestContactService_KeepsSubmissionWhenDeliveryFails(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	cfg := &config.AppConfig{Contact: config.ContactConfig{OwnerAlert: true, AutoReply: true}}
+	fixture := newContactNotificationFixture(t, cfg, now)
+	fixture.mailer.err = errors.New("smtp down")
+
+	submission, err := fixture.svc.SubmitContact(context.Background(), contactRequest("ja"))
+	require.NoError(t, err)
+
+	_, err = fixture.contacts.GetContactMessage(context.Background(), submission.ID)
+	require.NoError(t, err)
+
+	_, err = fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+
+	recorded, err := fixture.notifications.ListContactNotifications(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	// No owner address is configured, so only the auto-reply was attempted.
+	require.Equal(t, notificationStatusSkipped, recorded[0].Status)
+	require.Equal(t, "no recipient configured", recorded[0].ErrorMessage)
+	require.Equal(t, notificationStatusFailed, recorded[1].Status)
+	require.Equal(t, "smtp down", recorded[1].ErrorMessage)
+	require.NotNil(t, recorded[1].NextAttemptAt)
+	require.Equal(t, now.Add(5*time.Minute), *recorded[1].NextAttemptAt)
+
+	// The auto-reply is retried once its backoff has passed.
+	fixture.mailer.err = nil
+	fixture.retrier.clock = fixedClock{now: now.Add(5 * time.Minute)}
+	claimed, err := fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, claimed)
+	require.Len(t, fixture.mailer.sent, 1)
+
+	recorded, err = fixture.notifications.ListContactNotifications(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Equal(t, notificationStatusSent, recorded[1].Status)
+	require.Equal(t, 2, recorded[1].Attempts)
+}
+
+func TestNotificationRetrier_SkipsContactNotificationsOfSpam(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.AppConfig{Contact: config.ContactConfig{AutoReply: true}}
+	fixture := newContactNotificationFixture(t, cfg, time.Now().UTC())
+
+	submission, err := fixture.svc.SubmitContact(context.Background(), contactRequest("en"))
+	require.NoError(t, err)
+	message, err := fixture.contacts.GetContactMessage(context.Background(), submission.ID)
+	require.NoError(t, err)
+	message.Status = model.ContactStatusSpam
+	_, err = fixture.contacts.UpdateContactMessage(context.Background(), message)
+	require.NoError(t, err)
+
+	_, err = fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+
+	require.Empty(t, fixture.mailer.sent)
+	recorded, err := fixture.notifications.ListContactNotifications(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	require.Equal(t, notificationStatusSkipped, recorded[0].Status)
+}
+
+func TestNewNotificationRetrier_RequiresIntervalForContactEmails(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.AppConfig{Contact: config.ContactConfig{AutoReply: true}}
+	reservations := newStubReservationRepository()
+	dispatcher := newTestDispatcher(t, newStubOutboxRepository(reservations), reservations, newStubNotificationRepository(), &stubCalendarClient{}, &stubMailClient{}, cfg, time.Now())
+	contacts := inmemory.NewContactRepository().(repository.AdminContactRepository)
+
+	_, err := NewNotificationRetrier(inmemory.NewMeetingNotificationRepository(), reservations, inmemory.NewContactNotificationRepository(), contacts, dispatcher, cfg)
+	require.Error(t, err)
+
+	cfg.Contact.AutoReply = false
+	_, err = NewNotificationRetrier(inmemory.NewMeetingNotificationRepository(), reservations, inmemory.NewContactNotificationRepository(), contacts, dispatcher, cfg)
+	require.NoError(t, err)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+)
+
+// maxIntakeTextLength bounds free-text intake answers.
+const maxIntakeTextLength = 1000
+
+// findContactTopic returns the configured topic with the given ID, or nil when there is none.
+func findContactTopic(settings *model.ContactFormSettingsV2, topic string) *model.ContactTopicV2 {
+	topic = strings.TrimSpace(topic)
+	if settings == nil || topic == "" {
+		return nil
+	}
+	for i := range settings.Topics {
+		if strings.EqualFold(strings.TrimSpace(settings.Topics[i].ID), topic) {
+			return &settings.Topics[i]
+		}
+	}
+	return nil
+}
+
+// resolveIntakeAnswers validates answers against the topic's intake questions and returns them
+// in question order with labels resolved for locale. Answers to questions the topic does not ask
+// are rejected so typos in a client do not silently drop data.
+func resolveIntakeAnswers(topic *model.ContactTopicV2, answers map[string]model.IntakeAnswerValues, locale string) ([]model.IntakeAnswer, error) {
+	var questions []model.IntakeQuestion
+	if topic != nil {
+		questions = topic.Questions
+	}
+
+	known := make(map[string]struct{}, len(questions))
+	for _, question := range questions {
+		known[question.ID] = struct{}{}
+	}
+	for id := range answers {
+		if _, ok := known[id]; !ok {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("answers.%s is not a question for this topic", id), nil)
+		}
+	}
+
+	var resolved []model.IntakeAnswer
+	for _, question := range questions {
+		values, err := validateIntakeAnswer(question, answers[question.ID])
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			continue
+		}
+		resolved = append(resolved, model.IntakeAnswer{
+			QuestionID: question.ID,
+			Label:      question.Label.Resolve(locale),
+			Values:     values,
+		})
+	}
+	return resolved, nil
+}
+
+func validateIntakeAnswer(question model.IntakeQuestion, submitted model.IntakeAnswerValues) ([]string, error) {
+	invalid := func(message string) error {
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("answers.%s %s", question.ID, message), nil)
+	}
+
+	values := make([]string, 0, len(submitted))
+	for _, value := range submitted {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	if len(values) == 0 {
+		if question.Required {
+			return nil, invalid("is required")
+		}
+		return nil, nil
+	}
+
+	options := make(map[string]struct{}, len(question.Options))
+	for _, option := range question.Options {
+		options[option.Value] = struct{}{}
+	}
+
+	switch question.Type {
+	case model.IntakeQuestionText:
+		if len(values) > 1 {
+			return nil, invalid("must be a single value")
+		}
+		if utf8.RuneCountInString(values[0]) > maxIntakeTextLength {
+			return nil, invalid(fmt.Sprintf("must be %d characters or fewer", maxIntakeTextLength))
+		}
+	case model.IntakeQuestionSelect:
+		if len(values) > 1 {
+			return nil, invalid("must be a single option")
+		}
+		if _, ok := options[values[0]]; !ok {
+			return nil, invalid("must be one of the listed options")
+		}
+	case model.IntakeQuestionCheckbox:
+		if len(question.Options) == 0 {
+			if len(values) > 1 || values[0] != "true" {
+				return nil, invalid("must be true or false")
+			}
+			break
+		}
+		seen := make(map[string]struct{}, len(values))
+		deduped := values[:0]
+		for _, value := range values {
+			if _, ok := options[value]; !ok {
+				return nil, invalid("must only contain listed options")
+			}
+			if _, dup := seen[value]; dup {
+				continue
+			}
+			seen[value] = struct{}{}
+			deduped = append(deduped, value)
+		}
+		values = deduped
+	default:
+		return nil, invalid("has an unsupported question type")
+	}
+	return values, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func consultingIntakeTopic() model.ContactTopicV2 {
+	return model.ContactTopicV2{
+		ID:    "consulting",
+		Label: model.NewLocalizedText("開発相談", "Consulting"),
+		Questions: []model.IntakeQuestion{
+			{ID: "company", Type: model.IntakeQuestionText, Label: model.NewLocalizedText("会社名", "Company"), Required: true},
+			{ID: "role", Type: model.IntakeQuestionSelect, Label: model.NewLocalizedText("役職", "Role"), Options: []model.IntakeQuestionOption{
+				{Value: "engineer", Label: model.NewLocalizedText("エンジニア", "Engineer")},
+				{Value: "manager", Label: model.NewLocalizedText("マネージャー", "Manager")},
+			}},
+			{ID: "source", Type: model.IntakeQuestionCheckbox, Label: model.NewLocalizedText("きっかけ", "How did you hear about us"), Options: []model.IntakeQuestionOption{
+				{Value: "blog", Label: model.NewLocalizedText("ブログ", "Blog")},
+				{Value: "talk", Label: model.NewLocalizedText("講演", "Talk")},
+			}},
+			{ID: "nda", Type: model.IntakeQuestionCheckbox, Label: model.NewLocalizedText("NDA が必要", "NDA required")},
+		},
+	}
+}
+
+func TestResolveIntakeAnswers(t *testing.T) {
+	t.Parallel()
+
+	topic := consultingIntakeTopic()
+
+	var answers map[string]model.IntakeAnswerValues
+	require.NoError(t, json.Unmarshal([]byte(`{"company":" Acme ","role":"manager","source":["talk","blog","talk"],"nda":true}`), &answers))
+	resolved, err := resolveIntakeAnswers(&topic, answers, model.LocaleEn)
+	require.NoError(t, err)
+	require.Equal(t, []model.IntakeAnswer{
+		{QuestionID: "company", Label: "Company", Values: []string{"Acme"}},
+		{QuestionID: "role", Label: "Role", Values: []string{"manager"}},
+		{QuestionID: "source", Label: "How did you hear about us", Values: []string{"talk", "blog"}},
+		{QuestionID: "nda", Label: "NDA required", Values: []string{"true"}},
+	}, resolved)
+
+	cases := map[string]map[string]model.IntakeAnswerValues{
+		"missing required": {"role": {"engineer"}},
+		"unknown question": {"company": {"Acme"}, "budget": {"10k"}},
+		"unknown option":   {"company": {"Acme"}, "role": {"ceo"}},
+		"multiple select":  {"company": {"Acme"}, "role": {"engineer", "manager"}},
+		"bad checkbox":     {"company": {"Acme"}, "nda": {"maybe"}},
+	}
+	for name, answers := range cases {
+		_, err := resolveIntakeAnswers(&topic, answers, model.LocaleEn)
+		require.Equal(t, http.StatusBadRequest, errs.From(err).Status, name)
+	}
+
+	_, err = resolveIntakeAnswers(nil, map[string]model.IntakeAnswerValues{"company": {"Acme"}}, model.LocaleEn)
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+}
+
+func TestBookingService_StoresIntakeAnswers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	notifications := newStubNotificationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-intake"}}
+	settings := newStubContactSettingsRepository()
+	settings.settings.Topics = []model.ContactTopicV2{consultingIntakeTopic()}
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC", CalendarTimezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary", NotificationSender: "noreply@example.com", MaxRetries: 1},
+	}
+
+	svc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, settings, captcha.NewFakeVerifier("fail"), nil, calendarClient, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	req := model.BookingRequest{
+		Name:            "Client",
+		Email:           "client@example.com",
+		StartTime:       now.Add(2 * time.Hour),
+		DurationMinutes: 30,
+		Topic:           "consulting",
+		Locale:          "en",
+		RecaptchaToken:  "test-token",
+	}
+	_, err = svc.Book(context.Background(), req)
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+	require.Empty(t, reservations.created)
+
+	req.Answers = map[string]model.IntakeAnswerValues{"company": {"Acme"}, "source": {"blog"}}
+	result, err := svc.Book(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, []model.IntakeAnswer{
+		{QuestionID: "company", Label: "Company", Values: []string{"Acme"}},
+		{QuestionID: "source", Label: "How did you hear about us", Values: []string{"blog"}},
+	}, result.Reservation.IntakeAnswers)
+
+	dispatcher := newTestDispatcher(t, outbox, reservations, notifications, calendarClient, &stubMailClient{}, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Len(t, calendarClient.created, 1)
+	require.Contains(t, calendarClient.created[0].Description, "\nIntake:\nCompany: Acme\nHow did you hear about us: blog\n")
+}
+
+func TestContactService_StoresIntakeAnswers(t *testing.T) {
+	t.Parallel()
+
+	settings := newStubContactSettingsRepository()
+	settings.settings.Topics = []model.ContactTopicV2{consultingIntakeTopic()}
+	contacts := inmemory.NewContactRepository()
+	svc := NewContactService(contacts, settings, captcha.NewFakeVerifier("fail"), nil, nil, nil)
+
+	req := &model.ContactRequest{
+		Name:           "Client",
+		Email:          "client@example.com",
+		Message:        "Hello",
+		Topic:          "consulting",
+		Locale:         "ja",
+		Answers:        map[string]model.IntakeAnswerValues{"company": {"Acme"}, "role": {"ceo"}},
+		RecaptchaToken: "test-token",
+	}
+	_, err := svc.SubmitContact(context.Background(), req)
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+
+	req.Answers["role"] = model.IntakeAnswerValues{"engineer"}
+	submission, err := svc.SubmitContact(context.Background(), req)
+	require.NoError(t, err)
+
+	stored, err := contacts.(repository.AdminContactRepository).GetContactMessage(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.IntakeAnswer{
+		{QuestionID: "company", Label: "会社名", Values: []string{"Acme"}},
+		{QuestionID: "role", Label: "役職", Values: []string{"engineer"}},
+	}, stored.IntakeAnswers)
+}
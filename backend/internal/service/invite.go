@@ -0,0 +1,31 @@
+package service
+
+import (
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/mail"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/service/support"
+)
+
+const meetingInviteFilename = "invite.ics"
+
+// meetingInvite renders the reservation as an iTIP invite attachment.
+func meetingInvite(method ics.Method, reservation *model.MeetingReservation, cfg config.BookingConfig, meetURL string, stamp time.Time) mail.Attachment {
+	event := support.ReservationEvent(cfg, reservation, stamp)
+	event.Location = meetURL
+	event.URL = meetURL
+	event.Organizer = cfg.NotificationSender
+	if method == ics.MethodCancel {
+		event.Status = ics.StatusCancelled
+	}
+
+	invite := ics.Calendar{Method: method, Events: []ics.Event{event}}
+	return mail.Attachment{
+		Filename:    meetingInviteFilename,
+		ContentType: invite.ContentType(),
+		Data:        invite.Bytes(),
+	}
+}
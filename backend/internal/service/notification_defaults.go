@@ -0,0 +1,447 @@
+package service
+
+import "github.com/takumi/personal-website/internal/model"
+
+// builtinNotificationTemplates are used for any key without an admin override. Their order is
+// the order the admin API lists templates in.
+var builtinNotificationTemplates = []model.NotificationTemplate{
+	{
+		Key: model.NotificationTemplateBookingConfirmation,
+		Subject: model.NewLocalizedText(
+			"ミーティングのご予約を承りました: {{.Start}}",
+			"Meeting request confirmed: {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+ミーティングのご予約を承りました。
+日時: {{.Start}}（{{.DurationMinutes}} 分）
+{{if .Agenda}}
+議題:
+{{.Agenda}}
+{{end}}{{if .MeetURL}}
+Google Meet で参加: {{.MeetURL}}
+{{end}}
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+Your meeting has been scheduled for {{.Start}} (duration: {{.DurationMinutes}} minutes).
+{{if .Agenda}}
+Agenda:
+{{.Agenda}}
+{{end}}{{if .MeetURL}}
+Join via Google Meet: {{.MeetURL}}
+{{end}}
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p>ミーティングのご予約を承りました。<br>日時: <strong>{{.Start}}</strong>（{{.DurationMinutes}} 分）</p>
+{{if .Agenda}}<p>議題:</p>
+<p style="white-space: pre-line">{{.Agenda}}</p>
+{{end}}{{if .MeetURL}}<p><a href="{{.MeetURL}}">Google Meet で参加</a></p>
+{{end}}<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>Your meeting has been scheduled for <strong>{{.Start}}</strong> (duration: {{.DurationMinutes}} minutes).</p>
+{{if .Agenda}}<p>Agenda:</p>
+<p style="white-space: pre-line">{{.Agenda}}</p>
+{{end}}{{if .MeetURL}}<p><a href="{{.MeetURL}}">Join via Google Meet</a></p>
+{{end}}<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateBookingReschedule,
+		Subject: model.NewLocalizedText(
+			"ミーティングの日時を変更しました: {{.Start}}",
+			"Meeting rescheduled: {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+{{.PreviousStart}} に予定していたミーティングの日時を変更しました。
+新しい日時: {{.Start}}（{{.DurationMinutes}} 分）
+{{if .MeetURL}}
+Google Meet で参加: {{.MeetURL}}
+{{end}}
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+Your meeting originally scheduled for {{.PreviousStart}} has been moved.
+New time: {{.Start}} (duration: {{.DurationMinutes}} minutes).
+{{if .MeetURL}}
+Join via Google Meet: {{.MeetURL}}
+{{end}}
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p>{{.PreviousStart}} に予定していたミーティングの日時を変更しました。<br>新しい日時: <strong>{{.Start}}</strong>（{{.DurationMinutes}} 分）</p>
+{{if .MeetURL}}<p><a href="{{.MeetURL}}">Google Meet で参加</a></p>
+{{end}}<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>Your meeting originally scheduled for {{.PreviousStart}} has been moved.<br>New time: <strong>{{.Start}}</strong> (duration: {{.DurationMinutes}} minutes).</p>
+{{if .MeetURL}}<p><a href="{{.MeetURL}}">Join via Google Meet</a></p>
+{{end}}<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateBookingCancellation,
+		Subject: model.NewLocalizedText(
+			"ミーティングがキャンセルされました: {{.Start}}",
+			"Meeting cancelled: {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+{{.Start}} に予定していたミーティングはキャンセルされました。
+{{if .Reason}}
+理由: {{.Reason}}
+{{end}}
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+Your meeting scheduled for {{.Start}} has been cancelled.
+{{if .Reason}}
+Reason: {{.Reason}}
+{{end}}
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p>{{.Start}} に予定していたミーティングはキャンセルされました。</p>
+{{if .Reason}}<p>理由: {{.Reason}}</p>
+{{end}}<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>Your meeting scheduled for {{.Start}} has been cancelled.</p>
+{{if .Reason}}<p>Reason: {{.Reason}}</p>
+{{end}}<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateBookingReminder,
+		Subject: model.NewLocalizedText(
+			"リマインダー: {{.Start}} のミーティング",
+			"Reminder: meeting at {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+{{.Start}}（{{.DurationMinutes}} 分）にミーティングのご予定があります。
+{{if .MeetURL}}
+Google Meet で参加: {{.MeetURL}}
+{{end}}
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+This is a reminder of your meeting on {{.Start}} (duration: {{.DurationMinutes}} minutes).
+{{if .MeetURL}}
+Join via Google Meet: {{.MeetURL}}
+{{end}}
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p><strong>{{.Start}}</strong>（{{.DurationMinutes}} 分）にミーティングのご予定があります。</p>
+{{if .MeetURL}}<p><a href="{{.MeetURL}}">Google Meet で参加</a></p>
+{{end}}<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>This is a reminder of your meeting on <strong>{{.Start}}</strong> (duration: {{.DurationMinutes}} minutes).</p>
+{{if .MeetURL}}<p><a href="{{.MeetURL}}">Join via Google Meet</a></p>
+{{end}}<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateOwnerBookingNotice,
+		Subject: model.NewLocalizedText(
+			"新しい予約: {{.Name}} / {{.Start}}",
+			"New booking: {{.Name}} at {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`新しいミーティングの予約が入りました。
+
+お名前: {{.Name}}
+メール: {{.Email}}
+日時: {{.Start}}
+所要時間: {{.DurationMinutes}} 分
+{{if .Topic}}トピック: {{.Topic}}
+{{end}}{{if .Deadline}}承認期限: {{.Deadline}}（管理画面で承認または却下してください）
+{{end}}{{if .Agenda}}
+議題:
+{{.Agenda}}
+{{end}}`,
+			`A new meeting has been booked.
+
+Name: {{.Name}}
+Email: {{.Email}}
+Start: {{.Start}}
+Duration: {{.DurationMinutes}} minutes
+{{if .Topic}}Topic: {{.Topic}}
+{{end}}{{if .Deadline}}Approval needed by {{.Deadline}} (approve or decline it in the admin console)
+{{end}}{{if .Agenda}}
+Agenda:
+{{.Agenda}}
+{{end}}`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateBookingRequested,
+		Subject: model.NewLocalizedText(
+			"ミーティングのご依頼を受け付けました: {{.Start}}",
+			"Meeting request received: {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+ミーティングのご依頼を受け付けました。内容を確認のうえ、承認されましたら改めてご連絡いたします。
+希望日時: {{.Start}}（{{.DurationMinutes}} 分）
+{{if .Deadline}}回答期限: {{.Deadline}}
+{{end}}{{if .Agenda}}
+議題:
+{{.Agenda}}
+{{end}}
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+We have received your meeting request. It will be reviewed and you will hear from us once it is approved.
+Requested time: {{.Start}} (duration: {{.DurationMinutes}} minutes).
+{{if .Deadline}}You will receive an answer by {{.Deadline}}.
+{{end}}{{if .Agenda}}
+Agenda:
+{{.Agenda}}
+{{end}}
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p>ミーティングのご依頼を受け付けました。内容を確認のうえ、承認されましたら改めてご連絡いたします。<br>希望日時: <strong>{{.Start}}</strong>（{{.DurationMinutes}} 分）</p>
+{{if .Deadline}}<p>回答期限: {{.Deadline}}</p>
+{{end}}{{if .Agenda}}<p>議題:</p>
+<p style="white-space: pre-line">{{.Agenda}}</p>
+{{end}}<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>We have received your meeting request. It will be reviewed and you will hear from us once it is approved.<br>Requested time: <strong>{{.Start}}</strong> (duration: {{.DurationMinutes}} minutes).</p>
+{{if .Deadline}}<p>You will receive an answer by {{.Deadline}}.</p>
+{{end}}{{if .Agenda}}<p>Agenda:</p>
+<p style="white-space: pre-line">{{.Agenda}}</p>
+{{end}}<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateBookingApproved,
+		Subject: model.NewLocalizedText(
+			"ミーティングのご依頼が承認されました: {{.Start}}",
+			"Meeting request approved: {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+ミーティングのご依頼が承認されました。
+日時: {{.Start}}（{{.DurationMinutes}} 分）
+{{if .MeetURL}}
+Google Meet で参加: {{.MeetURL}}
+{{end}}
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+Your meeting request has been approved for {{.Start}} (duration: {{.DurationMinutes}} minutes).
+{{if .MeetURL}}
+Join via Google Meet: {{.MeetURL}}
+{{end}}
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p>ミーティングのご依頼が承認されました。<br>日時: <strong>{{.Start}}</strong>（{{.DurationMinutes}} 分）</p>
+{{if .MeetURL}}<p><a href="{{.MeetURL}}">Google Meet で参加</a></p>
+{{end}}<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>Your meeting request has been approved for <strong>{{.Start}}</strong> (duration: {{.DurationMinutes}} minutes).</p>
+{{if .MeetURL}}<p><a href="{{.MeetURL}}">Join via Google Meet</a></p>
+{{end}}<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateBookingDeclined,
+		Subject: model.NewLocalizedText(
+			"ミーティングのご依頼をお受けできませんでした: {{.Start}}",
+			"Meeting request declined: {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+{{.Start}} のミーティングのご依頼は、{{if .Expired}}期限までに承認されなかったため取り消されました。{{else}}誠に恐れ入りますがお受けできませんでした。{{end}}
+{{if .Reason}}
+理由: {{.Reason}}
+{{end}}
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+{{if .Expired}}Your meeting request for {{.Start}} was not approved in time and has been withdrawn.{{else}}Unfortunately, your meeting request for {{.Start}} has been declined.{{end}}
+{{if .Reason}}
+Reason: {{.Reason}}
+{{end}}
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p>{{.Start}} のミーティングのご依頼は、{{if .Expired}}期限までに承認されなかったため取り消されました。{{else}}誠に恐れ入りますがお受けできませんでした。{{end}}</p>
+{{if .Reason}}<p>理由: {{.Reason}}</p>
+{{end}}<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>{{if .Expired}}Your meeting request for {{.Start}} was not approved in time and has been withdrawn.{{else}}Unfortunately, your meeting request for {{.Start}} has been declined.{{end}}</p>
+{{if .Reason}}<p>Reason: {{.Reason}}</p>
+{{end}}<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateWaitlistOffer,
+		Subject: model.NewLocalizedText(
+			"ご希望の日に空きが出ました: {{.Start}}",
+			"A slot has opened up: {{.Start}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`{{.Name}} 様
+
+キャンセル待ちにご登録いただいた日にミーティングの枠が空きました。
+日時: {{.Start}}（{{.DurationMinutes}} 分）
+
+以下のリンクから {{.Deadline}} までにご予約を確定してください。期限を過ぎると次の方にご案内します。
+{{.ClaimURL}}
+
+よろしくお願いいたします。
+Portfolio Site
+`,
+			`Hi {{.Name}},
+
+A meeting slot has opened up on a day you are waitlisted for.
+Time: {{.Start}} (duration: {{.DurationMinutes}} minutes).
+
+Claim it by {{.Deadline}} using the link below. After that it will be offered to the next person on the waitlist.
+{{.ClaimURL}}
+
+Thank you,
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>{{.Name}} 様</p>
+<p>キャンセル待ちにご登録いただいた日にミーティングの枠が空きました。<br>日時: <strong>{{.Start}}</strong>（{{.DurationMinutes}} 分）</p>
+<p><a href="{{.ClaimURL}}">この枠を予約する</a>（期限: {{.Deadline}}）<br>期限を過ぎると次の方にご案内します。</p>
+<p>よろしくお願いいたします。<br>Portfolio Site</p>
+`,
+			`<p>Hi {{.Name}},</p>
+<p>A meeting slot has opened up on a day you are waitlisted for.<br>Time: <strong>{{.Start}}</strong> (duration: {{.DurationMinutes}} minutes).</p>
+<p><a href="{{.ClaimURL}}">Claim this slot</a> by {{.Deadline}}. After that it will be offered to the next person on the waitlist.</p>
+<p>Thank you,<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateContactAutoReply,
+		// The recipient address is whatever the visitor typed, so the reply repeats nothing
+		// they wrote; otherwise the form could relay arbitrary text to any address.
+		Subject: model.NewLocalizedText(
+			"お問い合わせを受け付けました",
+			"We received your message",
+		),
+		TextBody: model.NewLocalizedText(
+			`お問い合わせいただきありがとうございます。
+
+メッセージを受け付けました。内容を確認のうえ、折り返しご連絡いたします。
+お心当たりのない場合は、このメールを破棄してください。
+
+このメールは送信専用のアドレスから自動で送信しています。
+Portfolio Site
+`,
+			`Thank you for getting in touch.
+
+We have received your message and will get back to you soon.
+If you did not contact us, please ignore this email.
+
+This is an automated reply.
+Portfolio Site
+`,
+		),
+		HTMLBody: model.NewLocalizedText(
+			`<p>お問い合わせいただきありがとうございます。</p>
+<p>メッセージを受け付けました。内容を確認のうえ、折り返しご連絡いたします。<br>お心当たりのない場合は、このメールを破棄してください。</p>
+<p>このメールは送信専用のアドレスから自動で送信しています。<br>Portfolio Site</p>
+`,
+			`<p>Thank you for getting in touch.</p>
+<p>We have received your message and will get back to you soon.<br>If you did not contact us, please ignore this email.</p>
+<p>This is an automated reply.<br>Portfolio Site</p>
+`,
+		),
+	},
+	{
+		Key: model.NotificationTemplateOwnerContactNotice,
+		Subject: model.NewLocalizedText(
+			"新しいお問い合わせ: {{.Name}}",
+			"New contact message from {{.Name}}",
+		),
+		TextBody: model.NewLocalizedText(
+			`新しいお問い合わせが届きました。
+
+お名前: {{.Name}}
+メール: {{.Email}}
+{{if .Topic}}トピック: {{.Topic}}
+{{end}}{{if .Answers}}
+{{.Answers}}
+{{end}}
+{{.Message}}
+`,
+			`A new contact message has arrived.
+
+Name: {{.Name}}
+Email: {{.Email}}
+{{if .Topic}}Topic: {{.Topic}}
+{{end}}{{if .Answers}}
+{{.Answers}}
+{{end}}
+{{.Message}}
+`,
+		),
+	},
+}
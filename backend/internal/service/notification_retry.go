@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/mail"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+// Outcomes recorded on a retried notification and its attempt rows.
+const (
+	notificationStatusSent    = "sent"
+	notificationStatusFailed  = "failed"
+	notificationStatusDead    = "dead"
+	notificationStatusSkipped = "skipped"
+)
+
+// NotificationRetrier resends notification emails whose delivery failed or that were recorded as
+// pending but never sent (such as cancellations applied from Google Calendar). Each try is kept
+// as an attempt row; failures back off exponentially and a notification that runs out of
+// attempts, or fails permanently, is marked dead. Notifications the reservation has outgrown
+// (a reminder for a cancelled meeting, an email superseded by a later one) are skipped.
+//
+// Emails sent by outbox jobs, such as confirmations, are retried by their job alone; the
+// retrier only owns the notification types recorded outside the outbox.
+//
+// Contact form emails are only queued by the contact service, so the retrier sends their first
+// attempt too and applies the same backoff to later ones.
+type NotificationRetrier struct {
+	notifications        repository.MeetingNotificationRepository
+	reservations         repository.MeetingReservationRepository
+	contactNotifications repository.ContactNotificationRepository
+	contacts             repository.AdminContactRepository
+	senders              map[string]outboxHandler
+	templates            *notificationRenderer
+	mailer               mail.Client
+	appCfg               *config.AppConfig
+	cfg                  config.BookingConfig
+	clock                Clock
+}
+
+// NewNotificationRetrier wires the retrier with defaults for any unset retry settings. Emails are
+// composed and sent through the outbox dispatcher so a retry matches the original message, and
+// the retry settings are read from the dispatcher's booking configuration.
+func NewNotificationRetrier(
+	notifications repository.MeetingNotificationRepository,
+	reservations repository.MeetingReservationRepository,
+	contactNotifications repository.ContactNotificationRepository,
+	contacts repository.AdminContactRepository,
+	dispatcher *OutboxDispatcher,
+	cfg *config.AppConfig,
+) (*NotificationRetrier, error) {
+	if notifications == nil || reservations == nil || contactNotifications == nil || contacts == nil || dispatcher == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "notification retrier: missing dependencies", nil)
+	}
+	if cfg.Booking.NotificationRetryInterval <= 0 && (cfg.Contact.OwnerAlert || cfg.Contact.AutoReply) {
+		// Contact emails have no other sender, so a disabled worker would drop them silently.
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "notification retrier: booking.notification_retry_interval must be positive while contact.owner_alert or contact.auto_reply is enabled", nil)
+	}
+
+	// The dispatcher's settings already carry defaults for the outbox batch size and lease.
+	bookingCfg := dispatcher.cfg
+	if bookingCfg.NotificationRetryMaxAttempts <= 0 {
+		bookingCfg.NotificationRetryMaxAttempts = 5
+	}
+	if bookingCfg.NotificationRetryInitialBackoff <= 0 {
+		bookingCfg.NotificationRetryInitialBackoff = 5 * time.Minute
+	}
+	if bookingCfg.NotificationRetryMaxBackoff < bookingCfg.NotificationRetryInitialBackoff {
+		bookingCfg.NotificationRetryMaxBackoff = 6 * time.Hour
+	}
+
+	return &NotificationRetrier{
+		notifications:        notifications,
+		reservations:         reservations,
+		contactNotifications: contactNotifications,
+		contacts:             contacts,
+		senders: map[string]outboxHandler{
+			"cancellation_email":     dispatcher.sendCancellation,
+			"reschedule_email":       dispatcher.sendReschedule,
+			reminderNotificationType: dispatcher.sendReminder,
+		},
+		templates: dispatcher.templates,
+		mailer:    dispatcher.mailer,
+		appCfg:    cfg,
+		cfg:       bookingCfg,
+		clock:     realClock{},
+	}, nil
+}
+
+// Run retries due notifications every retry interval until ctx is cancelled.
+func (r *NotificationRetrier) Run(ctx context.Context) {
+	interval := r.cfg.NotificationRetryInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.RetryDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("notification retry: pass failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RetryDue processes one batch of due meeting notifications and one of contact notifications,
+// and reports how many were claimed. Pending meeting notifications are only picked up once they
+// are older than the outbox lease, so a send that is still in flight elsewhere is not
+// duplicated; pending contact notifications have no other sender and are due at once.
+func (r *NotificationRetrier) RetryDue(ctx context.Context) (int, error) {
+	types := make([]string, 0, len(r.senders))
+	for notificationType := range r.senders {
+		types = append(types, notificationType)
+	}
+
+	now := r.clock.Now().UTC()
+	claimed, err := r.notifications.ClaimRetryableNotifications(ctx, types, now, now.Add(-r.cfg.OutboxLease), r.cfg.OutboxBatchSize, r.cfg.OutboxLease)
+	if err != nil {
+		return 0, fmt.Errorf("claim notifications: %w", err)
+	}
+	for i := range claimed {
+		if ctx.Err() != nil {
+			// Unsettled notifications become due again once their lease expires.
+			break
+		}
+		if err := r.retry(ctx, &claimed[i]); err != nil {
+			log.Printf("notification retry: settle notification %d (%s): %v", claimed[i].ID, claimed[i].Type, err)
+		}
+	}
+
+	contactClaimed, err := r.contactNotifications.ClaimRetryableContactNotifications(ctx, now, r.cfg.OutboxBatchSize, r.cfg.OutboxLease)
+	if err != nil {
+		return len(claimed), fmt.Errorf("claim contact notifications: %w", err)
+	}
+	for i := range contactClaimed {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := r.retryContact(ctx, &contactClaimed[i]); err != nil {
+			log.Printf("notification retry: settle contact notification %d (%s): %v", contactClaimed[i].ID, contactClaimed[i].Type, err)
+		}
+	}
+	return len(claimed) + len(contactClaimed), nil
+}
+
+func (r *NotificationRetrier) retry(ctx context.Context, notification *model.MeetingNotification) error {
+	attempt := &model.MeetingNotificationAttempt{
+		NotificationID: notification.ID,
+		ReservationID:  notification.ReservationID,
+		Attempt:        notification.Attempts + 1,
+		AttemptedAt:    r.clock.Now().UTC(),
+	}
+
+	reservation, err := r.reservations.FindReservationByID(ctx, notification.ReservationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return r.settle(ctx, attempt, notificationStatusSkipped, "reservation no longer exists", nil)
+		}
+		return err
+	}
+	if reason, obsolete, err := r.obsolete(ctx, notification, reservation); err != nil {
+		return err
+	} else if obsolete {
+		return r.settle(ctx, attempt, notificationStatusSkipped, reason, nil)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, r.cfg.RequestTimeout)
+	sendErr := r.senders[notification.Type](callCtx, reservation)
+	cancel()
+
+	switch {
+	case sendErr == nil:
+		return r.settle(ctx, attempt, notificationStatusSent, "", nil)
+	case errors.Is(sendErr, errOutboxNotReady):
+		// The claim lease doubles as the wait; the notification is due again once it expires.
+		return nil
+	case attempt.Attempt >= r.cfg.NotificationRetryMaxAttempts || !isRetryable(sendErr):
+		return r.settle(ctx, attempt, notificationStatusDead, sendErr.Error(), nil)
+	default:
+		next := attempt.AttemptedAt.Add(r.backoff(attempt.Attempt))
+		return r.settle(ctx, attempt, notificationStatusFailed, sendErr.Error(), &next)
+	}
+}
+
+// obsolete reports why a notification no longer applies: the meeting is over, the reservation
+// moved to a status the email does not describe, or a later email of the same type went out.
+func (r *NotificationRetrier) obsolete(ctx context.Context, notification *model.MeetingNotification, reservation *model.MeetingReservation) (string, bool, error) {
+	now := r.clock.Now()
+	if !reservation.EndAt.After(now) {
+		return "meeting has already ended", true, nil
+	}
+
+	var applies bool
+	switch notification.Type {
+	case "reschedule_email":
+		applies = reservation.Status.IsActive()
+	case reminderNotificationType:
+		applies = reservation.Status.IsActive() && reservation.StartAt.After(now)
+	case "cancellation_email":
+		applies = reservation.Status.IsCancelled()
+	default:
+		applies = true
+	}
+	if !applies {
+		return fmt.Sprintf("reservation is %s", reservation.Status), true, nil
+	}
+
+	history, err := r.notifications.ListNotifications(ctx, notification.ReservationID)
+	if err != nil {
+		return "", false, fmt.Errorf("list notifications: %w", err)
+	}
+	for _, entry := range history {
+		if entry.ID > notification.ID && entry.Type == notification.Type && entry.Status == notificationStatusSent {
+			return fmt.Sprintf("superseded by notification %d", entry.ID), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (r *NotificationRetrier) settle(ctx context.Context, attempt *model.MeetingNotificationAttempt, status, message string, nextAttemptAt *time.Time) error {
+	attempt.Status = status
+	if status == notificationStatusDead {
+		// The attempt itself failed; dead only describes the notification.
+		attempt.Status = notificationStatusFailed
+	}
+	attempt.ErrorMessage = message
+	_, err := r.notifications.RecordNotificationAttempt(ctx, attempt, status, nextAttemptAt)
+	return err
+}
+
+// retryContact sends a queued contact email unless its message is gone or was since marked spam.
+func (r *NotificationRetrier) retryContact(ctx context.Context, notification *model.ContactNotification) error {
+	attempt := notification.Attempts + 1
+	settle := func(status, message string, nextAttemptAt *time.Time) error {
+		_, err := r.contactNotifications.RecordContactNotificationAttempt(ctx, notification.ID, attempt, status, message, nextAttemptAt)
+		return err
+	}
+
+	message, err := r.contacts.GetContactMessage(ctx, notification.ContactID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return settle(notificationStatusSkipped, "contact message no longer exists", nil)
+		}
+		return err
+	}
+	if message.Status == model.ContactStatusSpam {
+		return settle(notificationStatusSkipped, "contact message is marked as spam", nil)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, r.cfg.RequestTimeout)
+	defer cancel()
+	email, sendErr := contactEmail(callCtx, r.templates, r.appCfg, notification.Type, message)
+	if errors.Is(sendErr, errNoContactRecipient) {
+		return settle(notificationStatusSkipped, sendErr.Error(), nil)
+	}
+	if sendErr == nil {
+		sendErr = r.mailer.Send(callCtx, email)
+	}
+
+	switch {
+	case sendErr == nil:
+		return settle(notificationStatusSent, "", nil)
+	case attempt >= r.cfg.NotificationRetryMaxAttempts || !isRetryable(sendErr):
+		return settle(notificationStatusDead, sendErr.Error(), nil)
+	default:
+		next := r.clock.Now().UTC().Add(r.backoff(attempt))
+		return settle(notificationStatusFailed, sendErr.Error(), &next)
+	}
+}
+
+// backoff doubles the initial delay for every attempt before this one, up to the configured cap.
+func (r *NotificationRetrier) backoff(attempt int) time.Duration {
+	delay := r.cfg.NotificationRetryInitialBackoff
+	for i := 1; i < attempt && delay < r.cfg.NotificationRetryMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.NotificationRetryMaxBackoff {
+		delay = r.cfg.NotificationRetryMaxBackoff
+	}
+	return delay
+}
+
+func (d *OutboxDispatcher) sendCancellation(ctx context.Context, reservation *model.MeetingReservation) error {
+	message, err := cancellationMessage(ctx, d.templates, d.cfg, reservation, d.location(), d.clock.Now())
+	if err != nil {
+		return err
+	}
+	return d.mailer.Send(ctx, message)
+}
+
+// sendReschedule resends the reschedule email. The earlier time is not kept once a reservation
+// moves, so the resent email refers to it generically.
+func (d *OutboxDispatcher) sendReschedule(ctx context.Context, reservation *model.MeetingReservation) error {
+	meetURL, err := d.meetingLink(ctx, reservation)
+	if err != nil {
+		return err
+	}
+	previousStart := "an earlier time"
+	if d.templates.locale(reservation.Locale) == model.LocaleJa {
+		previousStart = "変更前の日時"
+	}
+	message, err := rescheduleMessage(ctx, d.templates, d.cfg, reservation, previousStart, meetURL, d.location(), d.clock.Now())
+	if err != nil {
+		return err
+	}
+	return d.mailer.Send(ctx, message)
+}
+
+func (d *OutboxDispatcher) sendReminder(ctx context.Context, reservation *model.MeetingReservation) error {
+	meetURL, err := d.meetingLink(ctx, reservation)
+	if err != nil {
+		return err
+	}
+	message, err := reminderMessage(ctx, d.templates, d.cfg, reservation, meetURL, d.location())
+	if err != nil {
+		return err
+	}
+	return d.mailer.Send(ctx, message)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+type retryFixture struct {
+	reservations  *stubReservationRepository
+	notifications repository.MeetingNotificationRepository
+	mailer        *stubMailClient
+	retrier       *NotificationRetrier
+}
+
+// newRetryFixture runs the retrier past the outbox lease from the wall clock, because the
+// in-memory repository stamps notifications with the current time.
+func newRetryFixture(t *testing.T, booking config.BookingConfig) (*retryFixture, time.Time) {
+	t.Helper()
+
+	now := time.Now().UTC().Add(time.Hour)
+	fixture := &retryFixture{
+		reservations:  newStubReservationRepository(),
+		notifications: inmemory.NewMeetingNotificationRepository(),
+		mailer:        &stubMailClient{},
+	}
+	booking.CalendarID = "primary"
+	cfg := &config.AppConfig{Contact: config.ContactConfig{Timezone: "UTC"}, Booking: booking}
+	dispatcher := newTestDispatcher(t, newStubOutboxRepository(fixture.reservations), fixture.reservations, newStubNotificationRepository(), &stubCalendarClient{}, fixture.mailer, cfg, now)
+
+	contacts := inmemory.NewContactRepository().(repository.AdminContactRepository)
+	retrier, err := NewNotificationRetrier(fixture.notifications, fixture.reservations, inmemory.NewContactNotificationRepository(), contacts, dispatcher, cfg)
+	require.NoError(t, err)
+	retrier.clock = fixedClock{now: now}
+	fixture.retrier = retrier
+	return fixture, now
+}
+
+func (f *retryFixture) reservation(t *testing.T, status model.MeetingReservationStatus, start time.Time) *model.MeetingReservation {
+	t.Helper()
+
+	created, err := f.reservations.CreateReservation(context.Background(), &model.MeetingReservation{
+		LookupHash: string(status) + start.String(),
+		Name:       "Retry User",
+		Email:      "retry@example.com",
+		StartAt:    start,
+		EndAt:      start.Add(30 * time.Minute),
+		Status:     status,
+	})
+	require.NoError(t, err)
+	return created
+}
+
+func (f *retryFixture) notification(t *testing.T, reservationID uint64, notificationType, status string) *model.MeetingNotification {
+	t.Helper()
+
+	recorded, err := f.notifications.RecordNotification(context.Background(), &model.MeetingNotification{
+		ReservationID: reservationID,
+		Type:          notificationType,
+		Status:        status,
+	})
+	require.NoError(t, err)
+	return recorded
+}
+
+func (f *retryFixture) status(t *testing.T, reservationID uint64) map[string]model.MeetingNotification {
+	t.Helper()
+
+	entries, err := f.notifications.ListNotifications(context.Background(), reservationID)
+	require.NoError(t, err)
+	byType := make(map[string]model.MeetingNotification, len(entries))
+	for _, entry := range entries {
+		byType[entry.Type] = entry
+	}
+	return byType
+}
+
+func TestNotificationRetrier_ResendsFailedAndUnsentNotifications(t *testing.T) {
+	t.Parallel()
+
+	fixture, now := newRetryFixture(t, config.BookingConfig{})
+	confirmed := fixture.reservation(t, model.MeetingReservationStatusConfirmed, now.Add(48*time.Hour))
+	cancelled := fixture.reservation(t, model.MeetingReservationStatusCancelledByOwner, now.Add(72*time.Hour))
+	fixture.notification(t, confirmed.ID, "reschedule_email", "failed")
+	fixture.notification(t, cancelled.ID, "cancellation_email", "pending")
+
+	claimed, err := fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, claimed)
+	require.Len(t, fixture.mailer.sent, 2)
+	require.Equal(t, []string{"retry@example.com"}, fixture.mailer.sent[1].To)
+
+	sent := fixture.status(t, confirmed.ID)["reschedule_email"]
+	require.Equal(t, "sent", sent.Status)
+	require.Equal(t, 1, sent.Attempts)
+	require.Nil(t, sent.NextAttemptAt)
+	require.Equal(t, "sent", fixture.status(t, cancelled.ID)["cancellation_email"].Status)
+
+	attempts, err := fixture.notifications.ListNotificationAttempts(context.Background(), cancelled.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	require.Equal(t, 1, attempts[0].Attempt)
+	require.Equal(t, "sent", attempts[0].Status)
+	require.Equal(t, cancelled.ID, attempts[0].ReservationID)
+
+	claimed, err = fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, claimed)
+	require.Len(t, fixture.mailer.sent, 2)
+}
+
+func TestNotificationRetrier_BacksOffThenMarksDead(t *testing.T) {
+	t.Parallel()
+
+	fixture, now := newRetryFixture(t, config.BookingConfig{
+		NotificationRetryMaxAttempts:    3,
+		NotificationRetryInitialBackoff: 5 * time.Minute,
+		NotificationRetryMaxBackoff:     8 * time.Minute,
+	})
+	fixture.mailer.err = errors.New("smtp down")
+	reservation := fixture.reservation(t, model.MeetingReservationStatusConfirmed, now.Add(48*time.Hour))
+	fixture.notification(t, reservation.ID, reminderNotificationType, "failed")
+
+	retryAt := func(at time.Time) int {
+		fixture.retrier.clock = fixedClock{now: at}
+		claimed, err := fixture.retrier.RetryDue(context.Background())
+		require.NoError(t, err)
+		return claimed
+	}
+
+	require.Equal(t, 1, retryAt(now))
+	reminder := fixture.status(t, reservation.ID)[reminderNotificationType]
+	require.Equal(t, "failed", reminder.Status)
+	require.Equal(t, "smtp down", reminder.ErrorMessage)
+	require.Equal(t, 1, reminder.Attempts)
+	require.Equal(t, now.Add(5*time.Minute), *reminder.NextAttemptAt)
+
+	// Not due until the backoff has passed; the second delay doubles up to the cap.
+	require.Zero(t, retryAt(now.Add(4*time.Minute)))
+	require.Equal(t, 1, retryAt(now.Add(5*time.Minute)))
+	reminder = fixture.status(t, reservation.ID)[reminderNotificationType]
+	require.Equal(t, 2, reminder.Attempts)
+	require.Equal(t, now.Add(13*time.Minute), *reminder.NextAttemptAt)
+
+	require.Equal(t, 1, retryAt(now.Add(13*time.Minute)))
+	reminder = fixture.status(t, reservation.ID)[reminderNotificationType]
+	require.Equal(t, "dead", reminder.Status)
+	require.Equal(t, 3, reminder.Attempts)
+	require.Nil(t, reminder.NextAttemptAt)
+	require.Zero(t, retryAt(now.Add(24*time.Hour)))
+
+	attempts, err := fixture.notifications.ListNotificationAttempts(context.Background(), reservation.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 3)
+	for i, attempt := range attempts {
+		require.Equal(t, i+1, attempt.Attempt)
+		require.Equal(t, "failed", attempt.Status)
+	}
+}
+
+func TestNotificationRetrier_SkipsObsoleteNotifications(t *testing.T) {
+	t.Parallel()
+
+	fixture, now := newRetryFixture(t, config.BookingConfig{})
+	cancelled := fixture.reservation(t, model.MeetingReservationStatusCancelledByVisitor, now.Add(48*time.Hour))
+	past := fixture.reservation(t, model.MeetingReservationStatusCancelledByOwner, now.Add(-2*time.Hour))
+	superseded := fixture.reservation(t, model.MeetingReservationStatusConfirmed, now.Add(48*time.Hour))
+	fixture.notification(t, cancelled.ID, "reschedule_email", "failed")
+	fixture.notification(t, past.ID, "cancellation_email", "pending")
+	fixture.notification(t, superseded.ID, "reschedule_email", "failed")
+	fixture.notification(t, superseded.ID, "reschedule_email", "sent")
+
+	claimed, err := fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, claimed)
+	require.Empty(t, fixture.mailer.sent)
+
+	reasons := map[uint64]string{
+		cancelled.ID:  "reservation is cancelled_by_visitor",
+		past.ID:       "meeting has already ended",
+		superseded.ID: "superseded by notification 4",
+	}
+	for reservationID, reason := range reasons {
+		attempts, err := fixture.notifications.ListNotificationAttempts(context.Background(), reservationID)
+		require.NoError(t, err)
+		require.Len(t, attempts, 1)
+		require.Equal(t, "skipped", attempts[0].Status)
+		require.Equal(t, reason, attempts[0].ErrorMessage)
+	}
+	entries, err := fixture.notifications.ListNotifications(context.Background(), superseded.ID)
+	require.NoError(t, err)
+	require.Equal(t, "skipped", entries[0].Status)
+	require.Equal(t, "sent", entries[1].Status)
+}
+
+func TestNotificationRetrier_LeavesOutboxNotificationsToTheirJobs(t *testing.T) {
+	t.Parallel()
+
+	fixture, now := newRetryFixture(t, config.BookingConfig{})
+	reservation := fixture.reservation(t, model.MeetingReservationStatusConfirmed, now.Add(48*time.Hour))
+	fixture.notification(t, reservation.ID, "confirmation_email", "failed")
+	fixture.notification(t, reservation.ID, "owner_notification", "failed")
+
+	// The outbox already retried these up to its own limit before recording the failure.
+	claimed, err := fixture.retrier.RetryDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, claimed)
+	require.Empty(t, fixture.mailer.sent)
+	require.Equal(t, "failed", fixture.status(t, reservation.ID)["confirmation_email"].Status)
+}
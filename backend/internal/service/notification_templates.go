@@ -0,0 +1,445 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"net/http"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/mail"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+// NotificationTemplateService lets admins edit, preview and test-send the notification email
+// templates. Keys without an override serve the built-in template.
+type NotificationTemplateService interface {
+	ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error)
+	GetTemplate(ctx context.Context, key string) (*model.NotificationTemplate, error)
+	UpdateTemplate(ctx context.Context, key string, input model.NotificationTemplateInput) (*model.NotificationTemplate, error)
+	ResetTemplate(ctx context.Context, key string) (*model.NotificationTemplate, error)
+	PreviewTemplate(ctx context.Context, key, locale string, draft *model.NotificationTemplateInput) (*model.RenderedNotification, error)
+	SendTestNotification(ctx context.Context, key, locale string, draft *model.NotificationTemplateInput, recipient string) (*model.RenderedNotification, error)
+}
+
+// notificationData is what notification templates can reference, e.g. {{.Name}} or {{.Start}}.
+// Times are preformatted for the recipient's locale; Start and PreviousStart are in the calendar
+// timezone, followed by the visitor's own time when they booked from a different zone.
+type notificationData struct {
+	Name            string
+	Email           string
+	Topic           string
+	Agenda          string
+	Start           string
+	PreviousStart   string
+	DurationMinutes int
+	MeetURL         string
+	Reason          string
+	// Deadline is when an unapproved request or a waitlist offer lapses; Expired marks a
+	// decline caused by the approval deadline.
+	Deadline string
+	Expired  bool
+	// ClaimURL is the link a waitlisted visitor follows to claim an offered slot.
+	ClaimURL string
+	// Message and Answers describe a contact form submission; Answers holds one
+	// "Label: value" line per intake question.
+	Message string
+	Answers string
+}
+
+var japaneseWeekdays = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+func formatNotificationTime(t time.Time, locale string) string {
+	if locale == model.LocaleJa {
+		return fmt.Sprintf("%d年%d月%d日(%s) %s", t.Year(), int(t.Month()), t.Day(), japaneseWeekdays[t.Weekday()], t.Format("15:04 MST"))
+	}
+	return t.Format(time.RFC1123)
+}
+
+// formatReservationTime renders t in the calendar timezone and appends it in the visitor's
+// timezone when that is set and differs, e.g. "Sat, 04 May 2024 09:00:00 JST
+// (America/New_York: Fri, 03 May 2024 20:00:00 EDT)".
+func formatReservationTime(t time.Time, locale string, loc *time.Location, visitorTimezone string) string {
+	formatted := formatNotificationTime(t.In(loc), locale)
+	visitorLoc, err := loadVisitorLocation(visitorTimezone)
+	if err != nil || visitorLoc == nil || visitorLoc.String() == loc.String() {
+		return formatted
+	}
+	visitor := formatNotificationTime(t.In(visitorLoc), locale)
+	if locale == model.LocaleJa {
+		return fmt.Sprintf("%s（%s: %s）", formatted, visitorLoc, visitor)
+	}
+	return fmt.Sprintf("%s (%s: %s)", formatted, visitorLoc, visitor)
+}
+
+func reservationNotificationData(reservation *model.MeetingReservation, locale string, loc *time.Location) notificationData {
+	return notificationData{
+		Name:            reservation.Name,
+		Email:           reservation.Email,
+		Topic:           strings.TrimSpace(reservation.Topic),
+		Agenda:          strings.TrimSpace(reservation.Message),
+		Start:           formatReservationTime(reservation.StartAt, locale, loc, reservation.VisitorTimezone),
+		DurationMinutes: int(reservation.EndAt.Sub(reservation.StartAt) / time.Minute),
+	}
+}
+
+// defaultNotificationLocale reads the configured fallback language, defaulting to Japanese.
+func defaultNotificationLocale(cfg config.BookingConfig) string {
+	if locale := model.NormalizeLocale(cfg.DefaultLocale); locale != "" {
+		return locale
+	}
+	return model.LocaleJa
+}
+
+func builtinNotificationTemplate(key model.NotificationTemplateKey) (model.NotificationTemplate, bool) {
+	for _, template := range builtinNotificationTemplates {
+		if template.Key == key {
+			return template, true
+		}
+	}
+	return model.NotificationTemplate{}, false
+}
+
+// notificationRenderer turns a template key into a localized message for the mail senders.
+type notificationRenderer struct {
+	templates     repository.NotificationTemplateRepository
+	defaultLocale string
+}
+
+func newNotificationRenderer(templates repository.NotificationTemplateRepository, cfg config.BookingConfig) *notificationRenderer {
+	return &notificationRenderer{templates: templates, defaultLocale: defaultNotificationLocale(cfg)}
+}
+
+// locale picks the recipient's stored locale, falling back to the configured default.
+func (r *notificationRenderer) locale(preferred string) string {
+	if locale := model.NormalizeLocale(preferred); locale != "" {
+		return locale
+	}
+	return r.defaultLocale
+}
+
+// compose renders the subject and bodies of a message; callers fill in addressing and attachments.
+func (r *notificationRenderer) compose(ctx context.Context, key model.NotificationTemplateKey, locale string, data notificationData) (mail.Message, error) {
+	rendered, err := r.render(ctx, key, locale, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+	return mail.Message{
+		Subject:  rendered.Subject,
+		Body:     rendered.TextBody,
+		HTMLBody: rendered.HTMLBody,
+	}, nil
+}
+
+// render prefers the admin override but never lets a broken or unreadable override block a
+// notification: it falls back to the built-in template instead.
+func (r *notificationRenderer) render(ctx context.Context, key model.NotificationTemplateKey, locale string, data notificationData) (*model.RenderedNotification, error) {
+	builtin, ok := builtinNotificationTemplate(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown notification template %q", key)
+	}
+
+	stored, err := r.templates.GetTemplate(ctx, key)
+	switch {
+	case err == nil:
+		rendered, renderErr := renderNotificationTemplate(*stored, locale, data)
+		if renderErr == nil {
+			return rendered, nil
+		}
+		log.Printf("notification templates: render customised %s: %v; using built-in template", key, renderErr)
+	case !errors.Is(err, repository.ErrNotFound):
+		log.Printf("notification templates: load %s: %v; using built-in template", key, err)
+	}
+	return renderNotificationTemplate(builtin, locale, data)
+}
+
+// renderNotificationTemplate executes one locale of a template. Subject and text fall back to the
+// other language when a variant is blank; HTML is only sent in the requested language so a
+// message never mixes a Japanese text part with an English HTML part.
+func renderNotificationTemplate(template model.NotificationTemplate, locale string, data any) (*model.RenderedNotification, error) {
+	subject, err := executeTextTemplate("subject", template.Subject.Resolve(locale), data)
+	if err != nil {
+		return nil, err
+	}
+	// Headers are single-line; collapse whatever whitespace the template produced.
+	subject = strings.Join(strings.Fields(subject), " ")
+	if subject == "" {
+		return nil, errors.New("subject renders empty")
+	}
+
+	textBody, err := executeTextTemplate("textBody", template.TextBody.Resolve(locale), data)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(textBody) == "" {
+		return nil, errors.New("textBody renders empty")
+	}
+
+	htmlSource := template.HTMLBody.Ja
+	if locale == model.LocaleEn {
+		htmlSource = template.HTMLBody.En
+	}
+	var htmlBody string
+	if strings.TrimSpace(htmlSource) != "" {
+		parsed, err := htmltemplate.New("htmlBody").Option("missingkey=error").Parse(htmlSource)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := parsed.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		htmlBody = buf.String()
+	}
+
+	return &model.RenderedNotification{
+		Locale:   locale,
+		Subject:  subject,
+		TextBody: textBody,
+		HTMLBody: htmlBody,
+	}, nil
+}
+
+func executeTextTemplate(name, source string, data any) (string, error) {
+	parsed, err := texttemplate.New(name).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type notificationTemplateService struct {
+	templates repository.NotificationTemplateRepository
+	renderer  *notificationRenderer
+	mailer    mail.Client
+	cfg       config.BookingConfig
+	timezone  string
+	clock     Clock
+}
+
+// NewNotificationTemplateService wires the admin template service.
+func NewNotificationTemplateService(
+	templates repository.NotificationTemplateRepository,
+	mailer mail.Client,
+	cfg *config.AppConfig,
+) (NotificationTemplateService, error) {
+	if templates == nil || mailer == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "notification template service: missing dependencies", nil)
+	}
+	return &notificationTemplateService{
+		templates: templates,
+		renderer:  newNotificationRenderer(templates, cfg.Booking),
+		mailer:    mailer,
+		cfg:       cfg.Booking,
+		timezone:  cfg.Contact.Timezone,
+		clock:     realClock{},
+	}, nil
+}
+
+func (s *notificationTemplateService) ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error) {
+	stored, err := s.templates.ListTemplates(ctx)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to list notification templates", err)
+	}
+	overrides := make(map[model.NotificationTemplateKey]model.NotificationTemplate, len(stored))
+	for _, template := range stored {
+		overrides[template.Key] = template
+	}
+
+	templates := make([]model.NotificationTemplate, 0, len(builtinNotificationTemplates))
+	for _, builtin := range builtinNotificationTemplates {
+		if override, ok := overrides[builtin.Key]; ok {
+			templates = append(templates, override)
+			continue
+		}
+		templates = append(templates, builtin)
+	}
+	return templates, nil
+}
+
+func (s *notificationTemplateService) GetTemplate(ctx context.Context, key string) (*model.NotificationTemplate, error) {
+	templateKey, builtin, err := lookupNotificationTemplate(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.effectiveTemplate(ctx, templateKey, builtin)
+}
+
+func (s *notificationTemplateService) UpdateTemplate(ctx context.Context, key string, input model.NotificationTemplateInput) (*model.NotificationTemplate, error) {
+	templateKey, _, err := lookupNotificationTemplate(key)
+	if err != nil {
+		return nil, err
+	}
+	template := templateFromInput(templateKey, input)
+	if err := s.validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.templates.SaveTemplate(ctx, &template)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to save notification template", err)
+	}
+	return saved, nil
+}
+
+func (s *notificationTemplateService) ResetTemplate(ctx context.Context, key string) (*model.NotificationTemplate, error) {
+	templateKey, builtin, err := lookupNotificationTemplate(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.DeleteTemplate(ctx, templateKey); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to reset notification template", err)
+	}
+	return &builtin, nil
+}
+
+func (s *notificationTemplateService) PreviewTemplate(ctx context.Context, key, locale string, draft *model.NotificationTemplateInput) (*model.RenderedNotification, error) {
+	templateKey, builtin, err := lookupNotificationTemplate(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// A draft previews unsaved edits; without one the template currently in use is shown.
+	var template model.NotificationTemplate
+	if draft != nil {
+		template = templateFromInput(templateKey, *draft)
+		if err := validateTemplateInput(template); err != nil {
+			return nil, err
+		}
+	} else {
+		effective, err := s.effectiveTemplate(ctx, templateKey, builtin)
+		if err != nil {
+			return nil, err
+		}
+		template = *effective
+	}
+
+	locale = s.renderer.locale(locale)
+	rendered, err := renderNotificationTemplate(template, locale, s.sampleData(locale))
+	if err != nil {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("template does not render: %v", err), err)
+	}
+	return rendered, nil
+}
+
+// SendTestNotification mails a preview rendered with sample data to the requesting admin.
+func (s *notificationTemplateService) SendTestNotification(ctx context.Context, key, locale string, draft *model.NotificationTemplateInput, recipient string) (*model.RenderedNotification, error) {
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "the signed-in admin has no email address to send a test to", nil)
+	}
+
+	rendered, err := s.PreviewTemplate(ctx, key, locale, draft)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mailer.Send(ctx, mail.Message{
+		From:     s.cfg.NotificationSender,
+		To:       []string{recipient},
+		Subject:  "[Test] " + rendered.Subject,
+		Body:     rendered.TextBody,
+		HTMLBody: rendered.HTMLBody,
+	}); err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusBadGateway, "failed to send test notification", err)
+	}
+	return rendered, nil
+}
+
+func (s *notificationTemplateService) effectiveTemplate(ctx context.Context, key model.NotificationTemplateKey, builtin model.NotificationTemplate) (*model.NotificationTemplate, error) {
+	stored, err := s.templates.GetTemplate(ctx, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return &builtin, nil
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load notification template", err)
+	}
+	return stored, nil
+}
+
+// validateTemplate renders both languages with sample data so typos in field names are caught
+// when the admin saves rather than when a visitor's email is due.
+func (s *notificationTemplateService) validateTemplate(template model.NotificationTemplate) error {
+	if err := validateTemplateInput(template); err != nil {
+		return err
+	}
+	for _, locale := range []string{model.LocaleJa, model.LocaleEn} {
+		if _, err := renderNotificationTemplate(template, locale, s.sampleData(locale)); err != nil {
+			return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, fmt.Sprintf("%s template does not render: %v", locale, err), err)
+		}
+	}
+	return nil
+}
+
+func validateTemplateInput(template model.NotificationTemplate) error {
+	if strings.TrimSpace(template.Subject.Ja) == "" && strings.TrimSpace(template.Subject.En) == "" {
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "subject is required in at least one language", nil)
+	}
+	if strings.TrimSpace(template.TextBody.Ja) == "" && strings.TrimSpace(template.TextBody.En) == "" {
+		return errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "textBody is required in at least one language", nil)
+	}
+	return nil
+}
+
+// sampleData fills every field so previews show each optional section of a template.
+func (s *notificationTemplateService) sampleData(locale string) notificationData {
+	loc, err := time.LoadLocation(s.timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	start := s.clock.Now().In(loc).Add(48 * time.Hour).Truncate(time.Hour)
+
+	data := notificationData{
+		Name:            "Taro Yamada",
+		Email:           "taro@example.com",
+		Topic:           "Portfolio review",
+		Agenda:          "Introductions\nQuestions",
+		Start:           formatNotificationTime(start, locale),
+		PreviousStart:   formatNotificationTime(start.Add(-24*time.Hour), locale),
+		DurationMinutes: 30,
+		MeetURL:         "https://meet.google.com/abc-defg-hij",
+		Reason:          "Schedule conflict",
+		Deadline:        formatNotificationTime(start.Add(-24*time.Hour), locale),
+		ClaimURL:        "https://example.com/contact/waitlist?token=sample-token",
+		Message:         "I would like to hear more about your research.",
+		Answers:         "Organization: Example Inc.",
+	}
+	if locale == model.LocaleJa {
+		data.Name = "山田 太郎"
+		data.Topic = "ポートフォリオについて"
+		data.Agenda = "自己紹介\n質疑応答"
+		data.Reason = "予定が重なったため"
+		data.Message = "研究内容について詳しくお伺いしたいです。"
+		data.Answers = "所属: 株式会社サンプル"
+	}
+	return data
+}
+
+func lookupNotificationTemplate(key string) (model.NotificationTemplateKey, model.NotificationTemplate, error) {
+	templateKey := model.NotificationTemplateKey(strings.TrimSpace(key))
+	builtin, ok := builtinNotificationTemplate(templateKey)
+	if !ok {
+		return "", model.NotificationTemplate{}, errs.New(errs.CodeNotFound, http.StatusNotFound, "notification template not found", nil)
+	}
+	return templateKey, builtin, nil
+}
+
+func templateFromInput(key model.NotificationTemplateKey, input model.NotificationTemplateInput) model.NotificationTemplate {
+	return model.NotificationTemplate{
+		Key:      key,
+		Subject:  model.NewLocalizedText(strings.TrimSpace(input.Subject.Ja), strings.TrimSpace(input.Subject.En)),
+		TextBody: input.TextBody,
+		HTMLBody: input.HTMLBody,
+	}
+}
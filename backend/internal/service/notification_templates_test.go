@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func TestNotificationRenderer_LocalizesAndFallsBackFromBrokenOverrides(t *testing.T) {
+	t.Parallel()
+
+	templates := inmemory.NewNotificationTemplateRepository()
+	renderer := newNotificationRenderer(templates, config.BookingConfig{})
+	start := time.Date(2024, 5, 6, 10, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	reservation := &model.MeetingReservation{
+		Name:    "Ada",
+		Email:   "ada@example.com",
+		Message: "Discuss <plans>",
+		StartAt: start,
+		EndAt:   start.Add(30 * time.Minute),
+	}
+
+	require.Equal(t, model.LocaleJa, renderer.locale(""))
+	require.Equal(t, model.LocaleEn, renderer.locale("en-US,en;q=0.9"))
+
+	data := reservationNotificationData(reservation, model.LocaleJa, start.Location())
+	message, err := renderer.compose(context.Background(), model.NotificationTemplateBookingConfirmation, model.LocaleJa, data)
+	require.NoError(t, err)
+	require.Equal(t, "ミーティングのご予約を承りました: 2024年5月6日(月) 10:00 JST", message.Subject)
+	require.Contains(t, message.Body, "Ada 様")
+	require.Contains(t, message.Body, "（30 分）")
+	require.Contains(t, message.HTMLBody, "Discuss &lt;plans&gt;")
+
+	_, err = templates.SaveTemplate(context.Background(), &model.NotificationTemplate{
+		Key:      model.NotificationTemplateBookingConfirmation,
+		Subject:  model.NewLocalizedText("", "Booked for {{.Start}}"),
+		TextBody: model.NewLocalizedText("", "See you, {{.Name}}"),
+	})
+	require.NoError(t, err)
+
+	data = reservationNotificationData(reservation, model.LocaleEn, start.Location())
+	message, err = renderer.compose(context.Background(), model.NotificationTemplateBookingConfirmation, model.LocaleEn, data)
+	require.NoError(t, err)
+	require.Equal(t, "Booked for Mon, 06 May 2024 10:00:00 JST", message.Subject)
+	require.Equal(t, "See you, Ada", message.Body)
+	require.Empty(t, message.HTMLBody)
+
+	_, err = templates.SaveTemplate(context.Background(), &model.NotificationTemplate{
+		Key:      model.NotificationTemplateBookingConfirmation,
+		Subject:  model.NewLocalizedText("", "{{.Missing}}"),
+		TextBody: model.NewLocalizedText("", "body"),
+	})
+	require.NoError(t, err)
+
+	message, err = renderer.compose(context.Background(), model.NotificationTemplateBookingConfirmation, model.LocaleEn, data)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(message.Subject, "Meeting request confirmed: "))
+}
+
+func TestNotificationTemplateService_ValidatesPreviewsAndSendsTests(t *testing.T) {
+	t.Parallel()
+
+	mailer := &stubMailClient{}
+	svc, err := NewNotificationTemplateService(inmemory.NewNotificationTemplateRepository(), mailer, &config.AppConfig{
+		Booking: config.BookingConfig{NotificationSender: "noreply@example.com", DefaultLocale: "en"},
+	})
+	require.NoError(t, err)
+	svc.(*notificationTemplateService).clock = fixedClock{now: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+
+	_, err = svc.UpdateTemplate(context.Background(), "booking_reminder", model.NotificationTemplateInput{
+		Subject:  model.NewLocalizedText("{{.Nmae}} さん", "Hello"),
+		TextBody: model.NewLocalizedText("本文", "Body"),
+	})
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+
+	_, err = svc.GetTemplate(context.Background(), "unknown")
+	require.Equal(t, http.StatusNotFound, errs.From(err).Status)
+
+	updated, err := svc.UpdateTemplate(context.Background(), "booking_reminder", model.NotificationTemplateInput{
+		Subject:  model.NewLocalizedText("{{.Name}} さん: {{.Start}}", "{{.Name}}: {{.Start}}"),
+		TextBody: model.NewLocalizedText("{{.DurationMinutes}} 分", "{{.DurationMinutes}} minutes"),
+	})
+	require.NoError(t, err)
+	require.True(t, updated.Customized)
+
+	preview, err := svc.PreviewTemplate(context.Background(), "booking_reminder", "ja", nil)
+	require.NoError(t, err)
+	require.Equal(t, "山田 太郎 さん: 2024年5月3日(金) 09:00 UTC", preview.Subject)
+	require.Equal(t, "30 分", preview.TextBody)
+
+	draft := &model.NotificationTemplateInput{
+		Subject:  model.NewLocalizedText("", "Draft for {{.Name}}"),
+		TextBody: model.NewLocalizedText("", "{{.MeetURL}}"),
+	}
+	_, err = svc.SendTestNotification(context.Background(), "booking_reminder", "", draft, "")
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+
+	sent, err := svc.SendTestNotification(context.Background(), "booking_reminder", "", draft, "owner@example.com")
+	require.NoError(t, err)
+	require.Equal(t, model.LocaleEn, sent.Locale)
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, []string{"owner@example.com"}, mailer.sent[0].To)
+	require.Equal(t, "[Test] Draft for Taro Yamada", mailer.sent[0].Subject)
+	require.Equal(t, "https://meet.google.com/abc-defg-hij", mailer.sent[0].Body)
+
+	reset, err := svc.ResetTemplate(context.Background(), "booking_reminder")
+	require.NoError(t, err)
+	require.False(t, reset.Customized)
+	listed, err := svc.ListTemplates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, listed, len(builtinNotificationTemplates))
+	for _, template := range listed {
+		require.False(t, template.Customized)
+	}
+}
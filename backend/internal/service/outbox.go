@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/mail"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/service/support"
+)
+
+// errOutboxNotReady marks a job that must wait for another job of the same reservation.
+var errOutboxNotReady = errors.New("outbox job is waiting on a prerequisite")
+
+type outboxHandler func(ctx context.Context, reservation *model.MeetingReservation) error
+
+// OutboxDispatcher drives booking outbox jobs: it leases due jobs, performs their calendar and
+// mail side effects, retries failures with exponential backoff, and dead-letters jobs that run
+// out of attempts. Outcomes are reported in meeting_notifications.
+type OutboxDispatcher struct {
+	outbox        repository.BookingOutboxRepository
+	reservations  repository.MeetingReservationRepository
+	notifications repository.MeetingNotificationRepository
+	waitlist      repository.WaitlistRepository
+	templates     *notificationRenderer
+	calendar      calendar.Client
+	mailer        mail.Client
+	cfg           config.BookingConfig
+	timezone      string
+	clock         Clock
+	handlers      map[model.OutboxJobKind]outboxHandler
+}
+
+// NewOutboxDispatcher wires the dispatcher with defaults for any unset outbox settings.
+func NewOutboxDispatcher(
+	outbox repository.BookingOutboxRepository,
+	reservations repository.MeetingReservationRepository,
+	notifications repository.MeetingNotificationRepository,
+	waitlist repository.WaitlistRepository,
+	templates repository.NotificationTemplateRepository,
+	calendarClient calendar.Client,
+	mailer mail.Client,
+	cfg *config.AppConfig,
+) (*OutboxDispatcher, error) {
+	if outbox == nil || reservations == nil || notifications == nil || waitlist == nil || templates == nil || calendarClient == nil || mailer == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "outbox dispatcher: missing dependencies", nil)
+	}
+
+	bookingCfg := cfg.Booking
+	if bookingCfg.OutboxBatchSize <= 0 {
+		bookingCfg.OutboxBatchSize = 20
+	}
+	if bookingCfg.OutboxLease <= 0 {
+		bookingCfg.OutboxLease = 2 * time.Minute
+	}
+	if bookingCfg.OutboxMaxAttempts <= 0 {
+		bookingCfg.OutboxMaxAttempts = 8
+	}
+	if bookingCfg.OutboxInitialBackoff <= 0 {
+		bookingCfg.OutboxInitialBackoff = 30 * time.Second
+	}
+	if bookingCfg.OutboxMaxBackoff < bookingCfg.OutboxInitialBackoff {
+		bookingCfg.OutboxMaxBackoff = time.Hour
+	}
+	if bookingCfg.RequestTimeout <= 0 {
+		bookingCfg.RequestTimeout = 8 * time.Second
+	}
+
+	d := &OutboxDispatcher{
+		outbox:        outbox,
+		reservations:  reservations,
+		notifications: notifications,
+		waitlist:      waitlist,
+		templates:     newNotificationRenderer(templates, bookingCfg),
+		calendar:      calendarClient,
+		mailer:        mailer,
+		cfg:           bookingCfg,
+		timezone:      cfg.Contact.Timezone,
+		clock:         realClock{},
+	}
+	d.handlers = map[model.OutboxJobKind]outboxHandler{
+		model.OutboxJobCreateCalendarEvent: d.createCalendarEvent,
+		model.OutboxJobSendConfirmation:    d.sendConfirmation,
+		model.OutboxJobNotifyOwner:         d.notifyOwner,
+		model.OutboxJobSendRequestReceived: d.sendRequestReceived,
+		model.OutboxJobSendApproval:        d.sendApproval,
+		model.OutboxJobSendDecline:         d.sendDecline,
+		model.OutboxJobExpireApproval:      d.expireApproval,
+		model.OutboxJobOfferWaitlist:       d.offerWaitlist,
+		model.OutboxJobDeleteCalendarEvent: d.deleteCalendarEvent,
+	}
+	return d, nil
+}
+
+// Run dispatches due jobs every poll interval until ctx is cancelled.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	interval := d.cfg.OutboxPollInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("booking outbox: dispatch failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue processes one batch of due jobs and reports how many were claimed.
+func (d *OutboxDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	jobs, err := d.outbox.ClaimDueJobs(ctx, d.clock.Now(), d.cfg.OutboxBatchSize, d.cfg.OutboxLease)
+	if err != nil {
+		return 0, fmt.Errorf("claim outbox jobs: %w", err)
+	}
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			// Unsettled jobs become due again once their lease expires.
+			break
+		}
+		if err := d.process(ctx, job); err != nil {
+			log.Printf("booking outbox: settle job %d (%s): %v", job.ID, job.Kind, err)
+		}
+	}
+	return len(jobs), nil
+}
+
+func (d *OutboxDispatcher) process(ctx context.Context, job model.OutboxJob) error {
+	handler, ok := d.handlers[job.Kind]
+	if !ok {
+		return d.outbox.DeadLetterJob(ctx, job.ID, fmt.Sprintf("unknown job kind %q", job.Kind))
+	}
+
+	reservation, err := d.reservations.FindReservationByID(ctx, job.ReservationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return d.outbox.DeadLetterJob(ctx, job.ID, "reservation no longer exists")
+		}
+		return d.retry(ctx, job, err)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, d.cfg.RequestTimeout)
+	err = handler(callCtx, reservation)
+	cancel()
+
+	switch {
+	case err == nil:
+		if settleErr := d.outbox.CompleteJob(ctx, job.ID, d.clock.Now()); settleErr != nil {
+			return settleErr
+		}
+		d.record(ctx, job, "sent", "")
+		return nil
+	case errors.Is(err, errOutboxNotReady):
+		return d.outbox.DeferJob(ctx, job.ID, d.clock.Now().Add(d.cfg.OutboxInitialBackoff))
+	default:
+		return d.retry(ctx, job, err)
+	}
+}
+
+func (d *OutboxDispatcher) retry(ctx context.Context, job model.OutboxJob, cause error) error {
+	maxAttempts := job.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = d.cfg.OutboxMaxAttempts
+	}
+	if job.Attempts+1 >= maxAttempts || !isRetryable(cause) {
+		if err := d.outbox.DeadLetterJob(ctx, job.ID, cause.Error()); err != nil {
+			return err
+		}
+		d.record(ctx, job, "failed", cause.Error())
+		return nil
+	}
+	return d.outbox.RetryJob(ctx, job.ID, d.clock.Now().Add(d.backoff(job.Attempts)), cause.Error())
+}
+
+// backoff doubles the initial delay for every attempt already made, up to the configured cap.
+func (d *OutboxDispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.OutboxInitialBackoff
+	for i := 0; i < attempts && delay < d.cfg.OutboxMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.OutboxMaxBackoff {
+		delay = d.cfg.OutboxMaxBackoff
+	}
+	return delay
+}
+
+func (d *OutboxDispatcher) record(ctx context.Context, job model.OutboxJob, status, message string) {
+	if _, err := d.notifications.RecordNotification(ctx, &model.MeetingNotification{
+		ReservationID: job.ReservationID,
+		Type:          outboxNotificationType(job.Kind),
+		Status:        status,
+		ErrorMessage:  message,
+	}); err != nil {
+		log.Printf("booking outbox: record notification for job %d: %v", job.ID, err)
+	}
+}
+
+func (d *OutboxDispatcher) createCalendarEvent(ctx context.Context, reservation *model.MeetingReservation) error {
+	if !reservation.Status.IsActive() || strings.TrimSpace(reservation.GoogleEventID) != "" {
+		return nil
+	}
+
+	loc := d.location()
+	input := support.CalendarEventInput(d.cfg, reservation, reservation.StartAt.In(loc), reservation.EndAt.In(loc))
+	event, err := d.calendar.CreateEvent(ctx, d.cfg.CalendarID, input)
+	if err != nil {
+		return err
+	}
+	if err := d.outbox.AttachCalendarEvent(ctx, reservation.ID, event.ID); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			// Another event was linked first; drop ours so the slot keeps a single event.
+			return d.dropCalendarEvent(ctx, event.ID)
+		}
+		return err
+	}
+
+	// The reservation may have been cancelled or moved while the event was being created.
+	current, err := d.reservations.FindReservationByID(ctx, reservation.ID)
+	if err != nil {
+		return err
+	}
+	if current.Status.IsCancelled() {
+		return d.dropCalendarEvent(ctx, event.ID)
+	}
+	if !current.StartAt.Equal(reservation.StartAt) || !current.EndAt.Equal(reservation.EndAt) {
+		input = support.CalendarEventInput(d.cfg, current, current.StartAt.In(loc), current.EndAt.In(loc))
+		_, err = d.calendar.UpdateEvent(ctx, d.cfg.CalendarID, event.ID, input)
+		return err
+	}
+	return nil
+}
+
+// deleteCalendarEvent removes the event of a cancelled reservation. The reservation is read when
+// the job runs, so an event attached after the cancellation is removed as well.
+func (d *OutboxDispatcher) deleteCalendarEvent(ctx context.Context, reservation *model.MeetingReservation) error {
+	eventID := strings.TrimSpace(reservation.GoogleEventID)
+	if !reservation.Status.IsCancelled() || eventID == "" {
+		return nil
+	}
+	return d.dropCalendarEvent(ctx, eventID)
+}
+
+// dropCalendarEvent deletes an event, treating one that is already gone as deleted.
+func (d *OutboxDispatcher) dropCalendarEvent(ctx context.Context, eventID string) error {
+	err := d.calendar.DeleteEvent(ctx, d.cfg.CalendarID, eventID)
+	if err != nil && !errors.Is(err, calendar.ErrEventNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (d *OutboxDispatcher) sendConfirmation(ctx context.Context, reservation *model.MeetingReservation) error {
+	if !reservation.Status.IsActive() {
+		return nil
+	}
+
+	meetURL, err := d.meetingLink(ctx, reservation)
+	if err != nil {
+		return err
+	}
+
+	locale := d.templates.locale(reservation.Locale)
+	data := reservationNotificationData(reservation, locale, d.location())
+	data.MeetURL = meetURL
+	message, err := d.templates.compose(ctx, model.NotificationTemplateBookingConfirmation, locale, data)
+	if err != nil {
+		return err
+	}
+	message.From = d.cfg.NotificationSender
+	message.To = []string{reservation.Email}
+	message.Attachments = []mail.Attachment{
+		meetingInvite(ics.MethodRequest, reservation, d.cfg, meetURL, d.clock.Now()),
+	}
+	if err := d.mailer.Send(ctx, message); err != nil {
+		return err
+	}
+
+	updated, err := d.reservations.MarkConfirmationSent(ctx, reservation.ID, d.clock.Now())
+	if err != nil {
+		return err
+	}
+	// The sent invitation confirms the booking. A reservation the visitor changed meanwhile keeps
+	// its newer status.
+	if updated.Status != model.MeetingReservationStatusRequested {
+		return nil
+	}
+	_, err = d.reservations.TransitionReservationStatus(ctx, &model.MeetingReservationStatusChange{
+		ReservationID: updated.ID,
+		FromStatus:    model.MeetingReservationStatusRequested,
+		ToStatus:      model.MeetingReservationStatusConfirmed,
+		Actor:         model.ReservationActorSystem,
+	})
+	if errors.Is(err, repository.ErrConflict) {
+		return nil
+	}
+	return err
+}
+
+func (d *OutboxDispatcher) notifyOwner(ctx context.Context, reservation *model.MeetingReservation) error {
+	receiver := strings.TrimSpace(d.cfg.NotificationReceiver)
+	if receiver == "" {
+		return nil
+	}
+
+	// The notice goes to the owner, so it follows the configured default language.
+	locale := d.templates.locale("")
+	data := reservationNotificationData(reservation, locale, d.location())
+	if support.AwaitingApproval(reservation) {
+		deadline, err := d.approvalDeadline(ctx, reservation)
+		if err != nil {
+			return err
+		}
+		if !deadline.IsZero() {
+			data.Deadline = formatNotificationTime(deadline.In(d.location()), locale)
+		}
+	}
+	message, err := d.templates.compose(ctx, model.NotificationTemplateOwnerBookingNotice, locale, data)
+	if err != nil {
+		return err
+	}
+	message.From = d.cfg.NotificationSender
+	message.To = []string{receiver}
+	return d.mailer.Send(ctx, message)
+}
+
+// meetingLink waits for the calendar job so the confirmation can carry the meeting link. Once
+// that job has been dead-lettered the confirmation goes out without one.
+func (d *OutboxDispatcher) meetingLink(ctx context.Context, reservation *model.MeetingReservation) (string, error) {
+	eventID := strings.TrimSpace(reservation.GoogleEventID)
+	if eventID == "" {
+		jobs, err := d.outbox.ListJobs(ctx, reservation.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, job := range jobs {
+			if job.Kind == model.OutboxJobCreateCalendarEvent && job.Status != model.OutboxJobStatusDone && job.Status != model.OutboxJobStatusDead {
+				return "", errOutboxNotReady
+			}
+		}
+		return "", nil
+	}
+
+	event, err := d.calendar.GetEvent(ctx, d.cfg.CalendarID, eventID)
+	if err != nil {
+		// The link is a convenience; a failed lookup should not hold back the confirmation.
+		log.Printf("booking outbox: fetch event %s for reservation %d: %v", eventID, reservation.ID, err)
+		return "", nil
+	}
+	if event.HangoutLink != "" {
+		return event.HangoutLink, nil
+	}
+	return event.HTMLLink, nil
+}
+
+func (d *OutboxDispatcher) location() *time.Location {
+	loc, err := time.LoadLocation(d.timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func outboxNotificationType(kind model.OutboxJobKind) string {
+	switch kind {
+	case model.OutboxJobCreateCalendarEvent:
+		return "calendar_invite"
+	case model.OutboxJobSendConfirmation:
+		return "confirmation_email"
+	case model.OutboxJobNotifyOwner:
+		return "owner_notification"
+	case model.OutboxJobSendRequestReceived:
+		return "request_received_email"
+	case model.OutboxJobSendApproval:
+		return "approval_email"
+	case model.OutboxJobSendDecline:
+		return "decline_email"
+	case model.OutboxJobExpireApproval:
+		return "approval_expiry"
+	case model.OutboxJobOfferWaitlist:
+		return "waitlist_offer"
+	case model.OutboxJobDeleteCalendarEvent:
+		return "calendar_cancellation"
+	default:
+		return string(kind)
+	}
+}
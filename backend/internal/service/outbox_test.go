@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func TestOutboxDispatcher_MailFailureBacksOffWithoutFailingBooking(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	notifications := newStubNotificationRepository()
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-1"}}
+	mailer := &stubMailClient{err: errors.New("smtp down")}
+
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{
+			CalendarID:           "primary",
+			OutboxMaxAttempts:    3,
+			OutboxInitialBackoff: time.Minute,
+			OutboxMaxBackoff:     90 * time.Second,
+		},
+	}
+
+	stored, err := outbox.CreateReservationWithJobs(context.Background(), &model.MeetingReservation{
+		LookupHash: "hash",
+		Name:       "Mail User",
+		Email:      "mail@example.com",
+		StartAt:    now.Add(24 * time.Hour),
+		EndAt:      now.Add(25 * time.Hour),
+		Status:     model.MeetingReservationStatusRequested,
+	}, []model.OutboxJob{
+		{Kind: model.OutboxJobCreateCalendarEvent, MaxAttempts: 3, NextAttemptAt: now},
+		{Kind: model.OutboxJobSendConfirmation, MaxAttempts: 3, NextAttemptAt: now},
+	})
+	require.NoError(t, err)
+
+	dispatcher := newTestDispatcher(t, outbox, reservations, notifications, calendarClient, mailer, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "evt-1", reservations.entries[stored.ID].GoogleEventID)
+	confirmation := outbox.jobs[1]
+	require.Equal(t, model.OutboxJobStatusPending, confirmation.Status)
+	require.True(t, confirmation.NextAttemptAt.Equal(now.Add(time.Minute)))
+
+	dispatcher.clock = fixedClock{now: now.Add(time.Minute)}
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	// The second delay doubles to two minutes but is capped at the configured maximum.
+	require.True(t, outbox.jobs[1].NextAttemptAt.Equal(now.Add(time.Minute+90*time.Second)))
+
+	dispatcher.clock = fixedClock{now: now.Add(time.Hour)}
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.OutboxJobStatusDead, outbox.jobs[1].Status)
+	require.Equal(t, 3, outbox.jobs[1].Attempts)
+	require.Equal(t, model.MeetingReservationStatusRequested, reservations.entries[stored.ID].Status)
+
+	last := notifications.recorded[len(notifications.recorded)-1]
+	require.Equal(t, "confirmation_email", last.Type)
+	require.Equal(t, "failed", last.Status)
+	require.Equal(t, "smtp down", last.ErrorMessage)
+}
+
+func TestOutboxDispatcher_SkipsCancelledReservation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-1"}}
+	mailer := &stubMailClient{}
+
+	stored, err := outbox.CreateReservationWithJobs(context.Background(), &model.MeetingReservation{
+		LookupHash: "hash",
+		Email:      "gone@example.com",
+		StartAt:    now.Add(time.Hour),
+		EndAt:      now.Add(2 * time.Hour),
+		Status:     model.MeetingReservationStatusRequested,
+	}, []model.OutboxJob{
+		{Kind: model.OutboxJobCreateCalendarEvent, NextAttemptAt: now},
+		{Kind: model.OutboxJobSendConfirmation, NextAttemptAt: now},
+	})
+	require.NoError(t, err)
+	_, err = reservations.TransitionReservationStatus(context.Background(), &model.MeetingReservationStatusChange{
+		ReservationID: stored.ID,
+		FromStatus:    model.MeetingReservationStatusRequested,
+		ToStatus:      model.MeetingReservationStatusCancelledByVisitor,
+		Actor:         model.ReservationActorVisitor,
+		Reason:        "changed mind",
+	})
+	require.NoError(t, err)
+
+	cfg := &config.AppConfig{Contact: config.ContactConfig{Timezone: "UTC"}}
+	dispatcher := newTestDispatcher(t, outbox, reservations, newStubNotificationRepository(), calendarClient, mailer, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+
+	require.Zero(t, calendarClient.createCalls)
+	require.Empty(t, mailer.sent)
+	for _, job := range outbox.jobs {
+		require.Equal(t, model.OutboxJobStatusDone, job.Status)
+	}
+}
+
+func TestOutboxDispatcher_DropsEventWhenAnotherWasLinked(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	outbox := newStubOutboxRepository(reservations)
+	calendarClient := &stubCalendarClient{event: &calendar.Event{ID: "evt-late"}}
+
+	stored, err := outbox.CreateReservationWithJobs(context.Background(), &model.MeetingReservation{
+		LookupHash: "hash",
+		Email:      "race@example.com",
+		StartAt:    now.Add(time.Hour),
+		EndAt:      now.Add(2 * time.Hour),
+		Status:     model.MeetingReservationStatusRequested,
+	}, []model.OutboxJob{{Kind: model.OutboxJobCreateCalendarEvent, NextAttemptAt: now}})
+	require.NoError(t, err)
+	// Another writer links its event while the job is still creating one.
+	calendarClient.onCreate = func() {
+		reservations.entries[stored.ID].GoogleEventID = "evt-first"
+	}
+
+	cfg := &config.AppConfig{Contact: config.ContactConfig{Timezone: "UTC"}}
+	dispatcher := newTestDispatcher(t, outbox, reservations, newStubNotificationRepository(), calendarClient, &stubMailClient{}, cfg, now)
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "evt-first", reservations.entries[stored.ID].GoogleEventID)
+	require.Equal(t, []string{"evt-late"}, calendarClient.deleted)
+	require.Equal(t, model.OutboxJobStatusDone, outbox.jobs[0].Status)
+}
+
+func newTestDispatcher(
+	t *testing.T,
+	outbox *stubOutboxRepository,
+	reservations *stubReservationRepository,
+	notifications *stubNotificationRepository,
+	calendarClient *stubCalendarClient,
+	mailer *stubMailClient,
+	cfg *config.AppConfig,
+	now time.Time,
+) *OutboxDispatcher {
+	t.Helper()
+	dispatcher, err := NewOutboxDispatcher(outbox, reservations, notifications, inmemory.NewWaitlistRepository(), inmemory.NewNotificationTemplateRepository(), calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	dispatcher.clock = fixedClock{now: now}
+	return dispatcher
+}
+
+type stubOutboxRepository struct {
+	reservations *stubReservationRepository
+	jobs         []model.OutboxJob
+	createErr    error
+}
+
+func newStubOutboxRepository(reservations *stubReservationRepository) *stubOutboxRepository {
+	return &stubOutboxRepository{reservations: reservations}
+}
+
+func (s *stubOutboxRepository) CreateReservationWithJobs(ctx context.Context, reservation *model.MeetingReservation, jobs []model.OutboxJob) (*model.MeetingReservation, error) {
+	if s.createErr != nil {
+		return nil, s.createErr
+	}
+	created, err := s.reservations.CreateReservation(ctx, reservation)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		job.ID = uint64(len(s.jobs) + 1)
+		job.ReservationID = created.ID
+		job.Status = model.OutboxJobStatusPending
+		s.jobs = append(s.jobs, job)
+	}
+	return created, nil
+}
+
+func (s *stubOutboxRepository) EnqueueJobs(_ context.Context, reservationID uint64, jobs []model.OutboxJob) error {
+	if _, ok := s.reservations.entries[reservationID]; !ok {
+		return repository.ErrNotFound
+	}
+	for _, job := range jobs {
+		job.ID = uint64(len(s.jobs) + 1)
+		job.ReservationID = reservationID
+		job.Status = model.OutboxJobStatusPending
+		s.jobs = append(s.jobs, job)
+	}
+	return nil
+}
+
+func (s *stubOutboxRepository) ClaimDueJobs(_ context.Context, now time.Time, limit int, lease time.Duration) ([]model.OutboxJob, error) {
+	var claimed []model.OutboxJob
+	for i := range s.jobs {
+		job := &s.jobs[i]
+		if len(claimed) == limit {
+			break
+		}
+		if job.Status != model.OutboxJobStatusPending && job.Status != model.OutboxJobStatusProcessing {
+			continue
+		}
+		if job.NextAttemptAt.After(now) {
+			continue
+		}
+		job.Status = model.OutboxJobStatusProcessing
+		job.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, *job)
+	}
+	return claimed, nil
+}
+
+func (s *stubOutboxRepository) ListJobs(_ context.Context, reservationID uint64) ([]model.OutboxJob, error) {
+	var result []model.OutboxJob
+	for _, job := range s.jobs {
+		if job.ReservationID == reservationID {
+			result = append(result, job)
+		}
+	}
+	return result, nil
+}
+
+func (s *stubOutboxRepository) CompleteJob(_ context.Context, id uint64, completedAt time.Time) error {
+	return s.update(id, func(job *model.OutboxJob) {
+		job.Status = model.OutboxJobStatusDone
+		job.Attempts++
+		job.CompletedAt = &completedAt
+	})
+}
+
+func (s *stubOutboxRepository) RetryJob(_ context.Context, id uint64, nextAttemptAt time.Time, lastError string) error {
+	return s.update(id, func(job *model.OutboxJob) {
+		job.Status = model.OutboxJobStatusPending
+		job.Attempts++
+		job.NextAttemptAt = nextAttemptAt
+		job.LastError = lastError
+	})
+}
+
+func (s *stubOutboxRepository) DeferJob(_ context.Context, id uint64, nextAttemptAt time.Time) error {
+	return s.update(id, func(job *model.OutboxJob) {
+		job.Status = model.OutboxJobStatusPending
+		job.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (s *stubOutboxRepository) DeadLetterJob(_ context.Context, id uint64, lastError string) error {
+	return s.update(id, func(job *model.OutboxJob) {
+		job.Status = model.OutboxJobStatusDead
+		job.Attempts++
+		job.LastError = lastError
+	})
+}
+
+func (s *stubOutboxRepository) AttachCalendarEvent(_ context.Context, reservationID uint64, eventID string) error {
+	entry, ok := s.reservations.entries[reservationID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if entry.GoogleEventID != "" {
+		return repository.ErrConflict
+	}
+	entry.GoogleEventID = eventID
+	entry.GoogleCalendarStatus = "confirmed"
+	return nil
+}
+
+func (s *stubOutboxRepository) update(id uint64, apply func(job *model.OutboxJob)) error {
+	for i := range s.jobs {
+		if s.jobs[i].ID == id {
+			apply(&s.jobs[i])
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/mail"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+const reminderNotificationType = "reminder_email"
+
+// ReminderScheduler emails visitors ahead of their confirmed meetings. Every send is claimed in
+// meeting_notifications under a dedupe key first, so instances running the scheduler side by
+// side never deliver the same reminder twice.
+type ReminderScheduler struct {
+	reservations  repository.MeetingReservationRepository
+	notifications repository.MeetingNotificationRepository
+	templates     *notificationRenderer
+	calendar      calendar.Client
+	mailer        mail.Client
+	cfg           config.BookingConfig
+	offsets       []time.Duration
+	timezone      string
+	clock         Clock
+}
+
+// NewReminderScheduler wires the scheduler. Non-positive and duplicate offsets are ignored.
+func NewReminderScheduler(
+	reservations repository.MeetingReservationRepository,
+	notifications repository.MeetingNotificationRepository,
+	templates repository.NotificationTemplateRepository,
+	calendarClient calendar.Client,
+	mailer mail.Client,
+	cfg *config.AppConfig,
+) (*ReminderScheduler, error) {
+	if reservations == nil || notifications == nil || templates == nil || calendarClient == nil || mailer == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "reminder scheduler: missing dependencies", nil)
+	}
+
+	seen := make(map[time.Duration]struct{}, len(cfg.Booking.ReminderOffsets))
+	offsets := make([]time.Duration, 0, len(cfg.Booking.ReminderOffsets))
+	for _, offset := range cfg.Booking.ReminderOffsets {
+		if offset <= 0 {
+			continue
+		}
+		if _, ok := seen[offset]; ok {
+			continue
+		}
+		seen[offset] = struct{}{}
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	return &ReminderScheduler{
+		reservations:  reservations,
+		notifications: notifications,
+		templates:     newNotificationRenderer(templates, cfg.Booking),
+		calendar:      calendarClient,
+		mailer:        mailer,
+		cfg:           cfg.Booking,
+		offsets:       offsets,
+		timezone:      cfg.Contact.Timezone,
+		clock:         realClock{},
+	}, nil
+}
+
+// Run sends due reminders every reminder interval until ctx is cancelled.
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	interval := s.cfg.ReminderInterval
+	if interval <= 0 || len(s.offsets) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.SendDueReminders(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("meeting reminders: scan failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDueReminders emails every confirmed reservation whose reminder is due and reports how
+// many reminders were delivered.
+func (s *ReminderScheduler) SendDueReminders(ctx context.Context) (int, error) {
+	if len(s.offsets) == 0 {
+		return 0, nil
+	}
+
+	now := s.clock.Now().UTC()
+	until := now.Add(s.offsets[len(s.offsets)-1])
+	reservations, err := s.reservations.ListReservations(ctx, repository.MeetingReservationListFilter{
+		Status:      []model.MeetingReservationStatus{model.MeetingReservationStatusConfirmed, model.MeetingReservationStatusRescheduled},
+		StartFrom:   &now,
+		StartBefore: &until,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list upcoming reservations: %w", err)
+	}
+
+	sent := 0
+	for i := range reservations {
+		if ctx.Err() != nil {
+			break
+		}
+		reservation := &reservations[i]
+		offset, ok := s.dueOffset(reservation, now)
+		if !ok {
+			continue
+		}
+		delivered, err := s.remind(ctx, reservation, offset)
+		if err != nil {
+			log.Printf("meeting reminders: reservation %d (%s before): %v", reservation.ID, offset, err)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// dueOffset picks the closest reminder whose send time has passed. Reminders whose send time
+// precedes the booking itself are skipped, and when the scheduler falls behind only the most
+// recent reminder goes out.
+func (s *ReminderScheduler) dueOffset(reservation *model.MeetingReservation, now time.Time) (time.Duration, bool) {
+	for _, offset := range s.offsets {
+		sendAt := reservation.StartAt.Add(-offset)
+		if now.Before(sendAt) {
+			continue
+		}
+		if !reservation.CreatedAt.IsZero() && reservation.CreatedAt.After(sendAt) {
+			continue
+		}
+		return offset, true
+	}
+	return 0, false
+}
+
+func (s *ReminderScheduler) remind(ctx context.Context, reservation *model.MeetingReservation, offset time.Duration) (bool, error) {
+	claim, err := s.notifications.ClaimNotification(ctx, &model.MeetingNotification{
+		ReservationID: reservation.ID,
+		Type:          reminderNotificationType,
+		DedupeKey:     reminderDedupeKey(reservation.StartAt, offset),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim reminder: %w", err)
+	}
+
+	reminder, sendErr := reminderMessage(ctx, s.templates, s.cfg, reservation, s.meetingLink(ctx, reservation), s.location())
+	if sendErr == nil {
+		sendErr = s.mailer.Send(ctx, reminder)
+	}
+
+	status, message := "sent", ""
+	if sendErr != nil {
+		status, message = "failed", sendErr.Error()
+	}
+	if _, err := s.notifications.UpdateNotificationStatus(ctx, claim.ID, status, message); err != nil {
+		log.Printf("meeting reminders: record %s for reservation %d: %v", status, reservation.ID, err)
+	}
+	if sendErr != nil {
+		return false, fmt.Errorf("send reminder: %w", sendErr)
+	}
+	return true, nil
+}
+
+func reminderMessage(ctx context.Context, templates *notificationRenderer, cfg config.BookingConfig, reservation *model.MeetingReservation, meetURL string, loc *time.Location) (mail.Message, error) {
+	locale := templates.locale(reservation.Locale)
+	data := reservationNotificationData(reservation, locale, loc)
+	data.MeetURL = meetURL
+	message, err := templates.compose(ctx, model.NotificationTemplateBookingReminder, locale, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+	message.From = cfg.NotificationSender
+	message.To = []string{reservation.Email}
+	return message, nil
+}
+
+func (s *ReminderScheduler) meetingLink(ctx context.Context, reservation *model.MeetingReservation) string {
+	eventID := strings.TrimSpace(reservation.GoogleEventID)
+	if eventID == "" {
+		return ""
+	}
+	event, err := s.calendar.GetEvent(ctx, s.cfg.CalendarID, eventID)
+	if err != nil {
+		log.Printf("meeting reminders: fetch event %s for reservation %d: %v", eventID, reservation.ID, err)
+		return ""
+	}
+	if event.HangoutLink != "" {
+		return event.HangoutLink
+	}
+	return event.HTMLLink
+}
+
+func (s *ReminderScheduler) location() *time.Location {
+	loc, err := time.LoadLocation(s.timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// reminderDedupeKey includes the start time so a rescheduled meeting is reminded again.
+func reminderDedupeKey(start time.Time, offset time.Duration) string {
+	return fmt.Sprintf("reminder:%dm:%d", int64(offset/time.Minute), start.UTC().Unix())
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func TestReminderScheduler_SendsEachOffsetOnceAcrossInstances(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	notifications := inmemory.NewMeetingNotificationRepository()
+	mailer := &stubMailClient{}
+
+	confirmed, err := reservations.CreateReservation(context.Background(), &model.MeetingReservation{
+		LookupHash: "confirmed",
+		Name:       "Reminder User",
+		Email:      "reminder@example.com",
+		StartAt:    now.Add(23 * time.Hour),
+		EndAt:      now.Add(24 * time.Hour),
+		Status:     model.MeetingReservationStatusConfirmed,
+		CreatedAt:  now.Add(-48 * time.Hour),
+	})
+	require.NoError(t, err)
+	_, err = reservations.CreateReservation(context.Background(), &model.MeetingReservation{
+		LookupHash: "pending",
+		Email:      "pending@example.com",
+		StartAt:    now.Add(2 * time.Hour),
+		EndAt:      now.Add(3 * time.Hour),
+		Status:     model.MeetingReservationStatusRequested,
+		CreatedAt:  now.Add(-48 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	first := newTestReminderScheduler(t, reservations, notifications, mailer, now)
+	second := newTestReminderScheduler(t, reservations, notifications, mailer, now)
+
+	sent, err := first.SendDueReminders(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+	sent, err = second.SendDueReminders(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, []string{"reminder@example.com"}, mailer.sent[0].To)
+
+	// Twenty-two hours later the one-hour reminder becomes due exactly once more.
+	first.clock = fixedClock{now: now.Add(22*time.Hour + 30*time.Minute)}
+	second.clock = first.clock
+	_, err = first.SendDueReminders(context.Background())
+	require.NoError(t, err)
+	_, err = second.SendDueReminders(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mailer.sent, 2)
+
+	recorded, err := notifications.ListNotifications(context.Background(), confirmed.ID)
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	for _, notification := range recorded {
+		require.Equal(t, "reminder_email", notification.Type)
+		require.Equal(t, "sent", notification.Status)
+	}
+	require.NotEqual(t, recorded[0].DedupeKey, recorded[1].DedupeKey)
+}
+
+func TestReminderScheduler_SkipsRemindersBeforeBookingAndRecordsFailures(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	notifications := newStubNotificationRepository()
+	mailer := &stubMailClient{err: errors.New("smtp down")}
+
+	// Booked five hours ahead, so the 24h reminder never applies but the 1h one does.
+	late, err := reservations.CreateReservation(context.Background(), &model.MeetingReservation{
+		LookupHash: "late",
+		Email:      "late@example.com",
+		StartAt:    now.Add(5 * time.Hour),
+		EndAt:      now.Add(6 * time.Hour),
+		Status:     model.MeetingReservationStatusConfirmed,
+		CreatedAt:  now,
+	})
+	require.NoError(t, err)
+
+	scheduler := newTestReminderScheduler(t, reservations, notifications, mailer, now)
+	sent, err := scheduler.SendDueReminders(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	require.Empty(t, notifications.recorded)
+
+	scheduler.clock = fixedClock{now: late.StartAt.Add(-30 * time.Minute)}
+	sent, err = scheduler.SendDueReminders(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	require.Len(t, notifications.recorded, 1)
+	require.Equal(t, "failed", notifications.recorded[0].Status)
+	require.Equal(t, "smtp down", notifications.recorded[0].ErrorMessage)
+}
+
+func newTestReminderScheduler(
+	t *testing.T,
+	reservations *stubReservationRepository,
+	notifications repository.MeetingNotificationRepository,
+	mailer *stubMailClient,
+	now time.Time,
+) *ReminderScheduler {
+	t.Helper()
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{ReminderOffsets: []time.Duration{time.Hour, 24 * time.Hour}},
+	}
+	scheduler, err := NewReminderScheduler(reservations, notifications, inmemory.NewNotificationTemplateRepository(), &stubCalendarClient{}, mailer, cfg)
+	require.NoError(t, err)
+	scheduler.clock = fixedClock{now: now}
+	return scheduler
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+// SpamSubmission is what the spam filter sees of a contact message or booking.
+type SpamSubmission struct {
+	Source        string
+	Name          string
+	Email         string
+	Message       string
+	Website       string
+	FormStartedAt *time.Time
+	// ContentHash is filled in by the filter before the rules run.
+	ContentHash string
+}
+
+// SpamRule scores one aspect of a submission. Evaluate returns nil when the rule has nothing to
+// say about it.
+type SpamRule interface {
+	Evaluate(ctx context.Context, submission *SpamSubmission, now time.Time) (*model.SpamSignal, error)
+}
+
+// SpamFilter runs submissions through its rules and adds up their scores. A rule that fails is
+// logged and skipped so an unavailable store never blocks a legitimate visitor. A nil filter
+// scores everything zero.
+type SpamFilter struct {
+	rules     []SpamRule
+	repo      repository.SpamRepository
+	contacts  repository.ContactRepository
+	threshold float64
+	clock     Clock
+}
+
+// NewSpamFilter builds the default pipeline: honeypot, submission time, link count, disposable
+// domain, repeated content, blacklist, and earlier admin verdicts. Booking submissions held as
+// spam are stored through contacts. It returns a nil filter when spam filtering is disabled.
+func NewSpamFilter(
+	repo repository.SpamRepository,
+	blacklist repository.BlacklistRepository,
+	contacts repository.ContactRepository,
+	cfg *config.AppConfig,
+) (*SpamFilter, error) {
+	if repo == nil || blacklist == nil || contacts == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "spam filter: missing dependencies", nil)
+	}
+	spamCfg := cfg.Contact.Spam
+	if !spamCfg.Enabled {
+		return nil, nil
+	}
+	if spamCfg.Threshold <= 0 {
+		spamCfg.Threshold = 1
+	}
+	if spamCfg.DuplicateLimit <= 0 {
+		spamCfg.DuplicateLimit = 1
+	}
+
+	return &SpamFilter{
+		rules: []SpamRule{
+			honeypotRule{},
+			submitTimeRule{min: spamCfg.MinSubmitTime},
+			linkCountRule{max: spamCfg.MaxLinks},
+			newDisposableDomainRule(spamCfg.DisposableDomains),
+			repeatedContentRule{repo: repo, window: spamCfg.DuplicateWindow, limit: spamCfg.DuplicateLimit},
+			blacklistRule{blacklist: blacklist},
+			feedbackRule{repo: repo},
+		},
+		repo:      repo,
+		contacts:  contacts,
+		threshold: spamCfg.Threshold,
+		clock:     realClock{},
+	}, nil
+}
+
+// Assess scores a submission. Callers pass it to Record once the submission is stored.
+func (f *SpamFilter) Assess(ctx context.Context, submission *SpamSubmission) *model.SpamAssessment {
+	assessment := &model.SpamAssessment{}
+	if f == nil || submission == nil {
+		return assessment
+	}
+
+	now := f.clock.Now().UTC()
+	submission.ContentHash = model.SpamContentHash(submission.Message)
+	assessment.ContentHash = submission.ContentHash
+	for _, rule := range f.rules {
+		signal, err := rule.Evaluate(ctx, submission, now)
+		if err != nil {
+			log.Printf("spam filter: %T: %v", rule, err)
+			continue
+		}
+		if signal == nil {
+			continue
+		}
+		assessment.Score += signal.Score
+		assessment.Signals = append(assessment.Signals, *signal)
+	}
+	assessment.Spam = assessment.Score >= f.threshold
+	return assessment
+}
+
+// Record remembers the content of a stored submission so repeats can be counted. Submissions
+// that were rejected or failed to save are not recorded, so they never count against a retry.
+func (f *SpamFilter) Record(ctx context.Context, submission *SpamSubmission) {
+	if f == nil || submission == nil || submission.ContentHash == "" {
+		return
+	}
+	if err := f.repo.RecordSpamFingerprint(ctx, &model.SpamFingerprint{
+		ContentHash: submission.ContentHash,
+		Email:       submission.Email,
+		Source:      submission.Source,
+		CreatedAt:   f.clock.Now().UTC(),
+	}); err != nil {
+		log.Printf("spam filter: record fingerprint: %v", err)
+	}
+}
+
+// Hold stores a submission the filter rejected as a spam contact message, so an admin can
+// still review it and mark it ham.
+func (f *SpamFilter) Hold(ctx context.Context, req *model.ContactRequest) error {
+	if f == nil || req == nil {
+		return nil
+	}
+	req.Status = model.ContactStatusSpam
+	_, err := f.contacts.CreateSubmission(ctx, req)
+	return err
+}
+
+// honeypotRule flags forms whose hidden website field was filled in; people never see it.
+type honeypotRule struct{}
+
+func (honeypotRule) Evaluate(_ context.Context, submission *SpamSubmission, _ time.Time) (*model.SpamSignal, error) {
+	if strings.TrimSpace(submission.Website) == "" {
+		return nil, nil
+	}
+	return &model.SpamSignal{Rule: "honeypot", Score: 1, Detail: "hidden website field was filled in"}, nil
+}
+
+// submitTimeRule flags forms sent faster than a person could fill them in. Clients that do not
+// report when the form opened are not scored.
+type submitTimeRule struct {
+	min time.Duration
+}
+
+func (r submitTimeRule) Evaluate(_ context.Context, submission *SpamSubmission, now time.Time) (*model.SpamSignal, error) {
+	if r.min <= 0 || submission.FormStartedAt == nil {
+		return nil, nil
+	}
+	elapsed := now.Sub(*submission.FormStartedAt)
+	if elapsed < 0 || elapsed >= r.min {
+		return nil, nil
+	}
+	return &model.SpamSignal{Rule: "submit_time", Score: 0.6, Detail: fmt.Sprintf("sent %s after the form opened", elapsed.Round(time.Millisecond))}, nil
+}
+
+// linkCountRule flags messages stuffed with links.
+type linkCountRule struct {
+	max int
+}
+
+func (r linkCountRule) Evaluate(_ context.Context, submission *SpamSubmission, _ time.Time) (*model.SpamSignal, error) {
+	if r.max < 0 {
+		return nil, nil
+	}
+	message := strings.ToLower(submission.Message)
+	links := strings.Count(message, "http://") + strings.Count(message, "https://")
+	if links <= r.max {
+		return nil, nil
+	}
+	return &model.SpamSignal{Rule: "links", Score: 0.5, Detail: fmt.Sprintf("%d links", links)}, nil
+}
+
+// disposableDomainRule flags addresses at throwaway mailbox providers and their subdomains.
+type disposableDomainRule struct {
+	domains map[string]struct{}
+}
+
+func newDisposableDomainRule(domains []string) disposableDomainRule {
+	rule := disposableDomainRule{domains: make(map[string]struct{}, len(domains))}
+	for _, domain := range domains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			rule.domains[domain] = struct{}{}
+		}
+	}
+	return rule
+}
+
+func (r disposableDomainRule) Evaluate(_ context.Context, submission *SpamSubmission, _ time.Time) (*model.SpamSignal, error) {
+	at := strings.LastIndex(submission.Email, "@")
+	if at < 0 {
+		return nil, nil
+	}
+	domain := strings.ToLower(strings.TrimSpace(submission.Email[at+1:]))
+	for domain != "" {
+		if _, ok := r.domains[domain]; ok {
+			return &model.SpamSignal{Rule: "disposable_domain", Score: 0.5, Detail: domain}, nil
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return nil, nil
+}
+
+// repeatedContentRule flags a message that was already sent at least limit times within the
+// window, whoever sent it.
+type repeatedContentRule struct {
+	repo   repository.SpamRepository
+	window time.Duration
+	limit  int
+}
+
+func (r repeatedContentRule) Evaluate(ctx context.Context, submission *SpamSubmission, now time.Time) (*model.SpamSignal, error) {
+	if r.window <= 0 || submission.ContentHash == "" {
+		return nil, nil
+	}
+	count, err := r.repo.CountSpamFingerprints(ctx, submission.ContentHash, now.Add(-r.window))
+	if err != nil {
+		return nil, err
+	}
+	if count < r.limit {
+		return nil, nil
+	}
+	return &model.SpamSignal{Rule: "repeated_content", Score: 0.6, Detail: fmt.Sprintf("same message sent %d times in %s", count, r.window)}, nil
+}
+
+// blacklistRule flags senders on the booking blacklist.
+type blacklistRule struct {
+	blacklist repository.BlacklistRepository
+}
+
+func (r blacklistRule) Evaluate(ctx context.Context, submission *SpamSubmission, _ time.Time) (*model.SpamSignal, error) {
+	email := strings.TrimSpace(submission.Email)
+	if email == "" {
+		return nil, nil
+	}
+	if _, err := r.blacklist.FindBlacklistEntryByEmail(ctx, email); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &model.SpamSignal{Rule: "blacklist", Score: 1, Detail: "sender is blacklisted"}, nil
+}
+
+// feedbackRule applies what admins taught the filter. The newest verdict on the same message
+// text counts most; the newest verdict on the sender adds or removes a smaller amount.
+type feedbackRule struct {
+	repo repository.SpamRepository
+}
+
+func (r feedbackRule) Evaluate(ctx context.Context, submission *SpamSubmission, _ time.Time) (*model.SpamSignal, error) {
+	feedback, err := r.repo.ListSpamFeedback(ctx, submission.ContentHash, submission.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var content, sender *model.SpamFeedback
+	for i := range feedback {
+		entry := &feedback[i]
+		if content == nil && submission.ContentHash != "" && entry.ContentHash == submission.ContentHash {
+			content = entry
+		}
+		if sender == nil && strings.EqualFold(entry.Email, strings.TrimSpace(submission.Email)) {
+			sender = entry
+		}
+	}
+
+	var score float64
+	var details []string
+	if content != nil {
+		if content.Verdict == model.SpamVerdictSpam {
+			score += 1
+		} else {
+			score -= 0.5
+		}
+		details = append(details, fmt.Sprintf("message marked %s", content.Verdict))
+	}
+	if sender != nil {
+		if sender.Verdict == model.SpamVerdictSpam {
+			score += 0.5
+		} else {
+			score -= 0.5
+		}
+		details = append(details, fmt.Sprintf("sender marked %s", sender.Verdict))
+	}
+	if len(details) == 0 {
+		return nil, nil
+	}
+	return &model.SpamSignal{Rule: "feedback", Score: score, Detail: strings.Join(details, ", ")}, nil
+}
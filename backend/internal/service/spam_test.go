@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+func newTestSpamFilter(t *testing.T, repo repository.SpamRepository, contacts repository.ContactRepository, now time.Time) *SpamFilter {
+	t.Helper()
+
+	blacklist := &stubBlacklistRepository{blocked: map[string]bool{"blocked@example.com": true}}
+	cfg := &config.AppConfig{Contact: config.ContactConfig{Spam: config.SpamConfig{
+		Enabled:           true,
+		Threshold:         1,
+		MinSubmitTime:     3 * time.Second,
+		MaxLinks:          2,
+		DisposableDomains: []string{"mailinator.com"},
+		DuplicateWindow:   time.Hour,
+		DuplicateLimit:    2,
+	}}}
+	filter, err := NewSpamFilter(repo, blacklist, contacts, cfg)
+	require.NoError(t, err)
+	filter.clock = fixedClock{now: now}
+	return filter
+}
+
+func spamRules(assessment *model.SpamAssessment) []string {
+	rules := make([]string, 0, len(assessment.Signals))
+	for _, signal := range assessment.Signals {
+		rules = append(rules, signal.Rule)
+	}
+	return rules
+}
+
+func TestSpamFilter_ScoresSubmissions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	filter := newTestSpamFilter(t, inmemory.NewSpamRepository(), inmemory.NewContactRepository(), now)
+	// assess scores a submission and records it as stored.
+	assess := func(submission SpamSubmission) *model.SpamAssessment {
+		submission.Source = model.SpamSourceContact
+		assessment := filter.Assess(context.Background(), &submission)
+		filter.Record(context.Background(), &submission)
+		return assessment
+	}
+
+	clean := assess(SpamSubmission{Email: "visitor@example.com", Message: "Could we talk about your research?"})
+	require.False(t, clean.Spam)
+	require.Empty(t, clean.Signals)
+
+	honeypot := assess(SpamSubmission{Email: "visitor@example.com", Message: "Hello", Website: "https://spam.example"})
+	require.True(t, honeypot.Spam)
+	require.Equal(t, []string{"honeypot"}, spamRules(honeypot))
+
+	// A throwaway address alone is not enough; combined with a form filled in too fast it is.
+	disposable := assess(SpamSubmission{Email: "someone@inbox.mailinator.com", Message: "Hi there"})
+	require.False(t, disposable.Spam)
+	require.Equal(t, []string{"disposable_domain"}, spamRules(disposable))
+	started := now.Add(-time.Second)
+	rushed := assess(SpamSubmission{Email: "someone@mailinator.com", Message: "Hi again", FormStartedAt: &started})
+	require.True(t, rushed.Spam)
+	require.Equal(t, []string{"submit_time", "disposable_domain"}, spamRules(rushed))
+
+	links := assess(SpamSubmission{Email: "visitor@example.com", Message: strings.Repeat("see https://example.com ", 3)})
+	require.Equal(t, []string{"links"}, spamRules(links))
+
+	blocked := assess(SpamSubmission{Email: "blocked@example.com", Message: "Let me book"})
+	require.True(t, blocked.Spam)
+	require.Equal(t, []string{"blacklist"}, spamRules(blocked))
+
+	// Submissions that were only scored, never stored, do not count as repeats.
+	for i := 0; i < 3; i++ {
+		filter.Assess(context.Background(), &SpamSubmission{Source: model.SpamSourceContact, Email: "a@example.com", Message: "Buy cheap followers now"})
+	}
+
+	// The same text from different senders is flagged once it was already sent twice,
+	// whatever its case and spacing.
+	for i, message := range []string{"Buy cheap followers now", "buy  cheap followers NOW"} {
+		require.Empty(t, assess(SpamSubmission{Email: "a@example.com", Message: message}).Signals, "submission %d", i)
+	}
+	repeated := assess(SpamSubmission{Email: "b@example.com", Message: "Buy cheap followers now"})
+	require.Equal(t, []string{"repeated_content"}, spamRules(repeated))
+}
+
+func TestSpamFilter_LearnsFromVerdicts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	repo := inmemory.NewSpamRepository()
+	filter := newTestSpamFilter(t, repo, inmemory.NewContactRepository(), now)
+	ctx := context.Background()
+
+	_, err := repo.RecordSpamFeedback(ctx, &model.SpamFeedback{ContentHash: model.SpamContentHash("Win a prize"), Email: "spammer@example.com", Verdict: model.SpamVerdictSpam})
+	require.NoError(t, err)
+	_, err = repo.RecordSpamFeedback(ctx, &model.SpamFeedback{Email: "friend@mailinator.com", Verdict: model.SpamVerdictHam})
+	require.NoError(t, err)
+
+	known := filter.Assess(ctx, &SpamSubmission{Email: "other@example.com", Message: "WIN a prize"})
+	require.True(t, known.Spam)
+	require.Equal(t, "message marked spam", known.Signals[0].Detail)
+
+	// A sender released as ham offsets the disposable-domain rule.
+	trusted := filter.Assess(ctx, &SpamSubmission{Email: "friend@mailinator.com", Message: "Hello again"})
+	require.False(t, trusted.Spam)
+	require.Equal(t, []string{"disposable_domain", "feedback"}, spamRules(trusted))
+	require.Zero(t, trusted.Score)
+}
+
+func TestContactService_HoldsSpamWithoutNotifying(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	contacts := inmemory.NewContactRepository()
+	notifications := inmemory.NewContactNotificationRepository()
+	cfg := &config.AppConfig{Contact: config.ContactConfig{SupportEmail: "owner@example.com", OwnerAlert: true, AutoReply: true}}
+	filter := newTestSpamFilter(t, inmemory.NewSpamRepository(), contacts, now)
+	svc := NewContactService(contacts, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), filter, notifications, cfg)
+
+	req := contactRequest("en")
+	req.Website = "https://spam.example"
+	submission, err := svc.SubmitContact(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, string(model.ContactStatusPending), submission.Status)
+
+	stored, err := contacts.(repository.AdminContactRepository).GetContactMessage(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.ContactStatusSpam, stored.Status)
+	require.Equal(t, 1.0, stored.SpamScore)
+	require.Equal(t, "honeypot", stored.SpamSignals[0].Rule)
+
+	recorded, err := notifications.ListContactNotifications(context.Background(), submission.ID)
+	require.NoError(t, err)
+	require.Empty(t, recorded)
+}
+
+func TestBookingService_HoldsSpamBookings(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	reservations := newStubReservationRepository()
+	contacts := inmemory.NewContactRepository()
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary"},
+	}
+	filter := newTestSpamFilter(t, inmemory.NewSpamRepository(), contacts, now)
+
+	svc, err := NewBookingService(reservations, newStubNotificationRepository(), inmemory.NewNotificationTemplateRepository(), newStubOutboxRepository(reservations), &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), filter, &stubCalendarClient{event: &calendar.Event{ID: "evt-1"}}, &stubMailClient{}, cfg)
+	require.NoError(t, err)
+	svc.(*bookingService).clock = fixedClock{now: now}
+
+	started := now.Add(-500 * time.Millisecond)
+	result, err := svc.Book(context.Background(), model.BookingRequest{
+		Name:            "Bot",
+		Email:           "bot@mailinator.com",
+		StartTime:       now.Add(2 * time.Hour),
+		DurationMinutes: 30,
+		Agenda:          "Great offer",
+		RecaptchaToken:  "test-token",
+		FormStartedAt:   &started,
+	})
+	// The sender gets the usual result and is not told the request was held.
+	require.NoError(t, err)
+	require.Equal(t, model.MeetingReservationStatusRequested, result.Reservation.Status)
+	require.True(t, result.Reservation.StartAt.Equal(now.Add(2*time.Hour)))
+	require.Empty(t, reservations.created)
+
+	held, err := contacts.(repository.AdminContactRepository).ListContactMessages(context.Background(), repository.ContactMessageListFilter{})
+	require.NoError(t, err)
+	var spam []model.ContactMessage
+	for _, message := range held {
+		if message.Status == model.ContactStatusSpam {
+			spam = append(spam, message)
+		}
+	}
+	require.Len(t, spam, 1)
+	require.Equal(t, "bot@mailinator.com", spam[0].Email)
+	require.Equal(t, "Great offer", spam[0].Message)
+	require.Contains(t, spam[0].AdminNote, "Booking request for 2024-05-01T11:00:00Z (30 minutes)")
+}
@@ -0,0 +1,110 @@
+package support
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/calendar"
+	"github.com/takumi/personal-website/internal/calendar/ics"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/model"
+)
+
+// AwaitingApproval reports whether a reservation is a request still waiting for the administrator.
+func AwaitingApproval(reservation *model.MeetingReservation) bool {
+	return reservation.RequiresApproval && reservation.Status == model.MeetingReservationStatusRequested
+}
+
+// ReservationSummary titles a reservation in the visitor's locale unless a meeting template is configured.
+func ReservationSummary(cfg config.BookingConfig, name, locale string) string {
+	if template := strings.TrimSpace(cfg.MeetTemplate); template != "" {
+		return fmt.Sprintf("%s - %s", template, name)
+	}
+	if locale == model.LocaleJa {
+		return fmt.Sprintf("%s 様とのご相談", name)
+	}
+	return fmt.Sprintf("Consultation with %s", name)
+}
+
+// CalendarEventSummary marks events of requests awaiting approval as tentative.
+func CalendarEventSummary(cfg config.BookingConfig, reservation *model.MeetingReservation) string {
+	summary := ReservationSummary(cfg, reservation.Name, reservation.Locale)
+	if !AwaitingApproval(reservation) {
+		return summary
+	}
+	if reservation.Locale == model.LocaleJa {
+		return "【承認待ち】" + summary
+	}
+	return "[Pending approval] " + summary
+}
+
+// CalendarEventDescription lists the attendee, agenda and intake answers of a reservation.
+func CalendarEventDescription(reservation *model.MeetingReservation) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Meeting with %s (%s)\n", reservation.Name, reservation.Email))
+	if agenda := strings.TrimSpace(reservation.Message); agenda != "" {
+		builder.WriteString("\nAgenda:\n")
+		builder.WriteString(agenda)
+		builder.WriteString("\n")
+	}
+	WriteIntakeAnswers(&builder, reservation.IntakeAnswers)
+	return builder.String()
+}
+
+// CalendarEventInput builds the Google Calendar event of a reservation held from start to end.
+// A tentative hold only blocks the owner's calendar; the visitor is invited on approval.
+func CalendarEventInput(cfg config.BookingConfig, reservation *model.MeetingReservation, start, end time.Time) calendar.EventInput {
+	input := calendar.EventInput{
+		Summary:     CalendarEventSummary(cfg, reservation),
+		Description: CalendarEventDescription(reservation),
+		Start:       start,
+		End:         end,
+		Attendees:   []string{reservation.Email},
+	}
+	if AwaitingApproval(reservation) {
+		input.Attendees = nil
+	}
+	return input
+}
+
+// ReservationEvent renders a reservation as an iCalendar event for invites, the private feed and
+// exports. Every copy shares the reservation's UID so calendar apps keep one event per meeting,
+// and the sequence follows the last update so later copies supersede earlier ones.
+func ReservationEvent(cfg config.BookingConfig, reservation *model.MeetingReservation, stamp time.Time) ics.Event {
+	status := ics.StatusConfirmed
+	switch {
+	case reservation.Status.IsCancelled():
+		status = ics.StatusCancelled
+	case reservation.Status == model.MeetingReservationStatusRequested:
+		status = ics.StatusTentative
+	}
+
+	sequence := 0
+	if !reservation.UpdatedAt.IsZero() {
+		sequence = int(reservation.UpdatedAt.Unix())
+	}
+
+	return ics.Event{
+		UID:         reservation.CalendarUID(),
+		Sequence:    sequence,
+		Stamp:       stamp,
+		Start:       reservation.StartAt,
+		End:         reservation.EndAt,
+		Summary:     CalendarEventSummary(cfg, reservation),
+		Description: CalendarEventDescription(reservation),
+		Status:      status,
+		Attendees:   []string{reservation.Email},
+	}
+}
+
+// WriteIntakeAnswers appends the intake answers, one labelled line each.
+func WriteIntakeAnswers(builder *strings.Builder, answers []model.IntakeAnswer) {
+	if len(answers) == 0 {
+		return
+	}
+	builder.WriteString("\nIntake:\n")
+	for _, answer := range answers {
+		builder.WriteString(fmt.Sprintf("%s: %s\n", answer.Label, strings.Join(answer.Values, ", ")))
+	}
+}
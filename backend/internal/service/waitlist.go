@@ -0,0 +1,447 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	mailpkg "net/mail"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+)
+
+// Visitors join the waitlist for fully booked days. Every cancellation queues an offer_waitlist
+// job for the freed slot; the dispatcher emails a claim link to the first waiting entry that
+// prefers the slot's day and re-runs the job when the offer expires, so an unclaimed slot moves
+// down the list until it is claimed, taken by someone else, or in the past.
+
+const (
+	waitlistDateLayout         = "2006-01-02"
+	maxWaitlistPreferredDates  = 14
+	waitlistTokenBytes         = 32
+	defaultWaitlistClaimWindow = 2 * time.Hour
+)
+
+// WaitlistService lets visitors join the waitlist and claim the slots offered to them.
+type WaitlistService interface {
+	Join(ctx context.Context, req model.WaitlistRequest) (*model.WaitlistEntry, error)
+	GetOffer(ctx context.Context, token string) (*model.WaitlistOffer, error)
+	ClaimOffer(ctx context.Context, token string) (*model.BookingResult, error)
+}
+
+type waitlistService struct {
+	waitlist       repository.WaitlistRepository
+	outbox         repository.BookingOutboxRepository
+	blacklist      repository.BlacklistRepository
+	settings       repository.ContactFormSettingsRepository
+	captcha        captcha.Verifier
+	cfg            config.BookingConfig
+	timezone       string
+	clock          Clock
+	supportEmail   string
+	calendarTZName string
+}
+
+// NewWaitlistService wires the waitlist service.
+func NewWaitlistService(
+	waitlist repository.WaitlistRepository,
+	outbox repository.BookingOutboxRepository,
+	blacklist repository.BlacklistRepository,
+	settings repository.ContactFormSettingsRepository,
+	verifier captcha.Verifier,
+	cfg *config.AppConfig,
+) (WaitlistService, error) {
+	if waitlist == nil || outbox == nil || blacklist == nil || settings == nil || verifier == nil || cfg == nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "waitlist service: missing dependencies", nil)
+	}
+
+	bookingCfg := cfg.Booking
+	if bookingCfg.OutboxMaxAttempts <= 0 {
+		bookingCfg.OutboxMaxAttempts = 8
+	}
+
+	return &waitlistService{
+		waitlist:       waitlist,
+		outbox:         outbox,
+		blacklist:      blacklist,
+		settings:       settings,
+		captcha:        verifier,
+		cfg:            bookingCfg,
+		timezone:       cfg.Contact.Timezone,
+		clock:          realClock{},
+		supportEmail:   deriveSupportEmail(cfg),
+		calendarTZName: deriveCalendarTimezone(cfg),
+	}, nil
+}
+
+func (s *waitlistService) Join(ctx context.Context, req model.WaitlistRequest) (*model.WaitlistEntry, error) {
+	name := strings.TrimSpace(req.Name)
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if name == "" {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "name is required", nil)
+	}
+	if email == "" {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "email is required", nil)
+	}
+	if _, err := mailpkg.ParseAddress(email); err != nil {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "email format is invalid", err)
+	}
+
+	loc, err := time.LoadLocation(s.timezone)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "waitlist service: invalid timezone configuration", err)
+	}
+	dates, err := normalizePreferredDates(req.PreferredDates, s.clock.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyHuman(ctx, s.captcha, req.RecaptchaToken, req.RemoteIP, captchaActionBooking); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.blacklist.FindBlacklistEntryByEmail(ctx, email); err == nil {
+		return nil, errs.New(errs.CodeUnauthorized, http.StatusForbidden, "email address is blocked from scheduling", nil)
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to validate blacklist status", err)
+	}
+
+	active, err := s.waitlist.ListEntries(ctx, []model.WaitlistStatus{model.WaitlistStatusWaiting, model.WaitlistStatusOffered})
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load waitlist", err)
+	}
+	for _, entry := range active {
+		if strings.EqualFold(entry.Email, email) {
+			return nil, errs.New(errs.CodeConflict, http.StatusConflict, "email address is already on the waitlist", nil)
+		}
+	}
+
+	created, err := s.waitlist.CreateEntry(ctx, &model.WaitlistEntry{
+		Name:           name,
+		Email:          email,
+		Topic:          strings.TrimSpace(req.Topic),
+		Locale:         model.NormalizeLocale(req.Locale),
+		PreferredDates: dates,
+		Status:         model.WaitlistStatusWaiting,
+	})
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to join waitlist", err)
+	}
+	return created, nil
+}
+
+func (s *waitlistService) GetOffer(ctx context.Context, token string) (*model.WaitlistOffer, error) {
+	entry, err := s.openOffer(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &model.WaitlistOffer{
+		Name:      entry.Name,
+		Topic:     entry.Topic,
+		StartAt:   entry.OfferStartAt.UTC(),
+		EndAt:     entry.OfferEndAt.UTC(),
+		ExpiresAt: entry.OfferExpiresAt.UTC(),
+	}, nil
+}
+
+// ClaimOffer books the offered slot for the waitlisted visitor. The reservation goes through the
+// same outbox jobs as a regular booking, including approval for approval-required topics.
+func (s *waitlistService) ClaimOffer(ctx context.Context, token string) (*model.BookingResult, error) {
+	entry, err := s.openOffer(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.blacklist.FindBlacklistEntryByEmail(ctx, entry.Email); err == nil {
+		return nil, errs.New(errs.CodeUnauthorized, http.StatusForbidden, "email address is blocked from scheduling", nil)
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to validate blacklist status", err)
+	}
+
+	settings, err := loadContactSettings(ctx, s.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	lookupHash, err := generateLookupHash()
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to allocate reservation identifier", err)
+	}
+
+	start := entry.OfferStartAt.UTC()
+	end := entry.OfferEndAt.UTC()
+	reservation := model.MeetingReservation{
+		LookupHash:       lookupHash,
+		Name:             entry.Name,
+		Email:            entry.Email,
+		Topic:            entry.Topic,
+		Locale:           entry.Locale,
+		StartAt:          start,
+		EndAt:            end,
+		DurationMinutes:  int(end.Sub(start) / time.Minute),
+		Status:           model.MeetingReservationStatusRequested,
+		RequiresApproval: topicRequiresApproval(settings, entry.Topic),
+	}
+
+	// Claiming is a compare-and-set on the open offer, so a token used twice at once books once.
+	// Every later change is conditioned on this claim, so the loser can never undo the winner.
+	claimed := *entry
+	claimed.Status = model.WaitlistStatusBooked
+	if _, err := s.waitlist.TransitionEntry(ctx, &claimed, model.WaitlistStatusOffered, entry.OfferTokenHash); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, errs.New(errs.CodeConflict, http.StatusGone, "waitlist offer is no longer available", err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to claim waitlist offer", err)
+	}
+
+	stored, err := s.outbox.CreateReservationWithJobs(ctx, &reservation, bookingJobs(s.cfg, &reservation, s.clock.Now()))
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			// Someone else booked the slot first; the visitor keeps their place on the waitlist.
+			s.releaseClaim(ctx, withdrawnOffer(entry, model.WaitlistStatusWaiting), entry.OfferTokenHash)
+			return nil, errs.New(errs.CodeConflict, http.StatusConflict, "the offered slot is no longer available", err)
+		}
+		// The offer stays open so the visitor can try again before it expires.
+		s.releaseClaim(ctx, entry, entry.OfferTokenHash)
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to persist reservation", err)
+	}
+
+	return &model.BookingResult{
+		Reservation:      *stored,
+		CalendarEventID:  stored.GoogleEventID,
+		SupportEmail:     s.supportEmail,
+		CalendarTimezone: s.calendarTZName,
+	}, nil
+}
+
+// releaseClaim undoes a claim whose reservation could not be stored, moving the entry from booked
+// to the given state only while it is still the claim made with the offer token tokenHash.
+func (s *waitlistService) releaseClaim(ctx context.Context, entry *model.WaitlistEntry, tokenHash string) {
+	if _, err := s.waitlist.TransitionEntry(ctx, entry, model.WaitlistStatusBooked, tokenHash); err != nil {
+		log.Printf("waitlist: release claim of entry %d: %v", entry.ID, err)
+	}
+}
+
+// openOffer resolves a claim token to an entry whose offer is still open.
+func (s *waitlistService) openOffer(ctx context.Context, token string) (*model.WaitlistEntry, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "offer token is required", nil)
+	}
+
+	entry, err := s.waitlist.FindEntryByOfferToken(ctx, hashWaitlistToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errs.New(errs.CodeNotFound, http.StatusNotFound, "waitlist offer not found", err)
+		}
+		return nil, errs.New(errs.CodeInternal, http.StatusInternalServerError, "failed to load waitlist offer", err)
+	}
+	if entry.Status != model.WaitlistStatusOffered || entry.OfferStartAt == nil || entry.OfferEndAt == nil ||
+		entry.OfferExpiresAt == nil || !s.clock.Now().Before(*entry.OfferExpiresAt) {
+		return nil, errs.New(errs.CodeConflict, http.StatusGone, "waitlist offer is no longer available", nil)
+	}
+	return entry, nil
+}
+
+// normalizePreferredDates validates YYYY-MM-DD days, dropping duplicates and rejecting past days.
+func normalizePreferredDates(values []string, today time.Time) ([]string, error) {
+	if len(values) == 0 {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "at least one preferred date is required", nil)
+	}
+
+	todayKey := today.Format(waitlistDateLayout)
+	seen := make(map[string]struct{}, len(values))
+	dates := make([]string, 0, len(values))
+	for _, value := range values {
+		day, err := time.Parse(waitlistDateLayout, strings.TrimSpace(value))
+		if err != nil {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "preferred dates must use the YYYY-MM-DD format", err)
+		}
+		key := day.Format(waitlistDateLayout)
+		if key < todayKey {
+			return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "preferred dates must not be in the past", nil)
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		dates = append(dates, key)
+	}
+	if len(dates) > maxWaitlistPreferredDates {
+		return nil, errs.New(errs.CodeInvalidInput, http.StatusBadRequest, "too many preferred dates", nil)
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+// withdrawnOffer clears the offer of an entry and moves it to the given status.
+func withdrawnOffer(entry *model.WaitlistEntry, status model.WaitlistStatus) *model.WaitlistEntry {
+	withdrawn := *entry
+	withdrawn.Status = status
+	withdrawn.OfferReservationID = 0
+	withdrawn.OfferStartAt = nil
+	withdrawn.OfferEndAt = nil
+	withdrawn.OfferExpiresAt = nil
+	withdrawn.OfferTokenHash = ""
+	return &withdrawn
+}
+
+// waitlistOfferJob queues an offer of a cancelled reservation's slot.
+func waitlistOfferJob(cfg config.BookingConfig, now time.Time) model.OutboxJob {
+	return model.OutboxJob{
+		Kind:          model.OutboxJobOfferWaitlist,
+		MaxAttempts:   cfg.OutboxMaxAttempts,
+		NextAttemptAt: now.UTC(),
+	}
+}
+
+func generateWaitlistToken() (string, error) {
+	raw := make([]byte, waitlistTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashWaitlistToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// waitlistClaimURL appends the claim token to the configured claim page.
+func waitlistClaimURL(base, token string) string {
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(token)
+}
+
+// offerWaitlist offers a cancelled reservation's slot to the next waiting entry. It runs once
+// when the reservation is cancelled and again whenever an offer for the slot expires.
+func (d *OutboxDispatcher) offerWaitlist(ctx context.Context, reservation *model.MeetingReservation) error {
+	if !reservation.Status.IsCancelled() {
+		return nil
+	}
+	now := d.clock.Now()
+
+	current, err := d.waitlist.FindOfferByReservation(ctx, reservation.ID)
+	switch {
+	case err == nil:
+		if current.OfferExpiresAt != nil && now.Before(*current.OfferExpiresAt) {
+			return errOutboxNotReady
+		}
+		lapsed := *current
+		lapsed.Status = model.WaitlistStatusLapsed
+		if _, err := d.waitlist.TransitionEntry(ctx, &lapsed, model.WaitlistStatusOffered, current.OfferTokenHash); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				// A claim raced the expiry; check back once it has booked the slot or been released.
+				return errOutboxNotReady
+			}
+			return err
+		}
+	case !errors.Is(err, repository.ErrNotFound):
+		return err
+	}
+
+	if !reservation.StartAt.After(now) {
+		return nil
+	}
+	claimPage := strings.TrimSpace(d.cfg.WaitlistClaimURL)
+	if claimPage == "" {
+		log.Printf("booking outbox: waitlist_claim_url is not configured; not offering the slot of reservation %d", reservation.ID)
+		return nil
+	}
+	taken, err := d.reservations.ListConflictingReservations(ctx, reservation.StartAt, reservation.EndAt)
+	if err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		return nil
+	}
+
+	entries, err := d.waitlist.ListEntries(ctx, []model.WaitlistStatus{model.WaitlistStatusWaiting})
+	if err != nil {
+		return err
+	}
+	day := reservation.StartAt.In(d.location()).Format(waitlistDateLayout)
+	var next *model.WaitlistEntry
+	for index := range entries {
+		for _, preferred := range entries[index].PreferredDates {
+			if preferred == day {
+				next = &entries[index]
+				break
+			}
+		}
+		if next != nil {
+			break
+		}
+	}
+	if next == nil {
+		return nil
+	}
+
+	window := d.cfg.WaitlistClaimWindow
+	if window <= 0 {
+		window = defaultWaitlistClaimWindow
+	}
+	expiresAt := now.Add(window).UTC()
+	if reservation.StartAt.Before(expiresAt) {
+		expiresAt = reservation.StartAt.UTC()
+	}
+
+	token, err := generateWaitlistToken()
+	if err != nil {
+		return err
+	}
+
+	// The email goes out before the offer is stored so a failed send is retried cleanly.
+	locale := d.templates.locale(next.Locale)
+	loc := d.location()
+	message, err := d.templates.compose(ctx, model.NotificationTemplateWaitlistOffer, locale, notificationData{
+		Name:            next.Name,
+		Email:           next.Email,
+		Topic:           next.Topic,
+		Start:           formatNotificationTime(reservation.StartAt.In(loc), locale),
+		DurationMinutes: int(reservation.EndAt.Sub(reservation.StartAt) / time.Minute),
+		Deadline:        formatNotificationTime(expiresAt.In(loc), locale),
+		ClaimURL:        waitlistClaimURL(claimPage, token),
+	})
+	if err != nil {
+		return err
+	}
+	message.From = d.cfg.NotificationSender
+	message.To = []string{next.Email}
+	if err := d.mailer.Send(ctx, message); err != nil {
+		return err
+	}
+
+	start := reservation.StartAt.UTC()
+	end := reservation.EndAt.UTC()
+	offered := *next
+	offered.Status = model.WaitlistStatusOffered
+	offered.OfferReservationID = reservation.ID
+	offered.OfferStartAt = &start
+	offered.OfferEndAt = &end
+	offered.OfferExpiresAt = &expiresAt
+	offered.OfferTokenHash = hashWaitlistToken(token)
+	if _, err := d.waitlist.UpdateEntry(ctx, &offered); err != nil {
+		return err
+	}
+
+	// Check back when the offer expires to pass an unclaimed slot on.
+	followUp := waitlistOfferJob(d.cfg, expiresAt)
+	return d.outbox.EnqueueJobs(ctx, reservation.ID, []model.OutboxJob{followUp})
+}
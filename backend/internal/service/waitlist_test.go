@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/takumi/personal-website/internal/captcha"
+	"github.com/takumi/personal-website/internal/config"
+	"github.com/takumi/personal-website/internal/errs"
+	"github.com/takumi/personal-website/internal/model"
+	"github.com/takumi/personal-website/internal/repository"
+	"github.com/takumi/personal-website/internal/repository/inmemory"
+)
+
+var claimTokenPattern = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+
+func TestWaitlist_CancellationOffersSlotInOrderUntilClaimed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	start := now.Add(72 * time.Hour)
+	reservations := newStubReservationRepository()
+	reservations.seq = 1
+	reservations.entries[1] = &model.MeetingReservation{
+		ID:            1,
+		LookupHash:    "lookup-hash",
+		Name:          "Existing",
+		Email:         "existing@example.com",
+		StartAt:       start,
+		EndAt:         start.Add(30 * time.Minute),
+		GoogleEventID: "evt-existing",
+		Status:        model.MeetingReservationStatusConfirmed,
+	}
+	reservations.index["lookup-hash"] = 1
+	outbox := newStubOutboxRepository(reservations)
+	notifications := newStubNotificationRepository()
+	calendarClient := &stubCalendarClient{}
+	mailer := &stubMailClient{}
+	waitlist := inmemory.NewWaitlistRepository()
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{
+			CalendarID:          "primary",
+			MaxRetries:          1,
+			DefaultLocale:       "en",
+			WaitlistClaimWindow: time.Hour,
+			WaitlistClaimURL:    "https://example.com/contact/waitlist",
+		},
+	}
+
+	waitlistSvc, err := NewWaitlistService(waitlist, outbox, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), cfg)
+	require.NoError(t, err)
+	waitlistSvc.(*waitlistService).clock = fixedClock{now: now}
+
+	day := start.Format("2006-01-02")
+	for _, req := range []model.WaitlistRequest{
+		{Name: "Carol", Email: "carol@example.com", PreferredDates: []string{start.Add(24 * time.Hour).Format("2006-01-02")}},
+		{Name: "Ada", Email: "ada@example.com", PreferredDates: []string{day, day}},
+		{Name: "Bob", Email: "bob@example.com", PreferredDates: []string{day}},
+	} {
+		req.RecaptchaToken = "test-token"
+		_, err := waitlistSvc.Join(context.Background(), req)
+		require.NoError(t, err)
+	}
+	_, err = waitlistSvc.Join(context.Background(), model.WaitlistRequest{
+		Name: "Ada", Email: "ADA@example.com", PreferredDates: []string{day}, RecaptchaToken: "test-token",
+	})
+	require.Equal(t, http.StatusConflict, errs.From(err).Status)
+	_, err = waitlistSvc.Join(context.Background(), model.WaitlistRequest{
+		Name: "Dan", Email: "dan@example.com", PreferredDates: []string{"2024-04-30"}, RecaptchaToken: "test-token",
+	})
+	require.Equal(t, http.StatusBadRequest, errs.From(err).Status)
+
+	bookingSvc, err := NewBookingService(reservations, notifications, inmemory.NewNotificationTemplateRepository(), outbox, &stubAvailabilityRepository{}, &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), nil, calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	bookingSvc.(*bookingService).clock = fixedClock{now: now}
+	_, err = bookingSvc.CancelReservation(context.Background(), "lookup-hash", "")
+	require.NoError(t, err)
+	require.Len(t, outbox.jobs, 2)
+	require.Equal(t, model.OutboxJobOfferWaitlist, outbox.jobs[0].Kind)
+	require.Equal(t, model.OutboxJobDeleteCalendarEvent, outbox.jobs[1].Kind)
+
+	dispatcher, err := NewOutboxDispatcher(outbox, reservations, notifications, waitlist, inmemory.NewNotificationTemplateRepository(), calendarClient, mailer, cfg)
+	require.NoError(t, err)
+	dispatcher.clock = fixedClock{now: now}
+
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mailer.sent, 2)
+	offer := mailer.sent[1]
+	require.Equal(t, []string{"ada@example.com"}, offer.To)
+	require.Equal(t, "A slot has opened up: Sat, 04 May 2024 09:00:00 UTC", offer.Subject)
+	require.Contains(t, offer.Body, "Claim it by Wed, 01 May 2024 10:00:00 UTC")
+	adaToken := claimTokenPattern.FindStringSubmatch(offer.Body)[1]
+	require.Len(t, outbox.jobs, 3)
+	require.True(t, outbox.jobs[2].NextAttemptAt.Equal(now.Add(time.Hour)))
+
+	// Ada lets the offer lapse, so the slot moves on to Bob.
+	later := now.Add(time.Hour)
+	dispatcher.clock = fixedClock{now: later}
+	waitlistSvc.(*waitlistService).clock = fixedClock{now: later}
+	_, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mailer.sent, 3)
+	require.Equal(t, []string{"bob@example.com"}, mailer.sent[2].To)
+	bobToken := claimTokenPattern.FindStringSubmatch(mailer.sent[2].Body)[1]
+
+	_, err = waitlistSvc.ClaimOffer(context.Background(), adaToken)
+	require.Equal(t, http.StatusGone, errs.From(err).Status)
+
+	offered, err := waitlistSvc.GetOffer(context.Background(), bobToken)
+	require.NoError(t, err)
+	require.True(t, offered.StartAt.Equal(start))
+
+	result, err := waitlistSvc.ClaimOffer(context.Background(), bobToken)
+	require.NoError(t, err)
+	require.Equal(t, "bob@example.com", result.Reservation.Email)
+	require.Equal(t, model.MeetingReservationStatusRequested, result.Reservation.Status)
+	require.True(t, result.Reservation.StartAt.Equal(start))
+	require.Equal(t, 30, result.Reservation.DurationMinutes)
+
+	entries, err := waitlist.ListEntries(context.Background(), nil)
+	require.NoError(t, err)
+	statuses := map[string]model.WaitlistStatus{}
+	for _, entry := range entries {
+		statuses[entry.Email] = entry.Status
+	}
+	require.Equal(t, map[string]model.WaitlistStatus{
+		"carol@example.com": model.WaitlistStatusWaiting,
+		"ada@example.com":   model.WaitlistStatusLapsed,
+		"bob@example.com":   model.WaitlistStatusBooked,
+	}, statuses)
+
+	_, err = waitlistSvc.ClaimOffer(context.Background(), bobToken)
+	require.Equal(t, http.StatusGone, errs.From(err).Status)
+}
+
+func TestWaitlist_ConcurrentClaimsOfOneOfferBookOnce(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	start := now.Add(72 * time.Hour)
+	end := start.Add(30 * time.Minute)
+	expires := now.Add(time.Hour)
+	waitlist := inmemory.NewWaitlistRepository()
+	entry, err := waitlist.CreateEntry(context.Background(), &model.WaitlistEntry{
+		Name: "Ada", Email: "ada@example.com", PreferredDates: []string{start.Format("2006-01-02")},
+	})
+	require.NoError(t, err)
+	const token = "claim-token"
+	entry.Status = model.WaitlistStatusOffered
+	entry.OfferReservationID = 1
+	entry.OfferStartAt = &start
+	entry.OfferEndAt = &end
+	entry.OfferExpiresAt = &expires
+	entry.OfferTokenHash = hashWaitlistToken(token)
+	_, err = waitlist.UpdateEntry(context.Background(), entry)
+	require.NoError(t, err)
+
+	// Both requests see the open offer before either claims it.
+	const attempts = 2
+	loaded := &sync.WaitGroup{}
+	loaded.Add(attempts)
+	racing := &barrierWaitlistRepository{WaitlistRepository: waitlist, loaded: loaded}
+
+	reservations := inmemory.NewMeetingReservationRepository()
+	cfg := &config.AppConfig{
+		Contact: config.ContactConfig{Timezone: "UTC"},
+		Booking: config.BookingConfig{CalendarID: "primary"},
+	}
+	svc, err := NewWaitlistService(racing, inmemory.NewBookingOutboxRepository(reservations), &stubBlacklistRepository{}, newStubContactSettingsRepository(), captcha.NewFakeVerifier("fail"), cfg)
+	require.NoError(t, err)
+	svc.(*waitlistService).clock = fixedClock{now: now}
+
+	var wg sync.WaitGroup
+	statuses := make([]int, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := svc.ClaimOffer(context.Background(), token); err != nil {
+				statuses[i] = errs.From(err).Status
+				return
+			}
+			statuses[i] = http.StatusCreated
+		}(i)
+	}
+	wg.Wait()
+
+	require.ElementsMatch(t, []int{http.StatusCreated, http.StatusGone}, statuses)
+	stored, err := waitlist.FindEntryByOfferToken(context.Background(), hashWaitlistToken(token))
+	require.NoError(t, err)
+	require.Equal(t, model.WaitlistStatusBooked, stored.Status)
+	booked, err := reservations.ListConflictingReservations(context.Background(), start, end)
+	require.NoError(t, err)
+	require.Len(t, booked, 1)
+}
+
+// barrierWaitlistRepository holds each token lookup until all racing claims have made one.
+type barrierWaitlistRepository struct {
+	repository.WaitlistRepository
+	loaded *sync.WaitGroup
+}
+
+func (r *barrierWaitlistRepository) FindEntryByOfferToken(ctx context.Context, tokenHash string) (*model.WaitlistEntry, error) {
+	entry, err := r.WaitlistRepository.FindEntryByOfferToken(ctx, tokenHash)
+	r.loaded.Done()
+	r.loaded.Wait()
+	return entry, err
+}
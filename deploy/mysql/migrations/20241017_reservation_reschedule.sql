@@ -0,0 +1,3 @@
+-- Allow reschedule notifications to be recorded for self-service booking changes.
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email') NOT NULL;
@@ -0,0 +1,7 @@
+-- Store blackout reasons and RRULE-based recurrence so admins can manage holidays and weekly blocks.
+ALTER TABLE schedule_blackouts
+  ADD COLUMN reason VARCHAR(255) NULL AFTER end_time,
+  ADD COLUMN recurrence_rule VARCHAR(512) NULL AFTER reason,
+  ADD COLUMN timezone VARCHAR(64) NULL AFTER recurrence_rule,
+  ADD COLUMN updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) AFTER created_at,
+  ADD INDEX idx_schedule_blackouts_range (start_time, end_time);
@@ -0,0 +1,3 @@
+-- Weekly working hours (multiple intervals per weekday) and per-date overrides for booking availability.
+ALTER TABLE contact_form_settings
+  ADD COLUMN working_hours JSON NULL AFTER meeting_url_template;
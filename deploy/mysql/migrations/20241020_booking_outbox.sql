@@ -0,0 +1,20 @@
+-- Transactional outbox for booking side effects (calendar event, confirmation, owner notice).
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email','owner_notification') NOT NULL;
+
+CREATE TABLE IF NOT EXISTS booking_outbox (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  kind VARCHAR(64) NOT NULL,
+  status ENUM('pending','processing','done','dead') NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  max_attempts INT NOT NULL DEFAULT 8,
+  next_attempt_at DATETIME(3) NOT NULL,
+  last_error TEXT NULL,
+  completed_at DATETIME(3) NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_booking_outbox_due (status, next_attempt_at),
+  INDEX idx_booking_outbox_reservation (reservation_id),
+  CONSTRAINT fk_booking_outbox_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,5 @@
+-- One lock row per UTC day so concurrent bookings for overlapping slots are serialised.
+CREATE TABLE IF NOT EXISTS booking_slot_locks (
+  slot_date DATE NOT NULL PRIMARY KEY,
+  locked_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
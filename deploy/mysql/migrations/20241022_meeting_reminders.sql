@@ -0,0 +1,7 @@
+-- Reminder emails claim a per-reservation dedupe key so each offset is sent at most once,
+-- even when several instances run the scheduler.
+ALTER TABLE meeting_notifications
+  ADD COLUMN dedupe_key VARCHAR(191) NULL AFTER error_message;
+
+ALTER TABLE meeting_notifications
+  ADD UNIQUE KEY uq_meeting_notifications_dedupe (reservation_id, dedupe_key);
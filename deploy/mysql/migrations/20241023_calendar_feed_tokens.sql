@@ -0,0 +1,10 @@
+-- Revocable tokens for the private reservations ICS feed; only SHA-256 hashes are stored.
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  label VARCHAR(255) NULL,
+  token_hash CHAR(64) NOT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  last_used_at DATETIME(3) NULL,
+  revoked_at DATETIME(3) NULL,
+  UNIQUE KEY uq_calendar_feed_tokens_hash (token_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
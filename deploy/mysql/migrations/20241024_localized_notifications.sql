@@ -0,0 +1,30 @@
+-- Visitors' preferred language (ja/en) is stored with each reservation and contact message so
+-- notification emails can be rendered in it. Admin edits to the built-in email templates are
+-- kept in notification_templates; keys without a row use the built-in template.
+ALTER TABLE meeting_reservations
+  ADD COLUMN locale VARCHAR(8) NULL AFTER message;
+
+CREATE TABLE IF NOT EXISTS contact_messages (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  topic VARCHAR(255) NULL,
+  message TEXT NOT NULL,
+  locale VARCHAR(8) NULL,
+  status VARCHAR(32) NOT NULL DEFAULT 'pending',
+  admin_note TEXT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_contact_messages_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS notification_templates (
+  template_key VARCHAR(64) NOT NULL PRIMARY KEY,
+  subject_ja VARCHAR(512) NULL,
+  subject_en VARCHAR(512) NULL,
+  text_body_ja TEXT NULL,
+  text_body_en TEXT NULL,
+  html_body_ja TEXT NULL,
+  html_body_en TEXT NULL,
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
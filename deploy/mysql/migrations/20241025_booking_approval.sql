@@ -0,0 +1,7 @@
+-- Bookings for topics that require approval stay pending until an administrator approves or
+-- declines them; the flag decides which emails the outbox sends on each transition.
+ALTER TABLE meeting_reservations
+  ADD COLUMN requires_approval TINYINT(1) NOT NULL DEFAULT 0 AFTER status;
+
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email','owner_notification','request_received_email','approval_email','decline_email','approval_expiry') NOT NULL;
@@ -0,0 +1,24 @@
+-- Visitors can join a waitlist for fully booked days; a cancelled reservation's slot is offered
+-- to them in order with a time-limited claim link.
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  topic VARCHAR(255) NULL,
+  locale VARCHAR(8) NULL,
+  preferred_dates JSON NOT NULL,
+  status VARCHAR(16) NOT NULL DEFAULT 'waiting',
+  offer_reservation_id BIGINT UNSIGNED NULL,
+  offer_start_at DATETIME(3) NULL,
+  offer_end_at DATETIME(3) NULL,
+  offer_expires_at DATETIME(3) NULL,
+  offer_token_hash CHAR(64) NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE KEY uq_waitlist_entries_offer_token (offer_token_hash),
+  INDEX idx_waitlist_entries_status (status, created_at),
+  INDEX idx_waitlist_entries_offer (offer_reservation_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email','owner_notification','request_received_email','approval_email','decline_email','approval_expiry','waitlist_offer') NOT NULL;
@@ -0,0 +1,3 @@
+-- Extra Google calendars whose busy time blocks booking slots (queried together via FreeBusy).
+ALTER TABLE contact_form_settings
+  ADD COLUMN conflict_calendar_ids JSON NULL AFTER google_calendar_id;
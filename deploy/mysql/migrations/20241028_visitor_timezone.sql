@@ -0,0 +1,3 @@
+-- IANA zone the visitor booked from; confirmation emails show the time in both zones.
+ALTER TABLE meeting_reservations
+  ADD COLUMN visitor_timezone VARCHAR(64) NULL AFTER locale;
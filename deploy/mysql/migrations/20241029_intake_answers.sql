@@ -0,0 +1,5 @@
+-- Answers to the per-topic intake questions, with labels captured at submission time.
+ALTER TABLE meeting_reservations
+  ADD COLUMN intake_answers JSON NULL AFTER visitor_timezone;
+ALTER TABLE contact_messages
+  ADD COLUMN intake_answers JSON NULL AFTER locale;
@@ -0,0 +1,20 @@
+-- Reservation lifecycle: explicit states and a status history. Legacy cancellations cannot be
+-- attributed to either side and are recorded as cancelled by the visitor.
+ALTER TABLE meeting_reservations
+  MODIFY COLUMN status ENUM('pending','cancelled','requested','confirmed','rescheduled','completed','no_show','cancelled_by_visitor','cancelled_by_owner') NOT NULL DEFAULT 'requested';
+UPDATE meeting_reservations SET status = 'requested' WHERE status = 'pending';
+UPDATE meeting_reservations SET status = 'cancelled_by_visitor' WHERE status = 'cancelled';
+ALTER TABLE meeting_reservations
+  MODIFY COLUMN status ENUM('requested','confirmed','rescheduled','completed','no_show','cancelled_by_visitor','cancelled_by_owner') NOT NULL DEFAULT 'requested';
+
+CREATE TABLE IF NOT EXISTS meeting_reservation_status_history (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  from_status VARCHAR(32) NULL,
+  to_status VARCHAR(32) NOT NULL,
+  actor VARCHAR(16) NOT NULL,
+  reason TEXT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_meeting_reservation_status_history_reservation (reservation_id, id),
+  CONSTRAINT fk_meeting_reservation_status_history_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,20 @@
+-- Differences found between reservations and their Google Calendar events (deleted, moved, or
+-- declined outside the app), kept for admin review.
+CREATE TABLE IF NOT EXISTS reservation_calendar_drifts (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  google_event_id VARCHAR(255) NULL,
+  kind VARCHAR(32) NOT NULL,
+  action VARCHAR(16) NOT NULL,
+  stored_start_at DATETIME(3) NOT NULL,
+  stored_end_at DATETIME(3) NOT NULL,
+  event_start_at DATETIME(3) NULL,
+  event_end_at DATETIME(3) NULL,
+  detail TEXT NULL,
+  dedupe_key VARCHAR(191) NOT NULL,
+  detected_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  resolved_at DATETIME(3) NULL,
+  UNIQUE KEY uq_reservation_calendar_drifts_dedupe (reservation_id, dedupe_key),
+  INDEX idx_reservation_calendar_drifts_resolved (resolved_at, id),
+  CONSTRAINT fk_reservation_calendar_drifts_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
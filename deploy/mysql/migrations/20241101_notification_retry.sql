@@ -0,0 +1,23 @@
+-- Failed and unsent notifications are retried in the background with backoff; each try is kept
+-- as an attempt row, and notifications that run out of attempts are marked dead.
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN status ENUM('pending','sent','failed','dead','skipped') DEFAULT 'pending';
+ALTER TABLE meeting_notifications
+  ADD COLUMN attempts INT NOT NULL DEFAULT 0 AFTER dedupe_key;
+ALTER TABLE meeting_notifications
+  ADD COLUMN next_attempt_at DATETIME(3) NULL AFTER attempts;
+ALTER TABLE meeting_notifications
+  ADD INDEX idx_meeting_notifications_retry (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS meeting_notification_attempts (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  notification_id BIGINT UNSIGNED NOT NULL,
+  reservation_id BIGINT UNSIGNED NOT NULL,
+  attempt INT NOT NULL,
+  status ENUM('sent','failed','skipped') NOT NULL,
+  error_message TEXT NULL,
+  attempted_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_meeting_notification_attempts_reservation (reservation_id, id),
+  CONSTRAINT fk_meeting_notification_attempts_notification FOREIGN KEY (notification_id) REFERENCES meeting_notifications(id) ON DELETE CASCADE,
+  CONSTRAINT fk_meeting_notification_attempts_reservation FOREIGN KEY (reservation_id) REFERENCES meeting_reservations(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
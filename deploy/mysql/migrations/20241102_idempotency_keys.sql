@@ -0,0 +1,12 @@
+-- Responses to public POST requests sent with an Idempotency-Key, replayed to retries until
+-- they expire. The key is a hash of the request path and the client's key.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  idempotency_key CHAR(64) NOT NULL PRIMARY KEY,
+  fingerprint CHAR(64) NOT NULL,
+  status_code INT NOT NULL DEFAULT 0,
+  content_type VARCHAR(255) NULL,
+  response_body MEDIUMBLOB NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  expires_at DATETIME(3) NOT NULL,
+  INDEX idx_idempotency_keys_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
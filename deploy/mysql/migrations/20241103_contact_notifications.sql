@@ -0,0 +1,11 @@
+-- Owner alerts and visitor auto-replies sent for contact form submissions, one row per email.
+CREATE TABLE IF NOT EXISTS contact_notifications (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  contact_id BIGINT UNSIGNED NOT NULL,
+  notification_type ENUM('owner_alert','auto_reply') NOT NULL,
+  status ENUM('pending','sent','failed','skipped') DEFAULT 'pending',
+  error_message TEXT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_contact_notifications_contact (contact_id, id),
+  CONSTRAINT fk_contact_notifications_contact FOREIGN KEY (contact_id) REFERENCES contact_messages(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,26 @@
+-- Spam filtering for contact messages and bookings. Messages keep the score and signals they
+-- arrived with; fingerprints count repeated messages and feedback holds admin spam/ham verdicts.
+ALTER TABLE contact_messages
+  ADD COLUMN spam_score DECIMAL(6,2) NULL AFTER admin_note;
+ALTER TABLE contact_messages
+  ADD COLUMN spam_signals JSON NULL AFTER spam_score;
+
+CREATE TABLE IF NOT EXISTS spam_fingerprints (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  content_hash CHAR(64) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  source ENUM('contact','booking') NOT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_spam_fingerprints_hash (content_hash, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS spam_feedback (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  contact_id BIGINT UNSIGNED NULL,
+  content_hash CHAR(64) NULL,
+  email VARCHAR(255) NOT NULL,
+  verdict ENUM('spam','ham') NOT NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_spam_feedback_hash (content_hash),
+  INDEX idx_spam_feedback_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,10 @@
+-- Contact form emails are queued as pending and sent by the notification retry worker instead of
+-- on the request path; failures back off and run out of attempts like meeting notifications.
+ALTER TABLE contact_notifications
+  MODIFY COLUMN status ENUM('pending','sent','failed','dead','skipped') DEFAULT 'pending';
+ALTER TABLE contact_notifications
+  ADD COLUMN attempts INT NOT NULL DEFAULT 0 AFTER error_message;
+ALTER TABLE contact_notifications
+  ADD COLUMN next_attempt_at DATETIME(3) NULL AFTER attempts;
+ALTER TABLE contact_notifications
+  ADD INDEX idx_contact_notifications_retry (status, next_attempt_at);
@@ -0,0 +1,4 @@
+-- Visitor cancellations delete the Google Calendar event from the booking outbox once the
+-- reservation is cancelled, and the job's outcome is recorded like other outbox notifications.
+ALTER TABLE meeting_notifications
+  MODIFY COLUMN notification_type ENUM('confirmation_email','reminder_email','calendar_invite','cancellation_email','reschedule_email','owner_notification','request_received_email','approval_email','decline_email','approval_expiry','waitlist_offer','calendar_cancellation') NOT NULL;